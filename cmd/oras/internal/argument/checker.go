@@ -30,3 +30,10 @@ func AtLeast(cnt int) func(args []string) (bool, string) {
 		return len(args) >= cnt, fmt.Sprintf("at least %d argument", cnt)
 	}
 }
+
+// AtMost checks if the number of arguments is less or equal to cnt.
+func AtMost(cnt int) func(args []string) (bool, string) {
+	return func(args []string) (bool, string) {
+		return len(args) <= cnt, fmt.Sprintf("at most %d argument", cnt)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"oras.land/oras/internal/cleanup"
+)
+
+// WithCleanup returns a context tracking the temporary and partially written
+// paths created during the command, and the function removing the remaining
+// tracked paths, which is to be deferred by the command so that the paths are
+// removed when the command fails or is interrupted. The paths are kept and
+// logged instead if keep is true.
+func WithCleanup(ctx context.Context, logger logrus.FieldLogger, keep bool) (context.Context, func()) {
+	tracker := cleanup.NewTracker(keep)
+	return cleanup.WithTracker(ctx, tracker), func() {
+		if keep {
+			for _, path := range tracker.Paths() {
+				logger.Warnf("temporary path kept: %s", path)
+			}
+			return
+		}
+		if err := tracker.Cleanup(); err != nil {
+			logger.Warnf("failed to clean up temporary paths: %v", err)
+		}
+	}
+}
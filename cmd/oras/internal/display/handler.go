@@ -25,6 +25,7 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/descriptor"
 	"oras.land/oras/cmd/oras/internal/display/metadata/json"
+	"oras.land/oras/cmd/oras/internal/display/metadata/jsonpath"
 	"oras.land/oras/cmd/oras/internal/display/metadata/table"
 	"oras.land/oras/cmd/oras/internal/display/metadata/template"
 	"oras.land/oras/cmd/oras/internal/display/metadata/text"
@@ -104,6 +105,8 @@ func NewPullHandler(printer *output.Printer, format option.Format, path string,
 		metadataHandler = json.NewPullHandler(printer, path)
 	case option.FormatTypeGoTemplate.Name:
 		metadataHandler = template.NewPullHandler(printer, path, format.Template)
+	case option.FormatTypeJSONPath.Name:
+		metadataHandler = jsonpath.NewPullHandler(printer, path, format.Template)
 	default:
 		return nil, nil, errors.UnsupportedFormatTypeError(format.Type)
 	}
@@ -163,6 +166,54 @@ func NewManifestFetchHandler(out io.Writer, format option.Format, outputDescript
 	return metadataHandler, contentHandler, nil
 }
 
+// NewBlobPushHandler returns a blob push handler.
+func NewBlobPushHandler(printer *output.Printer, format option.Format) (metadata.BlobPushHandler, error) {
+	var handler metadata.BlobPushHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewBlobPushHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewBlobPushHandler(printer)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewBlobPushHandler(printer, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewRepoTagsHandler returns a repo tags handler.
+func NewRepoTagsHandler(printer *output.Printer, format option.Format, repository string) (metadata.RepoTagsHandler, error) {
+	var handler metadata.RepoTagsHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewRepoTagsHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewRepoTagsHandler(printer, repository)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewRepoTagsHandler(printer, repository, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewRepoListHandler returns a repo ls handler.
+func NewRepoListHandler(printer *output.Printer, format option.Format, registry, namespace string) (metadata.RepoListHandler, error) {
+	var handler metadata.RepoListHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewRepoListHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewRepoListHandler(printer, registry, namespace)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewRepoListHandler(printer, registry, namespace, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
 // NewTagHandler returns a tag handler.
 func NewTagHandler(printer *output.Printer, target option.Target) metadata.TagHandler {
 	return text.NewTagHandler(printer, target)
@@ -174,6 +225,81 @@ func NewManifestPushHandler(printer *output.Printer) metadata.ManifestPushHandle
 }
 
 // NewCopyHandler returns a copy handler.
-func NewCopyHandler(printer *output.Printer) metadata.CopyHandler {
-	return text.NewCopyHandler(printer)
+func NewCopyHandler(printer *output.Printer, format option.Format) (metadata.CopyHandler, error) {
+	var handler metadata.CopyHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewCopyHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewCopyHandler(printer)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewCopyHandler(printer, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewDiskUsageHandler returns a repo du handler.
+func NewDiskUsageHandler(printer *output.Printer, format option.Format) (metadata.DiskUsageHandler, error) {
+	var handler metadata.DiskUsageHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewDiskUsageHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewDiskUsageHandler(printer)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewDiskUsageHandler(printer, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewManifestDiffHandler returns a manifest diff handler.
+func NewManifestDiffHandler(printer *output.Printer, format option.Format) (metadata.ManifestDiffHandler, error) {
+	var handler metadata.ManifestDiffHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewManifestDiffHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewManifestDiffHandler(printer)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewManifestDiffHandler(printer, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewVersionHandler returns a version handler.
+func NewVersionHandler(printer *output.Printer, format option.Format) (metadata.VersionHandler, error) {
+	var handler metadata.VersionHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewVersionHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewVersionHandler(printer)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewVersionHandler(printer, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewContextHandler returns a context handler.
+func NewContextHandler(printer *output.Printer, format option.Format) (metadata.ContextHandler, error) {
+	var handler metadata.ContextHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewContextHandler(printer)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewContextHandler(printer)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewContextHandler(printer, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
 }
@@ -0,0 +1,62 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package humanize formats sizes for the status, summary and listing output.
+package humanize
+
+import (
+	"strconv"
+	"sync/atomic"
+)
+
+// sizeUnits are the binary units of human-readable sizes.
+var sizeUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// exactBytes indicates whether sizes are formatted as exact byte counts.
+var exactBytes atomic.Bool
+
+// SetExactBytes sets whether Size formats sizes as exact byte counts instead
+// of human-readable ones.
+func SetExactBytes(exact bool) {
+	exactBytes.Store(exact)
+}
+
+// ExactBytes returns true if sizes are formatted as exact byte counts.
+func ExactBytes() bool {
+	return exactBytes.Load()
+}
+
+// Size formats size in bytes, e.g. "512 B", "1.5 KiB" or "12.0 MiB", with one
+// decimal in binary units. The exact byte count, e.g. "1536 B", is returned
+// if exact bytes are enabled.
+// The formatting does not depend on the locale.
+func Size(size int64) string {
+	if size < 1024 || ExactBytes() {
+		return strconv.FormatInt(size, 10) + " B"
+	}
+	value := float64(size)
+	unit := 0
+	for unit < len(sizeUnits)-1 && value >= 1024 {
+		value /= 1024
+		unit++
+	}
+	formatted := strconv.FormatFloat(value, 'f', 1, 64)
+	if formatted == "1024.0" && unit < len(sizeUnits)-1 {
+		// rounding up reaches the next unit
+		formatted = "1.0"
+		unit++
+	}
+	return formatted + " " + sizeUnits[unit]
+}
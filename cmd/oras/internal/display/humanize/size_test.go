@@ -0,0 +1,56 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package humanize
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSize(t *testing.T) {
+	tests := []struct {
+		name string
+		size int64
+		want string
+	}{
+		{"zero", 0, "0 B"},
+		{"bytes", 1023, "1023 B"},
+		{"KiB", 1024, "1.0 KiB"},
+		{"fraction", 1536, "1.5 KiB"},
+		{"rounded", 1024*12 + 900, "12.9 KiB"},
+		{"MiB", 12 * 1024 * 1024, "12.0 MiB"},
+		{"round up to next unit", 1024*1024 - 1, "1.0 MiB"},
+		{"GiB", 3 * 1024 * 1024 * 1024 / 2, "1.5 GiB"},
+		{"TiB", 2 << 40, "2.0 TiB"},
+		{"max", math.MaxInt64, "8.0 EiB"},
+		{"negative", -1, "-1 B"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Size(tt.size); got != tt.want {
+				t.Errorf("Size() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSize_exactBytes(t *testing.T) {
+	SetExactBytes(true)
+	defer SetExactBytes(false)
+	if got, want := Size(12*1024*1024), "12582912 B"; got != want {
+		t.Errorf("Size() = %q, want %q", got, want)
+	}
+}
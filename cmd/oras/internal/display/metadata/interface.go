@@ -17,12 +17,14 @@ package metadata
 
 import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 	"oras.land/oras/cmd/oras/internal/option"
 )
 
 // PushHandler handles metadata output for push events.
 type PushHandler interface {
 	TaggedHandler
+	TransferHandler
 
 	OnCopied(opts *option.Target) error
 	OnCompleted(root ocispec.Descriptor) error
@@ -56,16 +58,50 @@ type PullHandler interface {
 	OnLayerSkipped(ocispec.Descriptor) error
 	// OnFilePulled is called after a file is pulled.
 	OnFilePulled(name string, outputDir string, desc ocispec.Descriptor, descPath string) error
+	// OnMetadataPulled is called after the manifest and the config are pulled
+	// without files.
+	OnMetadataPulled(manifest, config any) error
 	// OnCompleted is called when the pull cmd execution is completed.
 	OnCompleted(opts *option.Target, desc ocispec.Descriptor) error
 }
 
+// BlobPushHandler handles metadata output for blob push events.
+type BlobPushHandler interface {
+	// OnBlobPushed is called after the blob is pushed.
+	OnBlobPushed(opts *option.Target, desc ocispec.Descriptor) error
+}
+
+// RepoTagsHandler handles metadata output for repo tags events.
+type RepoTagsHandler interface {
+	// OnTagListed is called for each listed tag. desc is nil if the tag is
+	// not resolved.
+	OnTagListed(tag string, desc *ocispec.Descriptor) error
+	// OnCompleted is called when tag listing is completed.
+	OnCompleted() error
+}
+
+// RepoListHandler handles metadata output for repo ls events.
+type RepoListHandler interface {
+	// OnRepositoryListed is called for each listed repository.
+	OnRepositoryListed(repo string) error
+	// OnCompleted is called when repository listing is completed.
+	OnCompleted() error
+}
+
 // TaggedHandler handles status output for tag command.
 type TaggedHandler interface {
 	// OnTagged is called when each tagging operation is done.
 	OnTagged(desc ocispec.Descriptor, tag string) error
 }
 
+// TransferHandler handles metadata output for transferred descriptors.
+type TransferHandler interface {
+	// OnTransferred is called when a descriptor is uploaded, copied, mounted
+	// or found existing in the destination. state is one of the
+	// model.TransferState constants.
+	OnTransferred(desc ocispec.Descriptor, state string) error
+}
+
 // TagHandler handles status output for tag command.
 type TagHandler interface {
 	// OnTagging is called when tagging starts.
@@ -78,7 +114,50 @@ type ManifestPushHandler interface {
 	TaggedHandler
 }
 
+// TraversalHandler handles metadata output for the nodes touched by a copy.
+type TraversalHandler interface {
+	// OnTraversed is called after a copy with the touched nodes, ordered child
+	// before parent.
+	OnTraversed(nodes []model.TraversalNode) error
+}
+
 // CopyHandler handles metadata output for cp events.
 type CopyHandler interface {
 	TaggedHandler
+	TransferHandler
+	TraversalHandler
+
+	// OnCopied is called after the artifact is copied.
+	OnCopied(opts *option.BinaryTarget, desc ocispec.Descriptor) error
+	// OnUpToDate is called instead of OnCopied if the destination is already
+	// up to date and nothing is copied.
+	OnUpToDate(opts *option.BinaryTarget, desc ocispec.Descriptor) error
+	// OnCompleted is called after the copy is completed.
+	OnCompleted(desc ocispec.Descriptor) error
+}
+
+// DiskUsageHandler handles metadata output for repo du events.
+type DiskUsageHandler interface {
+	// OnDiskUsage is called after the size of an artifact graph is calculated.
+	OnDiskUsage(du model.DiskUsage) error
+}
+
+// ManifestDiffHandler handles metadata output for manifest diff events.
+type ManifestDiffHandler interface {
+	// OnDiffed is called after the manifests are diffed.
+	OnDiffed(diff model.ManifestDiff) error
+}
+
+// VersionHandler handles metadata output for version events.
+type VersionHandler interface {
+	// OnVersion is called with the version information to be printed.
+	OnVersion(v model.Version) error
+}
+
+// ContextHandler handles metadata output for context events.
+type ContextHandler interface {
+	// OnContextList is called with the contexts defined in the config file.
+	OnContextList(contexts []model.Context) error
+	// OnContext is called with a context to be shown.
+	OnContext(ctx model.Context) error
 }
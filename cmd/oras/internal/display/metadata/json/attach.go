@@ -22,7 +22,6 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 	"oras.land/oras/cmd/oras/internal/option"
-	"oras.land/oras/cmd/oras/internal/output"
 )
 
 // AttachHandler handles json metadata output for attach events.
@@ -39,5 +38,5 @@ func NewAttachHandler(out io.Writer) metadata.AttachHandler {
 
 // OnCompleted is called when the attach command is completed.
 func (ah *AttachHandler) OnCompleted(opts *option.Target, root, subject ocispec.Descriptor) error {
-	return output.PrintPrettyJSON(ah.out, model.NewAttach(root, opts.Path))
+	return printJSON(ah.out, model.NewAttach(root, opts.Path))
 }
@@ -0,0 +1,45 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+// contextHandler handles JSON metadata output for context events.
+type contextHandler struct {
+	out io.Writer
+}
+
+// NewContextHandler creates a new handler for context events.
+func NewContextHandler(out io.Writer) metadata.ContextHandler {
+	return &contextHandler{
+		out: out,
+	}
+}
+
+// OnContextList implements metadata.ContextHandler.
+func (h *contextHandler) OnContextList(contexts []model.Context) error {
+	return printJSON(h.out, model.NewContextList(contexts))
+}
+
+// OnContext implements metadata.ContextHandler.
+func (h *contextHandler) OnContext(ctx model.Context) error {
+	return printJSON(h.out, ctx)
+}
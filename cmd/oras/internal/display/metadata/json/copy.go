@@ -0,0 +1,81 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
+)
+
+// copyHandler handles JSON metadata output for cp events.
+type copyHandler struct {
+	path        string
+	out         io.Writer
+	tagged      model.Tagged
+	transferred model.Transferred
+	traversal   []model.TraversalNode
+	upToDate    bool
+}
+
+// NewCopyHandler returns a new handler for cp events.
+func NewCopyHandler(out io.Writer) metadata.CopyHandler {
+	return &copyHandler{
+		out: out,
+	}
+}
+
+// OnTagged implements metadata.TaggedHandler.
+func (h *copyHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
+	h.tagged.AddTag(tag)
+	return nil
+}
+
+// OnCopied implements metadata.CopyHandler.
+func (h *copyHandler) OnCopied(opts *option.BinaryTarget, _ ocispec.Descriptor) error {
+	if opts.To.RawReference != "" && opts.To.Reference != "" && !contentutil.IsDigest(opts.To.Reference) {
+		h.tagged.AddTag(opts.To.Reference)
+	}
+	h.path = opts.To.Path
+	return nil
+}
+
+// OnUpToDate implements metadata.CopyHandler.
+func (h *copyHandler) OnUpToDate(opts *option.BinaryTarget, desc ocispec.Descriptor) error {
+	h.upToDate = true
+	return h.OnCopied(opts, desc)
+}
+
+// OnTransferred implements metadata.TransferHandler.
+func (h *copyHandler) OnTransferred(desc ocispec.Descriptor, state string) error {
+	h.transferred.AddTransfer(desc, state)
+	return nil
+}
+
+// OnTraversed implements metadata.TraversalHandler.
+func (h *copyHandler) OnTraversed(nodes []model.TraversalNode) error {
+	h.traversal = nodes
+	return nil
+}
+
+// OnCompleted implements metadata.CopyHandler.
+func (h *copyHandler) OnCompleted(desc ocispec.Descriptor) error {
+	return printJSON(h.out, model.NewCopy(desc, h.path, h.tagged.Tags(), h.transferred.Transfers(), h.traversal, h.upToDate))
+}
@@ -0,0 +1,37 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// printJSON prints the model to out in JSON format, with the registry
+// warnings received during the command run in the "warnings" field if any.
+func printJSON(out io.Writer, model any) error {
+	warnings := output.Warnings()
+	if len(warnings) == 0 {
+		return output.PrintPrettyJSON(out, model)
+	}
+	object, err := output.ToMap(model)
+	if err != nil {
+		return err
+	}
+	object["warnings"] = warnings
+	return output.PrintPrettyJSON(out, object)
+}
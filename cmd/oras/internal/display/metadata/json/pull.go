@@ -22,7 +22,6 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 	"oras.land/oras/cmd/oras/internal/option"
-	"oras.land/oras/cmd/oras/internal/output"
 )
 
 // PullHandler handles JSON metadata output for pull events.
@@ -50,7 +49,13 @@ func (ph *PullHandler) OnFilePulled(name string, outputDir string, desc ocispec.
 	return ph.pulled.Add(name, outputDir, desc, descPath)
 }
 
+// OnMetadataPulled implements metadata.PullHandler.
+func (ph *PullHandler) OnMetadataPulled(manifest, config any) error {
+	ph.pulled.SetMetadata(manifest, config)
+	return nil
+}
+
 // OnCompleted implements metadata.PullHandler.
 func (ph *PullHandler) OnCompleted(opts *option.Target, desc ocispec.Descriptor) error {
-	return output.PrintPrettyJSON(ph.out, model.NewPull(ph.path+"@"+desc.Digest.String(), ph.pulled.Files()))
+	return printJSON(ph.out, model.NewPull(ph.path+"@"+desc.Digest.String(), &ph.pulled))
 }
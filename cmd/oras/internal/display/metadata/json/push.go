@@ -22,15 +22,15 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 	"oras.land/oras/cmd/oras/internal/option"
-	"oras.land/oras/cmd/oras/internal/output"
 	"oras.land/oras/internal/contentutil"
 )
 
 // PushHandler handles JSON metadata output for push events.
 type PushHandler struct {
-	path   string
-	out    io.Writer
-	tagged model.Tagged
+	path        string
+	out         io.Writer
+	tagged      model.Tagged
+	transferred model.Transferred
 }
 
 // NewPushHandler creates a new handler for push events.
@@ -55,7 +55,13 @@ func (ph *PushHandler) OnCopied(opts *option.Target) error {
 	return nil
 }
 
+// OnTransferred implements metadata.TransferHandler.
+func (ph *PushHandler) OnTransferred(desc ocispec.Descriptor, state string) error {
+	ph.transferred.AddTransfer(desc, state)
+	return nil
+}
+
 // OnCompleted is called after the push is completed.
 func (ph *PushHandler) OnCompleted(root ocispec.Descriptor) error {
-	return output.PrintPrettyJSON(ph.out, model.NewPush(root, ph.path, ph.tagged.Tags()))
+	return printJSON(ph.out, model.NewPush(root, ph.path, ph.tagged.Tags(), ph.transferred.Transfers()))
 }
@@ -0,0 +1,95 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+// repoTagsHandler handles JSON metadata output for repo tags events.
+type repoTagsHandler struct {
+	out        io.Writer
+	repository string
+	tags       []model.Tag
+}
+
+// NewRepoTagsHandler creates a new handler for repo tags events.
+func NewRepoTagsHandler(out io.Writer, repository string) metadata.RepoTagsHandler {
+	return &repoTagsHandler{
+		out:        out,
+		repository: repository,
+	}
+}
+
+// OnTagListed implements metadata.RepoTagsHandler.
+func (h *repoTagsHandler) OnTagListed(tag string, desc *ocispec.Descriptor) error {
+	h.tags = append(h.tags, model.NewTag(tag, desc))
+	return nil
+}
+
+// OnCompleted implements metadata.RepoTagsHandler.
+func (h *repoTagsHandler) OnCompleted() error {
+	return printJSON(h.out, model.NewTags(h.repository, h.tags))
+}
+
+// repoListHandler handles JSON metadata output for repo ls events.
+type repoListHandler struct {
+	out       io.Writer
+	registry  string
+	namespace string
+	repos     []string
+}
+
+// NewRepoListHandler creates a new handler for repo ls events.
+func NewRepoListHandler(out io.Writer, registry, namespace string) metadata.RepoListHandler {
+	return &repoListHandler{
+		out:       out,
+		registry:  registry,
+		namespace: namespace,
+	}
+}
+
+// OnRepositoryListed implements metadata.RepoListHandler.
+func (h *repoListHandler) OnRepositoryListed(repo string) error {
+	h.repos = append(h.repos, repo)
+	return nil
+}
+
+// OnCompleted implements metadata.RepoListHandler.
+func (h *repoListHandler) OnCompleted() error {
+	return printJSON(h.out, model.NewRepositories(h.registry, h.namespace, h.repos))
+}
+
+// diskUsageHandler handles JSON metadata output for repo du events.
+type diskUsageHandler struct {
+	out io.Writer
+}
+
+// NewDiskUsageHandler creates a new handler for repo du events.
+func NewDiskUsageHandler(out io.Writer) metadata.DiskUsageHandler {
+	return &diskUsageHandler{
+		out: out,
+	}
+}
+
+// OnDiskUsage implements metadata.DiskUsageHandler.
+func (h *diskUsageHandler) OnDiskUsage(du model.DiskUsage) error {
+	return printJSON(h.out, du)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestRepoTagsHandler(t *testing.T) {
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("manifest"),
+		Size:      8,
+	}
+	tests := []struct {
+		name string
+		desc *ocispec.Descriptor
+		want map[string]any
+	}{
+		{
+			name: "tags only",
+			want: map[string]any{
+				"repository": "localhost:5000/test",
+				"tags": []any{
+					map[string]any{"name": "v1"},
+					map[string]any{"name": "v2"},
+				},
+			},
+		},
+		{
+			name: "tags with digests",
+			desc: &desc,
+			want: map[string]any{
+				"repository": "localhost:5000/test",
+				"tags": []any{
+					map[string]any{"name": "v1", "digest": desc.Digest.String(), "mediaType": desc.MediaType},
+					map[string]any{"name": "v2", "digest": desc.Digest.String(), "mediaType": desc.MediaType},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			h := NewRepoTagsHandler(out, "localhost:5000/test")
+			for _, tag := range []string{"v1", "v2"} {
+				if err := h.OnTagListed(tag, tt.desc); err != nil {
+					t.Fatalf("OnTagListed() error = %v", err)
+				}
+			}
+			if err := h.OnCompleted(); err != nil {
+				t.Fatalf("OnCompleted() error = %v", err)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("invalid JSON output %q: %v", out.String(), err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("output = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoTagsHandler_noTags(t *testing.T) {
+	out := &bytes.Buffer{}
+	h := NewRepoTagsHandler(out, "localhost:5000/test")
+	if err := h.OnCompleted(); err != nil {
+		t.Fatalf("OnCompleted() error = %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", out.String(), err)
+	}
+	// an empty array rather than null
+	if tags, ok := got["tags"].([]any); !ok || len(tags) != 0 {
+		t.Errorf("tags = %v, want an empty array", got["tags"])
+	}
+}
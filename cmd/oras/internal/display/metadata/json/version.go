@@ -0,0 +1,39 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package json
+
+import (
+	"io"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+// versionHandler handles JSON metadata output for version events.
+type versionHandler struct {
+	out io.Writer
+}
+
+// NewVersionHandler creates a new handler for version events.
+func NewVersionHandler(out io.Writer) metadata.VersionHandler {
+	return &versionHandler{
+		out: out,
+	}
+}
+
+// OnVersion implements metadata.VersionHandler.
+func (h *versionHandler) OnVersion(v model.Version) error {
+	return printJSON(h.out, v)
+}
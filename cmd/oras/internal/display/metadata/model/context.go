@@ -0,0 +1,56 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "oras.land/oras/internal/config"
+
+// Context is a named context in the config file.
+type Context struct {
+	Name           string   `json:"name"`
+	Registry       string   `json:"registry,omitempty"`
+	PlainHTTP      *bool    `json:"plainHTTP,omitempty"`
+	Insecure       bool     `json:"insecure"`
+	CAFile         string   `json:"caFile,omitempty"`
+	CertFile       string   `json:"certFile,omitempty"`
+	KeyFile        string   `json:"keyFile,omitempty"`
+	RegistryConfig []string `json:"registryConfig,omitempty"`
+	NoDockerConfig bool     `json:"noDockerConfig"`
+}
+
+// NewContext returns a metadata context of ctx.
+func NewContext(ctx *config.Context) Context {
+	return Context{
+		Name:           ctx.Name,
+		Registry:       ctx.Registry,
+		PlainHTTP:      ctx.PlainHTTP,
+		Insecure:       ctx.Insecure,
+		CAFile:         ctx.CAFile,
+		CertFile:       ctx.CertFile,
+		KeyFile:        ctx.KeyFile,
+		RegistryConfig: ctx.RegistryConfig,
+		NoDockerConfig: ctx.NoDockerConfig,
+	}
+}
+
+// contextList is the list of contexts in the config file.
+type contextList struct {
+	Contexts []Context `json:"contexts"`
+}
+
+// NewContextList returns a metadata list of contexts.
+func NewContextList(contexts []Context) any {
+	return contextList{Contexts: contexts}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// copyResult contains metadata formatted by oras cp.
+type copyResult struct {
+	push
+	// UpToDate is true if the destination is already up to date and nothing
+	// is copied.
+	UpToDate bool `json:"upToDate"`
+	// Traversal lists the nodes touched by the copy, child before parent.
+	Traversal []TraversalNode `json:"traversal,omitempty"`
+}
+
+// NewCopy returns a metadata getter for cp command.
+func NewCopy(desc ocispec.Descriptor, path string, tags []string, transfers []Transfer, traversal []TraversalNode, upToDate bool) any {
+	return copyResult{
+		push:      NewPush(desc, path, tags, transfers).(push),
+		UpToDate:  upToDate,
+		Traversal: traversal,
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// change types of manifest diffs
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Change is an entry added, removed or changed between two manifests.
+type Change struct {
+	Type  string `json:"type"`
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// ManifestDiff contains metadata formatted by oras manifest diff.
+type ManifestDiff struct {
+	// Platform is the platform of the diffed manifests of two indexes.
+	Platform string     `json:"platform,omitempty"`
+	From     Descriptor `json:"from"`
+	To       Descriptor `json:"to"`
+	Changes  []Change   `json:"changes"`
+	// Manifests are the diffs of the manifests of matching platforms if both
+	// manifests are indexes.
+	Manifests []ManifestDiff `json:"manifests,omitempty"`
+}
+
+// Identical returns true if there is no change in d and its manifests.
+func (d ManifestDiff) Identical() bool {
+	if len(d.Changes) != 0 {
+		return false
+	}
+	for _, m := range d.Manifests {
+		if !m.Identical() {
+			return false
+		}
+	}
+	return true
+}
@@ -57,22 +57,33 @@ func newFile(name string, outputDir string, desc ocispec.Descriptor, descPath st
 type pull struct {
 	DigestReference
 	Files []File `json:"files"`
+	// Manifest and Config are the decoded manifest and config pulled without
+	// files.
+	Manifest any `json:"manifest,omitempty"`
+	Config   any `json:"config,omitempty"`
 }
 
 // NewPull creates a new metadata struct for pull command.
-func NewPull(digestReference string, files []File) any {
+func NewPull(digestReference string, pulled *Pulled) any {
+	pulled.lock.Lock()
+	defer pulled.lock.Unlock()
 	return pull{
 		DigestReference: DigestReference{
 			Reference: digestReference,
 		},
-		Files: files,
+		Files:    slices.Clone(pulled.files),
+		Manifest: pulled.manifest,
+		Config:   pulled.config,
 	}
 }
 
-// Pulled records all pulled files.
+// Pulled records all pulled files, or the manifest and the config pulled
+// without files.
 type Pulled struct {
-	lock  sync.Mutex
-	files []File
+	lock     sync.Mutex
+	files    []File
+	manifest any
+	config   any
 }
 
 // Files returns all pulled files.
@@ -82,6 +93,14 @@ func (p *Pulled) Files() []File {
 	return slices.Clone(p.files)
 }
 
+// SetMetadata records the manifest and the config pulled without files.
+func (p *Pulled) SetMetadata(manifest, config any) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.manifest = manifest
+	p.config = config
+}
+
 // Add adds a pulled file.
 func (p *Pulled) Add(name string, outputDir string, desc ocispec.Descriptor, descPath string) error {
 	p.lock.Lock()
@@ -23,10 +23,12 @@ import (
 type push struct {
 	Descriptor
 	ReferenceAsTags []string `json:"referenceAsTags"`
+	// Transfers are the completion states of the transferred descriptors.
+	Transfers []Transfer `json:"transfers,omitempty"`
 }
 
 // NewPush returns a metadata getter for push command.
-func NewPush(desc ocispec.Descriptor, path string, tags []string) any {
+func NewPush(desc ocispec.Descriptor, path string, tags []string, transfers []Transfer) any {
 	var refAsTags []string
 	for _, tag := range tags {
 		refAsTags = append(refAsTags, path+":"+tag)
@@ -34,5 +36,6 @@ func NewPush(desc ocispec.Descriptor, path string, tags []string) any {
 	return push{
 		Descriptor:      FromDescriptor(path, desc),
 		ReferenceAsTags: refAsTags,
+		Transfers:       transfers,
 	}
 }
@@ -0,0 +1,93 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Tag contains metadata of a listed tag.
+type Tag struct {
+	Name      string `json:"name"`
+	Digest    string `json:"digest,omitempty"`
+	MediaType string `json:"mediaType,omitempty"`
+}
+
+// NewTag creates a new tag entry. desc is optional.
+func NewTag(name string, desc *ocispec.Descriptor) Tag {
+	tag := Tag{Name: name}
+	if desc != nil {
+		tag.Digest = desc.Digest.String()
+		tag.MediaType = desc.MediaType
+	}
+	return tag
+}
+
+// tags contains metadata formatted by oras repo tags.
+type tags struct {
+	Repository string `json:"repository"`
+	Tags       []Tag  `json:"tags"`
+}
+
+// NewTags returns a metadata getter for repo tags command.
+func NewTags(repository string, entries []Tag) any {
+	if entries == nil {
+		entries = []Tag{}
+	}
+	return tags{
+		Repository: repository,
+		Tags:       entries,
+	}
+}
+
+// repositories contains metadata formatted by oras repo ls.
+type repositories struct {
+	Registry     string   `json:"registry"`
+	Namespace    string   `json:"namespace,omitempty"`
+	Repositories []string `json:"repositories"`
+}
+
+// NewRepositories returns a metadata getter for repo ls command.
+func NewRepositories(registry, namespace string, repos []string) any {
+	if repos == nil {
+		repos = []string{}
+	}
+	return repositories{
+		Registry:     registry,
+		Namespace:    namespace,
+		Repositories: repos,
+	}
+}
+
+// MediaTypeUsage is the size of the unique contents of a media type.
+type MediaTypeUsage struct {
+	MediaType string `json:"mediaType"`
+	Count     int    `json:"count"`
+	Size      int64  `json:"size"`
+}
+
+// DiskUsage contains metadata formatted by oras repo du.
+type DiskUsage struct {
+	Root Descriptor `json:"root"`
+	// TotalSize is the size of all the contents counting each reference.
+	TotalSize int64 `json:"totalSize"`
+	// DeduplicatedSize is the size of all the contents counting each digest
+	// once.
+	DeduplicatedSize int64 `json:"deduplicatedSize"`
+	// Count is the number of unique contents.
+	Count      int              `json:"count"`
+	MediaTypes []MediaTypeUsage `json:"mediaTypes"`
+}
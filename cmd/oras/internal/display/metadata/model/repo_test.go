@@ -0,0 +1,47 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestNewTag(t *testing.T) {
+	desc := &ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Digest:    digest.FromString("index"),
+		Size:      5,
+	}
+	if got, want := NewTag("v1", nil), (Tag{Name: "v1"}); got != want {
+		t.Errorf("NewTag() = %v, want %v", got, want)
+	}
+	want := Tag{Name: "v1", Digest: desc.Digest.String(), MediaType: desc.MediaType}
+	if got := NewTag("v1", desc); got != want {
+		t.Errorf("NewTag() = %v, want %v", got, want)
+	}
+}
+
+func TestNewTags(t *testing.T) {
+	got := NewTags("localhost:5000/test", nil)
+	want := tags{Repository: "localhost:5000/test", Tags: []Tag{}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewTags() = %v, want %v", got, want)
+	}
+}
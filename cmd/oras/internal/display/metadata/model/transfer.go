@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// transfer states of descriptors
+const (
+	TransferStateUploaded = "uploaded"
+	TransferStateCopied   = "copied"
+	TransferStateExists   = "exists"
+	TransferStateMounted  = "mounted"
+)
+
+// Transfer is the completion state of a descriptor transferred to the
+// destination.
+type Transfer struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+	State     string `json:"state"`
+}
+
+// Transferred contains the transfers recorded during a push or copy.
+type Transferred struct {
+	transfers []Transfer
+	lock      sync.Mutex
+}
+
+// AddTransfer records the completion state of desc.
+func (t *Transferred) AddTransfer(desc ocispec.Descriptor, state string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.transfers = append(t.transfers, Transfer{
+		MediaType: desc.MediaType,
+		Digest:    desc.Digest.String(),
+		Size:      desc.Size,
+		State:     state,
+	})
+}
+
+// Transfers returns the recorded transfers in the order of completion.
+func (t *Transferred) Transfers() []Transfer {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]Transfer(nil), t.transfers...)
+}
@@ -0,0 +1,37 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// traversal actions of nodes
+const (
+	TraversalActionCopied  = "copied"
+	TraversalActionSkipped = "skipped"
+	TraversalActionMounted = "mounted"
+)
+
+// TraversalNode is a node touched by a copy. Nodes are listed child before
+// parent, so that the graph can be replayed in order.
+type TraversalNode struct {
+	Digest       string `json:"digest"`
+	MediaType    string `json:"mediaType"`
+	ArtifactType string `json:"artifactType,omitempty"`
+	Size         int64  `json:"size"`
+	// Action is one of the TraversalAction constants. Skipped nodes exist in
+	// the destination already.
+	Action string `json:"action"`
+	// Parents are the digests of the touched nodes pointing at this node.
+	Parents []string `json:"parents,omitempty"`
+}
@@ -0,0 +1,43 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package model
+
+import (
+	"runtime"
+
+	"oras.land/oras/internal/version"
+)
+
+// Version is the version information of the oras CLI.
+type Version struct {
+	Version      string `json:"version"`
+	GoVersion    string `json:"goVersion"`
+	Platform     string `json:"platform"`
+	GitCommit    string `json:"gitCommit,omitempty"`
+	GitTreeState string `json:"gitTreeState,omitempty"`
+	BuildDate    string `json:"buildDate,omitempty"`
+}
+
+// NewVersion returns the version information of the running oras CLI.
+func NewVersion() Version {
+	return Version{
+		Version:      version.GetVersion(),
+		GoVersion:    runtime.Version(),
+		Platform:     runtime.GOOS + "/" + runtime.GOARCH,
+		GitCommit:    version.GitCommit,
+		GitTreeState: version.GitTreeState,
+		BuildDate:    version.BuildDate,
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// blobPushHandler handles go-template metadata output for blob push events.
+type blobPushHandler struct {
+	template string
+	out      io.Writer
+}
+
+// NewBlobPushHandler creates a new handler for blob push events.
+func NewBlobPushHandler(out io.Writer, template string) metadata.BlobPushHandler {
+	return &blobPushHandler{
+		template: template,
+		out:      out,
+	}
+}
+
+// OnBlobPushed implements metadata.BlobPushHandler.
+func (h *blobPushHandler) OnBlobPushed(opts *option.Target, desc ocispec.Descriptor) error {
+	return output.ParseAndWrite(h.out, model.FromDescriptor(opts.Path, desc), h.template)
+}
@@ -35,7 +35,7 @@ type PullHandler struct {
 
 // OnCompleted implements metadata.PullHandler.
 func (ph *PullHandler) OnCompleted(opts *option.Target, desc ocispec.Descriptor) error {
-	return output.ParseAndWrite(ph.out, model.NewPull(ph.path+"@"+desc.Digest.String(), ph.pulled.Files()), ph.template)
+	return output.ParseAndWrite(ph.out, model.NewPull(ph.path+"@"+desc.Digest.String(), &ph.pulled), ph.template)
 }
 
 // OnFilePulled implements metadata.PullHandler.
@@ -43,6 +43,12 @@ func (ph *PullHandler) OnFilePulled(name string, outputDir string, desc ocispec.
 	return ph.pulled.Add(name, outputDir, desc, descPath)
 }
 
+// OnMetadataPulled implements metadata.PullHandler.
+func (ph *PullHandler) OnMetadataPulled(manifest, config any) error {
+	ph.pulled.SetMetadata(manifest, config)
+	return nil
+}
+
 // OnLayerSkipped implements metadata.PullHandler.
 func (ph *PullHandler) OnLayerSkipped(ocispec.Descriptor) error {
 	return nil
@@ -28,10 +28,11 @@ import (
 
 // PushHandler handles go-template metadata output for push events.
 type PushHandler struct {
-	template string
-	path     string
-	tagged   model.Tagged
-	out      io.Writer
+	template    string
+	path        string
+	tagged      model.Tagged
+	transferred model.Transferred
+	out         io.Writer
 }
 
 // NewPushHandler returns a new handler for push events.
@@ -57,7 +58,13 @@ func (ph *PushHandler) OnCopied(opts *option.Target) error {
 	return nil
 }
 
+// OnTransferred implements metadata.TransferHandler.
+func (ph *PushHandler) OnTransferred(desc ocispec.Descriptor, state string) error {
+	ph.transferred.AddTransfer(desc, state)
+	return nil
+}
+
 // OnCompleted is called after the push is completed.
 func (ph *PushHandler) OnCompleted(root ocispec.Descriptor) error {
-	return output.ParseAndWrite(ph.out, model.NewPush(root, ph.path, ph.tagged.Tags()), ph.template)
+	return output.ParseAndWrite(ph.out, model.NewPush(root, ph.path, ph.tagged.Tags(), ph.transferred.Transfers()), ph.template)
 }
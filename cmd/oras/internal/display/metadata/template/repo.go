@@ -0,0 +1,102 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// repoTagsHandler handles go-template metadata output for repo tags events.
+type repoTagsHandler struct {
+	template   string
+	out        io.Writer
+	repository string
+	tags       []model.Tag
+}
+
+// NewRepoTagsHandler creates a new handler for repo tags events.
+func NewRepoTagsHandler(out io.Writer, repository string, template string) metadata.RepoTagsHandler {
+	return &repoTagsHandler{
+		template:   template,
+		out:        out,
+		repository: repository,
+	}
+}
+
+// OnTagListed implements metadata.RepoTagsHandler.
+func (h *repoTagsHandler) OnTagListed(tag string, desc *ocispec.Descriptor) error {
+	h.tags = append(h.tags, model.NewTag(tag, desc))
+	return nil
+}
+
+// OnCompleted implements metadata.RepoTagsHandler.
+func (h *repoTagsHandler) OnCompleted() error {
+	return output.ParseAndWrite(h.out, model.NewTags(h.repository, h.tags), h.template)
+}
+
+// repoListHandler handles go-template metadata output for repo ls events.
+type repoListHandler struct {
+	template  string
+	out       io.Writer
+	registry  string
+	namespace string
+	repos     []string
+}
+
+// NewRepoListHandler creates a new handler for repo ls events.
+func NewRepoListHandler(out io.Writer, registry, namespace string, template string) metadata.RepoListHandler {
+	return &repoListHandler{
+		template:  template,
+		out:       out,
+		registry:  registry,
+		namespace: namespace,
+	}
+}
+
+// OnRepositoryListed implements metadata.RepoListHandler.
+func (h *repoListHandler) OnRepositoryListed(repo string) error {
+	h.repos = append(h.repos, repo)
+	return nil
+}
+
+// OnCompleted implements metadata.RepoListHandler.
+func (h *repoListHandler) OnCompleted() error {
+	return output.ParseAndWrite(h.out, model.NewRepositories(h.registry, h.namespace, h.repos), h.template)
+}
+
+// diskUsageHandler handles go-template metadata output for repo du events.
+type diskUsageHandler struct {
+	template string
+	out      io.Writer
+}
+
+// NewDiskUsageHandler creates a new handler for repo du events.
+func NewDiskUsageHandler(out io.Writer, template string) metadata.DiskUsageHandler {
+	return &diskUsageHandler{
+		template: template,
+		out:      out,
+	}
+}
+
+// OnDiskUsage implements metadata.DiskUsageHandler.
+func (h *diskUsageHandler) OnDiskUsage(du model.DiskUsage) error {
+	return output.ParseAndWrite(h.out, du, h.template)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestRepoTagsHandler(t *testing.T) {
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("manifest"),
+		Size:      8,
+	}
+	const template = `{{.repository}}{{range .tags}} {{.name}}={{.digest}}|{{.mediaType}}{{end}}`
+	tests := []struct {
+		name string
+		desc *ocispec.Descriptor
+		want string
+	}{
+		{
+			name: "tags only",
+			want: "localhost:5000/test v1=<no value>|<no value> v2=<no value>|<no value>",
+		},
+		{
+			name: "tags with digests",
+			desc: &desc,
+			want: "localhost:5000/test v1=" + desc.Digest.String() + "|" + desc.MediaType + " v2=" + desc.Digest.String() + "|" + desc.MediaType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			h := NewRepoTagsHandler(out, "localhost:5000/test", template)
+			for _, tag := range []string{"v1", "v2"} {
+				if err := h.OnTagListed(tag, tt.desc); err != nil {
+					t.Fatalf("OnTagListed() error = %v", err)
+				}
+			}
+			if err := h.OnCompleted(); err != nil {
+				t.Fatalf("OnCompleted() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
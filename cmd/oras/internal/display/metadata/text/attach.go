@@ -47,6 +47,6 @@ func (ah *AttachHandler) OnCompleted(opts *option.Target, root, subject ocispec.
 	if err != nil {
 		return err
 	}
-	err = ah.printer.Println("Digest:", root.Digest)
+	err = ah.printer.PrintDigest(root.Digest)
 	return err
 }
@@ -0,0 +1,95 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"strconv"
+	"strings"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// ContextHandler handles text metadata output for context events.
+type ContextHandler struct {
+	printer *output.Printer
+}
+
+// NewContextHandler returns a new handler for context events.
+func NewContextHandler(printer *output.Printer) metadata.ContextHandler {
+	return &ContextHandler{
+		printer: printer,
+	}
+}
+
+// OnContextList implements metadata.ContextHandler.
+func (h *ContextHandler) OnContextList(contexts []model.Context) error {
+	size := len("NAME")
+	for _, ctx := range contexts {
+		if length := len(ctx.Name); length > size {
+			size = length
+		}
+	}
+	if err := h.printer.PrintResult("NAME" + strings.Repeat(" ", size-len("NAME")) + "  REGISTRY"); err != nil {
+		return err
+	}
+	for _, ctx := range contexts {
+		if err := h.printer.PrintResult(ctx.Name + strings.Repeat(" ", size-len(ctx.Name)) + "  " + ctx.Registry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OnContext implements metadata.ContextHandler.
+func (h *ContextHandler) OnContext(ctx model.Context) error {
+	items := [][]string{
+		{"Name", ctx.Name},
+	}
+	if ctx.Registry != "" {
+		items = append(items, []string{"Registry", ctx.Registry})
+	}
+	if ctx.PlainHTTP != nil {
+		items = append(items, []string{"Plain HTTP", strconv.FormatBool(*ctx.PlainHTTP)})
+	}
+	items = append(items, []string{"Insecure", strconv.FormatBool(ctx.Insecure)})
+	if ctx.CAFile != "" {
+		items = append(items, []string{"CA file", ctx.CAFile})
+	}
+	if ctx.CertFile != "" {
+		items = append(items, []string{"Cert file", ctx.CertFile}, []string{"Key file", ctx.KeyFile})
+	}
+	for _, config := range ctx.RegistryConfig {
+		items = append(items, []string{"Registry config", config})
+	}
+	if ctx.NoDockerConfig {
+		items = append(items, []string{"No docker config", "true"})
+	}
+
+	size := 0
+	for _, item := range items {
+		if length := len(item[0]); length > size {
+			size = length
+		}
+	}
+	for _, item := range items {
+		if err := h.printer.PrintResult(item[0] + ": " + strings.Repeat(" ", size-len(item[0])) + item[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
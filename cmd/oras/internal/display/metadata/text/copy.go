@@ -18,6 +18,8 @@ package text
 import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/cmd/oras/internal/output"
 )
 
@@ -37,3 +39,30 @@ func NewCopyHandler(printer *output.Printer) metadata.CopyHandler {
 func (h *CopyHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
 	return h.printer.Println("Tagged", tag)
 }
+
+// OnCopied implements metadata.CopyHandler.
+func (h *CopyHandler) OnCopied(opts *option.BinaryTarget, desc ocispec.Descriptor) error {
+	return h.printer.Println("Copied", opts.From.AnnotatedReference(), "=>", opts.To.AnnotatedReference())
+}
+
+// OnUpToDate implements metadata.CopyHandler.
+func (h *CopyHandler) OnUpToDate(opts *option.BinaryTarget, desc ocispec.Descriptor) error {
+	return h.printer.Println("Up to date", opts.To.AnnotatedReference())
+}
+
+// OnTransferred implements metadata.TransferHandler. Transfers are reported
+// by the status handler in text output.
+func (h *CopyHandler) OnTransferred(ocispec.Descriptor, string) error {
+	return nil
+}
+
+// OnTraversed implements metadata.TraversalHandler. The traversal is not
+// reported in text output.
+func (h *CopyHandler) OnTraversed([]model.TraversalNode) error {
+	return nil
+}
+
+// OnCompleted implements metadata.CopyHandler.
+func (h *CopyHandler) OnCompleted(desc ocispec.Descriptor) error {
+	return h.printer.PrintDigest(desc.Digest)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// ManifestDiffHandler handles text metadata output for manifest diff events.
+type ManifestDiffHandler struct {
+	printer *output.Printer
+}
+
+// NewManifestDiffHandler returns a new handler for manifest diff events.
+func NewManifestDiffHandler(printer *output.Printer) metadata.ManifestDiffHandler {
+	return &ManifestDiffHandler{
+		printer: printer,
+	}
+}
+
+// OnDiffed implements metadata.ManifestDiffHandler.
+func (h *ManifestDiffHandler) OnDiffed(diff model.ManifestDiff) error {
+	if err := h.printer.PrintResult("---", diff.From.Reference); err != nil {
+		return err
+	}
+	if err := h.printer.PrintResult("+++", diff.To.Reference); err != nil {
+		return err
+	}
+	if diff.Identical() {
+		return h.printer.PrintResult("No differences")
+	}
+	return h.printDiff(diff, "")
+}
+
+func (h *ManifestDiffHandler) printDiff(diff model.ManifestDiff, indent string) error {
+	for _, change := range diff.Changes {
+		var err error
+		switch change.Type {
+		case model.ChangeAdded:
+			err = h.printer.PrintResult(indent+"+", change.Field+":", change.To)
+		case model.ChangeRemoved:
+			err = h.printer.PrintResult(indent+"-", change.Field+":", change.From)
+		default:
+			err = h.printer.PrintResult(indent+"~", change.Field+":", change.From, "->", change.To)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, m := range diff.Manifests {
+		if m.Identical() {
+			continue
+		}
+		if err := h.printer.PrintResult(indent+"Platform "+m.Platform+":", m.From.Digest, "->", m.To.Digest); err != nil {
+			return err
+		}
+		if err := h.printDiff(m, indent+"  "); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -46,6 +46,11 @@ func (ph *PullHandler) OnFilePulled(_ string, _ string, _ ocispec.Descriptor, _
 	return nil
 }
 
+// OnMetadataPulled implements metadata.PullHandler.
+func (ph *PullHandler) OnMetadataPulled(_, _ any) error {
+	return nil
+}
+
 // OnLayerSkipped implements metadata.PullHandler.
 func (ph *PullHandler) OnLayerSkipped(ocispec.Descriptor) error {
 	ph.layerSkipped.Store(true)
@@ -49,11 +49,17 @@ func (h *PushHandler) OnCopied(opts *option.Target) error {
 	return h.printer.Println("Pushed", opts.AnnotatedReference())
 }
 
+// OnTransferred implements metadata.TransferHandler. Transfers are reported
+// by the status handler in text output.
+func (h *PushHandler) OnTransferred(ocispec.Descriptor, string) error {
+	return nil
+}
+
 // OnCompleted is called after the push is completed.
 func (h *PushHandler) OnCompleted(root ocispec.Descriptor) error {
 	err := h.printer.Println("ArtifactType:", root.ArtifactType)
 	if err != nil {
 		return err
 	}
-	return h.printer.Println("Digest:", root.Digest)
+	return h.printer.PrintDigest(root.Digest)
 }
@@ -0,0 +1,114 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/humanize"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// RepoTagsHandler handles text metadata output for repo tags events.
+type RepoTagsHandler struct {
+	printer *output.Printer
+}
+
+// NewRepoTagsHandler returns a new handler for repo tags events.
+func NewRepoTagsHandler(printer *output.Printer) metadata.RepoTagsHandler {
+	return &RepoTagsHandler{
+		printer: printer,
+	}
+}
+
+// OnTagListed implements metadata.RepoTagsHandler.
+func (h *RepoTagsHandler) OnTagListed(tag string, desc *ocispec.Descriptor) error {
+	if desc == nil {
+		return h.printer.PrintResult(tag)
+	}
+	return h.printer.PrintResult(tag + "\t" + desc.Digest.String())
+}
+
+// OnCompleted implements metadata.RepoTagsHandler.
+func (h *RepoTagsHandler) OnCompleted() error {
+	return nil
+}
+
+// RepoListHandler handles text metadata output for repo ls events.
+type RepoListHandler struct {
+	printer *output.Printer
+}
+
+// NewRepoListHandler returns a new handler for repo ls events.
+func NewRepoListHandler(printer *output.Printer) metadata.RepoListHandler {
+	return &RepoListHandler{
+		printer: printer,
+	}
+}
+
+// OnRepositoryListed implements metadata.RepoListHandler.
+func (h *RepoListHandler) OnRepositoryListed(repo string) error {
+	return h.printer.PrintResult(repo)
+}
+
+// OnCompleted implements metadata.RepoListHandler.
+func (h *RepoListHandler) OnCompleted() error {
+	return nil
+}
+
+// DiskUsageHandler handles text metadata output for repo du events.
+type DiskUsageHandler struct {
+	printer *output.Printer
+}
+
+// NewDiskUsageHandler returns a new handler for repo du events.
+func NewDiskUsageHandler(printer *output.Printer) metadata.DiskUsageHandler {
+	return &DiskUsageHandler{
+		printer: printer,
+	}
+}
+
+// OnDiskUsage implements metadata.DiskUsageHandler.
+func (h *DiskUsageHandler) OnDiskUsage(du model.DiskUsage) error {
+	var buf strings.Builder
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Reference:\t%s\n", du.Root.Reference)
+	_, _ = fmt.Fprintf(w, "Total size:\t%s\n", sizeWithBytes(du.TotalSize))
+	_, _ = fmt.Fprintf(w, "Deduplicated size:\t%s\n", sizeWithBytes(du.DeduplicatedSize))
+	_, _ = fmt.Fprintf(w, "Unique contents:\t%d\n", du.Count)
+	_ = w.Flush()
+	w = tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprint(w, "\nMEDIA TYPE\tCOUNT\tSIZE\n")
+	for _, usage := range du.MediaTypes {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\n", usage.MediaType, usage.Count, humanize.Size(usage.Size))
+	}
+	_ = w.Flush()
+	return h.printer.PrintResult(strings.TrimSuffix(buf.String(), "\n"))
+}
+
+// sizeWithBytes formats size with the exact byte count appended unless the
+// size is already formatted as exact bytes.
+func sizeWithBytes(size int64) string {
+	if humanize.ExactBytes() {
+		return humanize.Size(size)
+	}
+	return fmt.Sprintf("%s (%d bytes)", humanize.Size(size), size)
+}
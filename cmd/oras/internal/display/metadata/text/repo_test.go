@@ -0,0 +1,48 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestRepoTagsHandler_OnTagListed(t *testing.T) {
+	desc := &ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("manifest"),
+		Size:      8,
+	}
+	out := &bytes.Buffer{}
+	h := NewRepoTagsHandler(output.NewPrinter(out, os.Stderr, false))
+	if err := h.OnTagListed("v1", nil); err != nil {
+		t.Fatalf("OnTagListed() error = %v", err)
+	}
+	if err := h.OnTagListed("v2", desc); err != nil {
+		t.Fatalf("OnTagListed() error = %v", err)
+	}
+	if err := h.OnCompleted(); err != nil {
+		t.Fatalf("OnCompleted() error = %v", err)
+	}
+	if want := "v1\nv2\t" + desc.Digest.String() + "\n"; out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package text
+
+import (
+	"strings"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// VersionHandler handles text metadata output for version events.
+type VersionHandler struct {
+	printer *output.Printer
+}
+
+// NewVersionHandler returns a new handler for version events.
+func NewVersionHandler(printer *output.Printer) metadata.VersionHandler {
+	return &VersionHandler{
+		printer: printer,
+	}
+}
+
+// OnVersion implements metadata.VersionHandler.
+func (h *VersionHandler) OnVersion(v model.Version) error {
+	items := [][]string{
+		{"Version", v.Version},
+		{"Go version", v.GoVersion},
+		{"OS/Arch", v.Platform},
+	}
+	if v.GitCommit != "" {
+		items = append(items, []string{"Git commit", v.GitCommit})
+	}
+	if v.GitTreeState != "" {
+		items = append(items, []string{"Git tree state", v.GitTreeState})
+	}
+	if v.BuildDate != "" {
+		items = append(items, []string{"Build date", v.BuildDate})
+	}
+
+	size := 0
+	for _, item := range items {
+		if length := len(item[0]); length > size {
+			size = length
+		}
+	}
+	for _, item := range items {
+		if err := h.printer.PrintResult(item[0] + ": " + strings.Repeat(" ", size-len(item[0])) + item[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
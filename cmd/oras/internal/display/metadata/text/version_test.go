@@ -0,0 +1,46 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestVersionHandler_OnVersion(t *testing.T) {
+	builder := &strings.Builder{}
+	handler := NewVersionHandler(output.NewPrinter(builder, os.Stderr, false))
+	err := handler.OnVersion(model.Version{
+		Version:   "1.2.0",
+		GoVersion: "go1.22.0",
+		Platform:  "linux/amd64",
+		BuildDate: "2024-01-02T03:04:05Z",
+	})
+	if err != nil {
+		t.Fatalf("OnVersion() error = %v", err)
+	}
+	want := "Version:    1.2.0\n" +
+		"Go version: go1.22.0\n" +
+		"OS/Arch:    linux/amd64\n" +
+		"Build date: 2024-01-02T03:04:05Z\n"
+	if got := builder.String(); got != want {
+		t.Errorf("OnVersion() printed %q, want %q", got, want)
+	}
+}
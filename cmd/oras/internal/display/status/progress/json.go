@@ -0,0 +1,216 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// progress event types
+const (
+	EventStart    = "start"
+	EventProgress = "progress"
+	EventDone     = "done"
+	EventSummary  = "summary"
+)
+
+// jsonEvents indicates whether new managers emit JSON events.
+var jsonEvents atomic.Bool
+
+// EnableJSONEvents makes the managers created afterwards emit progress as
+// newline-delimited JSON events instead of rendering it on a terminal.
+func EnableJSONEvents() {
+	jsonEvents.Store(true)
+}
+
+// Event is a progress event emitted as a JSON line.
+type Event struct {
+	Event     string `json:"event"`
+	Action    string `json:"action,omitempty"`
+	Digest    string `json:"digest,omitempty"`
+	MediaType string `json:"mediaType,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Bytes is the number of bytes done.
+	Bytes int64 `json:"bytes"`
+	// Total is the total number of bytes.
+	Total int64 `json:"total"`
+	// Count is the number of descriptors in the summary event.
+	Count int `json:"count,omitempty"`
+	// Duration is the elapsed time in milliseconds in the summary event.
+	Duration int64 `json:"durationMs,omitempty"`
+}
+
+// emitted records the last emitted state of a status.
+type emitted struct {
+	started bool
+	done    bool
+	offset  int64
+}
+
+type jsonManager struct {
+	encoder      *json.Encoder
+	status       []*status
+	emitted      map[*status]*emitted
+	statusLock   sync.Mutex
+	startTime    time.Time
+	updating     sync.WaitGroup
+	renderDone   chan struct{}
+	renderClosed chan struct{}
+}
+
+// newJSONManager returns a manager emitting progress events to w at most
+// framePerSecond times per second for each status.
+func newJSONManager(w io.Writer) *jsonManager {
+	m := &jsonManager{
+		encoder:      json.NewEncoder(w),
+		emitted:      make(map[*status]*emitted),
+		startTime:    time.Now(),
+		renderDone:   make(chan struct{}),
+		renderClosed: make(chan struct{}),
+	}
+	m.start()
+	return m
+}
+
+func (m *jsonManager) start() {
+	renderTicker := time.NewTicker(bufFlushDuration)
+	go func() {
+		defer renderTicker.Stop()
+		for {
+			select {
+			case <-m.renderDone:
+				m.render()
+				m.summarize()
+				close(m.renderClosed)
+				return
+			case <-renderTicker.C:
+				m.render()
+			}
+		}
+	}()
+}
+
+// render emits the events of the statuses changed since the last rendering.
+func (m *jsonManager) render() {
+	m.statusLock.Lock()
+	defer m.statusLock.Unlock()
+	for _, s := range m.status {
+		s.lock.Lock()
+		prompt, desc, offset, done, zero := s.prompt, s.descriptor, s.offset, s.done, s.isZero()
+		s.lock.Unlock()
+		if zero {
+			continue
+		}
+		e := m.emitted[s]
+		if !e.started {
+			e.started = true
+			m.emit(EventStart, prompt, desc, max(offset, 0))
+		}
+		switch {
+		case done && !e.done:
+			e.done = true
+			m.emit(EventDone, prompt, desc, desc.Size)
+		case !done && offset > e.offset:
+			e.offset = offset
+			m.emit(EventProgress, prompt, desc, offset)
+		}
+	}
+}
+
+// summarize emits the summary event with the totals of all statuses.
+func (m *jsonManager) summarize() {
+	m.statusLock.Lock()
+	defer m.statusLock.Unlock()
+	summary := Event{
+		Event:    EventSummary,
+		Duration: time.Since(m.startTime).Milliseconds(),
+	}
+	for _, s := range m.status {
+		s.lock.Lock()
+		if !s.isZero() {
+			summary.Count++
+			summary.Total += s.descriptor.Size
+			if s.done {
+				summary.Bytes += s.descriptor.Size
+			} else if s.offset > 0 {
+				summary.Bytes += s.offset
+			}
+		}
+		s.lock.Unlock()
+	}
+	_ = m.encoder.Encode(summary)
+}
+
+func (m *jsonManager) emit(event, prompt string, desc ocispec.Descriptor, bytes int64) {
+	_ = m.encoder.Encode(Event{
+		Event:     event,
+		Action:    strings.TrimSpace(prompt),
+		Digest:    desc.Digest.String(),
+		MediaType: desc.MediaType,
+		Name:      desc.Annotations[ocispec.AnnotationTitle],
+		Bytes:     bytes,
+		Total:     desc.Size,
+	})
+}
+
+// Add appends a new status.
+func (m *jsonManager) Add() (Status, error) {
+	if m.closed() {
+		return nil, errManagerStopped
+	}
+	s := newStatus()
+	m.statusLock.Lock()
+	m.status = append(m.status, s)
+	m.emitted[s] = &emitted{}
+	m.statusLock.Unlock()
+
+	ch := make(chan *status, BufferSize)
+	m.updating.Add(1)
+	go func() {
+		defer m.updating.Done()
+		for newStatus := range ch {
+			s.Update(newStatus)
+		}
+	}()
+	return ch, nil
+}
+
+// Close stops all status and waits for the last events to be emitted.
+func (m *jsonManager) Close() error {
+	if m.closed() {
+		return errManagerStopped
+	}
+	m.updating.Wait()
+	close(m.renderDone)
+	<-m.renderClosed
+	return nil
+}
+
+func (m *jsonManager) closed() bool {
+	select {
+	case <-m.renderClosed:
+		return true
+	default:
+		return false
+	}
+}
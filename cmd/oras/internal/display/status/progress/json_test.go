@@ -0,0 +1,73 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func Test_jsonManager(t *testing.T) {
+	desc := ocispec.Descriptor{
+		MediaType:   "application/vnd.test",
+		Digest:      "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		Size:        3,
+		Annotations: map[string]string{ocispec.AnnotationTitle: "foo.txt"},
+	}
+	var buf bytes.Buffer
+	m := newJSONManager(&buf)
+	ch, err := m.Add()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch <- StartTiming()
+	ch <- NewStatusMessage("Uploading", desc, 1)
+	ch <- NewStatusMessage("Uploaded ", desc, desc.Size)
+	ch <- EndTiming()
+	close(ch)
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var events []Event
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var e Event
+		if err := decoder.Decode(&e); err != nil {
+			t.Fatalf("failed to decode events: %v", err)
+		}
+		events = append(events, e)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expect start, done and summary events, got %+v", events)
+	}
+	if e := events[0]; e.Event != EventStart || e.Digest != desc.Digest.String() || e.Name != "foo.txt" || e.Total != desc.Size {
+		t.Errorf("unexpected start event %+v", e)
+	}
+	if e := events[1]; e.Event != EventDone || e.Action != "Uploaded" || e.Bytes != desc.Size {
+		t.Errorf("unexpected done event %+v", e)
+	}
+	if e := events[2]; e.Event != EventSummary || e.Count != 1 || e.Bytes != desc.Size || e.Total != desc.Size {
+		t.Errorf("unexpected summary event %+v", e)
+	}
+
+	if _, err := m.Add(); err != errManagerStopped {
+		t.Errorf("Add() error = %v, want %v", err, errManagerStopped)
+	}
+}
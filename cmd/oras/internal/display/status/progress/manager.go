@@ -51,8 +51,12 @@ type manager struct {
 	renderClosed chan struct{}
 }
 
-// NewManager initialized a new progress manager.
+// NewManager initialized a new progress manager. The manager emits JSON
+// events to f instead if EnableJSONEvents is called.
 func NewManager(f *os.File) (Manager, error) {
+	if jsonEvents.Load() {
+		return newJSONManager(f), nil
+	}
 	c, err := console.New(f)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,79 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/morikuni/aec"
+)
+
+// Phase is an indeterminate spinner with a label rendered on a terminal line
+// during an operation without measurable progress, e.g. resolving a reference.
+type Phase struct {
+	out      io.Writer
+	label    string
+	spinner  spinner
+	stopOnce sync.Once
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+// StartPhase starts rendering the spinner labeled with label to the terminal
+// out until the returned phase is stopped. Nothing is rendered if progress is
+// emitted as JSON events.
+func StartPhase(out io.Writer, label string) *Phase {
+	p := &Phase{
+		out:     out,
+		label:   label,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if jsonEvents.Load() {
+		close(p.stopped)
+		return p
+	}
+	go p.render()
+	return p
+}
+
+func (p *Phase) render() {
+	defer close(p.stopped)
+	ticker := time.NewTicker(bufFlushDuration)
+	defer ticker.Stop()
+	for {
+		_, _ = fmt.Fprintf(p.out, "\r%c %s", p.spinner.symbol(), p.label)
+		select {
+		case <-p.done:
+			// clear the line so that the status lines start from a clean line
+			_, _ = fmt.Fprint(p.out, "\r"+aec.EraseLine(aec.EraseModes.All).String())
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop stops rendering and clears the spinner. It is safe to call Stop more
+// than once.
+func (p *Phase) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.done)
+	})
+	<-p.stopped
+}
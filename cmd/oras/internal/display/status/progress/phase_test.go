@@ -0,0 +1,38 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/morikuni/aec"
+)
+
+func TestPhase(t *testing.T) {
+	var out strings.Builder
+	p := StartPhase(&out, "Resolving source…")
+	p.Stop()
+	p.Stop()
+
+	got := out.String()
+	if want := "\r" + string(spinnerSymbols[0]) + " Resolving source…"; !strings.HasPrefix(got, want) {
+		t.Errorf("StartPhase() rendered %q, want prefix %q", got, want)
+	}
+	if want := "\r" + aec.EraseLine(aec.EraseModes.All).String(); !strings.HasSuffix(got, want) {
+		t.Errorf("Stop() rendered %q, want suffix %q", got, want)
+	}
+}
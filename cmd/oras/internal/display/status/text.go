@@ -17,7 +17,6 @@ package status
 
 import (
 	"context"
-	"sync"
 
 	"oras.land/oras/cmd/oras/internal/output"
 
@@ -55,21 +54,31 @@ func (ph *TextPushHandler) TrackTarget(gt oras.GraphTarget) (oras.GraphTarget, S
 
 // UpdateCopyOptions adds status update to the copy options.
 func (ph *TextPushHandler) UpdateCopyOptions(opts *oras.CopyGraphOptions, fetcher content.Fetcher) {
-	committed := &sync.Map{}
+	committed := output.NewCommittedSet()
 	opts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
-		committed.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
-		return ph.printer.PrintStatus(desc, PushPromptExists)
+		if err := committed.Store(desc); err != nil {
+			return err
+		}
+		return ph.printer.PrintStatusOnce(desc, PushPromptExists)
 	}
 	opts.PreCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
 		return ph.printer.PrintStatus(desc, PushPromptUploading)
 	}
 	opts.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
-		committed.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
+		if err := committed.Store(desc); err != nil {
+			return err
+		}
 		if err := output.PrintSuccessorStatus(ctx, desc, fetcher, committed, ph.printer.StatusPrinter(PushPromptSkipped)); err != nil {
 			return err
 		}
 		return ph.printer.PrintStatus(desc, PushPromptUploaded)
 	}
+	opts.OnMounted = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if err := committed.Store(desc); err != nil {
+			return err
+		}
+		return ph.printer.PrintStatus(desc, PushPromptMounted)
+	}
 }
 
 // NewTextAttachHandler returns a new handler for attach command.
@@ -18,7 +18,6 @@ package status
 import (
 	"context"
 	"os"
-	"sync"
 
 	"oras.land/oras/cmd/oras/internal/output"
 
@@ -63,17 +62,27 @@ func (ph *TTYPushHandler) TrackTarget(gt oras.GraphTarget) (oras.GraphTarget, St
 
 // UpdateCopyOptions adds TTY status output to the copy options.
 func (ph *TTYPushHandler) UpdateCopyOptions(opts *oras.CopyGraphOptions, fetcher content.Fetcher) {
-	committed := &sync.Map{}
+	committed := output.NewCommittedSet()
 	opts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
-		committed.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
+		if err := committed.Store(desc); err != nil {
+			return err
+		}
 		return ph.tracked.Prompt(desc, PushPromptExists)
 	}
 	opts.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
-		committed.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
+		if err := committed.Store(desc); err != nil {
+			return err
+		}
 		return output.PrintSuccessorStatus(ctx, desc, fetcher, committed, func(d ocispec.Descriptor) error {
 			return ph.tracked.Prompt(d, PushPromptSkipped)
 		})
 	}
+	opts.OnMounted = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if err := committed.Store(desc); err != nil {
+			return err
+		}
+		return ph.tracked.Prompt(desc, PushPromptMounted)
+	}
 }
 
 // NewTTYAttachHandler returns a new handler for attach status events.
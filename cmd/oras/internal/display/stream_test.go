@@ -0,0 +1,162 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package display
+
+import (
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+var streamTestDesc = ocispec.Descriptor{
+	MediaType: ocispec.MediaTypeImageManifest,
+	Digest:    "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+	Size:      2,
+}
+
+// assertStreams checks that out only contains the result and err contains the
+// status.
+func assertStreams(t *testing.T, out, err *strings.Builder, wantOut string, wantErr ...string) {
+	t.Helper()
+	if got := out.String(); got != wantOut {
+		t.Errorf("output = %q, want %q", got, wantOut)
+	}
+	for _, want := range wantErr {
+		if !strings.Contains(err.String(), want) {
+			t.Errorf("error output = %q, want containing %q", err.String(), want)
+		}
+	}
+}
+
+func TestStreamSeparation(t *testing.T) {
+	text := option.Format{Type: option.FormatTypeText.Name}
+	target := &option.Target{Type: "registry", RawReference: "localhost:5000/test:v1", Path: "localhost:5000/test"}
+	wantDigest := "Digest: " + streamTestDesc.Digest.String() + "\n"
+
+	t.Run("push", func(t *testing.T) {
+		out, errOut := &strings.Builder{}, &strings.Builder{}
+		printer := output.NewPrinter(out, errOut, false)
+		statusHandler, metadataHandler, err := NewPushHandler(printer, text, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = statusHandler.OnEmptyArtifact()
+		_ = metadataHandler.OnTagged(streamTestDesc, "v2")
+		_ = metadataHandler.OnCopied(target)
+		_ = metadataHandler.OnCompleted(streamTestDesc)
+		assertStreams(t, out, errOut, wantDigest, "Uploading empty artifact", "Tagged v2", "Pushed [registry] localhost:5000/test:v1")
+	})
+
+	t.Run("push json", func(t *testing.T) {
+		out, errOut := &strings.Builder{}, &strings.Builder{}
+		printer := output.NewPrinter(out, errOut, false)
+		_, metadataHandler, err := NewPushHandler(printer, option.Format{Type: option.FormatTypeJSON.Name}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = metadataHandler.OnCopied(target)
+		_ = metadataHandler.OnCompleted(streamTestDesc)
+		if !strings.Contains(out.String(), streamTestDesc.Digest.String()) {
+			t.Errorf("output = %q, want JSON containing the digest", out.String())
+		}
+		if errOut.Len() != 0 {
+			t.Errorf("error output = %q, want empty", errOut.String())
+		}
+	})
+
+	t.Run("attach", func(t *testing.T) {
+		out, errOut := &strings.Builder{}, &strings.Builder{}
+		printer := output.NewPrinter(out, errOut, false)
+		_, metadataHandler, err := NewAttachHandler(printer, text, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		subject := *target
+		_ = metadataHandler.OnCompleted(&subject, streamTestDesc, streamTestDesc)
+		assertStreams(t, out, errOut, wantDigest, "Attached to [registry] localhost:5000/test@"+streamTestDesc.Digest.String())
+	})
+
+	t.Run("pull", func(t *testing.T) {
+		out, errOut := &strings.Builder{}, &strings.Builder{}
+		printer := output.NewPrinter(out, errOut, false)
+		_, metadataHandler, err := NewPullHandler(printer, text, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = metadataHandler.OnCompleted(target, streamTestDesc)
+		assertStreams(t, out, errOut, "", "Pulled [registry] localhost:5000/test:v1")
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		out, errOut := &strings.Builder{}, &strings.Builder{}
+		printer := output.NewPrinter(out, errOut, false)
+		handler, err := NewCopyHandler(printer, text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts := &option.BinaryTarget{From: *target, To: *target}
+		_ = handler.OnTagged(streamTestDesc, "v2")
+		_ = handler.OnCopied(opts, streamTestDesc)
+		_ = handler.OnCompleted(streamTestDesc)
+		assertStreams(t, out, errOut, wantDigest, "Tagged v2", "Copied [registry] localhost:5000/test:v1 => [registry] localhost:5000/test:v1")
+	})
+
+	t.Run("blob push", func(t *testing.T) {
+		out, errOut := &strings.Builder{}, &strings.Builder{}
+		printer := output.NewPrinter(out, errOut, false)
+		handler, err := NewBlobPushHandler(printer, text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = handler.OnBlobPushed(target, streamTestDesc)
+		assertStreams(t, out, errOut, wantDigest, "Pushed [registry] localhost:5000/test:v1")
+	})
+
+	t.Run("repo tags", func(t *testing.T) {
+		out, errOut := &strings.Builder{}, &strings.Builder{}
+		printer := output.NewPrinter(out, errOut, false)
+		handler, err := NewRepoTagsHandler(printer, text, "localhost:5000/test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = handler.OnTagListed("v1", nil)
+		_ = handler.OnCompleted()
+		assertStreams(t, out, errOut, "v1\n")
+	})
+
+	t.Run("tag", func(t *testing.T) {
+		out, errOut := &strings.Builder{}, &strings.Builder{}
+		printer := output.NewPrinter(out, errOut, false)
+		handler := NewTagHandler(printer, *target)
+		_ = handler.OnTagging(streamTestDesc, "v2")
+		_ = handler.OnTagged(streamTestDesc, "v2")
+		assertStreams(t, out, errOut, "", "Tagging [registry] localhost:5000/test@"+streamTestDesc.Digest.String(), "Tagged v2")
+	})
+
+	t.Run("status", func(t *testing.T) {
+		out, errOut := &strings.Builder{}, &strings.Builder{}
+		printer := output.NewPrinter(out, errOut, false)
+		_ = printer.PrintStatus(ocispec.Descriptor{
+			MediaType:   "application/vnd.test",
+			Digest:      streamTestDesc.Digest,
+			Annotations: map[string]string{ocispec.AnnotationTitle: "foo.txt"},
+		}, "Uploaded ")
+		assertStreams(t, out, errOut, "", "Uploaded  2c26b46b68ff foo.txt")
+	})
+}
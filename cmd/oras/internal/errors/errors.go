@@ -16,6 +16,7 @@ limitations under the License.
 package errors
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -85,17 +86,41 @@ func CheckArgs(checker func(args []string) (bool, string), Usage string) cobra.P
 	}
 }
 
+// CanceledError is the error of a command aborted by the cancellation of its
+// context, e.g. on SIGINT.
+type CanceledError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e *CanceledError) Error() string {
+	if errors.Is(e.Err, context.DeadlineExceeded) {
+		return "operation timed out"
+	}
+	return "operation canceled"
+}
+
+// Unwrap implements the errors.Wrapper interface.
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
 // Modifier modifies the error during cmd execution.
 type Modifier interface {
 	Modify(cmd *cobra.Command, err error) (modifiedErr error, modified bool)
 }
 
-// Command returns an error-handled cobra command.
+// Command returns an error-handled cobra command. Errors of a command whose
+// context is canceled are reported as a CanceledError instead of the raw
+// errors of the aborted operations.
 func Command(cmd *cobra.Command, handler Modifier) *cobra.Command {
 	runE := cmd.RunE
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		err := runE(cmd, args)
 		if err != nil {
+			if ctx := cmd.Context(); ctx != nil && ctx.Err() != nil {
+				return &CanceledError{Err: ctx.Err()}
+			}
 			err, _ = handler.Modify(cmd, err)
 			return err
 		}
@@ -166,7 +191,7 @@ func NewErrEmptyTagOrDigest(ref string, cmd *cobra.Command, needsTag bool) error
 	form := `"<name>@<digest>"`
 	errMsg := `no digest specified`
 	if needsTag {
-		form = fmt.Sprintf(`"<name>:<tag>" or %s`, form)
+		form = fmt.Sprintf(`"<name>:<tag>" or %s, e.g. "%s:latest"`, form, ref)
 		errMsg = "no tag or digest specified"
 	}
 	return &Error{
@@ -16,8 +16,13 @@ limitations under the License.
 package errors
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
@@ -92,3 +97,41 @@ func TestCheckRequiredTogetherFlags(t *testing.T) {
 		})
 	}
 }
+
+type noopModifier struct{}
+
+func (noopModifier) Modify(_ *cobra.Command, err error) (error, bool) {
+	return err, false
+}
+
+func TestCommand_canceled(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	timedOut, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    error
+		wantMsg string
+	}{
+		{name: "canceled", ctx: canceled, want: context.Canceled, wantMsg: "operation canceled"},
+		{name: "timed out", ctx: timedOut, want: context.DeadlineExceeded, wantMsg: "operation timed out"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := Command(&cobra.Command{
+				RunE: func(cmd *cobra.Command, args []string) error {
+					return fmt.Errorf("Get \"https://localhost:5000/v2/\": %w", cmd.Context().Err())
+				},
+			}, noopModifier{})
+			err := cmd.ExecuteContext(tt.ctx)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Command() error = %v, want %v", err, tt.want)
+			}
+			if got := err.Error(); got != tt.wantMsg {
+				t.Errorf("Command() error message = %q, want %q", got, tt.wantMsg)
+			}
+		})
+	}
+}
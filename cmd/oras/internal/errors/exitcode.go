@@ -0,0 +1,144 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// Exit codes of the oras CLI.
+const (
+	// ExitCodeGeneral is the exit code of failures not classified below.
+	ExitCodeGeneral = 1
+	// ExitCodeUsage is the exit code of invalid arguments and references.
+	ExitCodeUsage = 2
+	// ExitCodeUnauthorized is the exit code of failed authentication.
+	ExitCodeUnauthorized = 3
+	// ExitCodeDenied is the exit code of requests denied by the registry.
+	ExitCodeDenied = 4
+	// ExitCodeNotFound is the exit code of missing repositories, manifests
+	// and blobs.
+	ExitCodeNotFound = 5
+	// ExitCodeTooManyRequests is the exit code of rate-limited requests.
+	ExitCodeTooManyRequests = 6
+	// ExitCodeTransient is the exit code of transient network failures and
+	// server errors, which are worth retrying.
+	ExitCodeTransient = 7
+	// ExitCodeVerification is the exit code of content or references failing
+	// verification.
+	ExitCodeVerification = 8
+)
+
+// ExitCodeHelp documents the exit codes in the help of the root command.
+const ExitCodeHelp = `Exit codes:
+  0  success
+  1  general failure
+  2  invalid usage, e.g. invalid arguments or references
+  3  authentication failed, e.g. missing or invalid credentials
+  4  access denied by the registry
+  5  repository, manifest or blob not found
+  6  rate limited by the registry
+  7  transient failure, e.g. network timeout or server error
+  8  verification failed, e.g. digest mismatch
+`
+
+// ExitCoder is implemented by errors carrying a process exit code.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ExitCode returns the process exit code of err. Errors not implementing
+// ExitCoder are classified by the well-known errors they wrap.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var coder ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	switch {
+	case errors.Is(err, auth.ErrBasicCredentialNotFound):
+		return ExitCodeUnauthorized
+	case errors.Is(err, errdef.ErrNotFound):
+		return ExitCodeNotFound
+	case errors.Is(err, content.ErrMismatchedDigest), errors.Is(err, content.ErrTrailingData):
+		return ExitCodeVerification
+	case isTransient(err):
+		return ExitCodeTransient
+	}
+	return ExitCodeGeneral
+}
+
+// isTransient returns true if err is a network failure or a server error which
+// may not happen again on retry.
+func isTransient(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var errResp *errcode.ErrorResponse
+	return errors.As(err, &errResp) && classify(errResp) == RegistryErrorUnavailable
+}
+
+// VerificationError is the error of content or references failing
+// verification.
+type VerificationError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e *VerificationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap implements the errors.Wrapper interface.
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode implements ExitCoder.
+func (e *VerificationError) ExitCode() int {
+	return ExitCodeVerification
+}
+
+// ExitCode implements ExitCoder. Errors with usage are usage errors,
+// otherwise the exit code of the wrapped error is returned.
+func (o *Error) ExitCode() int {
+	if o.Usage != "" || o.OperationType == OperationTypeParseArtifactReference {
+		return ExitCodeUsage
+	}
+	return ExitCode(o.Err)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// ErrorCodeTooManyRequests is the distribution-spec error code of
+// rate-limited requests.
+const ErrorCodeTooManyRequests = "TOOMANYREQUESTS"
+
+// RegistryErrorKind classifies the error responses of registries.
+type RegistryErrorKind int
+
+const (
+	// RegistryErrorUnknown is an error response not classified below.
+	RegistryErrorUnknown RegistryErrorKind = iota
+	// RegistryErrorUnauthorized is a failed authentication.
+	RegistryErrorUnauthorized
+	// RegistryErrorDenied is a request denied for the authenticated identity.
+	RegistryErrorDenied
+	// RegistryErrorNameUnknown is a missing repository.
+	RegistryErrorNameUnknown
+	// RegistryErrorNotFound is a missing manifest, blob or other resource.
+	RegistryErrorNotFound
+	// RegistryErrorTooManyRequests is a rate-limited request.
+	RegistryErrorTooManyRequests
+	// RegistryErrorUnavailable is a server error which may be temporary.
+	RegistryErrorUnavailable
+)
+
+// RegistryError is an error response of a registry classified by its
+// distribution-spec error codes, or its status code if no known error code is
+// returned.
+type RegistryError struct {
+	Kind RegistryErrorKind
+	// Host is the host of the registry.
+	Host string
+	// RetryAfter is the delay requested by a rate-limiting registry, or 0 if
+	// not known.
+	RetryAfter time.Duration
+	Err        error
+}
+
+// NewRegistryError classifies errResp returned by the registry at host. err is
+// the error to be reported, typically errResp trimmed by TrimErrResp.
+func NewRegistryError(err error, errResp *errcode.ErrorResponse, host string) *RegistryError {
+	return &RegistryError{
+		Kind: classify(errResp),
+		Host: host,
+		Err:  err,
+	}
+}
+
+// classify returns the kind of errResp.
+func classify(errResp *errcode.ErrorResponse) RegistryErrorKind {
+	for _, e := range errResp.Errors {
+		switch e.Code {
+		case errcode.ErrorCodeUnauthorized:
+			return RegistryErrorUnauthorized
+		case errcode.ErrorCodeDenied:
+			return RegistryErrorDenied
+		case errcode.ErrorCodeNameUnknown:
+			return RegistryErrorNameUnknown
+		case errcode.ErrorCodeManifestUnknown, errcode.ErrorCodeBlobUnknown:
+			return RegistryErrorNotFound
+		case ErrorCodeTooManyRequests:
+			return RegistryErrorTooManyRequests
+		}
+	}
+	switch errResp.StatusCode {
+	case http.StatusUnauthorized:
+		return RegistryErrorUnauthorized
+	case http.StatusForbidden:
+		return RegistryErrorDenied
+	case http.StatusNotFound:
+		return RegistryErrorNotFound
+	case http.StatusTooManyRequests:
+		return RegistryErrorTooManyRequests
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return RegistryErrorUnavailable
+	}
+	return RegistryErrorUnknown
+}
+
+// Error implements the error interface.
+func (e *RegistryError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap implements the errors.Wrapper interface.
+func (e *RegistryError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode implements ExitCoder.
+func (e *RegistryError) ExitCode() int {
+	switch e.Kind {
+	case RegistryErrorUnauthorized:
+		return ExitCodeUnauthorized
+	case RegistryErrorDenied:
+		return ExitCodeDenied
+	case RegistryErrorNameUnknown, RegistryErrorNotFound:
+		return ExitCodeNotFound
+	case RegistryErrorTooManyRequests:
+		return ExitCodeTooManyRequests
+	case RegistryErrorUnavailable:
+		return ExitCodeTransient
+	}
+	return ExitCodeGeneral
+}
+
+// Recommendation returns the next step to resolve the error, or an empty
+// string if none.
+func (e *RegistryError) Recommendation() string {
+	switch e.Kind {
+	case RegistryErrorUnauthorized:
+		return fmt.Sprintf("Run `oras login %s` to log in to the registry, or check whether the provided credential is correct", e.Host)
+	case RegistryErrorDenied:
+		return fmt.Sprintf("The credential is not permitted to perform the operation. Please check the permissions granted on %s, or log in with another credential", e.Host)
+	case RegistryErrorNameUnknown:
+		return "Please check whether the repository name is correct"
+	case RegistryErrorNotFound:
+		return "Please check whether the repository name and the tag or digest are correct"
+	case RegistryErrorTooManyRequests:
+		if e.RetryAfter > 0 {
+			return fmt.Sprintf("The registry rate limited the requests. Retry after %s", e.RetryAfter.Round(time.Second))
+		}
+		return "The registry rate limited the requests. Retry later or reduce the concurrency"
+	case RegistryErrorUnavailable:
+		return "The registry is temporarily unavailable. Retry later"
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+func TestNewRegistryError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		code       string
+		wantKind   RegistryErrorKind
+		wantExit   int
+	}{
+		{"name unknown", http.StatusNotFound, errcode.ErrorCodeNameUnknown, RegistryErrorNameUnknown, ExitCodeNotFound},
+		{"manifest unknown", http.StatusNotFound, errcode.ErrorCodeManifestUnknown, RegistryErrorNotFound, ExitCodeNotFound},
+		{"denied", http.StatusForbidden, errcode.ErrorCodeDenied, RegistryErrorDenied, ExitCodeDenied},
+		{"unauthorized", http.StatusUnauthorized, errcode.ErrorCodeUnauthorized, RegistryErrorUnauthorized, ExitCodeUnauthorized},
+		{"too many requests", http.StatusTooManyRequests, ErrorCodeTooManyRequests, RegistryErrorTooManyRequests, ExitCodeTooManyRequests},
+		{"code over status", http.StatusNotFound, errcode.ErrorCodeDenied, RegistryErrorDenied, ExitCodeDenied},
+		{"status only", http.StatusUnauthorized, "", RegistryErrorUnauthorized, ExitCodeUnauthorized},
+		{"service unavailable", http.StatusServiceUnavailable, "", RegistryErrorUnavailable, ExitCodeTransient},
+		{"unknown", http.StatusConflict, errcode.ErrorCodeUnsupported, RegistryErrorUnknown, ExitCodeGeneral},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errResp := &errcode.ErrorResponse{StatusCode: tt.statusCode}
+			if tt.code != "" {
+				errResp.Errors = errcode.Errors{{Code: tt.code}}
+			}
+			got := NewRegistryError(errResp, errResp, "localhost:5000")
+			if got.Kind != tt.wantKind {
+				t.Errorf("Kind = %v, want %v", got.Kind, tt.wantKind)
+			}
+			if code := ExitCode(&Error{Err: got}); code != tt.wantExit {
+				t.Errorf("ExitCode() = %d, want %d", code, tt.wantExit)
+			}
+			if (got.Recommendation() == "") != (tt.wantKind == RegistryErrorUnknown) {
+				t.Errorf("unexpected recommendation %q", got.Recommendation())
+			}
+		})
+	}
+}
+
+func TestRegistryError_Recommendation(t *testing.T) {
+	err := &RegistryError{Kind: RegistryErrorUnauthorized, Host: "localhost:5000"}
+	if got := err.Recommendation(); !strings.Contains(got, "oras login localhost:5000") {
+		t.Errorf("Recommendation() = %q, want the login command", got)
+	}
+	err = &RegistryError{Kind: RegistryErrorTooManyRequests, RetryAfter: 1500 * time.Millisecond}
+	if got := err.Recommendation(); !strings.HasSuffix(got, "Retry after 2s") {
+		t.Errorf("Recommendation() = %q, want the retry delay", got)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, 0},
+		{"general", errors.New("failed"), ExitCodeGeneral},
+		{"usage", &Error{Err: errors.New("bad"), Usage: "oras cmd"}, ExitCodeUsage},
+		{"reference", &Error{OperationType: OperationTypeParseArtifactReference, Err: errors.New("bad")}, ExitCodeUsage},
+		{"wrapped", fmt.Errorf("wrapped: %w", &RegistryError{Kind: RegistryErrorDenied, Err: errors.New("denied")}), ExitCodeDenied},
+		{"credential not found", &Error{Err: fmt.Errorf("login: %w", auth.ErrBasicCredentialNotFound)}, ExitCodeUnauthorized},
+		{"not found", fmt.Errorf("v1: %w", errdef.ErrNotFound), ExitCodeNotFound},
+		{"digest mismatch", fmt.Errorf("fetch: %w", content.ErrMismatchedDigest), ExitCodeVerification},
+		{"verification", &Error{Err: &VerificationError{Err: errors.New("tag moved")}}, ExitCodeVerification},
+		{"connection refused", &url.Error{Op: "Get", URL: "https://localhost:5000/v2/", Err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}, ExitCodeTransient},
+		{"network timeout", &url.Error{Op: "Get", URL: "https://localhost:5000/v2/", Err: timeoutError{}}, ExitCodeTransient},
+		{"temporary dns failure", &net.DNSError{Err: "server misbehaving", Name: "registry", IsTemporary: true}, ExitCodeTransient},
+		{"dns not found", &net.DNSError{Err: "no such host", Name: "registry", IsNotFound: true}, ExitCodeGeneral},
+		{"unexpected eof", fmt.Errorf("read: %w", io.ErrUnexpectedEOF), ExitCodeTransient},
+		{"server error", &errcode.ErrorResponse{StatusCode: http.StatusBadGateway}, ExitCodeTransient},
+		{"timed out", &CanceledError{Err: context.DeadlineExceeded}, ExitCodeTransient},
+		{"canceled", &CanceledError{Err: context.Canceled}, ExitCodeGeneral},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// timeoutError is a net.Error timing out.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
@@ -0,0 +1,148 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+var (
+	// mediaTypeRegexp is the media type grammar of RFC 6838, used by the
+	// distribution specification.
+	mediaTypeRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}/[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}$`)
+	// annotationKeyRegexp is the format of annotation keys, e.g. in the
+	// reverse domain notation.
+	annotationKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+)
+
+// Violation is a violation of the OCI specification constraints in a
+// manifest.
+type Violation struct {
+	// Path is the JSON path of the offending field, e.g. $.layers[0].size.
+	Path string
+	// Message describes the violation.
+	Message string
+}
+
+// String returns the violation in the form of <path>: <message>.
+func (v Violation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+// document contains the fields of manifests and indexes to be validated.
+type document struct {
+	MediaType    string              `json:"mediaType"`
+	ArtifactType string              `json:"artifactType"`
+	Config       *ocispec.Descriptor `json:"config"`
+	Layers       []ocispec.Descriptor
+	Manifests    []ocispec.Descriptor
+	Subject      *ocispec.Descriptor
+	Annotations  map[string]string
+}
+
+// Validate validates the manifest or index content described by desc against
+// the OCI specification constraints: the consistency of the descriptors, the
+// media type syntax and the annotation key format. Duplicate layers with
+// conflicting titles are also reported.
+func Validate(desc ocispec.Descriptor, content []byte) []Violation {
+	var violations []Violation
+	report := func(path, format string, a ...any) {
+		violations = append(violations, Violation{Path: path, Message: fmt.Sprintf(format, a...)})
+	}
+	if size := int64(len(content)); size != desc.Size {
+		report("$", "content size %d does not match the descriptor size %d", size, desc.Size)
+	}
+	if desc.Digest.Validate() == nil {
+		if got := desc.Digest.Algorithm().FromBytes(content); got != desc.Digest {
+			report("$", "content digest %s does not match the descriptor digest %s", got, desc.Digest)
+		}
+	}
+	var doc document
+	if err := json.Unmarshal(content, &doc); err != nil {
+		report("$", "invalid JSON: %v", err)
+		return violations
+	}
+
+	if doc.MediaType != "" && !mediaTypeRegexp.MatchString(doc.MediaType) {
+		report("$.mediaType", "invalid media type %q", doc.MediaType)
+	}
+	if doc.ArtifactType != "" && !mediaTypeRegexp.MatchString(doc.ArtifactType) {
+		report("$.artifactType", "invalid media type %q", doc.ArtifactType)
+	}
+	validateAnnotations := func(path string, annotations map[string]string) {
+		keys := make([]string, 0, len(annotations))
+		for key := range annotations {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if !annotationKeyRegexp.MatchString(key) {
+				report(fmt.Sprintf("%s.annotations['%s']", path, key), "invalid annotation key %q", key)
+			}
+		}
+	}
+	validateDescriptor := func(path string, d ocispec.Descriptor) {
+		if !mediaTypeRegexp.MatchString(d.MediaType) {
+			report(path+".mediaType", "invalid media type %q", d.MediaType)
+		}
+		if d.ArtifactType != "" && !mediaTypeRegexp.MatchString(d.ArtifactType) {
+			report(path+".artifactType", "invalid media type %q", d.ArtifactType)
+		}
+		if err := d.Digest.Validate(); err != nil {
+			report(path+".digest", "invalid digest %q: %v", d.Digest, err)
+		} else if d.Size == 0 && d.Digest != d.Digest.Algorithm().FromBytes(nil) {
+			report(path+".size", "zero size with the digest %s of non-empty content", d.Digest)
+		}
+		if d.Size < 0 {
+			report(path+".size", "negative size %d", d.Size)
+		}
+		validateAnnotations(path, d.Annotations)
+	}
+	validateDescriptors := func(name string, descs []ocispec.Descriptor) {
+		titles := make(map[digest.Digest]string)
+		for i, d := range descs {
+			path := fmt.Sprintf("$.%s[%d]", name, i)
+			validateDescriptor(path, d)
+			title, ok := d.Annotations[ocispec.AnnotationTitle]
+			if !ok {
+				continue
+			}
+			if seen, ok := titles[d.Digest]; ok && seen != title {
+				report(fmt.Sprintf("%s.annotations['%s']", path, ocispec.AnnotationTitle), "duplicate %s %s with the conflicting title %q, previously titled %q", strings.TrimSuffix(name, "s"), d.Digest, title, seen)
+			} else if !ok {
+				titles[d.Digest] = title
+			}
+		}
+	}
+
+	if doc.Config != nil {
+		validateDescriptor("$.config", *doc.Config)
+	}
+	validateDescriptors("layers", doc.Layers)
+	validateDescriptors("manifests", doc.Manifests)
+	if doc.Subject != nil {
+		validateDescriptor("$.subject", *doc.Subject)
+	}
+	validateAnnotations("$", doc.Annotations)
+	return violations
+}
@@ -0,0 +1,93 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestValidate(t *testing.T) {
+	layer := `{"mediaType":"application/vnd.oci.image.layer.v1.tar","digest":"sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03","size":6,"annotations":{"org.opencontainers.image.title":"hello.txt"}}`
+	tests := []struct {
+		name    string
+		content string
+		want    []Violation
+	}{
+		{
+			name:    "valid manifest",
+			content: manifest,
+		},
+		{
+			name:    "duplicate layers with conflicting titles",
+			content: `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","layers":[` + layer + `,{"mediaType":"application/vnd.oci.image.layer.v1.tar","digest":"sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03","size":6,"annotations":{"org.opencontainers.image.title":"bye.txt"}}]}`,
+			want: []Violation{
+				{"$.layers[1].annotations['org.opencontainers.image.title']", `duplicate layer sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03 with the conflicting title "bye.txt", previously titled "hello.txt"`},
+			},
+		},
+		{
+			name:    "zero size with non-empty digest",
+			content: `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":0}}`,
+			want: []Violation{
+				{"$.config.size", "zero size with the digest sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a of non-empty content"},
+			},
+		},
+		{
+			name:    "invalid media types and annotation keys",
+			content: `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[{"mediaType":"not a media type","digest":"sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03","size":6}],"annotations":{"bad key":"value","org.example.ok":"value"}}`,
+			want: []Violation{
+				{"$.manifests[0].mediaType", `invalid media type "not a media type"`},
+				{"$.annotations['bad key']", `invalid annotation key "bad key"`},
+			},
+		},
+		{
+			name:    "invalid JSON",
+			content: `{`,
+			want: []Violation{
+				{"$", "invalid JSON: unexpected end of JSON input"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := []byte(tt.content)
+			desc := ocispec.Descriptor{
+				MediaType: ocispec.MediaTypeImageManifest,
+				Digest:    digest.FromBytes(content),
+				Size:      int64(len(content)),
+			}
+			if got := Validate(desc, content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_descriptorMismatch(t *testing.T) {
+	content := []byte(manifest)
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("other"),
+		Size:      int64(len(content)) + 1,
+	}
+	got := Validate(desc, content)
+	if len(got) != 2 || got[0].Path != "$" || got[1].Path != "$" {
+		t.Errorf("Validate() = %v, want size and digest mismatches", got)
+	}
+}
@@ -25,7 +25,7 @@ type FlagApplier interface {
 }
 
 // ApplyFlags applies applicable fields of the passed-in option pointer to the
-// target flag set.
+// target flag set, and binds the applicable flags to environment variables.
 // NOTE: The option argument need to be a pointer to the options, so its value
 // becomes addressable.
 func ApplyFlags(optsPtr interface{}, target *pflag.FlagSet) {
@@ -33,4 +33,7 @@ func ApplyFlags(optsPtr interface{}, target *pflag.FlagSet) {
 		fa.ApplyFlags(target)
 		return nil
 	})
+	if target != nil {
+		bindEnvs(target)
+	}
 }
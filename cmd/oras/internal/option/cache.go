@@ -18,24 +18,70 @@ package option
 import (
 	"os"
 
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content/oci"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/internal/cache"
 )
 
+// CacheEnv is the environment variable specifying the root of the local
+// content cache shared across commands.
+const CacheEnv = "ORAS_CACHE"
+
+const (
+	cacheDirFlag = "cache-dir"
+	noCacheFlag  = "no-cache"
+)
+
+// Cache option struct.
 type Cache struct {
-	Root string
+	Root    string
+	noCache bool
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Cache) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&opts.Root, cacheDirFlag, "", "`path` of the local content cache shared across commands (default $"+CacheEnv+")")
+	fs.BoolVar(&opts.noCache, noCacheFlag, false, "do not read from or write to the local content cache")
+}
+
+// Parse parses the cache root from the flags or the environment variable.
+func (opts *Cache) Parse(cmd *cobra.Command) error {
+	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), cacheDirFlag, noCacheFlag); err != nil {
+		return err
+	}
+	switch {
+	case opts.noCache:
+		opts.Root = ""
+	case !cmd.Flags().Changed(cacheDirFlag):
+		opts.Root = os.Getenv(CacheEnv)
+	}
+	return nil
 }
 
 // CachedTarget gets the target storage with caching if cache root is specified.
 func (opts *Cache) CachedTarget(src oras.ReadOnlyTarget) (oras.ReadOnlyTarget, error) {
-	opts.Root = os.Getenv("ORAS_CACHE")
-	if opts.Root != "" {
-		ociStore, err := oci.New(opts.Root)
-		if err != nil {
-			return nil, err
-		}
-		return cache.New(src, ociStore), nil
+	if opts.Root == "" {
+		return src, nil
+	}
+	storage, err := oci.NewStorage(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+	return cache.New(src, storage), nil
+}
+
+// CachedGraphTarget gets the graph target storage with caching if cache root
+// is specified.
+func (opts *Cache) CachedGraphTarget(src oras.ReadOnlyGraphTarget) (oras.ReadOnlyGraphTarget, error) {
+	if opts.Root == "" {
+		return src, nil
+	}
+	storage, err := oci.NewStorage(opts.Root)
+	if err != nil {
+		return nil, err
 	}
-	return src, nil
+	return cache.NewGraph(src, storage), nil
 }
@@ -16,10 +16,10 @@ limitations under the License.
 package option
 
 import (
-	"os"
 	"reflect"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/content/oci"
@@ -28,17 +28,31 @@ import (
 
 var mockTarget oras.ReadOnlyTarget = memory.New()
 
+// parseCache parses the cache options from the given flags.
+func parseCache(t *testing.T, args ...string) Cache {
+	t.Helper()
+	var opts Cache
+	cmd := &cobra.Command{}
+	opts.ApplyFlags(cmd.Flags())
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.Parse(cmd); err != nil {
+		t.Fatal("Cache.Parse() error =", err)
+	}
+	return opts
+}
+
 func TestCache_CachedTarget(t *testing.T) {
 	tempDir := t.TempDir()
-	os.Setenv("ORAS_CACHE", tempDir)
-	defer os.Unsetenv("ORAS_CACHE")
-	opts := Cache{}
+	t.Setenv(CacheEnv, tempDir)
+	opts := parseCache(t)
 
-	ociStore, err := oci.New(tempDir)
+	storage, err := oci.NewStorage(tempDir)
 	if err != nil {
-		t.Fatal("error calling oci.New(), error =", err)
+		t.Fatal("error calling oci.NewStorage(), error =", err)
 	}
-	want := cache.New(mockTarget, ociStore)
+	want := cache.New(mockTarget, storage)
 
 	got, err := opts.CachedTarget(mockTarget)
 	if err != nil {
@@ -50,8 +64,8 @@ func TestCache_CachedTarget(t *testing.T) {
 }
 
 func TestCache_CachedTarget_emptyRoot(t *testing.T) {
-	os.Setenv("ORAS_CACHE", "")
-	opts := Cache{}
+	t.Setenv(CacheEnv, "")
+	opts := parseCache(t)
 
 	got, err := opts.CachedTarget(mockTarget)
 	if err != nil {
@@ -61,3 +75,26 @@ func TestCache_CachedTarget_emptyRoot(t *testing.T) {
 		t.Fatalf("Cache.CachedTarget() got %v, want %v", got, mockTarget)
 	}
 }
+
+func TestCache_Parse(t *testing.T) {
+	t.Setenv(CacheEnv, "env-cache")
+	if opts := parseCache(t); opts.Root != "env-cache" {
+		t.Errorf("Root = %q, want the cache root from $%s", opts.Root, CacheEnv)
+	}
+	if opts := parseCache(t, "--cache-dir", "flag-cache"); opts.Root != "flag-cache" {
+		t.Errorf("Root = %q, want the cache root from --cache-dir", opts.Root)
+	}
+	if opts := parseCache(t, "--no-cache"); opts.Root != "" {
+		t.Errorf("Root = %q, want no cache with --no-cache", opts.Root)
+	}
+
+	var opts Cache
+	cmd := &cobra.Command{}
+	opts.ApplyFlags(cmd.Flags())
+	if err := cmd.ParseFlags([]string{"--cache-dir", "flag-cache", "--no-cache"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.Parse(cmd); err == nil {
+		t.Error("Cache.Parse() expects error for --cache-dir with --no-cache")
+	}
+}
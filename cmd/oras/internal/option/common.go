@@ -16,54 +16,228 @@ limitations under the License.
 package option
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
 
+	"github.com/morikuni/aec"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"golang.org/x/term"
+	"oras.land/oras/cmd/oras/internal/display/humanize"
+	"oras.land/oras/cmd/oras/internal/display/status/progress"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/descriptor"
 )
 
 const NoTTYFlag = "no-tty"
 
+const (
+	debugFlag             = "debug"
+	logLevelFlag          = "log-level"
+	quietFlag             = "quiet"
+	shortDigestLengthFlag = "short-digest-length"
+)
+
+// minShortDigestLength is the minimum length of short digests.
+const minShortDigestLength = 8
+
+// logLevels are the supported values of --log-level.
+var logLevels = []string{"trace", "debug", "info", "warn", "error"}
+
+// NoColorEnv is the environment variable disabling colored output if set to a
+// non-empty value. See https://no-color.org/.
+const NoColorEnv = "NO_COLOR"
+
+// progress output modes
+const (
+	progressAuto = "auto"
+	progressJSON = "json"
+)
+
 // Common option struct.
 type Common struct {
 	Debug   bool
 	Verbose bool
+	Quiet   bool
 	TTY     *os.File
 	*output.Printer
-	noTTY bool
+	noTTY      bool
+	noColor    bool
+	fullDigest bool
+	bytes      bool
+	progress   string
+	logLevel   string
+	// shortDigestLength is the initial length of short digests in status
+	// output.
+	shortDigestLength int
 }
 
 // ApplyFlags applies flags to a command flag set.
 func (opts *Common) ApplyFlags(fs *pflag.FlagSet) {
-	fs.BoolVarP(&opts.Debug, "debug", "d", false, "output debug logs (implies --no-tty)")
-	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "verbose output")
-	fs.BoolVarP(&opts.noTTY, NoTTYFlag, "", false, "[Preview] do not show progress output")
+	fs.BoolVarP(&opts.Debug, debugFlag, "d", false, "output debug logs (implies --no-tty), same as --log-level debug")
+	fs.StringVar(&opts.logLevel, logLevelFlag, "", "log `level`, one of "+strings.Join(logLevels, ", ")+" (default warn, or info if --verbose is specified), debug and trace imply --no-tty")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "verbose output, with info logs unless --"+logLevelFlag+" is specified; use --"+debugFlag+" for debug logs")
+	fs.BoolVarP(&opts.Quiet, quietFlag, "q", false, "only print the digest of the produced content, or the raw fetched content, and suppress status output")
+	fs.BoolVarP(&opts.noTTY, NoTTYFlag, "", false, "[Preview] do not show progress output, which is the default if stdout or stderr is not a terminal")
+	fs.StringVar(&opts.progress, "progress", progressAuto, "[Experimental] progress output mode, one of 'auto' and 'json' which emits newline-delimited JSON events to stderr")
+	fs.BoolVar(&opts.fullDigest, "full-digest", false, "print the full digest, the size and the media type in status output")
+	fs.BoolVar(&opts.bytes, "bytes", false, "print sizes as exact byte counts instead of human-readable sizes")
+	fs.IntVar(&opts.shortDigestLength, shortDigestLengthFlag, descriptor.DefaultShortDigestLength, fmt.Sprintf("length of short digests in status output, at least %d, lengthened automatically if displayed digests share the same prefix", minShortDigestLength))
+	fs.BoolVar(&opts.noColor, "no-color", false, "disable colored status output, which is the default if stderr is not a terminal or $"+NoColorEnv+" is set")
 }
 
 // Parse gets target options from user input.
 func (opts *Common) Parse(cmd *cobra.Command) error {
-	opts.Printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr(), opts.Verbose)
+	if err := opts.parseLogLevel(cmd); err != nil {
+		return err
+	}
+	if err := opts.parseQuiet(cmd); err != nil {
+		return err
+	}
+	opts.Printer = output.NewPrinter(cmd.OutOrStdout(), cmd.ErrOrStderr(), opts.Verbose)
+	if opts.Quiet {
+		opts.Printer.EnableQuiet()
+	}
+	if opts.useColor(cmd.ErrOrStderr()) {
+		opts.Printer.EnableColor()
+		cmd.SetErrPrefix(aec.RedF.Apply(cmd.ErrPrefix()))
+	}
+	if opts.fullDigest {
+		opts.Printer.EnableFullDigest()
+	}
+	if opts.bytes {
+		humanize.SetExactBytes(true)
+	}
+	if cmd.Flags().Changed(shortDigestLengthFlag) {
+		if opts.shortDigestLength < minShortDigestLength {
+			return fmt.Errorf("invalid --%s %d: expecting at least %d", shortDigestLengthFlag, opts.shortDigestLength, minShortDigestLength)
+		}
+		opts.Printer.SetShortDigestLength(opts.shortDigestLength)
+	}
 	// use STDERR as TTY output since STDOUT is reserved for pipeable output
-	return opts.parseTTY(os.Stderr)
+	if err := opts.parseTTY(os.Stderr, os.Stdout, IsSet(cmd.Flags(), NoTTYFlag)); err != nil {
+		return err
+	}
+	return opts.parseProgress(cmd)
 }
 
-// parseTTY gets target options from user input.
-func (opts *Common) parseTTY(f *os.File) error {
-	if !opts.noTTY {
-		if opts.Debug {
-			opts.noTTY = true
-		} else if term.IsTerminal(int(f.Fd())) {
-			opts.TTY = f
+// parseQuiet validates --quiet, which conflicts with the flags asking for
+// more or differently formatted output. Progress output is disabled in quiet
+// mode.
+func (opts *Common) parseQuiet(cmd *cobra.Command) error {
+	if !opts.Quiet {
+		return nil
+	}
+	for _, flag := range []string{"verbose", "format"} {
+		if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), quietFlag, flag); err != nil {
+			return err
 		}
 	}
+	opts.noTTY = true
+	return nil
+}
+
+// parseProgress parses the progress output mode. JSON events are written to
+// STDERR regardless of whether it is a terminal.
+func (opts *Common) parseProgress(cmd *cobra.Command) error {
+	switch opts.progress {
+	case "", progressAuto:
+		return nil
+	case progressJSON:
+		if IsSet(cmd.Flags(), NoTTYFlag) && opts.noTTY {
+			return fmt.Errorf("--progress %s and --%s cannot be used at the same time", progressJSON, NoTTYFlag)
+		}
+		progress.EnableJSONEvents()
+		opts.TTY = os.Stderr
+		return nil
+	default:
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid --progress %q", opts.progress),
+			Recommendation: fmt.Sprintf("supported modes: %s, %s", progressAuto, progressJSON),
+		}
+	}
+}
+
+// parseLogLevel validates --log-level. HTTP requests are traced if the
+// level is debug or trace.
+func (opts *Common) parseLogLevel(cmd *cobra.Command) error {
+	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), debugFlag, logLevelFlag); err != nil {
+		return err
+	}
+	if opts.logLevel == "" {
+		return nil
+	}
+	if !slices.Contains(logLevels, opts.logLevel) {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid --%s %q", logLevelFlag, opts.logLevel),
+			Recommendation: fmt.Sprintf("supported levels: %s", strings.Join(logLevels, ", ")),
+		}
+	}
+	opts.Debug = opts.LogLevel() >= logrus.DebugLevel
+	return nil
+}
+
+// LogLevel returns the log level specified by --log-level, --debug or
+// --verbose.
+func (opts *Common) LogLevel() logrus.Level {
+	switch {
+	case opts.logLevel != "":
+		// validated in parseLogLevel
+		level, _ := logrus.ParseLevel(opts.logLevel)
+		return level
+	case opts.Debug:
+		return logrus.DebugLevel
+	case opts.Verbose:
+		return logrus.InfoLevel
+	default:
+		return logrus.WarnLevel
+	}
+}
+
+// useColor returns true if colored output is written to out, i.e. out is a
+// terminal and colors are not disabled by --no-color or NO_COLOR.
+func (opts *Common) useColor(out io.Writer) bool {
+	if opts.noColor || os.Getenv(NoColorEnv) != "" {
+		return false
+	}
+	f, ok := out.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// parseTTY decides whether progress output is written to f. Progress output
+// is only enabled if both f and stdout are terminals capable of cursor
+// movement, so that redirected output such as CI logs only contains plain
+// line-based status. The detection is skipped if --no-tty=false is explicitly
+// specified.
+func (opts *Common) parseTTY(f *os.File, stdout *os.File, ttyEnforced bool) error {
+	if opts.noTTY {
+		return nil
+	}
+	if opts.Debug {
+		opts.noTTY = true
+		return nil
+	}
+	if !term.IsTerminal(int(f.Fd())) {
+		return nil
+	}
+	if ttyEnforced || (term.IsTerminal(int(stdout.Fd())) && os.Getenv("TERM") != "dumb") {
+		opts.TTY = f
+	}
 	return nil
 }
 
 // UpdateTTY updates the TTY value, given the status of --no-tty flag and output
 // path value.
 func (opts *Common) UpdateTTY(flagPresent bool, toSTDOUT bool) {
+	if opts.progress == progressJSON {
+		// JSON events do not interfere with the output to STDOUT
+		return
+	}
 	ttyEnforced := flagPresent && !opts.noTTY
 	if opts.noTTY || (toSTDOUT && !ttyEnforced) {
 		opts.TTY = nil
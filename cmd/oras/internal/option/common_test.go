@@ -16,10 +16,13 @@ limitations under the License.
 package option
 
 import (
+	"bytes"
 	"os"
 	"reflect"
 	"testing"
 
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
@@ -72,3 +75,105 @@ func TestCommon_UpdateTTY(t *testing.T) {
 		})
 	}
 }
+
+func TestCommon_parseTTY_notTerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	for _, ttyEnforced := range []bool{false, true} {
+		opts := &Common{}
+		if err := opts.parseTTY(w, w, ttyEnforced); err != nil {
+			t.Fatalf("parseTTY() error = %v", err)
+		}
+		if opts.TTY != nil {
+			t.Fatalf("parseTTY() TTY = %v, want nil for non-terminal output", opts.TTY)
+		}
+	}
+}
+
+func TestCommon_useColor(t *testing.T) {
+	var opts Common
+	if opts.useColor(&bytes.Buffer{}) {
+		t.Error("useColor() = true, want false for non-terminal output")
+	}
+	t.Setenv(NoColorEnv, "1")
+	if opts.useColor(os.Stdout) {
+		t.Errorf("useColor() = true, want false with $%s set", NoColorEnv)
+	}
+}
+
+func TestCommon_parseProgress_invalid(t *testing.T) {
+	opts := &Common{progress: "bar"}
+	if err := opts.parseProgress(&cobra.Command{}); err == nil {
+		t.Fatal("parseProgress() expects error for invalid mode")
+	}
+}
+
+func TestCommon_LogLevel(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      Common
+		want      logrus.Level
+		wantDebug bool
+	}{
+		{"default", Common{}, logrus.WarnLevel, false},
+		{"verbose", Common{Verbose: true}, logrus.InfoLevel, false},
+		{"debug", Common{Debug: true}, logrus.DebugLevel, true},
+		{"log level error", Common{logLevel: "error"}, logrus.ErrorLevel, false},
+		{"log level trace", Common{logLevel: "trace"}, logrus.TraceLevel, true},
+		{"log level overrides verbose", Common{Verbose: true, logLevel: "debug"}, logrus.DebugLevel, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.parseLogLevel(&cobra.Command{}); err != nil {
+				t.Fatalf("parseLogLevel() error = %v", err)
+			}
+			if got := tt.opts.LogLevel(); got != tt.want {
+				t.Errorf("LogLevel() = %v, want %v", got, tt.want)
+			}
+			if tt.opts.Debug != tt.wantDebug {
+				t.Errorf("Debug = %v, want %v", tt.opts.Debug, tt.wantDebug)
+			}
+		})
+	}
+}
+
+func TestCommon_parseLogLevel_invalid(t *testing.T) {
+	opts := Common{logLevel: "fatal"}
+	if err := opts.parseLogLevel(&cobra.Command{}); err == nil {
+		t.Fatal("parseLogLevel() expects error for unsupported level")
+	}
+}
+
+func TestCommon_parseQuiet(t *testing.T) {
+	for _, conflict := range []string{"--verbose", "--format=json"} {
+		t.Run(conflict, func(t *testing.T) {
+			opts := Common{}
+			cmd := &cobra.Command{}
+			opts.ApplyFlags(cmd.Flags())
+			cmd.Flags().String("format", "text", "")
+			if err := cmd.ParseFlags([]string{"--quiet", conflict}); err != nil {
+				t.Fatal(err)
+			}
+			if err := opts.parseQuiet(cmd); err == nil {
+				t.Fatalf("parseQuiet() expects error with %s", conflict)
+			}
+		})
+	}
+
+	opts := Common{}
+	cmd := &cobra.Command{}
+	opts.ApplyFlags(cmd.Flags())
+	if err := cmd.ParseFlags([]string{"-q"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.parseQuiet(cmd); err != nil {
+		t.Fatalf("parseQuiet() error = %v", err)
+	}
+	if !opts.noTTY {
+		t.Error("parseQuiet() should disable progress output")
+	}
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package option
 
 import (
+	"os"
 	"testing"
 
 	"oras.land/oras/cmd/oras/internal/display/status/console/testutils"
@@ -29,19 +30,74 @@ func TestCommon_parseTTY(t *testing.T) {
 		t.Fatal(err)
 	}
 	defer device.Close()
+	t.Setenv("TERM", "xterm")
 	var opts Common
 
 	// TTY output
-	if err := opts.parseTTY(device); err != nil {
+	if err := opts.parseTTY(device, device, false); err != nil {
 		t.Errorf("unexpected error with TTY output: %v", err)
 	}
+	if opts.TTY != device {
+		t.Errorf("expected TTY output to be enabled")
+	}
+
+	// redirected stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	opts = Common{}
+	if err := opts.parseTTY(device, w, false); err != nil {
+		t.Errorf("unexpected error with redirected stdout: %v", err)
+	}
+	if opts.TTY != nil {
+		t.Errorf("expected TTY output to be disabled with redirected stdout")
+	}
+
+	// redirected stdout with --no-tty=false
+	if err := opts.parseTTY(device, w, true); err != nil {
+		t.Errorf("unexpected error with --no-tty=false: %v", err)
+	}
+	if opts.TTY != device {
+		t.Errorf("expected TTY output to be enabled with --no-tty=false")
+	}
+
+	// dumb terminal
+	t.Setenv("TERM", "dumb")
+	opts = Common{}
+	if err := opts.parseTTY(device, device, false); err != nil {
+		t.Errorf("unexpected error with dumb terminal: %v", err)
+	}
+	if opts.TTY != nil {
+		t.Errorf("expected TTY output to be disabled with dumb terminal")
+	}
 
 	// --debug
-	opts.Debug = true
-	if err := opts.parseTTY(device); err != nil {
+	opts = Common{Debug: true}
+	if err := opts.parseTTY(device, device, false); err != nil {
 		t.Errorf("unexpected error with --debug: %v", err)
 	}
 	if !opts.noTTY {
 		t.Errorf("expected --no-tty to be true with --debug")
 	}
 }
+
+func TestCommon_useColor_terminal(t *testing.T) {
+	_, device, err := testutils.NewPty()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer device.Close()
+	t.Setenv(NoColorEnv, "")
+
+	var opts Common
+	if !opts.useColor(device) {
+		t.Error("useColor() = false, want true for terminal output")
+	}
+	opts.noColor = true
+	if opts.useColor(device) {
+		t.Error("useColor() = true, want false with --no-color")
+	}
+}
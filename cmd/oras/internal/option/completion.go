@@ -0,0 +1,140 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout is the timeout of the registry requests made for shell
+// completion so that the shell never hangs on slow or unreachable registries.
+const completionTimeout = 3 * time.Second
+
+// maxCompletions is the maximum number of completion candidates queried from
+// a registry.
+const maxCompletions = 1000
+
+// errCompletionLimit stops listing once enough candidates are found.
+var errCompletionLimit = errors.New("completion limit reached")
+
+// wellKnownArtifactTypes are the artifact types suggested for completion.
+var wellKnownArtifactTypes = []string{
+	"application/vnd.cncf.notary.signature",
+	"application/vnd.dev.sigstore.bundle.v0.3+json",
+	"application/vnd.in-toto+json",
+	"application/spdx+json",
+	"application/vnd.cyclonedx+json",
+	"application/vnd.cncf.helm.config.v1+json",
+	"application/vnd.unknown.artifact.v1",
+}
+
+// CompleteArtifactType completes the --artifact-type flag with well-known
+// artifact types.
+func CompleteArtifactType(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return wellKnownArtifactTypes, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteReference completes the first argument with the repositories and
+// the tags listed by the registry. Files are completed instead if the target
+// is an OCI image layout.
+func (opts *Target) CompleteReference(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 || opts.IsOCILayout {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return opts.Remote.completeReference(cmd.Context(), toComplete)
+}
+
+// CompleteReference completes the source and the destination references with
+// the repositories and the tags listed by the registries.
+func (opts *BinaryTarget) CompleteReference(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return opts.From.CompleteReference(cmd, nil, toComplete)
+	case 1:
+		return opts.To.CompleteReference(cmd, nil, toComplete)
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeReference returns the references starting with toComplete. The
+// repositories are completed if toComplete does not contain a tag, and the
+// tags are completed otherwise. No completion is returned on any error.
+func (opts *Remote) completeReference(ctx context.Context, toComplete string) ([]string, cobra.ShellCompDirective) {
+	registry, path, found := strings.Cut(toComplete, "/")
+	if !found || registry == "" {
+		// registries cannot be listed
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	var completions []string
+	if repoName, tagPrefix, found := strings.Cut(path, ":"); found {
+		repo, err := opts.NewRepository(registry+"/"+repoName, Common{}, logger)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		err = repo.Tags(ctx, "", func(tags []string) error {
+			for _, tag := range tags {
+				if strings.HasPrefix(tag, tagPrefix) {
+					completions = append(completions, registry+"/"+repoName+":"+tag)
+				}
+			}
+			if len(completions) >= maxCompletions {
+				return errCompletionLimit
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errCompletionLimit) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	reg, err := opts.NewRegistry(registry, Common{}, logger)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	err = reg.Repositories(ctx, "", func(repos []string) error {
+		for _, repo := range repos {
+			if strings.HasPrefix(repo, path) {
+				completions = append(completions, registry+"/"+repo)
+			}
+		}
+		if len(completions) >= maxCompletions {
+			return errCompletionLimit
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errCompletionLimit) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	// no space is appended so that a tag can be typed after the repository
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
@@ -0,0 +1,83 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRemote_completeReference(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/_catalog":
+			_ = json.NewEncoder(w).Encode(map[string][]string{"repositories": {"org/app", "org/lib", "other"}})
+		case "/v2/org/app/tags/list":
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": "org/app", "tags": []string{"v1", "v2", "latest"}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	uri, _ := url.Parse(ts.URL)
+	host := uri.Host
+	opts := Remote{plainHTTP: plainHTTPEnabled, NoDockerConfig: true}
+
+	tests := []struct {
+		name       string
+		toComplete string
+		want       []string
+	}{
+		{"repositories", host + "/org/", []string{host + "/org/app", host + "/org/lib"}},
+		{"tags", host + "/org/app:v", []string{host + "/org/app:v1", host + "/org/app:v2"}},
+		{"no registry", host, nil},
+		{"unknown repository", host + "/unknown:", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := opts.completeReference(context.Background(), tt.toComplete)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("completeReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemote_completeReference_unreachable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	uri, _ := url.Parse(ts.URL)
+	ts.Close()
+	opts := Remote{plainHTTP: plainHTTPEnabled, NoDockerConfig: true}
+	got, directive := opts.completeReference(context.Background(), uri.Host+"/repo:")
+	if got != nil || directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("completeReference() = %v, %v, want no completion", got, directive)
+	}
+}
+
+func TestTarget_CompleteReference_ociLayout(t *testing.T) {
+	opts := Target{IsOCILayout: true}
+	got, directive := opts.CompleteReference(&cobra.Command{}, nil, "layout")
+	if got != nil || directive != cobra.ShellCompDirectiveDefault {
+		t.Errorf("CompleteReference() = %v, %v, want file completion", got, directive)
+	}
+}
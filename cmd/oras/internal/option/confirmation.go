@@ -60,3 +60,28 @@ func (opts *Confirmation) AskForConfirmation(r io.Reader, prompt string) (bool,
 		return false, nil
 	}
 }
+
+// AskForTypedConfirmation prints a prompt asking the user to type expected to
+// confirm an irreversible action.
+func (opts *Confirmation) AskForTypedConfirmation(r io.Reader, prompt string, expected string) (bool, error) {
+	if opts.Force {
+		return true, nil
+	}
+
+	fmt.Printf("%s Type %q to confirm: ", prompt, expected)
+
+	var response string
+	scanner := bufio.NewScanner(r)
+	if ok := scanner.Scan(); ok {
+		response = scanner.Text()
+	}
+	if err := scanner.Err(); err != nil {
+		return false, err
+	}
+
+	if strings.TrimSpace(response) != expected {
+		fmt.Println("Operation cancelled.")
+		return false, nil
+	}
+	return true, nil
+}
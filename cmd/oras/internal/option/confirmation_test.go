@@ -69,3 +69,31 @@ func TestConfirmation_AskForConfirmation_manuallyConfirmed(t *testing.T) {
 		t.Fatalf("Confirmation.AskForConfirmation() got %v, want %v", got, false)
 	}
 }
+
+func TestConfirmation_AskForTypedConfirmation(t *testing.T) {
+	tests := []struct {
+		name  string
+		force bool
+		input string
+		want  bool
+	}{
+		{name: "forcibly confirmed", force: true, want: true},
+		{name: "typed", input: "localhost:5000/hello\n", want: true},
+		{name: "typed with spaces", input: "  localhost:5000/hello  \n", want: true},
+		{name: "mistyped", input: "localhost:5000/hell\n", want: false},
+		{name: "yes is not enough", input: "yes\n", want: false},
+		{name: "no input", input: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Confirmation{Force: tt.force}
+			got, err := opts.AskForTypedConfirmation(strings.NewReader(tt.input), "", "localhost:5000/hello")
+			if err != nil {
+				t.Fatal("Confirmation.AskForTypedConfirmation() error =", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Confirmation.AskForTypedConfirmation() got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// envAnnotation is the flag annotation recording the environment variable
+// bound to the flag.
+const envAnnotation = "oras_env"
+
+// envPrefix is the prefix of the environment variables bound to flags.
+const envPrefix = "ORAS_"
+
+// envFlags are the names of the flags bound to environment variables. Flags
+// for non-unary remote targets with the prefixes "from-" and "to-" are also
+// bound, e.g. --from-username to ORAS_FROM_USERNAME.
+var envFlags = map[string]bool{
+	// Common
+	debugFlag: true,
+	"verbose": true,
+	NoTTYFlag: true,
+	// Remote
+	usernameFlag:        true,
+	passwordFlag:        true,
+	identityTokenFlag:   true,
+	registryTokenFlag:   true,
+	plainHTTPFlag:       true,
+	insecureFlag:        true,
+	caFileFlag:          true,
+	certFileFlag:        true,
+	keyFileFlag:         true,
+	proxyFlag:           true,
+	retryFlag:           true,
+	userAgentSuffixFlag: true,
+	limitRateFlag:       true,
+	redactHeaderFlag:    true,
+	// command specific
+	"concurrency": true,
+}
+
+// EnvName returns the name of the environment variable bound to the flag of
+// the given name.
+func EnvName(flag string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// bindEnvs binds the applicable flags in fs to environment variables and notes
+// the environment variables in the flag usages.
+func bindEnvs(fs *pflag.FlagSet) {
+	fs.VisitAll(func(f *pflag.Flag) {
+		if _, ok := f.Annotations[envAnnotation]; ok {
+			return
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(f.Name, "from-"), "to-")
+		if !envFlags[name] {
+			return
+		}
+		env := EnvName(f.Name)
+		_ = fs.SetAnnotation(f.Name, envAnnotation, []string{env})
+		f.Usage += " (env $" + env + ")"
+	})
+}
+
+// envSetAnnotation is the flag annotation marking a flag set from its bound
+// environment variable.
+const envSetAnnotation = "oras_env_set"
+
+// envPeers are the flags which, if specified on the command line, prevent the
+// environment variable of the flag from being applied since they cannot be
+// used together. Flags are matched with the same prefix and without prefix.
+var envPeers = map[string][]string{
+	usernameFlag:      {identityTokenFlag, identityTokenFromStdinFlag, registryTokenFlag},
+	passwordFlag:      {passwordFileFlag, passwordFromStdinFlag, identityTokenFlag, identityTokenFromStdinFlag, registryTokenFlag},
+	identityTokenFlag: {usernameFlag, passwordFlag, passwordFileFlag, passwordFromStdinFlag, identityTokenFromStdinFlag, registryTokenFlag},
+	registryTokenFlag: {usernameFlag, passwordFlag, passwordFileFlag, passwordFromStdinFlag, identityTokenFlag, identityTokenFromStdinFlag},
+	plainHTTPFlag:     {insecureFlag},
+	insecureFlag:      {plainHTTPFlag},
+	certFileFlag:      {keyFileFlag},
+	keyFileFlag:       {certFileFlag},
+	"verbose":         {quietFlag},
+}
+
+// applyEnvs sets the flags bound to environment variables which are not
+// specified on the command line. Precedence is flag > env > default. Flags set
+// from the environment variables are not marked as changed, so that the checks
+// on the flags specified on the command line are not affected.
+func applyEnvs(fs *pflag.FlagSet) error {
+	var err error
+	fs.VisitAll(func(f *pflag.Flag) {
+		envs, ok := f.Annotations[envAnnotation]
+		if err != nil || !ok || f.Changed {
+			return
+		}
+		if _, ok := f.Annotations[envSetAnnotation]; ok {
+			return
+		}
+		value, ok := os.LookupEnv(envs[0])
+		if !ok || value == "" {
+			return
+		}
+		if peerChanged(fs, f.Name) {
+			return
+		}
+		if setErr := f.Value.Set(value); setErr != nil {
+			err = fmt.Errorf("invalid $%s: %w", envs[0], setErr)
+			return
+		}
+		_ = fs.SetAnnotation(f.Name, envSetAnnotation, []string{envs[0]})
+	})
+	return err
+}
+
+// peerChanged returns true if any flag which cannot be used together with the
+// named flag is specified on the command line.
+func peerChanged(fs *pflag.FlagSet, name string) bool {
+	prefix := ""
+	for _, p := range []string{"from-", "to-"} {
+		if strings.HasPrefix(name, p) {
+			prefix = p
+			break
+		}
+	}
+	for _, peer := range envPeers[strings.TrimPrefix(name, prefix)] {
+		if fs.Changed(prefix+peer) || fs.Changed(peer) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSet returns true if the named flag is specified on the command line or set
+// from its bound environment variable.
+func IsSet(fs *pflag.FlagSet, name string) bool {
+	if fs.Changed(name) {
+		return true
+	}
+	f := fs.Lookup(name)
+	if f == nil {
+		return false
+	}
+	_, ok := f.Annotations[envSetAnnotation]
+	return ok
+}
@@ -0,0 +1,134 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestEnvName(t *testing.T) {
+	if got, want := EnvName("from-plain-http"), "ORAS_FROM_PLAIN_HTTP"; got != want {
+		t.Errorf("EnvName() = %q, want %q", got, want)
+	}
+}
+
+func TestParse_env(t *testing.T) {
+	newCmd := func(args ...string) (*cobra.Command, *struct {
+		Common
+		Remote
+		concurrency int
+	}) {
+		opts := &struct {
+			Common
+			Remote
+			concurrency int
+		}{}
+		cmd := &cobra.Command{}
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		cmd.Flags().IntVar(&opts.concurrency, "concurrency", 3, "concurrency level")
+		ApplyFlags(opts, cmd.Flags())
+		if err := cmd.Flags().Parse(args); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return cmd, opts
+	}
+
+	t.Setenv("ORAS_USERNAME", "env-user")
+	t.Setenv("ORAS_PASSWORD", "env-password")
+	t.Setenv("ORAS_PLAIN_HTTP", "true")
+	t.Setenv("ORAS_CONCURRENCY", "7")
+
+	// env overrides defaults
+	cmd, opts := newCmd()
+	if err := Parse(cmd, opts); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if opts.Username != "env-user" {
+		t.Errorf("Username = %q, want %q", opts.Username, "env-user")
+	}
+	if plainHTTP, enforced := opts.plainHTTP(); !plainHTTP || !enforced {
+		t.Errorf("plainHTTP() = %v, %v, want true, true", plainHTTP, enforced)
+	}
+	if opts.concurrency != 7 {
+		t.Errorf("concurrency = %d, want 7", opts.concurrency)
+	}
+
+	// flags override env
+	cmd, opts = newCmd("--username", "flag-user", "--plain-http=false", "--concurrency", "2")
+	if err := Parse(cmd, opts); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if opts.Username != "flag-user" {
+		t.Errorf("Username = %q, want %q", opts.Username, "flag-user")
+	}
+	if plainHTTP, _ := opts.plainHTTP(); plainHTTP {
+		t.Error("plainHTTP() = true, want false")
+	}
+	if opts.concurrency != 2 {
+		t.Errorf("concurrency = %d, want 2", opts.concurrency)
+	}
+
+	// env not applied if conflicting with the flags on the command line
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+	if _, err := io.WriteString(w, "stdin-password\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	w.Close()
+	cmd, opts = newCmd("--password-stdin")
+	if err := Parse(cmd, opts); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if opts.Secret != "stdin-password" {
+		t.Errorf("Secret = %q, want %q", opts.Secret, "stdin-password")
+	}
+	if cmd.Flags().Changed("password") {
+		t.Error("--password is marked as changed by $ORAS_PASSWORD")
+	}
+	cmd, opts = newCmd("--identity-token", "token")
+	if err := Parse(cmd, opts); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if opts.Username != "" {
+		t.Errorf("Username = %q, want empty since --identity-token is specified", opts.Username)
+	}
+	if opts.Secret != "token" {
+		t.Errorf("Secret = %q, want %q", opts.Secret, "token")
+	}
+
+	// usages note the env vars
+	if usage := cmd.Flags().Lookup("password").Usage; !strings.Contains(usage, "$ORAS_PASSWORD") {
+		t.Errorf("usage of --password = %q, want $ORAS_PASSWORD noted", usage)
+	}
+
+	// invalid env
+	t.Setenv("ORAS_CONCURRENCY", "many")
+	cmd, opts = newCmd()
+	if err := Parse(cmd, opts); err == nil || !strings.Contains(err.Error(), "$ORAS_CONCURRENCY") {
+		t.Errorf("Parse() error = %v, want error on $ORAS_CONCURRENCY", err)
+	}
+}
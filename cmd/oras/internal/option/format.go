@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/output"
 )
 
 // FormatType represents a format type.
@@ -56,6 +57,11 @@ var (
 		Usage:     "Print output using the given Go template",
 		HasParams: true,
 	}
+	FormatTypeJSONPath = &FormatType{
+		Name:      "jsonpath",
+		Usage:     "Print the value selected by the given JSONPath expression, e.g. jsonpath='$.config.os'",
+		HasParams: true,
+	}
 	FormatTypeTable = &FormatType{
 		Name:  "table",
 		Usage: "Get direct referrers and output in table format",
@@ -72,10 +78,11 @@ var (
 
 // Format contains input and parsed options for formatted output flags.
 type Format struct {
-	FormatFlag   string
-	Type         string
-	Template     string
-	allowedTypes []*FormatType
+	FormatFlag     string
+	Type           string
+	Template       string
+	strictTemplate bool
+	allowedTypes   []*FormatType
 }
 
 // SetTypes sets the default format type and allowed format types.
@@ -95,6 +102,7 @@ func (opts *Format) ApplyFlags(fs *pflag.FlagSet) {
 	// apply flags
 	fs.StringVar(&opts.FormatFlag, "format", opts.FormatFlag, buf.String())
 	fs.StringVar(&opts.Template, "template", "", "[Experimental] Template string used to format output")
+	fs.BoolVar(&opts.strictTemplate, "strict-template", false, "[Experimental] fail with no output if the template or the JSONPath expression refers to nonexistent fields")
 }
 
 // Parse parses the input format flag.
@@ -102,13 +110,19 @@ func (opts *Format) Parse(_ *cobra.Command) error {
 	if err := opts.parseFlag(); err != nil {
 		return err
 	}
+	if opts.strictTemplate {
+		if opts.Type != FormatTypeGoTemplate.Name && opts.Type != FormatTypeJSONPath.Name {
+			return fmt.Errorf("--strict-template must be used with --format %s or %s", FormatTypeGoTemplate.Name, FormatTypeJSONPath.Name)
+		}
+		output.SetStrictTemplates(true)
+	}
 
 	if opts.Type == FormatTypeText.Name {
 		// flag not specified
 		return nil
 	}
 
-	if opts.Type == FormatTypeGoTemplate.Name && opts.Template == "" {
+	if (opts.Type == FormatTypeGoTemplate.Name || opts.Type == FormatTypeJSONPath.Name) && opts.Template == "" {
 		return &oerrors.Error{
 			Err:            fmt.Errorf("%q format specified but no template given", opts.Type),
 			Recommendation: fmt.Sprintf("use `--format %s=TEMPLATE` to specify the template", opts.Type),
@@ -119,7 +133,7 @@ func (opts *Format) Parse(_ *cobra.Command) error {
 	for _, t := range opts.allowedTypes {
 		if opts.Type == t.Name {
 			// type validation passed
-			return nil
+			return opts.parseTemplate()
 		}
 		optionalTypes = append(optionalTypes, t.Name)
 	}
@@ -129,6 +143,29 @@ func (opts *Format) Parse(_ *cobra.Command) error {
 	}
 }
 
+// parseTemplate reports template errors before any network request is sent.
+func (opts *Format) parseTemplate() error {
+	if opts.Type == FormatTypeJSONPath.Name {
+		if _, err := output.ParseJSONPath(opts.Template); err != nil {
+			return &oerrors.Error{
+				Err:            err,
+				Recommendation: "Please select the value by object keys and array indexes, e.g. $.manifest.annotations['org.opencontainers.image.version']",
+			}
+		}
+		return nil
+	}
+	if opts.Type != FormatTypeGoTemplate.Name {
+		return nil
+	}
+	if _, err := output.ParseTemplate(opts.Template); err != nil {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid template: %w", err),
+			Recommendation: "Please check the syntax of the Go template, see https://pkg.go.dev/text/template",
+		}
+	}
+	return nil
+}
+
 func (opts *Format) parseFlag() error {
 	opts.Type = opts.FormatFlag
 	if opts.Template != "" {
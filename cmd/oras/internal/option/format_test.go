@@ -0,0 +1,78 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"testing"
+)
+
+func TestFormat_Parse_invalidTemplate(t *testing.T) {
+	opts := Format{
+		FormatFlag: FormatTypeGoTemplate.Name + "={{.digest",
+	}
+	opts.allowedTypes = []*FormatType{FormatTypeText, FormatTypeGoTemplate}
+	if err := opts.Parse(nil); err == nil {
+		t.Fatal("Parse() expects error for invalid template")
+	}
+
+	opts = Format{
+		FormatFlag: FormatTypeGoTemplate.Name + "={{shortDigest .digest}}",
+	}
+	opts.allowedTypes = []*FormatType{FormatTypeText, FormatTypeGoTemplate}
+	if err := opts.Parse(nil); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+}
+
+func TestFormat_Parse_jsonPath(t *testing.T) {
+	opts := Format{
+		FormatFlag: FormatTypeJSONPath.Name + "=$.config[os",
+	}
+	opts.allowedTypes = []*FormatType{FormatTypeText, FormatTypeJSONPath}
+	if err := opts.Parse(nil); err == nil {
+		t.Fatal("Parse() expects error for invalid JSONPath")
+	}
+
+	opts = Format{
+		FormatFlag: FormatTypeJSONPath.Name,
+	}
+	opts.allowedTypes = []*FormatType{FormatTypeText, FormatTypeJSONPath}
+	if err := opts.Parse(nil); err == nil {
+		t.Fatal("Parse() expects error for missing JSONPath")
+	}
+
+	opts = Format{
+		FormatFlag: FormatTypeJSONPath.Name + "=$.config.os",
+	}
+	opts.allowedTypes = []*FormatType{FormatTypeText, FormatTypeJSONPath}
+	if err := opts.Parse(nil); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if opts.Type != FormatTypeJSONPath.Name || opts.Template != "$.config.os" {
+		t.Errorf("Parse() = (%q, %q), want (%q, %q)", opts.Type, opts.Template, FormatTypeJSONPath.Name, "$.config.os")
+	}
+}
+
+func TestFormat_Parse_strictTemplate(t *testing.T) {
+	opts := Format{
+		FormatFlag:     FormatTypeJSON.Name,
+		strictTemplate: true,
+	}
+	opts.allowedTypes = []*FormatType{FormatTypeText, FormatTypeJSON}
+	if err := opts.Parse(nil); err == nil {
+		t.Fatal("Parse() expects error for --strict-template without a template")
+	}
+}
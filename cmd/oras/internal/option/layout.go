@@ -0,0 +1,66 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// LayoutCorruptedError is returned when a blob referenced in an OCI image
+// layout is missing.
+type LayoutCorruptedError struct {
+	// Layout is the path of the OCI image layout.
+	Layout string
+	// Digest is the digest of the missing blob.
+	Digest digest.Digest
+	// Path is the expected path of the missing blob.
+	Path string
+}
+
+// Error returns the error message.
+func (e *LayoutCorruptedError) Error() string {
+	return fmt.Sprintf("OCI image layout %q is corrupted: blob %s is missing, expected at %s", e.Layout, e.Digest, e.Path)
+}
+
+// layoutTarget is a read-only OCI image layout reporting the missing blobs of
+// the resolved descriptors as layout corruption. Blobs resolved by digest are
+// checked for existence on resolving, so a blob missing on fetching must be
+// referenced by the index or a manifest.
+type layoutTarget struct {
+	ReadOnlyGraphTagFinderTarget
+	path string
+}
+
+// Fetch fetches the content identified by the descriptor.
+func (t *layoutTarget) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	rc, err := t.ReadOnlyGraphTagFinderTarget.Fetch(ctx, target)
+	if err != nil && errors.Is(err, errdef.ErrNotFound) {
+		return nil, &LayoutCorruptedError{
+			Layout: t.path,
+			Digest: target.Digest,
+			Path:   filepath.Join(t.path, ocispec.ImageBlobsDir, target.Digest.Algorithm().String(), target.Digest.Encoded()),
+		}
+	}
+	return rc, err
+}
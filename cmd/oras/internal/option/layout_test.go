@@ -0,0 +1,70 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+func TestTarget_NewReadonlyTarget_layoutCorrupted(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Tag(ctx, desc, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	blobPath := filepath.Join(dir, "blobs", desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+	if err := os.Remove(blobPath); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := Target{
+		Type:         TargetTypeOCILayout,
+		IsOCILayout:  true,
+		Path:         dir,
+		RawReference: dir + ":v1",
+	}
+	target, err := opts.NewReadonlyTarget(ctx, Common{}, logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = oras.FetchBytes(ctx, target, "v1", oras.DefaultFetchBytesOptions)
+	var corruptedErr *LayoutCorruptedError
+	if !errors.As(err, &corruptedErr) {
+		t.Fatalf("FetchBytes() error = %v, want LayoutCorruptedError", err)
+	}
+	if corruptedErr.Digest != desc.Digest || corruptedErr.Path != blobPath {
+		t.Errorf("LayoutCorruptedError = %+v, want digest %s at %s", corruptedErr, desc.Digest, blobPath)
+	}
+	if _, modified := opts.Modify(nil, err); !modified {
+		t.Error("Target.Modify() does not handle LayoutCorruptedError")
+	}
+}
@@ -42,6 +42,7 @@ var (
 	errAnnotationConflict    = errors.New("`--annotation` and `--annotation-file` cannot be both specified")
 	errAnnotationFormat      = errors.New("annotation value doesn't match the required format")
 	errAnnotationDuplication = errors.New("duplicate annotation key")
+	errAnnotationFileMissing = errors.New("annotated file is not specified")
 	errPathValidation        = errors.New("absolute file path detected. If it's intentional, use --disable-path-validation flag to skip this check")
 )
 
@@ -58,7 +59,7 @@ type Packer struct {
 // ApplyFlags applies flags to a command flag set.
 func (opts *Packer) ApplyFlags(fs *pflag.FlagSet) {
 	fs.StringVarP(&opts.ManifestExportPath, "export-manifest", "", "", "`path` of the pushed manifest")
-	fs.StringArrayVarP(&opts.ManifestAnnotations, "annotation", "a", nil, "manifest annotations")
+	fs.StringArrayVarP(&opts.ManifestAnnotations, "annotation", "a", nil, "manifest annotations in the form of `key=value`, or annotations of the layer packed from a file in the form of `file=key=value`")
 	fs.StringVarP(&opts.AnnotationFilePath, "annotation-file", "", "", "path of the annotation file")
 	fs.BoolVarP(&opts.PathValidationDisabled, "disable-path-validation", "", false, "skip path validation")
 }
@@ -109,7 +110,7 @@ func (opts *Packer) LoadManifestAnnotations() (annotations map[string]map[string
 	}
 	if len(opts.ManifestAnnotations) != 0 {
 		annotations = make(map[string]map[string]string)
-		if err = parseAnnotationFlags(opts.ManifestAnnotations, annotations); err != nil {
+		if err = parseAnnotationFlags(opts.ManifestAnnotations, annotations, opts.FileRefs); err != nil {
 			return nil, err
 		}
 	}
@@ -126,22 +127,57 @@ func decodeJSON(filename string, v interface{}) error {
 	return json.NewDecoder(file).Decode(v)
 }
 
-// parseAnnotationFlags parses annotation flags into a map.
-func parseAnnotationFlags(flags []string, annotations map[string]map[string]string) error {
+// parseAnnotationFlags parses annotation flags into a map. A flag in the form
+// of "file=key=value" annotates the layer packed from file, which must be one
+// of fileRefs; other flags annotate the manifest. A flag is a file annotation
+// if its part before the first "=" names a file in fileRefs, or looks like a
+// path, i.e. contains a path separator or exists, so that values of manifest
+// annotations can still contain "=".
+func parseAnnotationFlags(flags []string, annotations map[string]map[string]string, fileRefs []string) error {
+	files := make(map[string]string)
+	for _, fileRef := range fileRefs {
+		if filename, _, err := fileref.Parse(fileRef, ""); err == nil {
+			files[filepath.Clean(filename)] = filename
+		}
+	}
 	manifestAnnotations := make(map[string]string)
+	annotations[AnnotationManifest] = manifestAnnotations
 	for _, anno := range flags {
 		key, val, success := strings.Cut(anno, "=")
 		if !success {
 			return &oerrors.Error{
 				Err:            errAnnotationFormat,
-				Recommendation: `Please use the correct format in the flag: --annotation "key=value"`,
+				Recommendation: `Please use the correct format in the flag: --annotation "key=value" or --annotation "file=key=value"`,
+			}
+		}
+		target := manifestAnnotations
+		if fileKey, fileVal, ok := strings.Cut(val, "="); ok && fileKey != "" {
+			if filename, found := files[filepath.Clean(key)]; found {
+				if annotations[filename] == nil {
+					annotations[filename] = make(map[string]string)
+				}
+				target, key, val = annotations[filename], fileKey, fileVal
+			} else if isPathLike(key) {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("%w: %q in annotation %q", errAnnotationFileMissing, key, anno),
+					Recommendation: "Please specify the file as an argument of the command to annotate the layer packed from it",
+				}
 			}
 		}
-		if _, ok := manifestAnnotations[key]; ok {
+		if _, ok := target[key]; ok {
 			return fmt.Errorf("%w: %v, ", errAnnotationDuplication, key)
 		}
-		manifestAnnotations[key] = val
+		target[key] = val
 	}
-	annotations[AnnotationManifest] = manifestAnnotations
 	return nil
 }
+
+// isPathLike returns true if name contains a path separator or names an
+// existing file.
+func isPathLike(name string) bool {
+	if strings.ContainsAny(name, `/\`) {
+		return true
+	}
+	_, err := os.Stat(name)
+	return err == nil
+}
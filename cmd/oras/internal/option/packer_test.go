@@ -85,6 +85,47 @@ func TestPacker_LoadManifestAnnotations_annotationFile(t *testing.T) {
 	}
 }
 
+func TestPacker_LoadManifestAnnotations_fileAnnotationFlag(t *testing.T) {
+	opts := Packer{
+		ManifestAnnotations: []string{
+			"hi.txt=key=val=ue",
+			"./dir/bye.txt=key=val",
+			"key=val=ue",
+		},
+		FileRefs: []string{"hi.txt:text/plain", "dir/bye.txt"},
+	}
+	got, err := opts.LoadManifestAnnotations()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]map[string]string{
+		AnnotationManifest: {"key": "val=ue"},
+		"hi.txt":           {"key": "val=ue"},
+		"dir/bye.txt":      {"key": "val"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected annotations: got %v, want %v", got, want)
+	}
+
+	// annotated file not in the arguments
+	opts = Packer{
+		ManifestAnnotations: []string{"dir/missing.txt=key=val"},
+		FileRefs:            []string{"hi.txt"},
+	}
+	if _, err := opts.LoadManifestAnnotations(); !errors.Is(err, errAnnotationFileMissing) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// duplicate key of a file
+	opts = Packer{
+		ManifestAnnotations: []string{"hi.txt=key=0", "hi.txt=key=1"},
+		FileRefs:            []string{"hi.txt"},
+	}
+	if _, err := opts.LoadManifestAnnotations(); !errors.Is(err, errAnnotationDuplication) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestPacker_LoadManifestAnnotations_annotationFlag(t *testing.T) {
 	// Item do not contains '='
 	invalidFlag0 := []string{
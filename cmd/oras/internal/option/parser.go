@@ -27,8 +27,14 @@ type FlagParser interface {
 }
 
 // Parse parses applicable fields of the passed-in option pointer and returns
-// error during parsing.
+// error during parsing. Flags not specified on the command line are set from
+// the bound environment variables beforehand.
 func Parse(cmd *cobra.Command, optsPtr interface{}) error {
+	if cmd != nil {
+		if err := applyEnvs(cmd.Flags()); err != nil {
+			return err
+		}
+	}
 	return rangeFields(optsPtr, func(fp FlagParser) error {
 		return fp.Parse(cmd)
 	})
@@ -16,20 +16,59 @@ limitations under the License.
 package option
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"runtime"
+	"slices"
 	"strings"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/docker"
+	"oras.land/oras/internal/registryutil"
+)
+
+// known operating systems and architectures, i.e. the valid GOOS and GOARCH
+// values.
+var (
+	knownOS = []string{
+		"aix", "android", "darwin", "dragonfly", "freebsd", "illumos", "ios",
+		"js", "linux", "netbsd", "openbsd", "plan9", "solaris", "wasip1",
+		"windows",
+	}
+	knownArch = []string{
+		"386", "amd64", "arm", "arm64", "loong64", "mips", "mips64",
+		"mips64le", "mipsle", "ppc64", "ppc64le", "riscv64", "s390x", "wasm",
+	}
+	// platformAliases maps the common non-GOOS/GOARCH names to the valid ones.
+	platformAliases = map[string]string{
+		"macos":   "darwin",
+		"osx":     "darwin",
+		"x86_64":  "amd64",
+		"x86-64":  "amd64",
+		"aarch64": "arm64",
+		"armhf":   "arm",
+		"armel":   "arm",
+		"i386":    "386",
+		"i686":    "386",
+	}
 )
 
 // Platform option struct.
 type Platform struct {
 	platform        string
+	allowUnknown    bool
 	Platform        *ocispec.Platform
 	FlagDescription string
+	// MaxConfigBytes limits the size of the configs fetched to match the
+	// platform if positive.
+	MaxConfigBytes int64
 }
 
 // ApplyFlags applies flags to a command flag set.
@@ -38,6 +77,7 @@ func (opts *Platform) ApplyFlags(fs *pflag.FlagSet) {
 		opts.FlagDescription = "request platform"
 	}
 	fs.StringVarP(&opts.platform, "platform", "", "", opts.FlagDescription+" in the form of `os[/arch][/variant][:os_version]`")
+	fs.BoolVarP(&opts.allowUnknown, "allow-unknown-platform", "", false, "[Preview] allow the operating system and architecture of --platform not known to Go")
 }
 
 // parse parses the input platform flag to an oci platform type.
@@ -70,6 +110,190 @@ func (opts *Platform) Parse(*cobra.Command) error {
 	if p.Architecture == "" {
 		return fmt.Errorf("invalid platform: Architecture cannot be empty")
 	}
+	if !opts.allowUnknown {
+		if err := opts.validate("operating system", p.OS, knownOS); err != nil {
+			return err
+		}
+		if err := opts.validate("architecture", p.Architecture, knownArch); err != nil {
+			return err
+		}
+	}
 	opts.Platform = &p
 	return nil
 }
+
+// validate returns an error if value of the platform field is not known.
+func (opts *Platform) validate(field, value string, known []string) error {
+	if slices.Contains(known, value) {
+		return nil
+	}
+	recommendation := "Use --allow-unknown-platform if the platform is intended"
+	if alias, ok := platformAliases[strings.ToLower(value)]; ok && slices.Contains(known, alias) {
+		recommendation = fmt.Sprintf("Do you mean %q? %s", alias, recommendation)
+	} else if lower := strings.ToLower(value); lower != value && slices.Contains(known, lower) {
+		recommendation = fmt.Sprintf("Do you mean %q? %s", lower, recommendation)
+	}
+	return &oerrors.Error{
+		Err:            fmt.Errorf("invalid platform %q: unknown %s %q", opts.platform, field, value),
+		Recommendation: recommendation,
+	}
+}
+
+// Match returns true if p matches the requested platform, or if no platform
+// is requested. The architecture and the operating system must be the same.
+// The variant and the OS version are compared only if requested, where
+//   - the default variants are matched, i.e. "v8" for arm64 and "v7" for arm,
+//   - Windows OS versions are compared by build number, and by revision only
+//     if it is requested.
+func (opts *Platform) Match(p ocispec.Platform) bool {
+	want := opts.Platform
+	if want == nil {
+		return true
+	}
+	if p.OS != want.OS || p.Architecture != want.Architecture {
+		return false
+	}
+	if want.Variant != "" && normalizeVariant(p.Architecture, p.Variant) != normalizeVariant(want.Architecture, want.Variant) {
+		return false
+	}
+	if want.OSVersion != "" && !matchOSVersion(p.OS, p.OSVersion, want.OSVersion) {
+		return false
+	}
+	return true
+}
+
+// normalizeVariant returns the canonical form of the variant of arch.
+func normalizeVariant(arch, variant string) string {
+	if variant != "" && !strings.HasPrefix(variant, "v") {
+		variant = "v" + variant
+	}
+	if variant == "" {
+		switch arch {
+		case "arm64":
+			return "v8"
+		case "arm":
+			return "v7"
+		}
+	}
+	return variant
+}
+
+// matchOSVersion returns true if the OS version got matches want.
+func matchOSVersion(os, got, want string) bool {
+	if os != "windows" {
+		return got == want
+	}
+	// Windows versions are in the form of major.minor.build[.revision]
+	gotParts := strings.SplitN(got, ".", 4)
+	wantParts := strings.SplitN(want, ".", 4)
+	if len(gotParts) < 3 || len(wantParts) < 3 {
+		return got == want
+	}
+	if !slices.Equal(gotParts[:3], wantParts[:3]) {
+		return false
+	}
+	return len(wantParts) == 3 || len(gotParts) == 4 && gotParts[3] == wantParts[3]
+}
+
+// SelectManifest returns the first manifest of root matching the requested
+// platform if root is an index. If root is an image manifest, root is returned
+// if the platform in its config matches.
+func (opts *Platform) SelectManifest(ctx context.Context, src content.ReadOnlyStorage, root ocispec.Descriptor) (ocispec.Descriptor, error) {
+	if opts.Platform == nil {
+		return root, nil
+	}
+	switch root.MediaType {
+	case ocispec.MediaTypeImageIndex, docker.MediaTypeManifestList:
+		var index ocispec.Index
+		if err := fetchJSON(ctx, src, root, &index); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		var available []string
+		for _, m := range index.Manifests {
+			if m.Platform == nil {
+				continue
+			}
+			if opts.Match(*m.Platform) {
+				return m, nil
+			}
+			available = append(available, platformString(m.Platform))
+		}
+		if len(available) == 0 {
+			return ocispec.Descriptor{}, fmt.Errorf("%s: %w: no manifest matching platform %s was found in the index, which has no platform-specific manifests", root.Digest, errdef.ErrNotFound, opts.platform)
+		}
+		return ocispec.Descriptor{}, fmt.Errorf("%s: %w: no manifest matching platform %s was found in the index, available platforms: %s", root.Digest, errdef.ErrNotFound, opts.platform, strings.Join(available, ", "))
+	case ocispec.MediaTypeImageManifest, docker.MediaTypeManifest:
+		var manifest ocispec.Manifest
+		if err := fetchJSON(ctx, src, root, &manifest); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if mt := manifest.Config.MediaType; mt != ocispec.MediaTypeImageConfig && mt != docker.MediaTypeConfig {
+			return ocispec.Descriptor{}, fmt.Errorf("%s: %w: platform is unknown for config %s", root.Digest, errdef.ErrNotFound, manifest.Config.MediaType)
+		}
+		configJSON, err := registryutil.FetchConfig(ctx, src, manifest.Config, opts.MaxConfigBytes)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		var config ocispec.Platform
+		if err := json.Unmarshal(configJSON, &config); err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to parse %s: %w", manifest.Config.Digest, err)
+		}
+		if opts.Match(config) {
+			return root, nil
+		}
+		return ocispec.Descriptor{}, fmt.Errorf("%s: %w: platform in manifest does not match platform %s", root.Digest, errdef.ErrNotFound, opts.platform)
+	default:
+		return ocispec.Descriptor{}, fmt.Errorf("%s: %s: %w", root.Digest, root.MediaType, errdef.ErrUnsupported)
+	}
+}
+
+// Resolve resolves reference and selects the manifest matching the requested
+// platform.
+func (opts *Platform) Resolve(ctx context.Context, src oras.ReadOnlyTarget, reference string) (ocispec.Descriptor, error) {
+	root, err := oras.Resolve(ctx, src, reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return opts.SelectManifest(ctx, src, root)
+}
+
+// UpdateCopyOptions makes copyOpts copy the manifest matching the requested
+// platform.
+func (opts *Platform) UpdateCopyOptions(copyOpts *oras.CopyOptions) {
+	if opts.Platform == nil {
+		return
+	}
+	mapRoot := copyOpts.MapRoot
+	copyOpts.MapRoot = func(ctx context.Context, src content.ReadOnlyStorage, root ocispec.Descriptor) (desc ocispec.Descriptor, err error) {
+		if mapRoot != nil {
+			if root, err = mapRoot(ctx, src, root); err != nil {
+				return ocispec.Descriptor{}, err
+			}
+		}
+		return opts.SelectManifest(ctx, src, root)
+	}
+}
+
+// platformString returns p in the form of os/arch[/variant][:os_version].
+func platformString(p *ocispec.Platform) string {
+	s := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+	if p.OSVersion != "" {
+		s += ":" + p.OSVersion
+	}
+	return s
+}
+
+// fetchJSON fetches the content of desc and decodes it into v.
+func fetchJSON(ctx context.Context, src content.Fetcher, desc ocispec.Descriptor, v any) error {
+	data, err := content.FetchAll(ctx, src, desc)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", desc.Digest, err)
+	}
+	return nil
+}
@@ -14,12 +14,25 @@ limitations under the License.
 package option
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/pflag"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/errdef"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	oio "oras.land/oras/internal/io"
+	"oras.land/oras/internal/registryutil"
 )
 
 func TestPlatform_ApplyFlags(t *testing.T) {
@@ -35,11 +48,11 @@ func TestPlatform_Parse_err(t *testing.T) {
 		name string
 		opts *Platform
 	}{
-		{name: "empty arch 1", opts: &Platform{"os/", nil, ""}},
-		{name: "empty arch 2", opts: &Platform{"os//variant", nil, ""}},
-		{name: "empty os", opts: &Platform{"/arch", nil, ""}},
-		{name: "empty os with variant", opts: &Platform{"/arch/variant", nil, ""}},
-		{name: "trailing slash", opts: &Platform{"os/arch/variant/llama", nil, ""}},
+		{name: "empty arch 1", opts: &Platform{platform: "os/"}},
+		{name: "empty arch 2", opts: &Platform{platform: "os//variant"}},
+		{name: "empty os", opts: &Platform{platform: "/arch"}},
+		{name: "empty os with variant", opts: &Platform{platform: "/arch/variant"}},
+		{name: "trailing slash", opts: &Platform{platform: "os/arch/variant/llama"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -59,12 +72,12 @@ func TestPlatform_Parse(t *testing.T) {
 		want *ocispec.Platform
 	}{
 		{name: "empty", opts: &Platform{platform: ""}, want: nil},
-		{name: "default arch", opts: &Platform{platform: "os"}, want: &ocispec.Platform{OS: "os", Architecture: runtime.GOARCH}},
-		{name: "os&arch", opts: &Platform{platform: "os/aRcH"}, want: &ocispec.Platform{OS: "os", Architecture: "aRcH"}},
-		{name: "empty variant", opts: &Platform{platform: "os/aRcH/"}, want: &ocispec.Platform{OS: "os", Architecture: "aRcH", Variant: ""}},
-		{name: "os&arch&variant", opts: &Platform{platform: "os/aRcH/vAriAnt"}, want: &ocispec.Platform{OS: "os", Architecture: "aRcH", Variant: "vAriAnt"}},
-		{name: "os version", opts: &Platform{platform: "os/aRcH/vAriAnt:osversion"}, want: &ocispec.Platform{OS: "os", Architecture: "aRcH", Variant: "vAriAnt", OSVersion: "osversion"}},
-		{name: "long os version", opts: &Platform{platform: "os/aRcH"}, want: &ocispec.Platform{OS: "os", Architecture: "aRcH"}},
+		{name: "default arch", opts: &Platform{platform: "os", allowUnknown: true}, want: &ocispec.Platform{OS: "os", Architecture: runtime.GOARCH}},
+		{name: "os&arch", opts: &Platform{platform: "os/aRcH", allowUnknown: true}, want: &ocispec.Platform{OS: "os", Architecture: "aRcH"}},
+		{name: "empty variant", opts: &Platform{platform: "os/aRcH/", allowUnknown: true}, want: &ocispec.Platform{OS: "os", Architecture: "aRcH", Variant: ""}},
+		{name: "os&arch&variant", opts: &Platform{platform: "os/aRcH/vAriAnt", allowUnknown: true}, want: &ocispec.Platform{OS: "os", Architecture: "aRcH", Variant: "vAriAnt"}},
+		{name: "os version", opts: &Platform{platform: "os/aRcH/vAriAnt:osversion", allowUnknown: true}, want: &ocispec.Platform{OS: "os", Architecture: "aRcH", Variant: "vAriAnt", OSVersion: "osversion"}},
+		{name: "long os version", opts: &Platform{platform: "os/aRcH", allowUnknown: true}, want: &ocispec.Platform{OS: "os", Architecture: "aRcH"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -78,3 +91,171 @@ func TestPlatform_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestPlatform_Parse_unknown(t *testing.T) {
+	tests := []struct {
+		platform           string
+		wantRecommendation string
+	}{
+		{platform: "linux/x86_64", wantRecommendation: `Do you mean "amd64"?`},
+		{platform: "Linux/amd64", wantRecommendation: `Do you mean "linux"?`},
+		{platform: "macos/arm64", wantRecommendation: `Do you mean "darwin"?`},
+		{platform: "unknown/unknown", wantRecommendation: "--allow-unknown-platform"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			opts := &Platform{platform: tt.platform}
+			err := opts.Parse(nil)
+			var oerr *oerrors.Error
+			if !errors.As(err, &oerr) {
+				t.Fatalf("Platform.Parse() error = %v, want %T", err, oerr)
+			}
+			if !strings.Contains(oerr.Recommendation, tt.wantRecommendation) {
+				t.Errorf("Platform.Parse() recommendation = %q, want %q", oerr.Recommendation, tt.wantRecommendation)
+			}
+
+			opts = &Platform{platform: tt.platform, allowUnknown: true}
+			if err := opts.Parse(nil); err != nil {
+				t.Errorf("Platform.Parse() with unknown platforms allowed error = %v", err)
+			}
+		})
+	}
+}
+
+func TestPlatform_Match(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		got  ocispec.Platform
+		ok   bool
+	}{
+		{"os mismatch", "linux/amd64", ocispec.Platform{OS: "windows", Architecture: "amd64"}, false},
+		{"arch mismatch", "linux/amd64", ocispec.Platform{OS: "linux", Architecture: "arm64"}, false},
+		{"any variant", "linux/arm", ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v6"}, true},
+		{"arm variant", "linux/arm/v7", ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}, true},
+		{"arm variant mismatch", "linux/arm/v7", ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v6"}, false},
+		{"arm default variant", "linux/arm/v7", ocispec.Platform{OS: "linux", Architecture: "arm"}, true},
+		{"arm non-default variant", "linux/arm/v6", ocispec.Platform{OS: "linux", Architecture: "arm"}, false},
+		{"arm variant without prefix", "linux/arm/7", ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}, true},
+		{"arm64 default variant", "linux/arm64/v8", ocispec.Platform{OS: "linux", Architecture: "arm64"}, true},
+		{"arm64 variant mismatch", "linux/arm64/v8", ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v9"}, false},
+		{"os version", "linux/amd64:1.0", ocispec.Platform{OS: "linux", Architecture: "amd64", OSVersion: "1.0"}, true},
+		{"os version mismatch", "linux/amd64:1.0", ocispec.Platform{OS: "linux", Architecture: "amd64", OSVersion: "1.0.1"}, false},
+		{"windows build", "windows/amd64:10.0.17763", ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763.5329"}, true},
+		{"windows build mismatch", "windows/amd64:10.0.17763", ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.20348.2227"}, false},
+		{"windows revision", "windows/amd64:10.0.17763.5329", ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763.5329"}, true},
+		{"windows revision mismatch", "windows/amd64:10.0.17763.5329", ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763.1"}, false},
+		{"windows revision missing", "windows/amd64:10.0.17763.5329", ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &Platform{platform: tt.want}
+			if err := opts.Parse(nil); err != nil {
+				t.Fatal(err)
+			}
+			if got := opts.Match(tt.got); got != tt.ok {
+				t.Errorf("Platform.Match(%v) = %v, want %v", tt.got, got, tt.ok)
+			}
+		})
+	}
+
+	var none Platform
+	if !none.Match(ocispec.Platform{OS: "linux", Architecture: "amd64"}) {
+		t.Error("Platform.Match() = false without requested platform, want true")
+	}
+}
+
+func TestPlatform_SelectManifest(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	var manifests []ocispec.Descriptor
+	for _, p := range []ocispec.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+	} {
+		desc := ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromString(p.Architecture),
+			Size:      int64(len(p.Architecture)),
+			Platform:  &p,
+		}
+		manifests = append(manifests, desc)
+	}
+	indexJSON, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: manifests,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, indexJSON)
+	if err := store.Push(ctx, index, bytes.NewReader(indexJSON)); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &Platform{platform: "linux/arm64/v8"}
+	if err := opts.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	got, err := opts.SelectManifest(ctx, store, index)
+	if err != nil {
+		t.Fatalf("Platform.SelectManifest() error = %v", err)
+	}
+	if got.Digest != manifests[1].Digest {
+		t.Errorf("Platform.SelectManifest() = %v, want %v", got.Digest, manifests[1].Digest)
+	}
+
+	opts = &Platform{platform: "linux/s390x"}
+	if err := opts.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	_, err = opts.SelectManifest(ctx, store, index)
+	if !errors.Is(err, errdef.ErrNotFound) {
+		t.Fatalf("Platform.SelectManifest() error = %v, want %v", err, errdef.ErrNotFound)
+	}
+	if want := "available platforms: linux/amd64, linux/arm64"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Platform.SelectManifest() error = %v, want %q", err, want)
+	}
+}
+
+func TestPlatform_SelectManifest_configSizeLimit(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	configJSON := []byte(`{"os":"linux","architecture":"amd64"}`)
+	config := content.NewDescriptorFromBytes(ocispec.MediaTypeImageConfig, configJSON)
+	if err := store.Push(ctx, config, bytes.NewReader(configJSON)); err != nil {
+		t.Fatal(err)
+	}
+	manifestJSON, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ocispec.Descriptor{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, manifestJSON)
+	if err := store.Push(ctx, manifest, bytes.NewReader(manifestJSON)); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &Platform{platform: "linux/amd64", MaxConfigBytes: config.Size}
+	if err := opts.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := opts.SelectManifest(ctx, store, manifest); err != nil {
+		t.Fatalf("Platform.SelectManifest() error = %v", err)
+	}
+
+	opts.MaxConfigBytes = config.Size - 1
+	_, err = opts.SelectManifest(ctx, store, manifest)
+	var sizeErr *registryutil.ConfigSizeError
+	if !errors.As(err, &sizeErr) {
+		t.Fatalf("Platform.SelectManifest() error = %v, want a config size error", err)
+	}
+	if !errors.Is(err, oio.ErrSizeLimitExceeded) {
+		t.Errorf("Platform.SelectManifest() error = %v, want %v", err, oio.ErrSizeLimitExceeded)
+	}
+}
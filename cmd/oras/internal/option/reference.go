@@ -0,0 +1,198 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+)
+
+var (
+	// repositoryComponentRegexp is the path component grammar of repository
+	// names in the distribution specification.
+	repositoryComponentRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|[-]+)[a-z0-9]+)*$`)
+	// tagRegexp is the tag grammar in the distribution specification.
+	tagRegexp = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+)
+
+// ValidateReference validates the reference raw in the form of
+// <registry>/<repository>[:tag|@digest] against the distribution grammar
+// before any request is sent. The returned error points to the offending
+// segment with a caret and suggests a corrected reference if possible.
+func ValidateReference(raw string) error {
+	_, err := parseReference(raw, raw)
+	return err
+}
+
+// parseReference parses the normalized form of the reference raw, reporting
+// the errors against raw.
+func parseReference(raw, normalized string) (registry.Reference, error) {
+	ref, err := registry.ParseReference(normalized)
+	if err == nil && strings.HasSuffix(raw, ":") {
+		err = fmt.Errorf("%w: missing tag", errdef.ErrInvalidReference)
+	}
+	if err != nil {
+		return registry.Reference{}, newErrInvalidReference(raw, err)
+	}
+	return ref, nil
+}
+
+// newErrInvalidReference returns the error of the invalid reference raw
+// pointing to the offending segment, with the form of a valid reference.
+func newErrInvalidReference(raw string, err error) *oerrors.Error {
+	recommendation := "Please make sure the provided reference is in the form of <registry>/<repo>[:tag|@digest], e.g. localhost:5000/hello:v1"
+	offset, reason, fixed := locateReferenceError(raw)
+	fixed = completeFix(fixed)
+	candidate := raw
+	if fixed != "" {
+		candidate = fixed
+	}
+	if name, tag, _ := strings.Cut(candidate, ":"); name != "" && !strings.ContainsAny(candidate, "/@") && !strings.Contains(name, ".") && name != "localhost" {
+		// only a repository is given
+		if tag == "" {
+			tag = "latest"
+		}
+		if _, err := registry.ParseReference(placeholderRegistry + "/" + name + ":" + tag); err == nil {
+			recommendation += fmt.Sprintf(". Do you mean \"<registry>/%s:%s\"?", name, tag)
+		}
+	} else if fixed != "" {
+		recommendation += fmt.Sprintf(". Do you mean %q?", fixed)
+	}
+	err = fmt.Errorf("%q: %w", raw, err)
+	if reason != "" {
+		err = fmt.Errorf("%w\n  %s\n  %s^ %s", err, raw, strings.Repeat(" ", utf8.RuneCountInString(raw[:offset])), reason)
+	}
+	return &oerrors.Error{
+		OperationType:  oerrors.OperationTypeParseArtifactReference,
+		Err:            err,
+		Recommendation: recommendation,
+	}
+}
+
+// placeholderRegistry is the registry prepended to a corrected reference
+// without a registry, so that the reference can be validated.
+const placeholderRegistry = "localhost"
+
+// completeFix keeps correcting the corrected reference fixed until it is
+// valid, since more than one segment may violate the distribution grammar. An
+// empty string is returned if no valid reference can be derived.
+func completeFix(fixed string) string {
+	if fixed == "" {
+		return ""
+	}
+	prefix := ""
+	if !strings.Contains(fixed, "/") {
+		prefix = placeholderRegistry + "/"
+	}
+	ref := prefix + fixed
+	for {
+		if _, err := registry.ParseReference(ref); err == nil {
+			return strings.TrimPrefix(ref, prefix)
+		}
+		_, _, next := locateReferenceError(ref)
+		if next == "" || next == ref {
+			return ""
+		}
+		ref = next
+	}
+}
+
+// locateReferenceError returns the byte offset of the first segment of the
+// reference raw violating the distribution grammar, the reason, and the
+// corrected reference if it can be derived. An empty reason is returned if no
+// offending segment is found.
+func locateReferenceError(raw string) (offset int, reason string, fixed string) {
+	if i := strings.IndexFunc(raw, unicode.IsSpace); i >= 0 {
+		return i, "whitespace is not allowed", strings.Join(strings.Fields(raw), "")
+	}
+
+	// registry
+	start := 0
+	switch i := strings.Index(raw, "/"); {
+	case i == 0:
+		return 0, "missing registry", ""
+	case i > 0:
+		if j := strings.IndexFunc(raw[:i], func(r rune) bool {
+			return isInvalidNameRune(r) && !strings.ContainsRune(":[]", r)
+		}); j >= 0 {
+			r, _ := utf8.DecodeRuneInString(raw[j:])
+			return j, fmt.Sprintf("invalid character %q in the registry", r), ""
+		}
+		start = i + 1
+	}
+
+	// digest
+	path := raw[start:]
+	if i := strings.Index(path, "@"); i >= 0 {
+		if _, err := digest.Parse(path[i+1:]); err != nil {
+			return start + i + 1, fmt.Sprintf("invalid digest: %v", err), ""
+		}
+		path = path[:i]
+	}
+
+	// tag
+	repository := path
+	if i := strings.LastIndex(path, ":"); i >= 0 && !strings.Contains(path[i:], "/") {
+		repository = path[:i]
+		tagOffset := start + i + 1
+		switch tag := path[i+1:]; {
+		case tag == "":
+			return tagOffset, "missing tag after \":\"", raw[:tagOffset] + "latest" + raw[tagOffset:]
+		case !tagRegexp.MatchString(tag):
+			if strings.ContainsRune(".-", rune(tag[0])) {
+				return tagOffset, "tag must start with a letter, a digit or \"_\"", ""
+			}
+			if j := strings.IndexFunc(tag, isInvalidNameRune); j >= 0 {
+				r, _ := utf8.DecodeRuneInString(tag[j:])
+				return tagOffset + j, fmt.Sprintf("invalid character %q in the tag", r), ""
+			}
+			return tagOffset + 128, "tag must be at most 128 characters", ""
+		}
+	}
+
+	// repository
+	if i := strings.IndexFunc(repository, unicode.IsUpper); i >= 0 {
+		return start + i, "repository must be lowercase", raw[:start] + strings.ToLower(repository) + raw[start+len(repository):]
+	}
+	componentOffset := start
+	for _, component := range strings.Split(repository, "/") {
+		if !repositoryComponentRegexp.MatchString(component) {
+			if j := strings.IndexFunc(component, isInvalidNameRune); j >= 0 {
+				r, _ := utf8.DecodeRuneInString(component[j:])
+				return componentOffset + j, fmt.Sprintf("invalid character %q in the repository", r), ""
+			}
+			return componentOffset, fmt.Sprintf("invalid repository component %q", component), ""
+		}
+		componentOffset += len(component) + 1
+	}
+	if start == 0 {
+		return 0, "missing registry or repository", ""
+	}
+	return 0, "", ""
+}
+
+// isInvalidNameRune returns true if r is not allowed in repositories and tags.
+func isInvalidNameRune(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.' || r == '-')
+}
@@ -0,0 +1,95 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"strings"
+	"testing"
+
+	"oras.land/oras-go/v2/errdef"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+)
+
+func TestValidateReference(t *testing.T) {
+	tests := []struct {
+		raw       string
+		wantErr   bool
+		wantCaret string
+		wantFixed string
+	}{
+		{"localhost:5000/hello:v1", false, "", ""},
+		{"localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5", false, "", ""},
+		{"localhost:5000/Hello:v1", true, "               ^ repository must be lowercase", `"localhost:5000/hello:v1"`},
+		{"localhost:5000/hello world:v1", true, "                    ^ whitespace is not allowed", `"localhost:5000/helloworld:v1"`},
+		{"localhost:5000/hello:", true, "                     ^ missing tag", `"localhost:5000/hello:latest"`},
+		{"localhost:5000/hello:v1+1", true, "                       ^ invalid character '+' in the tag", ""},
+		{"localhost:5000/hello:.v1", true, "                     ^ tag must start", ""},
+		{"localhost:5000/a//b:v1", true, "                 ^ invalid repository component", ""},
+		{"localhost:5000/hello@sha256:abc", true, "                     ^ invalid digest", ""},
+		{"/hello:v1", true, "^ missing registry", ""},
+		{"Hello", true, "^ repository must be lowercase", `"<registry>/hello:latest"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			err := ValidateReference(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateReference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			if oerrors.ExitCode(err) != oerrors.ExitCodeUsage {
+				t.Errorf("ExitCode() = %d, want %d", oerrors.ExitCode(err), oerrors.ExitCodeUsage)
+			}
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) < 3 || lines[1] != "  "+tt.raw || !strings.HasPrefix(lines[2], "  "+tt.wantCaret) {
+				t.Errorf("ValidateReference() error = %q, want caret %q", err, tt.wantCaret)
+			}
+			if tt.wantFixed != "" && !strings.Contains(err.Error(), "Do you mean "+tt.wantFixed) {
+				t.Errorf("ValidateReference() error = %q, want suggestion %s", err, tt.wantFixed)
+			}
+		})
+	}
+}
+
+func Test_newErrInvalidReference(t *testing.T) {
+	tests := []struct {
+		raw     string
+		suggest string
+	}{
+		{"hello", `Do you mean "<registry>/hello:latest"?`},
+		{"hello:v1", `Do you mean "<registry>/hello:v1"?`},
+		{"Hello:v1", `Do you mean "<registry>/hello:v1"?`},
+		{"localhost:5000/Hello World:v1", `Do you mean "localhost:5000/helloworld:v1"?`},
+		{"localhost:5000/Hello World:", `Do you mean "localhost:5000/helloworld:latest"?`},
+		{"localhost:5000", ""},
+		{"/test", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			err := newErrInvalidReference(tt.raw, errdef.ErrInvalidReference)
+			if oerrors.ExitCode(err) != oerrors.ExitCodeUsage {
+				t.Errorf("ExitCode() = %d, want %d", oerrors.ExitCode(err), oerrors.ExitCodeUsage)
+			}
+			if !strings.Contains(err.Recommendation, "<registry>/<repo>[:tag|@digest]") {
+				t.Errorf("recommendation %q does not contain the reference form", err.Recommendation)
+			}
+			if got := strings.Contains(err.Recommendation, "Do you mean"); got != (tt.suggest != "") || !strings.Contains(err.Recommendation, tt.suggest) {
+				t.Errorf("recommendation %q, want suggestion %q", err.Recommendation, tt.suggest)
+			}
+		})
+	}
+}
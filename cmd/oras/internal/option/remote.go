@@ -17,20 +17,30 @@ package option
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"math"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/opencontainers/go-digest"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/term"
 	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
@@ -38,9 +48,13 @@ import (
 	"oras.land/oras-go/v2/registry/remote/errcode"
 	"oras.land/oras-go/v2/registry/remote/retry"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/config"
 	"oras.land/oras/internal/credential"
 	"oras.land/oras/internal/crypto"
+	oio "oras.land/oras/internal/io"
 	onet "oras.land/oras/internal/net"
+	"oras.land/oras/internal/registryutil"
 	"oras.land/oras/internal/trace"
 	"oras.land/oras/internal/version"
 )
@@ -52,10 +66,79 @@ const (
 	usernameFlag               = "username"
 	passwordFlag               = "password"
 	passwordFromStdinFlag      = "password-stdin"
+	passwordFileFlag           = "password-file"
 	identityTokenFlag          = "identity-token"
 	identityTokenFromStdinFlag = "identity-token-stdin"
+	registryTokenFlag          = "registry-token"
+	insecureFlag               = "insecure"
+	plainHTTPFlag              = "plain-http"
+	proxyFlag                  = "proxy"
+	retryFlag                  = "retry"
+	retryDelayFlag             = "retry-delay"
+	retryMaxDelayFlag          = "retry-max-delay"
+	userAgentSuffixFlag        = "user-agent-suffix"
+	maxIdleConnsPerHostFlag    = "max-idle-conns-per-host"
+	disableHTTP2Flag           = "disable-http2"
+	tlsMinVersionFlag          = "tls-min-version"
+	unixSocketFlag             = "unix-socket"
+	registryConfigFlag         = "registry-config"
+	noDockerConfigFlag         = "no-docker-config"
+	limitRateFlag              = "limit-rate"
+	maxMetadataSizeFlag        = "max-metadata-size"
+	noWarningsFlag             = "no-warnings"
+	requestIDHeaderFlag        = "request-id-header"
+	redactHeaderFlag           = "redact-header"
+	contextFlag                = "context"
+	dockerCompatFlag           = "docker-compat"
+	manifestHeadCheckFlag      = "manifest-head-check"
+	authTimeoutFlag            = "auth-timeout"
 )
 
+// defaultAuthTimeout is the default timeout of the requests to token endpoints.
+const defaultAuthTimeout = 30 * time.Second
+
+// manifestHeadCheckUsage is the usage of the flag controlling HEAD requests
+// for manifests.
+const manifestHeadCheckUsage = "check the existence of manifests and resolve references with HEAD requests, falling back to GET requests if the responses are unreliable; set to false to always use GET requests for registries with broken HEAD support"
+
+// authCaches holds the auth caches shared by the remote clients created in one
+// invocation, keyed by the source of the credentials. Tokens are reused across
+// clients and repositories only if they authenticate with the same credentials.
+var authCaches sync.Map // map[string]auth.Cache
+
+// referrersCapabilities holds the referrers API capabilities of the registries
+// detected from manifest pushes in one process, keyed by the registry host.
+var referrersCapabilities sync.Map // map[string]bool
+
+// InsecureRegistriesEnv is the environment variable listing the registries,
+// separated by commas, for which TLS certificate verification is skipped
+// unless --insecure is explicitly specified. Each entry is a host, a host:port
+// pair, or a CIDR notation.
+const InsecureRegistriesEnv = "ORAS_INSECURE_REGISTRIES"
+
+// PlainHTTPRegistriesEnv is the environment variable listing the registries,
+// separated by commas, accessed via plain HTTP unless --plain-http is
+// explicitly specified. Entries are in the same format as
+// ORAS_INSECURE_REGISTRIES.
+const PlainHTTPRegistriesEnv = "ORAS_PLAIN_HTTP_REGISTRIES"
+
+// RegistryConfigEnv is the environment variable specifying the paths of the
+// authentication files, separated by the OS-specific path list separator.
+// It is used when no authentication file is specified via flags.
+const RegistryConfigEnv = "ORAS_REGISTRY_CONFIG"
+
+// RegistryConfigsFromEnv returns the authentication file paths specified by
+// the ORAS_REGISTRY_CONFIG environment variable.
+func RegistryConfigsFromEnv() []string {
+	var configs []string
+	for _, config := range filepath.SplitList(os.Getenv(RegistryConfigEnv)) {
+		if config != "" {
+			configs = append(configs, config)
+		}
+	}
+	return configs
+}
+
 // Remote options struct contains flags and arguments specifying one registry.
 // Remote implements oerrors.Handler and interface.
 type Remote struct {
@@ -65,18 +148,70 @@ type Remote struct {
 	KeyFilePath     string
 	Insecure        bool
 	Configs         []string
+	NoDockerConfig  bool
 	Username        string
 	secretFromStdin bool
+	passwordFile    string
 	Secret          string
-	flagPrefix      string
+	RegistryToken   string
+	// MaxMetadataBytes is the size limit of metadata fetched from the registry,
+	// or 0 for no limit.
+	MaxMetadataBytes int64
+	flagPrefix       string
 
 	resolveFlag           []string
 	applyDistributionSpec bool
+	anonymousFallback     bool
+	applyMirror           bool
+	noPasswordPrompt      bool
+	mirrors               []string
 	headerFlags           []string
 	headers               http.Header
 	warned                map[string]*sync.Map
 	plainHTTP             func() (plainHTTP bool, enforced bool)
+	insecureEnforced      func() bool
+	rootCAs               *x509.CertPool
+	clientCert            *tls.Certificate
+	proxyFlag             string
+	proxyURL              *url.URL
+	retry                 int
+	retryDelay            time.Duration
+	retryMaxDelay         time.Duration
+	authTimeout           time.Duration
+	userAgentSuffix       string
+	maxIdleConnsPerHost   int
+	disableHTTP2          bool
+	tlsMinVersionFlag     string
+	tlsMinVersion         uint16
+	unixSocket            string
+	limitRate             string
+	rateLimiter           *oio.RateLimiter
+	maxMetadataSize       string
+	noWarnings            bool
+	requestIDHeader       string
+	redactHeaders         []string
+	contextName           string
+	registry              string
+	dockerCompat          bool
+	manifestHeadCheck     *bool
+	retryAfter            *onet.RetryAfterRecorder
+	transports            map[string]*http.Transport
 	store                 credentials.Store
+	shared                *sharedAuthClient
+}
+
+// sharedAuthClient holds the auth client shared by the remote options of the
+// source and the destination on the same registry.
+type sharedAuthClient struct {
+	client *auth.Client
+	store  credentials.Store
+}
+
+// SetRegistry sets the registry the remote flags are for, so that the
+// selected context is only applied if it is meant for the registry. It must be
+// called before Parse.
+func (opts *Remote) SetRegistry(registry string) {
+	opts.registry = registry
 }
 
 // EnableDistributionSpecFlag set distribution specification flag as applicable.
@@ -84,6 +219,24 @@ func (opts *Remote) EnableDistributionSpecFlag() {
 	opts.applyDistributionSpec = true
 }
 
+// EnableAnonymousFallback allows read-only requests to be retried anonymously
+// when the credentials from the credential store are rejected.
+func (opts *Remote) EnableAnonymousFallback() {
+	opts.anonymousFallback = true
+}
+
+// EnableMirrorFlag set mirror flag as applicable. Mirrors are only used for
+// read-only requests.
+func (opts *Remote) EnableMirrorFlag() {
+	opts.applyMirror = true
+}
+
+// DisablePasswordPrompt disables prompting for the password when only the
+// username is specified, for commands handling the prompt by themselves.
+func (opts *Remote) DisablePasswordPrompt() {
+	opts.noPasswordPrompt = true
+}
+
 // ApplyFlags applies flags to a command flag set.
 func (opts *Remote) ApplyFlags(fs *pflag.FlagSet) {
 	opts.ApplyFlagsWithPrefix(fs, "", "")
@@ -107,30 +260,58 @@ func (opts *Remote) ApplyFlagsWithPrefix(fs *pflag.FlagSet, prefix, description
 		shortHeader   string
 		notePrefix    string
 	)
+	opts.flagPrefix, notePrefix = applyPrefix(prefix, description)
 	if prefix == "" {
 		shortUser, shortPassword = "u", "p"
 		shortHeader = "H"
+		fs.StringVar(&opts.userAgentSuffix, userAgentSuffixFlag, "", "suffix appended to the User-Agent header of requests, e.g. a job identifier")
+		fs.BoolVar(&opts.noWarnings, noWarningsFlag, false, "do not print warnings returned by registries")
+		fs.StringVar(&opts.limitRate, limitRateFlag, "", "maximum transfer `rate` in bytes per second shared by all uploads and downloads, with an optional K, M or G suffix, e.g. 10M")
+		fs.StringVar(&opts.requestIDHeader, requestIDHeaderFlag, trace.DefaultRequestIDHeader, "`name` of the header carrying the request ID generated for each request in debug logs, empty to not send the header")
+		fs.StringSliceVar(&opts.redactHeaders, redactHeaderFlag, nil, "`names` of the headers, e.g. set via --header, whose values are redacted in debug logs in addition to the authorization and cookie headers, can be specified multiple times")
+		fs.BoolVar(&opts.dockerCompat, dockerCompatFlag, false, "resolve references without a registry against Docker Hub like docker, e.g. alpine:3.19 as docker.io/library/alpine:3.19")
+		opts.manifestHeadCheck = fs.Bool(manifestHeadCheckFlag, true, manifestHeadCheckUsage)
 	}
-	opts.flagPrefix, notePrefix = applyPrefix(prefix, description)
 
 	if opts.applyDistributionSpec {
 		opts.DistributionSpec.ApplyFlagsWithPrefix(fs, prefix, description)
 	}
+	if opts.applyMirror {
+		fs.StringArrayVar(&opts.mirrors, opts.flagPrefix+"mirror", nil, "`host` of a mirror to try before the "+notePrefix+"registry for reads, can be specified multiple times in the order of preference")
+	}
 	fs.StringVarP(&opts.Username, opts.flagPrefix+usernameFlag, shortUser, "", notePrefix+"registry username")
 	fs.StringVarP(&opts.Secret, opts.flagPrefix+passwordFlag, shortPassword, "", notePrefix+"registry password or identity token")
+	fs.StringVar(&opts.passwordFile, opts.flagPrefix+passwordFileFlag, "", "`path` of the file containing the "+notePrefix+"registry password")
 	fs.StringVar(&opts.Secret, opts.flagPrefix+identityTokenFlag, "", notePrefix+"registry identity token")
-	fs.BoolVar(&opts.Insecure, opts.flagPrefix+"insecure", false, "allow connections to "+notePrefix+"SSL registry without certs")
-	plainHTTPFlagName := opts.flagPrefix + "plain-http"
-	plainHTTP := fs.Bool(plainHTTPFlagName, false, "allow insecure connections to "+notePrefix+"registry without SSL check")
+	fs.StringVar(&opts.RegistryToken, opts.flagPrefix+registryTokenFlag, "", notePrefix+"registry bearer token used directly for authorization")
+	insecureFlagName := opts.flagPrefix + insecureFlag
+	fs.BoolVar(&opts.Insecure, insecureFlagName, false, "connect to "+notePrefix+"registry via TLS without verifying its certificate")
+	opts.insecureEnforced = func() bool {
+		return IsSet(fs, insecureFlagName)
+	}
+	plainHTTPFlagName := opts.flagPrefix + plainHTTPFlag
+	plainHTTP := fs.Bool(plainHTTPFlagName, false, "connect to "+notePrefix+"registry via plain HTTP without TLS; to use TLS without verifying the certificate, use --"+insecureFlagName+" instead")
 	opts.plainHTTP = func() (bool, bool) {
-		return *plainHTTP, fs.Changed(plainHTTPFlagName)
+		return *plainHTTP, IsSet(fs, plainHTTPFlagName)
 	}
+	fs.StringVar(&opts.contextName, opts.flagPrefix+contextFlag, "", "`name` of the context in the config file providing the defaults of the "+notePrefix+"registry flags (default $"+config.ContextEnv+")")
 	fs.StringVar(&opts.CACertFilePath, opts.flagPrefix+caFileFlag, "", "server certificate authority file for the remote "+notePrefix+"registry")
 	fs.StringVarP(&opts.CertFilePath, opts.flagPrefix+certFileFlag, "", "", "client certificate file for the remote "+notePrefix+"registry")
 	fs.StringVarP(&opts.KeyFilePath, opts.flagPrefix+keyFileFlag, "", "", "client private key file for the remote "+notePrefix+"registry")
-	fs.StringArrayVar(&opts.resolveFlag, opts.flagPrefix+"resolve", nil, "customized DNS for "+notePrefix+"registry, formatted in `host:port:address[:address_port]`")
-	fs.StringArrayVar(&opts.Configs, opts.flagPrefix+"registry-config", nil, "`path` of the authentication file for "+notePrefix+"registry")
+	fs.IntVar(&opts.retry, opts.flagPrefix+retryFlag, 3, "maximum number of retries for failed requests to "+notePrefix+"registry, 0 to disable retries")
+	fs.DurationVar(&opts.retryDelay, opts.flagPrefix+retryDelayFlag, 200*time.Millisecond, "initial delay between retries to "+notePrefix+"registry, increasing exponentially")
+	fs.DurationVar(&opts.retryMaxDelay, opts.flagPrefix+retryMaxDelayFlag, 3*time.Second, "maximum delay between retries to "+notePrefix+"registry")
+	fs.DurationVar(&opts.authTimeout, opts.flagPrefix+authTimeoutFlag, defaultAuthTimeout, "timeout of the requests to the token endpoints of "+notePrefix+"registry, which does not apply to content transfers, 0 to disable")
+	fs.IntVar(&opts.maxIdleConnsPerHost, opts.flagPrefix+maxIdleConnsPerHostFlag, 10, "maximum number of idle connections kept for reuse to "+notePrefix+"registry")
+	fs.BoolVar(&opts.disableHTTP2, opts.flagPrefix+disableHTTP2Flag, false, "only use HTTP/1.1 for connections to "+notePrefix+"registry")
+	fs.StringVar(&opts.tlsMinVersionFlag, opts.flagPrefix+tlsMinVersionFlag, "", "minimum TLS `version` for connections to "+notePrefix+"registry, one of 1.0, 1.1, 1.2 and 1.3 (default 1.2)")
+	fs.StringVar(&opts.unixSocket, opts.flagPrefix+unixSocketFlag, "", "`path` of the unix domain socket to connect to "+notePrefix+"registry, using plain HTTP unless --"+opts.flagPrefix+plainHTTPFlag+"=false is specified")
+	fs.StringVar(&opts.proxyFlag, opts.flagPrefix+proxyFlag, "", "`url` of the proxy for "+notePrefix+"registry, overriding the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables")
+	fs.StringArrayVar(&opts.resolveFlag, opts.flagPrefix+"resolve", nil, "customized DNS for "+notePrefix+"registry, formatted in `host:port:address[:address_port]`, where port can be * to match any port")
+	fs.StringArrayVar(&opts.Configs, opts.flagPrefix+registryConfigFlag, nil, "`path` of the authentication file for "+notePrefix+"registry, can be specified multiple times and the first match wins (default $"+RegistryConfigEnv+" or the docker config file)")
+	fs.BoolVar(&opts.NoDockerConfig, opts.flagPrefix+noDockerConfigFlag, false, "do not read credentials of "+notePrefix+"registry from the docker config file or credential helpers")
 	fs.StringArrayVarP(&opts.headerFlags, opts.flagPrefix+"header", shortHeader, nil, "add custom headers to "+notePrefix+"requests")
+	fs.StringVar(&opts.maxMetadataSize, opts.flagPrefix+maxMetadataSizeFlag, "4M", "maximum `size` of manifests, configs and other metadata fetched from "+notePrefix+"registry, with an optional K, M or G suffix")
 }
 
 // CheckStdinConflict checks if PasswordFromStdin or IdentityTokenFromStdin of a
@@ -147,15 +328,26 @@ func CheckStdinConflict(flags *pflag.FlagSet) error {
 
 // Parse tries to read password with optional cmd prompt.
 func (opts *Remote) Parse(cmd *cobra.Command) error {
+	if err := opts.applyContext(cmd); err != nil {
+		return err
+	}
 	usernameAndIdTokenFlags := []string{opts.flagPrefix + usernameFlag, opts.flagPrefix + identityTokenFlag}
-	passwordAndIdTokenFlags := []string{opts.flagPrefix + passwordFlag, opts.flagPrefix + identityTokenFlag}
+	passwordAndIdTokenFlags := []string{opts.flagPrefix + passwordFlag, opts.flagPrefix + passwordFileFlag, opts.flagPrefix + identityTokenFlag}
 	certFileAndKeyFileFlags := []string{opts.flagPrefix + certFileFlag, opts.flagPrefix + keyFileFlag}
+	passwordFlags := []string{opts.flagPrefix + passwordFlag, opts.flagPrefix + passwordFileFlag}
+	credentialFlags := []string{opts.flagPrefix + usernameFlag, opts.flagPrefix + passwordFlag, opts.flagPrefix + passwordFileFlag, opts.flagPrefix + identityTokenFlag}
 	if cmd.Flags().Lookup(identityTokenFromStdinFlag) != nil {
 		usernameAndIdTokenFlags = append(usernameAndIdTokenFlags, identityTokenFromStdinFlag)
 		passwordAndIdTokenFlags = append(passwordAndIdTokenFlags, identityTokenFromStdinFlag)
+		credentialFlags = append(credentialFlags, identityTokenFromStdinFlag)
 	}
 	if cmd.Flags().Lookup(passwordFromStdinFlag) != nil {
 		passwordAndIdTokenFlags = append(passwordAndIdTokenFlags, passwordFromStdinFlag)
+		passwordFlags = append(passwordFlags, passwordFromStdinFlag)
+		credentialFlags = append(credentialFlags, passwordFromStdinFlag)
+	}
+	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), passwordFlags...); err != nil {
+		return err
 	}
 	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), usernameAndIdTokenFlags...); err != nil {
 		return err
@@ -163,15 +355,310 @@ func (opts *Remote) Parse(cmd *cobra.Command) error {
 	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), passwordAndIdTokenFlags...); err != nil {
 		return err
 	}
+	if plainHTTP, _ := opts.plainHTTP(); plainHTTP && opts.Insecure {
+		return fmt.Errorf("--%s and --%s cannot be used at the same time: --%s connects without TLS while --%s uses TLS without certificate verification", opts.flagPrefix+plainHTTPFlag, opts.flagPrefix+insecureFlag, opts.flagPrefix+plainHTTPFlag, opts.flagPrefix+insecureFlag)
+	}
+	if cmd.Flags().Changed(opts.flagPrefix + registryTokenFlag) {
+		for _, flag := range credentialFlags {
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), opts.flagPrefix+registryTokenFlag, flag); err != nil {
+				return err
+			}
+		}
+	}
+	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), opts.flagPrefix+noDockerConfigFlag, opts.flagPrefix+registryConfigFlag); err != nil {
+		return err
+	}
+	if len(opts.Configs) == 0 && !opts.NoDockerConfig {
+		opts.Configs = RegistryConfigsFromEnv()
+	}
 	if err := opts.parseCustomHeaders(); err != nil {
 		return err
 	}
 	if err := oerrors.CheckRequiredTogetherFlags(cmd.Flags(), certFileAndKeyFileFlags...); err != nil {
 		return err
 	}
+	if err := opts.parseCACert(cmd); err != nil {
+		return err
+	}
+	if err := opts.parseClientCert(); err != nil {
+		return err
+	}
+	if err := opts.parseProxy(); err != nil {
+		return err
+	}
+	if err := opts.parseRetry(); err != nil {
+		return err
+	}
+	if err := opts.parseTLSMinVersion(); err != nil {
+		return err
+	}
+	if err := opts.parseUnixSocket(cmd); err != nil {
+		return err
+	}
+	if err := opts.parseLimitRate(); err != nil {
+		return err
+	}
+	if err := opts.parseMaxMetadataSize(); err != nil {
+		return err
+	}
+	if opts.authTimeout < 0 {
+		return fmt.Errorf("invalid --%s %v: expecting a non-negative duration", opts.flagPrefix+authTimeoutFlag, opts.authTimeout)
+	}
+	if opts.maxIdleConnsPerHost < 0 {
+		return fmt.Errorf("invalid --%s %d: expecting a non-negative number", opts.flagPrefix+maxIdleConnsPerHostFlag, opts.maxIdleConnsPerHost)
+	}
 	return opts.readSecret(cmd)
 }
 
+// applyContext applies the settings of the selected context as the defaults
+// of the remote flags. Flags specified explicitly always take precedence. A
+// context meant for another registry is skipped if selected via the
+// environment variable, and rejected if selected via the flag.
+func (opts *Remote) applyContext(cmd *cobra.Command) error {
+	flagName := opts.flagPrefix + contextFlag
+	name := opts.contextName
+	if name == "" && !cmd.Flags().Changed(flagName) {
+		name = os.Getenv(config.ContextEnv)
+	}
+	if name == "" {
+		return nil
+	}
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		return err
+	}
+	ctx, err := cfg.Context(name)
+	if err != nil {
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: `Run "oras context ls" to list the contexts in the config file`,
+		}
+	}
+	if ctx.Registry != "" && registryutil.NormalizeDockerHubRegistry(ctx.Registry) != registryutil.NormalizeDockerHubRegistry(opts.registry) {
+		if !cmd.Flags().Changed(flagName) {
+			// the context from the environment variable only applies to
+			// the registry it is meant for
+			return nil
+		}
+		return &oerrors.Error{
+			Err:            fmt.Errorf("context %q is meant for the registry %s, not %s", name, ctx.Registry, opts.registry),
+			Recommendation: fmt.Sprintf("Please select a context meant for %s via --%s, or remove the registry from the context", opts.registry, flagName),
+		}
+	}
+
+	changed := func(flag string) bool {
+		return cmd.Flags().Changed(opts.flagPrefix + flag)
+	}
+	if ctx.PlainHTTP != nil && !changed(plainHTTPFlag) && opts.plainHTTP != nil {
+		plainHTTP := *ctx.PlainHTTP
+		opts.plainHTTP = func() (bool, bool) {
+			return plainHTTP, true
+		}
+	}
+	if ctx.Insecure && !changed(insecureFlag) {
+		opts.Insecure = true
+	}
+	if ctx.CAFile != "" && !changed(caFileFlag) {
+		opts.CACertFilePath = ctx.CAFile
+	}
+	if ctx.CertFile != "" && !changed(certFileFlag) && !changed(keyFileFlag) {
+		opts.CertFilePath = ctx.CertFile
+		opts.KeyFilePath = ctx.KeyFile
+	}
+	if !changed(registryConfigFlag) && !changed(noDockerConfigFlag) {
+		if len(ctx.RegistryConfig) > 0 {
+			opts.Configs = ctx.RegistryConfig
+		}
+		if ctx.NoDockerConfig {
+			opts.NoDockerConfig = true
+		}
+	}
+	return nil
+}
+
+// parseLimitRate parses the transfer rate limit.
+func (opts *Remote) parseLimitRate() error {
+	if opts.limitRate == "" {
+		return nil
+	}
+	rate, err := parseByteSize(opts.limitRate)
+	if err != nil || rate <= 0 {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid --%s %q", limitRateFlag, opts.limitRate),
+			Recommendation: "Please specify a positive number of bytes per second with an optional K, M or G suffix, e.g. 512K or 10M",
+		}
+	}
+	opts.rateLimiter = oio.NewRateLimiter(rate)
+	return nil
+}
+
+// parseMaxMetadataSize parses the size limit of metadata responses.
+func (opts *Remote) parseMaxMetadataSize() error {
+	if opts.maxMetadataSize == "" {
+		return nil
+	}
+	size, err := parseByteSize(opts.maxMetadataSize)
+	if err != nil || size <= 0 {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid --%s %q", opts.flagPrefix+maxMetadataSizeFlag, opts.maxMetadataSize),
+			Recommendation: "Please specify a positive number of bytes with an optional K, M or G suffix, e.g. 4M",
+		}
+	}
+	opts.MaxMetadataBytes = size
+	return nil
+}
+
+// parseByteSize parses a size in bytes with an optional binary unit suffix
+// K, M or G, case-insensitive.
+func parseByteSize(value string) (int64, error) {
+	multiplier := int64(1)
+	switch value[len(value)-1] {
+	case 'k', 'K':
+		multiplier = 1 << 10
+	case 'm', 'M':
+		multiplier = 1 << 20
+	case 'g', 'G':
+		multiplier = 1 << 30
+	}
+	if multiplier != 1 {
+		value = value[:len(value)-1]
+	}
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if size > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("size %s overflows", value)
+	}
+	return size * multiplier, nil
+}
+
+// parseUnixSocket validates the unix domain socket.
+func (opts *Remote) parseUnixSocket(cmd *cobra.Command) error {
+	if opts.unixSocket == "" {
+		return nil
+	}
+	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), opts.flagPrefix+unixSocketFlag, opts.flagPrefix+proxyFlag); err != nil {
+		return err
+	}
+	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), opts.flagPrefix+unixSocketFlag, opts.flagPrefix+"resolve"); err != nil {
+		return err
+	}
+	info, err := os.Stat(opts.unixSocket)
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %w", opts.flagPrefix+unixSocketFlag, err)
+	}
+	if info.Mode().Type() != fs.ModeSocket {
+		return fmt.Errorf("invalid --%s: %s is not a unix domain socket", opts.flagPrefix+unixSocketFlag, opts.unixSocket)
+	}
+	return nil
+}
+
+// tlsVersions maps the values of --tls-min-version to TLS versions.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// parseTLSMinVersion parses the minimum TLS version.
+func (opts *Remote) parseTLSMinVersion() error {
+	if opts.tlsMinVersionFlag == "" {
+		return nil
+	}
+	version, ok := tlsVersions[opts.tlsMinVersionFlag]
+	if !ok {
+		return fmt.Errorf("invalid --%s %q: expecting one of 1.0, 1.1, 1.2 and 1.3", opts.flagPrefix+tlsMinVersionFlag, opts.tlsMinVersionFlag)
+	}
+	opts.tlsMinVersion = version
+	return nil
+}
+
+// parseRetry validates the retry flags.
+func (opts *Remote) parseRetry() error {
+	if opts.retry < 0 {
+		return fmt.Errorf("invalid --%s %d: expecting a non-negative number", opts.flagPrefix+retryFlag, opts.retry)
+	}
+	if opts.retryDelay < 0 || opts.retryMaxDelay < 0 {
+		return fmt.Errorf("--%s and --%s must be non-negative", opts.flagPrefix+retryDelayFlag, opts.flagPrefix+retryMaxDelayFlag)
+	}
+	if opts.retryDelay > opts.retryMaxDelay {
+		return fmt.Errorf("--%s %v must not be greater than --%s %v", opts.flagPrefix+retryDelayFlag, opts.retryDelay, opts.flagPrefix+retryMaxDelayFlag, opts.retryMaxDelay)
+	}
+	return nil
+}
+
+// retryPolicy returns the retry policy based on the retry flags, or nil if
+// retries are disabled.
+func (opts *Remote) retryPolicy() retry.Policy {
+	if opts.retry <= 0 {
+		return nil
+	}
+	return &retry.GenericPolicy{
+		Retryable: retry.DefaultPredicate,
+		Backoff:   retry.ExponentialBackoff(opts.retryDelay, 2, 0.1),
+		MinWait:   opts.retryDelay,
+		MaxWait:   opts.retryMaxDelay,
+		MaxRetry:  opts.retry,
+	}
+}
+
+// parseProxy parses the proxy URL.
+func (opts *Remote) parseProxy() error {
+	if opts.proxyFlag == "" {
+		return nil
+	}
+	proxyURL, err := url.Parse(opts.proxyFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %w", opts.flagPrefix+proxyFlag, err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("invalid --%s %q: expecting scheme http, https or socks5", opts.flagPrefix+proxyFlag, proxyURL.Redacted())
+	}
+	opts.proxyURL = proxyURL
+	return nil
+}
+
+// parseClientCert loads the client key pair for mutual TLS, so that invalid
+// files fail the command before any request is sent.
+func (opts *Remote) parseClientCert() error {
+	if opts.CertFilePath == "" || opts.KeyFilePath == "" {
+		return nil
+	}
+	cert, err := crypto.LoadX509KeyPair(opts.CertFilePath, opts.KeyFilePath)
+	if err != nil {
+		if errors.Is(err, crypto.ErrEncryptedPrivateKey) {
+			return &oerrors.Error{
+				Err:            err,
+				Recommendation: fmt.Sprintf("Please decrypt the private key before passing it via --%s", opts.flagPrefix+keyFileFlag),
+			}
+		}
+		return err
+	}
+	opts.clientCert = &cert
+	return nil
+}
+
+// parseCACert loads the CA certificate file, so that an invalid file fails
+// the command before any request is sent.
+func (opts *Remote) parseCACert(cmd *cobra.Command) error {
+	if opts.CACertFilePath == "" {
+		return nil
+	}
+	if opts.Insecure {
+		cmd.PrintErrf("WARNING! --%s has no effect since --%s skips certificate verification.\n", opts.flagPrefix+caFileFlag, opts.flagPrefix+insecureFlag)
+	}
+	rootCAs, err := crypto.LoadCertPool(opts.CACertFilePath)
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %w", opts.flagPrefix+caFileFlag, err)
+	}
+	opts.rootCAs = rootCAs
+	return nil
+}
+
 // readSecret tries to read password or identity token with
 // optional cmd prompt.
 func (opts *Remote) readSecret(cmd *cobra.Command) (err error) {
@@ -187,10 +674,67 @@ func (opts *Remote) readSecret(cmd *cobra.Command) (err error) {
 		}
 		opts.Secret = strings.TrimSuffix(string(secret), "\n")
 		opts.Secret = strings.TrimSuffix(opts.Secret, "\r")
+	} else if opts.passwordFile != "" {
+		if opts.Secret, err = readPasswordFile(opts.passwordFile); err != nil {
+			return err
+		}
+	}
+	if opts.Username != "" && opts.Secret == "" && !opts.noPasswordPrompt {
+		return opts.promptPassword(cmd)
 	}
 	return nil
 }
 
+// readPasswordFile reads the password from the file at path with exactly one
+// trailing newline trimmed. The content is never included in the error.
+func readPasswordFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the password file: %w", err)
+	}
+	password := string(content)
+	if trimmed, ok := strings.CutSuffix(password, "\n"); ok {
+		password = strings.TrimSuffix(trimmed, "\r")
+	}
+	if password == "" {
+		return "", fmt.Errorf("the password file %q is empty", path)
+	}
+	return password, nil
+}
+
+// promptPassword prompts for the password of the username if stdin is a
+// terminal, or fails otherwise rather than sending an empty password.
+func (opts *Remote) promptPassword(cmd *cobra.Command) error {
+	usernameFlagName := opts.flagPrefix + usernameFlag
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		recommendation := fmt.Sprintf("Use --%s to read the password from a file, or set $%s", opts.flagPrefix+passwordFileFlag, EnvName(opts.flagPrefix+passwordFlag))
+		if opts.flagPrefix == "" && cmd.Flags().Lookup(passwordFromStdinFlag) != nil {
+			recommendation = fmt.Sprintf("Use --%s or --%s to read the password from stdin or a file, or set $%s", passwordFromStdinFlag, passwordFileFlag, EnvName(passwordFlag))
+		}
+		return &oerrors.Error{
+			Err:            fmt.Errorf("no password specified for --%s %q and stdin is not a terminal to prompt for it", usernameFlagName, opts.Username),
+			Recommendation: recommendation,
+		}
+	}
+	prompt := "Password: "
+	if opts.flagPrefix != "" {
+		prompt = fmt.Sprintf("Password for --%s %s: ", usernameFlagName, opts.Username)
+	}
+	out := cmd.ErrOrStderr()
+	_, _ = fmt.Fprint(out, prompt)
+	secret, err := term.ReadPassword(fd)
+	_, _ = fmt.Fprintln(out)
+	if err != nil {
+		return fmt.Errorf("failed to read the password: %w", err)
+	}
+	if len(secret) == 0 {
+		return fmt.Errorf("password required for --%s %q", usernameFlagName, opts.Username)
+	}
+	opts.Secret = string(secret)
+	return nil
+}
+
 // parseResolve parses resolve flag.
 func (opts *Remote) parseResolve(baseDial onet.DialFunc) (onet.DialFunc, error) {
 	if len(opts.resolveFlag) == 0 {
@@ -208,22 +752,31 @@ func (opts *Remote) parseResolve(baseDial onet.DialFunc) (onet.DialFunc, error)
 			return nil, formatError(r, "expecting host:port:address[:address_port]")
 		}
 		host := parts[0]
-		hostPort, err := strconv.Atoi(parts[1])
-		if err != nil {
-			return nil, formatError(r, "expecting uint64 host port")
-		}
 		// ipv6 zone is not parsed
 		address := net.ParseIP(parts[2])
 		if address == nil {
 			return nil, formatError(r, "invalid IP address")
 		}
-		addressPort := hostPort
+		var addressPort int
 		if length > 3 {
+			var err error
 			addressPort, err = strconv.Atoi(parts[3])
 			if err != nil {
 				return nil, formatError(r, "expecting uint64 address port")
 			}
 		}
+		if parts[1] == "*" {
+			// any port of the host
+			dialer.AddWildcard(host, address, addressPort)
+			continue
+		}
+		hostPort, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, formatError(r, "expecting uint64 host port or *")
+		}
+		if length <= 3 {
+			addressPort = hostPort
+		}
 		dialer.Add(host, hostPort, address, addressPort)
 	}
 	dialer.BaseDialContext = baseDial
@@ -234,16 +787,21 @@ func (opts *Remote) parseResolve(baseDial onet.DialFunc) (onet.DialFunc, error)
 func (opts *Remote) tlsConfig() (*tls.Config, error) {
 	config := &tls.Config{
 		InsecureSkipVerify: opts.Insecure,
+		MinVersion:         opts.tlsMinVersion,
 	}
-	if opts.CACertFilePath != "" {
+	if opts.rootCAs != nil {
+		config.RootCAs = opts.rootCAs
+	} else if opts.CACertFilePath != "" {
 		var err error
 		config.RootCAs, err = crypto.LoadCertPool(opts.CACertFilePath)
 		if err != nil {
 			return nil, err
 		}
 	}
-	if opts.CertFilePath != "" && opts.KeyFilePath != "" {
-		cert, err := tls.LoadX509KeyPair(opts.CertFilePath, opts.KeyFilePath)
+	if opts.clientCert != nil {
+		config.Certificates = []tls.Certificate{*opts.clientCert}
+	} else if opts.CertFilePath != "" && opts.KeyFilePath != "" {
+		cert, err := crypto.LoadX509KeyPair(opts.CertFilePath, opts.KeyFilePath)
 		if err != nil {
 			return nil, err
 		}
@@ -252,31 +810,128 @@ func (opts *Remote) tlsConfig() (*tls.Config, error) {
 	return config, nil
 }
 
-// authClient assembles a oras auth client.
-func (opts *Remote) authClient(registry string, debug bool) (client *auth.Client, err error) {
+// transport returns the HTTP transport for the registry. The transport is
+// shared by all clients of the registry created from opts, so that idle
+// connections are reused across requests within one command.
+func (opts *Remote) transport(registry string) (*http.Transport, error) {
+	if t, ok := opts.transports[registry]; ok {
+		return t, nil
+	}
 	config, err := opts.tlsConfig()
 	if err != nil {
 		return nil, err
 	}
+	config.InsecureSkipVerify = opts.isInsecure(registry)
 	baseTransport := http.DefaultTransport.(*http.Transport).Clone()
 	baseTransport.TLSClientConfig = config
-	dialContext, err := opts.parseResolve(baseTransport.DialContext)
+	if opts.proxyURL != nil {
+		baseTransport.Proxy = http.ProxyURL(opts.proxyURL)
+	}
+	baseTransport.OnProxyConnectResponse = onet.CheckProxyConnectResponse
+	if opts.maxIdleConnsPerHost > 0 {
+		baseTransport.MaxIdleConnsPerHost = opts.maxIdleConnsPerHost
+	}
+	if opts.disableHTTP2 {
+		// a non-nil empty TLSNextProto disables HTTP/2
+		baseTransport.ForceAttemptHTTP2 = false
+		baseTransport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		baseTransport.TLSClientConfig.NextProtos = []string{"http/1.1"}
+	}
+	if opts.unixSocket != "" {
+		// all connections go to the unix domain socket regardless of the
+		// registry address, which is still used for the Host header and TLS
+		var dialer net.Dialer
+		baseTransport.Proxy = nil
+		baseTransport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", opts.unixSocket)
+		}
+	} else {
+		dialContext, err := opts.parseResolve(baseTransport.DialContext)
+		if err != nil {
+			return nil, err
+		}
+		baseTransport.DialContext = dialContext
+	}
+	if opts.transports == nil {
+		opts.transports = make(map[string]*http.Transport)
+	}
+	opts.transports[registry] = baseTransport
+	return baseTransport, nil
+}
+
+// authClient assembles a oras auth client.
+func (opts *Remote) authClient(registry string, debug bool) (client *auth.Client, err error) {
+	if opts.shared != nil && opts.shared.client != nil {
+		opts.store = opts.shared.store
+		return opts.shared.client, nil
+	}
+	defer func() {
+		if err == nil && opts.shared != nil {
+			opts.shared.client, opts.shared.store = client, opts.store
+		}
+	}()
+	baseTransport, err := opts.transport(registry)
 	if err != nil {
 		return nil, err
 	}
-	baseTransport.DialContext = dialContext
+	if opts.retryAfter == nil {
+		opts.retryAfter = &onet.RetryAfterRecorder{}
+	}
+	var transport http.RoundTripper = baseTransport
+	if opts.MaxMetadataBytes > 0 {
+		transport = &onet.MetadataSizeLimitTransport{
+			Base:  transport,
+			Limit: opts.MaxMetadataBytes,
+		}
+	}
+	if opts.rateLimiter != nil {
+		transport = &onet.RateLimitTransport{
+			Base:    transport,
+			Limiter: opts.rateLimiter,
+		}
+	}
+	if policy := opts.retryPolicy(); policy != nil {
+		// http.RoundTripper with a retry using the policy from the flags
+		// see: https://pkg.go.dev/oras.land/oras-go/v2/registry/remote/retry#Policy
+		transport = &retry.Transport{
+			Base: transport,
+			Policy: func() retry.Policy {
+				return policy
+			},
+		}
+	}
+	transport = &onet.ManifestHeadTransport{
+		Base:    transport,
+		GetOnly: opts.manifestHeadCheck != nil && !*opts.manifestHeadCheck,
+	}
+	// chunks of large blobs are uploaded and retried individually if the
+	// registry limits the size of upload requests
+	transport = &onet.ChunkedUploadTransport{Base: transport}
+	if opts.authTimeout > 0 {
+		// the token endpoints are requested via the same client, with a
+		// timeout covering the retries
+		transport = &onet.TokenTimeoutTransport{
+			Base:    transport,
+			Timeout: opts.authTimeout,
+		}
+	}
 	client = &auth.Client{
 		Client: &http.Client{
-			// http.RoundTripper with a retry using the DefaultPolicy
-			// see: https://pkg.go.dev/oras.land/oras-go/v2/registry/remote/retry#Policy
-			Transport: retry.NewTransport(baseTransport),
+			Transport: &onet.RetryAfterTransport{
+				Base:     transport,
+				Recorder: opts.retryAfter,
+			},
+			CheckRedirect: onet.CheckRedirect(opts.plainHTTP != nil && opts.isPlainHttp(registry)),
 		},
-		Cache:  auth.NewCache(),
+		Cache:  opts.authCache(),
 		Header: opts.headers,
 	}
-	client.SetUserAgent("oras/" + version.GetVersion())
+	client.SetUserAgent(opts.userAgent())
 	if debug {
-		client.Client.Transport = trace.NewTransport(client.Client.Transport)
+		traceTransport := trace.NewTransport(client.Client.Transport)
+		traceTransport.RequestIDHeader = opts.requestIDHeader
+		traceTransport.SensitiveHeaders = opts.redactHeaders
+		client.Client.Transport = traceTransport
 	}
 
 	cred := opts.Credential()
@@ -284,17 +939,183 @@ func (opts *Remote) authClient(registry string, debug bool) (client *auth.Client
 		client.Credential = func(ctx context.Context, s string) (auth.Credential, error) {
 			return cred, nil
 		}
-	} else {
+	} else if !opts.NoDockerConfig {
 		var err error
 		opts.store, err = credential.NewStore(opts.Configs...)
 		if err != nil {
 			return nil, err
 		}
-		client.Credential = credentials.Credential(opts.store)
+		client.Credential = opts.credentialFromStore()
 	}
 	return
 }
 
+// authCache returns the auth cache shared by the remote clients using the same
+// credentials as opts.
+func (opts *Remote) authCache() auth.Cache {
+	var key string
+	if cred := opts.Credential(); cred != auth.EmptyCredential {
+		digest := sha256.Sum256([]byte(strings.Join([]string{cred.Username, cred.Password, cred.RefreshToken, cred.AccessToken}, "\x00")))
+		key = "credential:" + hex.EncodeToString(digest[:])
+	} else if opts.NoDockerConfig {
+		key = "anonymous"
+	} else {
+		key = "store:" + strings.Join(opts.Configs, string(os.PathListSeparator))
+	}
+	cache, _ := authCaches.LoadOrStore(key, auth.NewCache())
+	return cache.(auth.Cache)
+}
+
+// userAgent returns the User-Agent header value for requests.
+func (opts *Remote) userAgent() string {
+	userAgent := version.GetUserAgent()
+	if opts.userAgentSuffix != "" {
+		userAgent += " " + opts.userAgentSuffix
+	}
+	return userAgent
+}
+
+// remoteClient assembles a remote client. Requests to loopback registries
+// fall back to plain HTTP if the registry does not speak TLS. For read-only
+// requests, it tries the mirrors first if any, and falls back to anonymous
+// access if enabled and the credentials stored for the registry are rejected.
+func (opts *Remote) remoteClient(registry string, debug bool, logger logrus.FieldLogger) (remote.Client, error) {
+	authClient, err := opts.authClient(registry, debug)
+	if err != nil {
+		return nil, err
+	}
+	var client remote.Client = authClient
+	if opts.anonymousFallback && opts.hasStoredCredential(registry) {
+		client = registryutil.NewAnonymousFallbackClient(authClient, func() {
+			logger.Warnf("Stored credentials for %s were rejected, falling back to anonymous access", registry)
+		})
+	}
+	// the plain HTTP fallback is outermost so that both the authenticated
+	// and the anonymous requests fall back
+	if opts.isPlainHTTPFallbackAllowed(registry) {
+		client = &registryutil.PlainHTTPFallbackClient{
+			Client: client,
+			OnFallback: func() {
+				logger.Infof("Registry %s does not speak TLS, falling back to plain HTTP", registry)
+			},
+		}
+	}
+	if len(opts.mirrors) > 0 {
+		mirrorClient := &registryutil.MirrorClient{
+			Client: client,
+			Logger: logger,
+		}
+		for _, mirror := range opts.mirrors {
+			c, err := opts.mirrorClient(mirror, debug)
+			if err != nil {
+				return nil, err
+			}
+			mirrorClient.Mirrors = append(mirrorClient.Mirrors, registryutil.Mirror{
+				Host:   mirror,
+				Client: c,
+			})
+		}
+		client = mirrorClient
+	}
+	return client, nil
+}
+
+// mirrorClient assembles a client for the mirror. The credentials, the
+// headers and the TLS settings given for the registry are not used; the
+// mirror is accessed with its own credentials from the credential store, or
+// anonymously.
+func (opts *Remote) mirrorClient(mirror string, debug bool) (*auth.Client, error) {
+	baseTransport := http.DefaultTransport.(*http.Transport).Clone()
+	baseTransport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: onet.MatchHost(mirror, strings.Split(os.Getenv(InsecureRegistriesEnv), ",")),
+		MinVersion:         opts.tlsMinVersion,
+	}
+	if opts.proxyURL != nil {
+		baseTransport.Proxy = http.ProxyURL(opts.proxyURL)
+	}
+	baseTransport.OnProxyConnectResponse = onet.CheckProxyConnectResponse
+	client := &auth.Client{
+		Client: &http.Client{
+			Transport:     baseTransport,
+			CheckRedirect: onet.CheckRedirect(false),
+		},
+		Cache: auth.NewCache(),
+	}
+	client.SetUserAgent(opts.userAgent())
+	if debug {
+		traceTransport := trace.NewTransport(client.Client.Transport)
+		traceTransport.RequestIDHeader = opts.requestIDHeader
+		traceTransport.SensitiveHeaders = opts.redactHeaders
+		client.Client.Transport = traceTransport
+	}
+	if opts.NoDockerConfig {
+		return client, nil
+	}
+	store, err := credential.NewStore(opts.Configs...)
+	if err != nil {
+		return nil, err
+	}
+	credFunc := credentials.Credential(store)
+	client.Credential = func(ctx context.Context, hostport string) (auth.Credential, error) {
+		if hostport != mirror {
+			return auth.EmptyCredential, nil
+		}
+		return credFunc(ctx, hostport)
+	}
+	return client, nil
+}
+
+// hasStoredCredential returns true if the credential store has a non-empty
+// credential for the registry.
+func (opts *Remote) hasStoredCredential(registry string) bool {
+	if opts.store == nil {
+		return false
+	}
+	cred, err := opts.credentialFromStore()(context.Background(), registry)
+	return err == nil && cred != auth.EmptyCredential
+}
+
+// credentialFromStore returns a credential function backed by the credential
+// store, decorating credential helper errors with the helper name.
+func (opts *Remote) credentialFromStore() auth.CredentialFunc {
+	credFunc := credentials.Credential(opts.store)
+	return func(ctx context.Context, hostport string) (auth.Credential, error) {
+		cred, err := credFunc(ctx, hostport)
+		if err == nil {
+			if cred == auth.EmptyCredential && registryutil.IsDockerHub(hostport) {
+				return opts.dockerHubCredential(ctx)
+			}
+			return cred, nil
+		}
+		configPaths := opts.Configs
+		if len(configPaths) == 0 {
+			if configPath, pathErr := opts.ConfigPath(); pathErr == nil {
+				configPaths = []string{configPath}
+			}
+		}
+		serverAddress := credentials.ServerAddressFromHostname(hostport)
+		for _, configPath := range configPaths {
+			if helper := credential.HelperName(configPath, serverAddress); helper != "" {
+				return auth.EmptyCredential, fmt.Errorf("failed to get credential for %s from credential helper docker-credential-%s: %w", hostport, helper, err)
+			}
+		}
+		return auth.EmptyCredential, err
+	}
+}
+
+// dockerHubCredential returns the Docker Hub credential stored under one of
+// its aliases, as some tools store it under "docker.io" or the registry host
+// instead of "https://index.docker.io/v1/".
+func (opts *Remote) dockerHubCredential(ctx context.Context) (auth.Credential, error) {
+	for _, alias := range registryutil.DockerHubAliases {
+		cred, err := opts.store.Get(ctx, alias)
+		if err != nil || cred != auth.EmptyCredential {
+			return cred, err
+		}
+	}
+	return auth.EmptyCredential, nil
+}
+
 // ConfigPath returns the config path of the credential store.
 func (opts *Remote) ConfigPath() (string, error) {
 	if opts.store == nil {
@@ -316,6 +1137,9 @@ func (opts *Remote) parseCustomHeaders() error {
 				// Reference: https://www.rfc-editor.org/rfc/rfc2616#section-4.2
 				return fmt.Errorf("invalid header: %q", h)
 			}
+			if err := checkCustomHeaderName(name); err != nil {
+				return err
+			}
 			headers[name] = append(headers[name], value)
 		}
 		opts.headers = headers
@@ -323,8 +1147,31 @@ func (opts *Remote) parseCustomHeaders() error {
 	return nil
 }
 
+// checkCustomHeaderName rejects the headers managed by the client, which
+// cannot be set via --header.
+func checkCustomHeaderName(name string) error {
+	switch name = strings.TrimSpace(name); {
+	case strings.EqualFold(name, "Authorization"):
+		return &oerrors.Error{
+			Err:            fmt.Errorf("header %q cannot be set via --header", name),
+			Recommendation: "Use --username and --password, or --registry-token to authenticate to the registry",
+		}
+	case strings.EqualFold(name, "Host"):
+		return &oerrors.Error{
+			Err:            fmt.Errorf("header %q cannot be set via --header", name),
+			Recommendation: "Use --resolve to connect to the registry at a different address",
+		}
+	}
+	return nil
+}
+
 // Credential returns a credential based on the remote options.
 func (opts *Remote) Credential() auth.Credential {
+	if opts.RegistryToken != "" {
+		return auth.Credential{
+			AccessToken: opts.RegistryToken,
+		}
+	}
 	return credential.Credential(opts.Username, opts.Secret)
 }
 
@@ -339,22 +1186,30 @@ func (opts *Remote) handleWarning(registry string, logger logrus.FieldLogger) fu
 	}
 	logger = logger.WithField("registry", registry)
 	return func(warning remote.Warning) {
+		if opts.noWarnings {
+			return
+		}
 		if _, loaded := warned.LoadOrStore(warning.WarningValue, struct{}{}); !loaded {
-			logger.Warn(warning.Text)
+			logger.Warnf("Registry warning: %s", warning.Text)
+			output.RecordWarning(output.Warning{
+				Registry: registry,
+				Text:     warning.Text,
+			})
 		}
 	}
 }
 
 // NewRegistry assembles a oras remote registry.
 func (opts *Remote) NewRegistry(registry string, common Common, logger logrus.FieldLogger) (reg *remote.Registry, err error) {
-	reg, err = remote.NewRegistry(registry)
+	reg, err = remote.NewRegistry(registryutil.NormalizeDockerHubRegistry(registry))
 	if err != nil {
 		return nil, err
 	}
 	registry = reg.Reference.Registry
 	reg.PlainHTTP = opts.isPlainHttp(registry)
 	reg.HandleWarning = opts.handleWarning(registry, logger)
-	if reg.Client, err = opts.authClient(registry, common.Debug); err != nil {
+	reg.MaxMetadataBytes = opts.MaxMetadataBytes
+	if reg.Client, err = opts.remoteClient(registry, common.Debug, logger); err != nil {
 		return nil, err
 	}
 	return
@@ -362,17 +1217,18 @@ func (opts *Remote) NewRegistry(registry string, common Common, logger logrus.Fi
 
 // NewRepository assembles a oras remote repository.
 func (opts *Remote) NewRepository(reference string, common Common, logger logrus.FieldLogger) (repo *remote.Repository, err error) {
-	repo, err = remote.NewRepository(reference)
+	repo, err = remote.NewRepository(opts.normalizeReference(reference))
 	if err != nil {
-		if errors.Unwrap(err) == errdef.ErrInvalidReference {
-			return nil, fmt.Errorf("%q: %v", reference, err)
+		if errors.Is(err, errdef.ErrInvalidReference) {
+			return nil, newErrInvalidReference(reference, err)
 		}
 		return nil, err
 	}
 	registry := repo.Reference.Registry
 	repo.PlainHTTP = opts.isPlainHttp(registry)
 	repo.HandleWarning = opts.handleWarning(registry, logger)
-	if repo.Client, err = opts.authClient(registry, common.Debug); err != nil {
+	repo.MaxMetadataBytes = opts.MaxMetadataBytes
+	if repo.Client, err = opts.remoteClient(registry, common.Debug, logger); err != nil {
 		return nil, err
 	}
 	repo.SkipReferrersGC = true
@@ -380,24 +1236,80 @@ func (opts *Remote) NewRepository(reference string, common Common, logger logrus
 		if err := repo.SetReferrersCapability(*opts.ReferrersAPI); err != nil {
 			return nil, err
 		}
+	} else if supported, ok := referrersCapabilities.Load(registry); ok {
+		logger.Debugf("using the referrers API capability of %s detected earlier: %v", registry, supported)
+		_ = repo.SetReferrersCapability(supported.(bool))
+	}
+	repo.Client = &registryutil.ReferrersCapabilityClient{
+		Client: repo.Client,
+		OnDetected: func(supported bool) {
+			if _, loaded := referrersCapabilities.Swap(registry, supported); !loaded {
+				if supported {
+					logger.Debugf("registry %s supports the referrers API as the OCI-Subject header is returned on pushing a manifest with a subject", registry)
+				} else {
+					logger.Debugf("registry %s does not support the referrers API as no OCI-Subject header is returned on pushing a manifest with a subject, falling back to the referrers tag schema", registry)
+				}
+			}
+			// the capability can only be set once and is kept if set by
+			// --distribution-spec or detected earlier
+			_ = repo.SetReferrersCapability(supported)
+		},
+	}
+	repo.Client = &registryutil.ManifestDigestClient{
+		Client: repo.Client,
+		OnRewritten: func(pushed, stored digest.Digest) {
+			text := fmt.Sprintf("manifest %s is stored as %s, the registry may have rewritten the pushed content", pushed, stored)
+			logger.Warnf("Registry %s: %s", registry, text)
+			output.RecordWarning(output.Warning{
+				Registry: registry,
+				Text:     text,
+			})
+		},
 	}
 	return
 }
 
+// normalizeReference normalizes the Docker Hub references, resolving the
+// references without a registry against Docker Hub if --docker-compat is set.
+func (opts *Remote) normalizeReference(reference string) string {
+	return registryutil.NormalizeDockerHubReference(reference, opts.dockerCompat)
+}
+
 // isPlainHttp returns the plain http flag for a given registry.
 func (opts *Remote) isPlainHttp(registry string) bool {
 	plainHTTP, enforced := opts.plainHTTP()
 	if enforced {
 		return plainHTTP
 	}
-	host, _, _ := net.SplitHostPort(registry)
-	if host == "localhost" || registry == "localhost" {
-		// not specified, defaults to plain http for localhost
+	if opts.unixSocket != "" {
+		// not specified, defaults to plain http over unix domain sockets
+		return true
+	}
+	if onet.MatchHost(registry, strings.Split(os.Getenv(PlainHTTPRegistriesEnv), ",")) {
 		return true
 	}
 	return plainHTTP
 }
 
+// isPlainHTTPFallbackAllowed returns whether requests to the registry may fall
+// back to plain HTTP if the registry does not speak TLS. It is only allowed
+// for loopback registries without --plain-http specified.
+func (opts *Remote) isPlainHTTPFallbackAllowed(registry string) bool {
+	if _, enforced := opts.plainHTTP(); enforced {
+		return false
+	}
+	return onet.IsLoopback(registry) && !opts.isPlainHttp(registry)
+}
+
+// isInsecure returns whether TLS certificate verification is skipped for the
+// registry.
+func (opts *Remote) isInsecure(registry string) bool {
+	if opts.Insecure || (opts.insecureEnforced != nil && opts.insecureEnforced()) {
+		return opts.Insecure
+	}
+	return onet.MatchHost(registry, strings.Split(os.Getenv(InsecureRegistriesEnv), ","))
+}
+
 // Modify modifies error during cmd execution.
 func (opts *Remote) Modify(cmd *cobra.Command, err error) (error, bool) {
 	var errResp *errcode.ErrorResponse
@@ -406,13 +1318,134 @@ func (opts *Remote) Modify(cmd *cobra.Command, err error) (error, bool) {
 		return opts.DecorateCredentialError(err), true
 	}
 
+	if connErr, ok := opts.DecorateConnectionError(err); ok {
+		return connErr, true
+	}
+
+	if sizeErr, ok := opts.decorateSizeError(err); ok {
+		return sizeErr, true
+	}
+
+	var tokenErr *onet.TokenTimeoutError
+	if errors.As(err, &tokenErr) {
+		return &oerrors.Error{
+			Err:            tokenErr,
+			Recommendation: fmt.Sprintf("If the token server is expected to be slow, increase the timeout via `--%s`", opts.flagPrefix+authTimeoutFlag),
+		}, true
+	}
+
 	if errors.As(err, &errResp) {
 		cmd.SetErrPrefix(oerrors.RegistryErrorPrefix)
+		return opts.decorateErrorResponse(err, errResp, errResp.URL.Host), true
+	}
+	return err, false
+}
+
+// decorateErrorResponse trims err down to the error response errResp from the
+// registry host and classifies it with a hint of the next step.
+func (opts *Remote) decorateErrorResponse(err error, errResp *errcode.ErrorResponse, host string) *oerrors.Error {
+	ret := &oerrors.Error{
+		Err: oerrors.TrimErrResp(err, errResp),
+	}
+	if regErr := oerrors.NewRegistryError(ret.Err, errResp, host); regErr.Kind != oerrors.RegistryErrorUnknown {
+		if regErr.Kind == oerrors.RegistryErrorTooManyRequests && opts.retryAfter != nil {
+			regErr.RetryAfter, _ = opts.retryAfter.Get(errResp.URL.Host)
+		}
+		ret.Err = regErr
+		ret.Recommendation = regErr.Recommendation()
+	}
+	var oErr *oerrors.Error
+	if errors.As(err, &oErr) && oErr.Recommendation != "" {
+		// keep the recommendation provided by the command
+		ret.Recommendation = oErr.Recommendation
+	}
+	return ret
+}
+
+// decorateSizeError decorates the errors of the metadata and the configs
+// exceeding the metadata size limit.
+func (opts *Remote) decorateSizeError(err error) (*oerrors.Error, bool) {
+	var sizeErr *onet.MetadataSizeError
+	if errors.As(err, &sizeErr) {
 		return &oerrors.Error{
-			Err: oerrors.TrimErrResp(err, errResp),
+			Err:            sizeErr,
+			Recommendation: fmt.Sprintf("If the registry is trusted and the metadata is expected to be larger, increase the limit via `--%s`", opts.flagPrefix+maxMetadataSizeFlag),
 		}, true
 	}
-	return err, false
+	var configErr *registryutil.ConfigSizeError
+	if errors.As(err, &configErr) {
+		return &oerrors.Error{
+			Err:            configErr,
+			Recommendation: fmt.Sprintf("If the registry is trusted and the config is expected to be larger, increase the limit via `--%s`", opts.flagPrefix+maxMetadataSizeFlag),
+		}, true
+	}
+	return nil, false
+}
+
+// DecorateConnectionError decorates errors of proxy and TLS connections with a
+// recommendation of the flag to use. It returns false if err is not caused by
+// the connection.
+func (opts *Remote) DecorateConnectionError(err error) (*oerrors.Error, bool) {
+	var (
+		certVerifyErr  *tls.CertificateVerificationError
+		unknownAuthErr x509.UnknownAuthorityError
+		hostnameErr    x509.HostnameError
+		certInvalidErr x509.CertificateInvalidError
+		recordErr      tls.RecordHeaderError
+	)
+	switch {
+	case onet.IsProxyError(err):
+		return &oerrors.Error{
+			Err:            fmt.Errorf("failed to connect via proxy: %w", err),
+			Recommendation: fmt.Sprintf("Please check the proxy specified by `--%s` or the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables", opts.flagPrefix+proxyFlag),
+		}, true
+	case errors.As(err, &certVerifyErr), errors.As(err, &unknownAuthErr), errors.As(err, &hostnameErr), errors.As(err, &certInvalidErr):
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: fmt.Sprintf("The registry certificate cannot be verified. Use `--%s` to trust its certificate authority, or `--%s` to skip the verification if the registry is trusted", opts.flagPrefix+caFileFlag, opts.flagPrefix+insecureFlag),
+		}, true
+	case isTLSVersionError(err):
+		required := opts.tlsMinVersionFlag
+		if required == "" {
+			required = "1.2"
+		}
+		negotiated := "an unsupported version"
+		if version, ok := serverTLSVersion(err); ok {
+			negotiated = tls.VersionName(version)
+		}
+		return &oerrors.Error{
+			Err:            fmt.Errorf("TLS handshake failed: the registry offered %s while TLS %s or later is required: %w", negotiated, required, err),
+			Recommendation: fmt.Sprintf("The registry does not seem to support the required TLS version. Use `--%s` to change the minimum TLS version if allowed by your security policy", opts.flagPrefix+tlsMinVersionFlag),
+		}, true
+	case errors.As(err, &recordErr), strings.Contains(err.Error(), "server gave HTTP response to HTTPS client"):
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: fmt.Sprintf("The registry does not seem to support TLS. Use `--%s` to connect via plain HTTP", opts.flagPrefix+plainHTTPFlag),
+		}, true
+	}
+	return nil, false
+}
+
+// isTLSVersionError returns true if err is caused by TLS version mismatch
+// during the handshake.
+func isTLSVersionError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "protocol version not supported") || strings.Contains(msg, "unsupported protocol version")
+}
+
+// serverTLSVersion extracts the TLS version selected by the server from the
+// handshake error, e.g. "tls: server selected unsupported protocol version 301".
+func serverTLSVersion(err error) (uint16, bool) {
+	_, hexVersion, found := strings.Cut(err.Error(), "unsupported protocol version ")
+	if !found {
+		return 0, false
+	}
+	hexVersion, _, _ = strings.Cut(hexVersion, " ")
+	version, parseErr := strconv.ParseUint(hexVersion, 16, 16)
+	if parseErr != nil {
+		return 0, false
+	}
+	return uint16(version), true
 }
 
 // DecorateCredentialError decorate error with recommendation.
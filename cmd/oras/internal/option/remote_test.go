@@ -24,18 +24,37 @@ import (
 	_ "embed"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/config"
+	"oras.land/oras/internal/registryutil"
 )
 
 var ts *httptest.Server
@@ -155,6 +174,65 @@ func TestRemote_authClient_RawCredential(t *testing.T) {
 	}
 }
 
+func TestRemote_authClient_noDockerConfig(t *testing.T) {
+	opts := Remote{
+		NoDockerConfig: true,
+	}
+	client, err := opts.authClient("hostname", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.Credential != nil {
+		t.Fatal("expect no credential function when docker config is disabled")
+	}
+	if opts.store != nil {
+		t.Fatal("expect no credential store when docker config is disabled")
+	}
+}
+
+func TestRemote_authClient_credentialHelperError(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"credsStore":"oras-test-nonexistent"}`), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts := Remote{
+		Configs: []string{configPath},
+	}
+	client, err := opts.authClient("hostname", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = client.Credential(context.Background(), "localhost:5000")
+	if err == nil {
+		t.Fatal("expect error from the credential helper")
+	}
+	if want := "docker-credential-oras-test-nonexistent"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("expect error to contain %q, got %v", want, err)
+	}
+}
+
+func TestRemote_authClient_dockerHubAlias(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	auth := base64.StdEncoding.EncodeToString([]byte("user:secret"))
+	if err := os.WriteFile(configPath, []byte(`{"auths":{"index.docker.io":{"auth":"`+auth+`"}}}`), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts := Remote{
+		Configs: []string{configPath},
+	}
+	client, err := opts.authClient("docker.io", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := client.Credential(context.Background(), "registry-1.docker.io")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Username != "user" || got.Password != "secret" {
+		t.Fatalf("expect the credential stored under index.docker.io, got: %v", got)
+	}
+}
+
 func TestRemote_authClient_skipTlsVerify(t *testing.T) {
 	opts := Remote{
 		Insecure: true,
@@ -370,6 +448,9 @@ func TestRemote_NewRepository_Retry(t *testing.T) {
 		Remote{
 			CACertFilePath: caPath,
 			plainHTTP:      plainHTTPNotSpecified,
+			retry:          retries,
+			retryDelay:     time.Millisecond,
+			retryMaxDelay:  10 * time.Millisecond,
 		},
 		Common{},
 	}
@@ -400,16 +481,187 @@ func TestRemote_NewRepository_Retry(t *testing.T) {
 
 func TestRemote_default_localhost(t *testing.T) {
 	opts := Remote{plainHTTP: plainHTTPNotSpecified}
-	got := opts.isPlainHttp("localhost")
-	if got != true {
-		t.Fatalf("tls should be disabled when domain is localhost")
+	for _, registry := range []string{"localhost", "localhost:9090", "127.0.0.1:5000", "[::1]:5000"} {
+		if opts.isPlainHttp(registry) {
+			t.Fatalf("tls should be attempted first when domain is %s", registry)
+		}
+		if !opts.isPlainHTTPFallbackAllowed(registry) {
+			t.Fatalf("plain http fallback should be allowed when domain is %s", registry)
+		}
+	}
+	if opts.isPlainHTTPFallbackAllowed("registry.example.com") {
+		t.Fatal("plain http fallback should not be allowed for non-loopback domain")
+	}
 
+	opts.plainHTTP = HTTPSEnabled
+	if opts.isPlainHTTPFallbackAllowed("localhost:9090") {
+		t.Fatal("plain http fallback should not be allowed when --plain-http=false is used")
 	}
+}
 
-	got = opts.isPlainHttp("localhost:9090")
-	if got != true {
-		t.Fatalf("tls should be disabled when domain is localhost")
+func TestRemote_remoteClient_plainHTTPAndAnonymousFallback(t *testing.T) {
+	// a plain HTTP registry rejecting the stored credentials but allowing
+	// anonymous access after the initial challenge
+	var challenged atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" || !challenged.Swap(true) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	cred := base64.StdEncoding.EncodeToString([]byte("user:rejected"))
+	if err := os.WriteFile(configPath, []byte(`{"auths":{"`+uri.Host+`":{"auth":"`+cred+`"}}}`), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts := Remote{
+		Configs:           []string{configPath},
+		plainHTTP:         plainHTTPNotSpecified,
+		anonymousFallback: true,
+	}
+	client, err := opts.remoteClient(uri.Host, false, logrus.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodGet, "https://"+uri.Host+"/v2/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expect status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if scheme := resp.Request.URL.Scheme; scheme != "http" {
+		t.Fatalf("expect the request to fall back to plain HTTP, got %s", scheme)
+	}
+}
+
+func TestRemote_remoteClient_anonymousFallbackWithStoredCredential(t *testing.T) {
+	const host = "registry.example.com"
+	cred := base64.StdEncoding.EncodeToString([]byte("user:pass"))
+	for _, tt := range []struct {
+		name     string
+		config   string
+		fallback bool
+	}{
+		{"stored credential", `{"auths":{"` + host + `":{"auth":"` + cred + `"}}}`, true},
+		{"credential of another host", `{"auths":{"other.example.com":{"auth":"` + cred + `"}}}`, false},
+		{"no credential", `{}`, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(configPath, []byte(tt.config), 0600); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			opts := Remote{
+				Configs:           []string{configPath},
+				plainHTTP:         HTTPSEnabled,
+				anonymousFallback: true,
+			}
+			client, err := opts.remoteClient(host, false, logrus.New())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := client.(*registryutil.AnonymousFallbackClient); ok != tt.fallback {
+				t.Errorf("anonymous fallback = %v, want %v", ok, tt.fallback)
+			}
+		})
+	}
+}
+
+func TestRemote_remoteClient_mirrorWithoutOriginCredential(t *testing.T) {
+	// a mirror challenging every request, recording the credentials it
+	// receives
+	var mirrorAuth atomic.Value
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			mirrorAuth.Store(auth)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer mirror.Close()
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer origin.Close()
+	mirrorURI, err := url.Parse(mirror.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	originURI, err := url.Parse(origin.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts := Remote{
+		Username:       "user",
+		Secret:         "origin-password",
+		NoDockerConfig: true,
+		plainHTTP:      HTTPSEnabled,
+		mirrors:        []string{mirrorURI.Host},
+	}
+	client, err := opts.remoteClient(originURI.Host, false, logrus.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodGet, origin.URL+"/v2/test/manifests/latest", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if host := resp.Request.URL.Host; host != originURI.Host {
+		t.Fatalf("expect the request to be served by the origin %s, got %s", originURI.Host, host)
+	}
+	if got := mirrorAuth.Load(); got != nil {
+		t.Fatalf("expect the mirror not to receive the origin credentials, got Authorization %q", got)
+	}
+}
+
+func TestRemote_NewRepository_plainHTTPFallback(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewEncoder(w).Encode(testTagList); err != nil {
+			http.Error(w, "error encoding", http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+	uri, err := url.ParseRequestURI(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	opts := Remote{plainHTTP: plainHTTPNotSpecified}
+	repo, err := opts.NewRepository(uri.Host+"/"+testRepo, Common{}, logrus.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.Tags(context.Background(), "", func([]string) error { return nil }); err != nil {
+		t.Fatalf("expect falling back to plain http, got error: %v", err)
+	}
 
+	opts.plainHTTP = HTTPSEnabled
+	repo, err = opts.NewRepository(uri.Host+"/"+testRepo, Common{}, logrus.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.Tags(context.Background(), "", func([]string) error { return nil }); err == nil {
+		t.Fatal("expect error when --plain-http=false is used")
 	}
 }
 
@@ -503,6 +755,14 @@ func TestRemote_parseResolve(t *testing.T) {
 			name: "fromHost:fromPort:toIp:toPort",
 			opts: &Remote{resolveFlag: []string{"host:443:0.0.0.0:5000"}},
 		},
+		{
+			name: "fromHost:*:toIp",
+			opts: &Remote{resolveFlag: []string{"host:*:0.0.0.0"}},
+		},
+		{
+			name: "fromHost:*:toIp:toPort",
+			opts: &Remote{resolveFlag: []string{"host:*:0.0.0.0:5000"}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -598,6 +858,18 @@ func TestRemote_parseCustomHeaders(t *testing.T) {
 			want:        nil,
 			wantErr:     true,
 		},
+		{
+			name:        "authorization header is rejected",
+			headerFlags: []string{"key:value", "authorization: Bearer token"},
+			want:        nil,
+			wantErr:     true,
+		},
+		{
+			name:        "host header is rejected",
+			headerFlags: []string{"Host :example.com"},
+			want:        nil,
+			wantErr:     true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -613,3 +885,1102 @@ func TestRemote_parseCustomHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistryConfigsFromEnv(t *testing.T) {
+	t.Setenv(RegistryConfigEnv, "")
+	if got := RegistryConfigsFromEnv(); len(got) != 0 {
+		t.Fatalf("expect no config, got %v", got)
+	}
+
+	want := []string{filepath.Join("a", "config.json"), filepath.Join("b", "config.json")}
+	t.Setenv(RegistryConfigEnv, strings.Join(want, string(os.PathListSeparator)))
+	if got := RegistryConfigsFromEnv(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expect %v, got %v", want, got)
+	}
+}
+
+func TestRemote_Credential_registryToken(t *testing.T) {
+	opts := Remote{
+		RegistryToken: "mocked-token",
+	}
+	want := auth.Credential{
+		AccessToken: "mocked-token",
+	}
+	if got := opts.Credential(); got != want {
+		t.Fatalf("expect: %v, got: %v", want, got)
+	}
+}
+
+func TestRemote_Parse_conflict(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"registry token only", []string{"--registry-token", "token"}, false},
+		{"username and password", []string{"--username", "user", "--password", "pass"}, false},
+		{"with username", []string{"--registry-token", "token", "--username", "user"}, true},
+		{"with identity token", []string{"--registry-token", "token", "--identity-token", "id"}, true},
+		{"plain HTTP and insecure", []string{"--plain-http", "--insecure"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts Remote
+			cmd := &cobra.Command{}
+			cmd.SetErr(io.Discard)
+			opts.ApplyFlags(cmd.Flags())
+			if err := cmd.Flags().Parse(tt.args); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := opts.Parse(cmd); (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRemote_authCache(t *testing.T) {
+	from := Remote{Username: "user", Secret: "secret"}
+	to := Remote{Username: "user", Secret: "secret"}
+	if from.authCache() != to.authCache() {
+		t.Fatal("expect the auth cache to be shared for the same credential")
+	}
+	other := Remote{Username: "other", Secret: "secret"}
+	if from.authCache() == other.authCache() {
+		t.Fatal("expect different auth caches for different credentials")
+	}
+	storeA := Remote{Configs: []string{"a.json"}}
+	storeB := Remote{Configs: []string{"b.json"}}
+	if storeA.authCache() == storeB.authCache() {
+		t.Fatal("expect different auth caches for different credential stores")
+	}
+}
+
+func TestRemote_isPlainHttp_env(t *testing.T) {
+	t.Setenv(PlainHTTPRegistriesEnv, "lab.example.com,10.0.0.0/8")
+	opts := Remote{plainHTTP: plainHTTPNotSpecified}
+	if !opts.isPlainHttp("lab.example.com:5000") {
+		t.Fatal("expect plain HTTP for registry listed in the environment variable")
+	}
+	if !opts.isPlainHttp("10.1.2.3") {
+		t.Fatal("expect plain HTTP for registry in the listed CIDR")
+	}
+	if opts.isPlainHttp("registry.example.com") {
+		t.Fatal("expect HTTPS for registry not listed")
+	}
+	opts.plainHTTP = HTTPSEnabled
+	if opts.isPlainHttp("lab.example.com:5000") {
+		t.Fatal("expect --plain-http=false to override the environment variable")
+	}
+}
+
+func TestRemote_isInsecure_env(t *testing.T) {
+	t.Setenv(InsecureRegistriesEnv, "lab.example.com")
+	opts := Remote{}
+	if !opts.isInsecure("lab.example.com") {
+		t.Fatal("expect TLS verification skipped for registry listed in the environment variable")
+	}
+	if opts.isInsecure("registry.example.com") {
+		t.Fatal("expect TLS verification for registry not listed")
+	}
+	opts.insecureEnforced = func() bool { return true }
+	if opts.isInsecure("lab.example.com") {
+		t.Fatal("expect --insecure=false to override the environment variable")
+	}
+	opts.Insecure = true
+	if !opts.isInsecure("registry.example.com") {
+		t.Fatal("expect --insecure to skip TLS verification")
+	}
+}
+
+func TestRemote_DecorateConnectionError(t *testing.T) {
+	opts := Remote{flagPrefix: "from-"}
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"unknown authority", x509.UnknownAuthorityError{}, "--from-insecure"},
+		{"not TLS", errors.New("http: server gave HTTP response to HTTPS client"), "--from-plain-http"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := opts.DecorateConnectionError(fmt.Errorf("Get \"https://localhost/v2/\": %w", tt.err))
+			if !ok {
+				t.Fatal("expect TLS error to be decorated")
+			}
+			if !strings.Contains(got.Recommendation, tt.want) {
+				t.Fatalf("expect recommendation to contain %q, got %q", tt.want, got.Recommendation)
+			}
+		})
+	}
+	if _, ok := opts.DecorateConnectionError(errors.New("other error")); ok {
+		t.Fatal("expect non-TLS error not to be decorated")
+	}
+}
+
+func TestRemote_Parse_caFile(t *testing.T) {
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caPath, localhostServerCert, 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	invalidPath := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalidPath, []byte("invalid"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	missingPath := filepath.Join(t.TempDir(), "missing.pem")
+	tests := []struct {
+		name        string
+		args        []string
+		wantErr     bool
+		wantWarning bool
+	}{
+		{"valid", []string{"--ca-file", caPath}, false, false},
+		{"with insecure", []string{"--ca-file", caPath, "--insecure"}, false, true},
+		{"invalid", []string{"--ca-file", invalidPath}, true, false},
+		{"missing", []string{"--ca-file", missingPath}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts Remote
+			var stderr bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetErr(&stderr)
+			opts.ApplyFlags(cmd.Flags())
+			if err := cmd.Flags().Parse(tt.args); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err := opts.Parse(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.args[1]) {
+				t.Fatalf("expect error to contain the path %q, got %v", tt.args[1], err)
+			}
+			if err == nil && opts.rootCAs == nil {
+				t.Fatal("expect CA certificates to be loaded")
+			}
+			if gotWarning := strings.Contains(stderr.String(), "WARNING!"); gotWarning != tt.wantWarning {
+				t.Fatalf("expect warning %v, got %q", tt.wantWarning, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRemote_authClient_proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodConnect {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		proxied = r.URL.Host
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	opts := Remote{proxyFlag: proxy.URL}
+	if err := opts.parseProxy(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client, err := opts.authClient("registry.example.com", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// plain HTTP request is forwarded by the proxy
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://registry.example.com/v2/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if proxied != "registry.example.com" {
+		t.Fatalf("expect request to be sent via proxy, got %q", proxied)
+	}
+
+	// HTTPS request fails on CONNECT
+	req, err = http.NewRequestWithContext(context.Background(), http.MethodGet, "https://registry.example.com/v2/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = client.Do(req)
+	if err == nil {
+		t.Fatal("expect error on rejected CONNECT request")
+	}
+	got, ok := opts.DecorateConnectionError(err)
+	if !ok || !strings.Contains(got.Error(), "failed to connect via proxy") {
+		t.Fatalf("expect proxy error, got %v", err)
+	}
+}
+
+func TestRemote_parseProxy_invalid(t *testing.T) {
+	opts := Remote{proxyFlag: "ftp://proxy.example.com"}
+	if err := opts.parseProxy(); err == nil {
+		t.Fatal("expect error for unsupported proxy scheme")
+	}
+}
+
+func TestRemote_authClient_noRetry(t *testing.T) {
+	count := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		http.Error(w, "error", http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	opts := Remote{retry: 0}
+	client, err := opts.authClient("hostname", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if count != 1 {
+		t.Fatalf("expect 1 request with retries disabled, got %d", count)
+	}
+}
+
+func TestRemote_parseRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Remote
+		wantErr bool
+	}{
+		{"default", Remote{retry: 3, retryDelay: 200 * time.Millisecond, retryMaxDelay: 3 * time.Second}, false},
+		{"disabled", Remote{retry: 0}, false},
+		{"negative retry", Remote{retry: -1}, true},
+		{"delay greater than max delay", Remote{retry: 3, retryDelay: time.Second, retryMaxDelay: time.Millisecond}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.parseRetry(); (err != nil) != tt.wantErr {
+				t.Errorf("parseRetry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRemote_NewRepository_userAgent(t *testing.T) {
+	const (
+		suffix = "job/42"
+		token  = "test-token"
+		digest = "sha256:b5b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7"
+	)
+	var ts *httptest.Server
+	userAgents := map[string]string{}
+	var mu sync.Mutex
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		userAgents[r.URL.Path] = r.Header.Get("User-Agent")
+		mu.Unlock()
+		if r.URL.Path == "/token" {
+			fmt.Fprintf(w, `{"token":%q}`, token)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.Header().Set("Www-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, ts.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/" + testRepo + "/manifests/latest":
+			w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+			w.Header().Set("Docker-Content-Digest", digest)
+			w.Header().Set("Content-Length", "0")
+		case "/v2/" + testRepo + "/blobs/" + digest:
+			w.Header().Set("Content-Length", "0")
+		case "/v2/" + testRepo + "/referrers/" + digest:
+			w.Header().Set("Content-Type", "application/vnd.oci.image.index.v1+json")
+			_, _ = io.WriteString(w, `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	uri, err := url.ParseRequestURI(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts := Remote{
+		plainHTTP:       plainHTTPEnabled,
+		userAgentSuffix: suffix,
+		Username:        "username",
+		Secret:          "password",
+	}
+	repo, err := opts.NewRepository(uri.Host+"/"+testRepo, Common{}, logrus.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+	desc, err := repo.Resolve(ctx, "latest")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repo.Blobs().Exists(ctx, desc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.Referrers(ctx, desc, "", func([]ocispec.Descriptor) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := opts.userAgent()
+	if !strings.HasPrefix(want, "oras/") || !strings.HasSuffix(want, " "+suffix) {
+		t.Fatalf("unexpected user agent %q", want)
+	}
+	for _, path := range []string{
+		"/token",
+		"/v2/" + testRepo + "/manifests/latest",
+		"/v2/" + testRepo + "/blobs/" + digest,
+		"/v2/" + testRepo + "/referrers/" + digest,
+	} {
+		if got, ok := userAgents[path]; !ok || got != want {
+			t.Errorf("expect User-Agent %q for %s, got %q", want, path, got)
+		}
+	}
+}
+
+func TestRemote_NewRepository_connectionReuse(t *testing.T) {
+	var newConns atomic.Int32
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewEncoder(w).Encode(testTagList); err != nil {
+			http.Error(w, "error encoding", http.StatusBadRequest)
+		}
+	}))
+	ts.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			newConns.Add(1)
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+	uri, err := url.ParseRequestURI(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	opts := Remote{plainHTTP: plainHTTPEnabled}
+	for i := 0; i < 3; i++ {
+		// repositories created within one command share connections
+		repo, err := opts.NewRepository(uri.Host+"/"+testRepo, Common{}, logrus.New())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.Tags(context.Background(), "", func([]string) error { return nil }); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := newConns.Load(); got != 1 {
+		t.Fatalf("expect 1 connection to be reused, got %d connections", got)
+	}
+}
+
+func TestRemote_authClient_disableHTTP2(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	defer ts.Close()
+
+	for _, tt := range []struct {
+		disableHTTP2 bool
+		want         string
+	}{
+		{false, "HTTP/2.0"},
+		{true, "HTTP/1.1"},
+	} {
+		t.Run(tt.want, func(t *testing.T) {
+			opts := Remote{Insecure: true, disableHTTP2: tt.disableHTTP2}
+			client, err := opts.authClient("hostname", false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			if resp.Proto != tt.want {
+				t.Fatalf("expect protocol %s, got %s", tt.want, resp.Proto)
+			}
+		})
+	}
+}
+
+func TestRemote_tlsMinVersion(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	ts.Config.ErrorLog = log.New(io.Discard, "", 0)
+	ts.StartTLS()
+	defer ts.Close()
+
+	opts := Remote{Insecure: true, tlsMinVersionFlag: "1.3"}
+	if err := opts.parseTLSMinVersion(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client, err := opts.authClient("hostname", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = client.Do(req)
+	if err == nil {
+		t.Fatal("expect handshake failure with TLS 1.3 required")
+	}
+	got, ok := opts.DecorateConnectionError(err)
+	if !ok {
+		t.Fatalf("expect TLS version error to be decorated, got %v", err)
+	}
+	if !strings.Contains(got.Error(), "TLS 1.3 or later is required") || !strings.Contains(got.Recommendation, "--tls-min-version") {
+		t.Fatalf("unexpected decorated error: %v", got)
+	}
+
+	opts = Remote{tlsMinVersionFlag: "1.4"}
+	if err := opts.parseTLSMinVersion(); err == nil {
+		t.Fatal("expect error for invalid TLS version")
+	}
+}
+
+func TestRemote_NewRepository_unixSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "oras-test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "registry.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var gotHost string
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+		if err := json.NewEncoder(w).Encode(testTagList); err != nil {
+			http.Error(w, "error encoding", http.StatusBadRequest)
+		}
+	}))
+	ts.Listener = listener
+	ts.Start()
+	defer ts.Close()
+
+	var opts Remote
+	cmd := &cobra.Command{}
+	opts.ApplyFlags(cmd.Flags())
+	if err := cmd.Flags().Parse([]string{"--unix-socket", socketPath}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := opts.Parse(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	repo, err := opts.NewRepository("registry.local/"+testRepo, Common{}, logrus.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.Tags(context.Background(), "", func([]string) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotHost != "registry.local" {
+		t.Fatalf("expect host registry.local, got %q", gotHost)
+	}
+
+	opts = Remote{}
+	cmd = &cobra.Command{}
+	opts.ApplyFlags(cmd.Flags())
+	if err := cmd.Flags().Parse([]string{"--unix-socket", filepath.Join(dir, "missing.sock")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := opts.Parse(cmd); err == nil {
+		t.Fatal("expect error for missing unix socket")
+	}
+}
+
+func Test_parseByteSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"1024", 1024, false},
+		{"512k", 512 << 10, false},
+		{"10M", 10 << 20, false},
+		{"2G", 2 << 30, false},
+		{"M", 0, true},
+		{"1.5M", 0, true},
+		{"10T", 0, true},
+		{"9223372036854775807G", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseByteSize(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseByteSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseByteSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemote_parseLimitRate(t *testing.T) {
+	opts := Remote{limitRate: "0"}
+	if err := opts.parseLimitRate(); err == nil {
+		t.Error("parseLimitRate() expects error for zero rate")
+	}
+	opts = Remote{limitRate: "10M"}
+	if err := opts.parseLimitRate(); err != nil {
+		t.Fatalf("parseLimitRate() error = %v", err)
+	}
+	if opts.rateLimiter == nil {
+		t.Error("parseLimitRate() expects a rate limiter")
+	}
+}
+
+func TestRemote_parseMaxMetadataSize(t *testing.T) {
+	opts := Remote{maxMetadataSize: "4M"}
+	if err := opts.parseMaxMetadataSize(); err != nil {
+		t.Fatalf("parseMaxMetadataSize() error = %v", err)
+	}
+	if want := int64(4 << 20); opts.MaxMetadataBytes != want {
+		t.Errorf("MaxMetadataBytes = %d, want %d", opts.MaxMetadataBytes, want)
+	}
+	opts = Remote{maxMetadataSize: "-1"}
+	if err := opts.parseMaxMetadataSize(); err == nil {
+		t.Error("parseMaxMetadataSize() expects error for negative size")
+	}
+}
+
+func TestRemote_handleWarning(t *testing.T) {
+	warning := remote.Warning{
+		WarningValue: remote.WarningValue{Code: 299, Agent: "-", Text: "this repository is deprecated"},
+	}
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+
+	// warnings are printed and recorded once
+	opts := Remote{}
+	recorded := len(output.Warnings())
+	handle := opts.handleWarning("localhost:5000", logger)
+	handle(warning)
+	handle(warning)
+	if got := strings.Count(buf.String(), "Registry warning: this repository is deprecated"); got != 1 {
+		t.Fatalf("expect the warning to be printed once, got %q", buf.String())
+	}
+	warnings := output.Warnings()
+	if len(warnings) != recorded+1 {
+		t.Fatalf("expect the warning to be recorded once, got %v", warnings)
+	}
+	if want := (output.Warning{Registry: "localhost:5000", Text: warning.Text}); warnings[recorded] != want {
+		t.Fatalf("recorded warning = %v, want %v", warnings[recorded], want)
+	}
+
+	// warnings are silenced
+	buf.Reset()
+	opts = Remote{noWarnings: true}
+	opts.handleWarning("localhost:5000", logger)(warning)
+	if buf.Len() != 0 {
+		t.Fatalf("expect no warning printed, got %q", buf.String())
+	}
+	if got := len(output.Warnings()); got != recorded+1 {
+		t.Fatalf("expect no warning recorded, got %v", output.Warnings())
+	}
+}
+
+func TestRemote_Parse_context(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := `contexts:
+  local:
+    registry: localhost:5000
+    plainHTTP: true
+    registryConfig:
+      - /path/to/auth.json
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.ConfigEnv, path)
+	t.Setenv(config.ContextEnv, "")
+
+	parse := func(registry string, args ...string) (*Remote, error) {
+		var opts Remote
+		opts.SetRegistry(registry)
+		cmd := &cobra.Command{}
+		cmd.SetErr(io.Discard)
+		opts.ApplyFlags(cmd.Flags())
+		if err := cmd.Flags().Parse(args); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return &opts, opts.Parse(cmd)
+	}
+
+	opts, err := parse("localhost:5000", "--context", "local")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !opts.isPlainHttp("example.com") {
+		t.Error("plain HTTP of the context is not applied")
+	}
+	if want := []string{"/path/to/auth.json"}; !reflect.DeepEqual(opts.Configs, want) {
+		t.Errorf("Configs = %v, want %v", opts.Configs, want)
+	}
+
+	// explicit flags take precedence
+	opts, err = parse("localhost:5000", "--context", "local", "--plain-http=false", "--registry-config", "auth.json")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if opts.isPlainHttp("example.com") {
+		t.Error("--plain-http=false is overridden by the context")
+	}
+	if want := []string{"auth.json"}; !reflect.DeepEqual(opts.Configs, want) {
+		t.Errorf("Configs = %v, want %v", opts.Configs, want)
+	}
+
+	// context selected by the environment variable
+	t.Setenv(config.ContextEnv, "local")
+	if opts, err = parse("localhost:5000"); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !opts.isPlainHttp("example.com") {
+		t.Errorf("plain HTTP of the context selected by $%s is not applied", config.ContextEnv)
+	}
+
+	// context selected by the environment variable for another registry
+	if opts, err = parse("example.com"); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if opts.isPlainHttp("example.com") {
+		t.Error("plain HTTP of the context is applied to another registry")
+	}
+	if opts.Configs != nil {
+		t.Errorf("registry config of the context is applied to another registry: %v", opts.Configs)
+	}
+
+	// context selected by the flag for another registry
+	t.Setenv(config.ContextEnv, "")
+	_, err = parse("example.com", "--context", "local")
+	var oErr *oerrors.Error
+	if !errors.As(err, &oErr) {
+		t.Fatalf("Parse() error = %v, want an oerrors.Error", err)
+	}
+	if want := `context "local" is meant for the registry localhost:5000, not example.com`; oErr.Err.Error() != want {
+		t.Errorf("Parse() error = %v, want %v", oErr.Err, want)
+	}
+
+	// unknown context
+	if _, err = parse("localhost:5000", "--context", "unknown"); !errors.Is(err, config.ErrContextNotFound) {
+		t.Errorf("Parse() error = %v, want %v", err, config.ErrContextNotFound)
+	}
+}
+
+func TestRemote_Modify_tooManyRequests(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"errors":[{"code":"TOOMANYREQUESTS","message":"pull rate limit exceeded"}]}`))
+	}))
+	defer ts.Close()
+	uri, err := url.ParseRequestURI(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts := Remote{plainHTTP: func() (bool, bool) { return true, true }}
+	repo, err := opts.NewRepository(uri.Host+"/"+testRepo, Common{}, logrus.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = repo.Tags(context.Background(), "", func([]string) error { return nil })
+	if err == nil {
+		t.Fatal("expect error but got nil")
+	}
+
+	got, modified := opts.Modify(&cobra.Command{}, err)
+	if !modified {
+		t.Fatalf("expect error to be modified: %v", err)
+	}
+	if code := oerrors.ExitCode(got); code != oerrors.ExitCodeTooManyRequests {
+		t.Errorf("ExitCode() = %d, want %d", code, oerrors.ExitCodeTooManyRequests)
+	}
+	var oErr *oerrors.Error
+	if !errors.As(got, &oErr) || oErr.Recommendation != "The registry rate limited the requests. Retry after 30s" {
+		t.Errorf("unexpected recommendation of %v", got)
+	}
+}
+
+func TestRemote_Parse_passwordPrompt(t *testing.T) {
+	// stdin is not a terminal
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+
+	tests := []struct {
+		name               string
+		prefix             string
+		args               []string
+		disablePrompt      bool
+		wantErr            bool
+		wantRecommendation string
+	}{
+		{
+			name:               "username only",
+			args:               []string{"--username", "me"},
+			wantErr:            true,
+			wantRecommendation: "Use --password-stdin or --password-file to read the password from stdin or a file, or set $ORAS_PASSWORD",
+		},
+		{
+			name:               "prefixed username only",
+			prefix:             "from",
+			args:               []string{"--from-username", "me"},
+			wantErr:            true,
+			wantRecommendation: "Use --from-password-file to read the password from a file, or set $ORAS_FROM_PASSWORD",
+		},
+		{
+			name: "username and password",
+			args: []string{"--username", "me", "--password", "secret"},
+		},
+		{
+			name:          "prompt disabled",
+			args:          []string{"--username", "me"},
+			disablePrompt: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Remote{}
+			if tt.disablePrompt {
+				opts.DisablePasswordPrompt()
+			}
+			cmd := &cobra.Command{}
+			cmd.SetErr(io.Discard)
+			if tt.prefix == "" {
+				opts.ApplyFlags(cmd.Flags())
+			} else {
+				opts.ApplyFlagsWithPrefix(cmd.Flags(), tt.prefix, "source")
+			}
+			if err := cmd.Flags().Parse(tt.args); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err := opts.Parse(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Remote.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var oErr *oerrors.Error
+			if tt.wantErr && (!errors.As(err, &oErr) || oErr.Recommendation != tt.wantRecommendation) {
+				t.Errorf("Remote.Parse() error = %v, want recommendation %q", err, tt.wantRecommendation)
+			}
+		})
+	}
+}
+
+func TestRemote_Parse_passwordFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return path
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{"one newline trimmed", []string{"--password-file", writeFile("newline", "secret\n\n")}, "secret\n", false},
+		{"crlf trimmed", []string{"--password-file", writeFile("crlf", "secret\r\n")}, "secret", false},
+		{"no newline", []string{"--password-file", writeFile("plain", "secret")}, "secret", false},
+		{"empty", []string{"--password-file", writeFile("empty", "\n")}, "", true},
+		{"missing", []string{"--password-file", filepath.Join(dir, "missing")}, "", true},
+		{"with password", []string{"--password-file", writeFile("conflict", "secret"), "--password", "secret"}, "", true},
+		{"with password-stdin", []string{"--password-file", writeFile("stdin", "secret"), "--password-stdin"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Remote{}
+			cmd := &cobra.Command{}
+			cmd.SetErr(io.Discard)
+			opts.ApplyFlags(cmd.Flags())
+			if err := cmd.Flags().Parse(append(tt.args, "--username", "me")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err := opts.Parse(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Remote.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && strings.Contains(err.Error(), "secret") {
+				t.Errorf("Remote.Parse() error = %v, leaking the password", err)
+			}
+			if err == nil && opts.Secret != tt.want {
+				t.Errorf("Remote.Parse() secret = %q, want %q", opts.Secret, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemote_NewRepository_referrersCapability(t *testing.T) {
+	subject := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2",
+		Size:      123,
+	}
+	manifest, err := json.Marshal(ocispec.Manifest{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.test",
+		Config:       ocispec.DescriptorEmptyJSON,
+		Layers:       []ocispec.Descriptor{ocispec.DescriptorEmptyJSON},
+		Subject:      &subject,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestDesc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(manifest),
+		Size:      int64(len(manifest)),
+	}
+	tagSchemaPath := "/v2/" + testRepo + "/manifests/sha256-" + subject.Digest.Encoded()
+
+	tests := []struct {
+		name              string
+		pushStatus        int
+		returnHeader      bool
+		wantErr           bool
+		wantTagSchema     bool
+		wantCapability    bool
+		wantCapabilitySet bool
+	}{
+		{name: "header returned", pushStatus: http.StatusCreated, returnHeader: true, wantCapability: true, wantCapabilitySet: true},
+		{name: "header omitted", pushStatus: http.StatusCreated, wantTagSchema: true, wantCapabilitySet: true},
+		{name: "artifact manifest rejected", pushStatus: http.StatusBadRequest, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				mu.Unlock()
+				switch {
+				case r.Method == http.MethodPut && r.URL.Path == "/v2/"+testRepo+"/manifests/"+manifestDesc.Digest.String():
+					if tt.returnHeader {
+						w.Header().Set("OCI-Subject", subject.Digest.String())
+					}
+					w.WriteHeader(tt.pushStatus)
+				case r.Method == http.MethodPut && r.URL.Path == tagSchemaPath:
+					w.WriteHeader(http.StatusCreated)
+				case strings.Contains(r.URL.Path, "/referrers/"):
+					w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+					_, _ = w.Write([]byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[]}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer ts.Close()
+			uri, _ := url.Parse(ts.URL)
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			opts := Remote{plainHTTP: plainHTTPEnabled}
+			repo, err := opts.NewRepository(uri.Host+"/"+testRepo, Common{}, logger)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = repo.Push(context.Background(), manifestDesc, bytes.NewReader(manifest))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Repository.Push() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			mu.Lock()
+			pushed := slices.Contains(requests, http.MethodPut+" "+tagSchemaPath)
+			probed := slices.ContainsFunc(requests, func(r string) bool {
+				return strings.Contains(r, "/referrers/")
+			})
+			mu.Unlock()
+			if pushed != tt.wantTagSchema {
+				t.Errorf("referrers tag schema pushed = %v, want %v", pushed, tt.wantTagSchema)
+			}
+			if probed {
+				t.Error("referrers API is probed")
+			}
+
+			// the capability is cached for the registry host
+			got, ok := referrersCapabilities.Load(uri.Host)
+			if ok != tt.wantCapabilitySet {
+				t.Fatalf("referrers capability cached = %v, want %v", ok, tt.wantCapabilitySet)
+			}
+			if ok && got.(bool) != tt.wantCapability {
+				t.Errorf("referrers capability = %v, want %v", got, tt.wantCapability)
+			}
+			if !ok {
+				return
+			}
+			repo, err = opts.NewRepository(uri.Host+"/"+testRepo, Common{}, logger)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := repo.SetReferrersCapability(!tt.wantCapability); !errors.Is(err, remote.ErrReferrersCapabilityAlreadySet) {
+				t.Errorf("Repository.SetReferrersCapability() error = %v, want %v", err, remote.ErrReferrersCapabilityAlreadySet)
+			}
+		})
+	}
+}
+
+func TestRemote_NewRepository_referrersFallbackIndex(t *testing.T) {
+	subject := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2",
+		Size:      123,
+	}
+	newReferrer := func(artifactType string, annotations map[string]string) (ocispec.Descriptor, []byte) {
+		manifest, err := json.Marshal(ocispec.Manifest{
+			Versioned:    specs.Versioned{SchemaVersion: 2},
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: artifactType,
+			Config:       ocispec.DescriptorEmptyJSON,
+			Layers:       []ocispec.Descriptor{ocispec.DescriptorEmptyJSON},
+			Subject:      &subject,
+			Annotations:  annotations,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromBytes(manifest),
+			Size:      int64(len(manifest)),
+		}, manifest
+	}
+	signature, signatureJSON := newReferrer("application/vnd.test.signature", map[string]string{"created": "1"})
+	sbom, sbomJSON := newReferrer("application/vnd.test.sbom", map[string]string{"created": "2"})
+	tagSchemaPath := "/v2/" + testRepo + "/manifests/sha256-" + subject.Digest.Encoded()
+
+	var mu sync.Mutex
+	manifests := make(map[string][]byte)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/manifests/"):
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			manifests[r.URL.Path] = body
+			w.WriteHeader(http.StatusCreated)
+		case (r.Method == http.MethodGet || r.Method == http.MethodHead) && manifests[r.URL.Path] != nil:
+			body := manifests[r.URL.Path]
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+			w.Header().Set("Docker-Content-Digest", digest.FromBytes(body).String())
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			if r.Method == http.MethodGet {
+				_, _ = w.Write(body)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	uri, _ := url.Parse(ts.URL)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	opts := Remote{plainHTTP: plainHTTPEnabled}
+	repo, err := opts.NewRepository(uri.Host+"/"+testRepo, Common{}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for _, push := range []struct {
+		desc ocispec.Descriptor
+		blob []byte
+	}{
+		{signature, signatureJSON},
+		{sbom, sbomJSON},
+		{signature, signatureJSON},
+	} {
+		if err := repo.Push(ctx, push.desc, bytes.NewReader(push.blob)); err != nil {
+			t.Fatalf("Repository.Push() error = %v", err)
+		}
+	}
+
+	mu.Lock()
+	indexJSON := manifests[tagSchemaPath]
+	mu.Unlock()
+	var index ocispec.Index
+	if err := json.Unmarshal(indexJSON, &index); err != nil {
+		t.Fatalf("failed to decode the referrers index: %v", err)
+	}
+	signature.ArtifactType = "application/vnd.test.signature"
+	signature.Annotations = map[string]string{"created": "1"}
+	sbom.ArtifactType = "application/vnd.test.sbom"
+	sbom.Annotations = map[string]string{"created": "2"}
+	if want := []ocispec.Descriptor{signature, sbom}; !reflect.DeepEqual(index.Manifests, want) {
+		t.Errorf("referrers index entries = %v, want %v", index.Manifests, want)
+	}
+}
+
+func TestRemote_NewRepository_manifestDigestRewritten(t *testing.T) {
+	manifest := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[]}`)
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(manifest),
+		Size:      int64(len(manifest)),
+	}
+	rewritten := digest.FromString("rewritten")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.Contains(r.URL.Path, "/manifests/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", rewritten.String())
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+	uri, _ := url.Parse(ts.URL)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	opts := Remote{plainHTTP: plainHTTPEnabled}
+	repo, err := opts.NewRepository(uri.Host+"/"+testRepo, Common{}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded := len(output.Warnings())
+	if err := repo.PushReference(context.Background(), desc, bytes.NewReader(manifest), "latest"); err == nil {
+		t.Fatal("Repository.PushReference() expects a digest mismatch error")
+	}
+	warnings := output.Warnings()
+	if len(warnings) != recorded+1 {
+		t.Fatalf("expect one warning recorded, got %v", warnings[recorded:])
+	}
+	if got := warnings[recorded]; got.Registry != uri.Host || !strings.Contains(got.Text, desc.Digest.String()) || !strings.Contains(got.Text, rewritten.String()) {
+		t.Errorf("recorded warning = %+v, want the pushed and stored digests of %s", got, uri.Host)
+	}
+}
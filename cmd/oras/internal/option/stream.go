@@ -0,0 +1,160 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// StreamTo option struct.
+type StreamTo struct {
+	StreamCommand string
+	args          []string
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *StreamTo) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&opts.StreamCommand, "stream-to", "", "", "[Experimental] stream the verified content into the standard input of `command`, e.g. \"tar -xzf -\"")
+}
+
+// Parse parses the stream command into arguments.
+func (opts *StreamTo) Parse(_ *cobra.Command) error {
+	if opts.StreamCommand == "" {
+		return nil
+	}
+	args, err := splitCommand(opts.StreamCommand)
+	if err != nil {
+		return fmt.Errorf("invalid value %q for flag --stream-to: %w", opts.StreamCommand, err)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("invalid value %q for flag --stream-to: command is empty", opts.StreamCommand)
+	}
+	opts.args = args
+	return nil
+}
+
+// Streaming returns true if a stream command is specified.
+func (opts *StreamTo) Streaming() bool {
+	return len(opts.args) > 0
+}
+
+// Stream spawns the stream command, writes the content read from r into its
+// standard input and waits for it to exit. verify is called once r is drained
+// and before the standard input is closed. If reading or verification fails,
+// the command is killed so that it never sees the end of the content and the
+// error is returned even if the command has already exited successfully. If
+// the command fails, the returned error carries its exit code.
+func (opts *StreamTo) Stream(ctx context.Context, r io.Reader, verify func() error) error {
+	cmd := exec.CommandContext(ctx, opts.args[0], opts.args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start stream command %q: %w", opts.StreamCommand, err)
+	}
+
+	_, err = io.Copy(stdin, r)
+	if errors.Is(err, syscall.EPIPE) {
+		// the command exits without consuming the whole content, which is
+		// still drained for verification
+		_, err = io.Copy(io.Discard, r)
+	}
+	if err == nil && verify != nil {
+		err = verify()
+	}
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return err
+	}
+	if err := stdin.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return err
+	}
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("stream command %q failed: %w", opts.StreamCommand, err)
+	}
+	return nil
+}
+
+// splitCommand splits a command line into arguments separated by white
+// spaces. As in shells, quotes group characters into one argument and
+// backslashes escape the following character outside single quotes.
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+	for _, c := range command {
+		switch {
+		case escaped:
+			arg.WriteRune(c)
+			escaped = false
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				arg.WriteRune(c)
+			}
+		case c == '\\':
+			escaped = true
+			inArg = true
+		case quote == '"':
+			if c == '"' {
+				quote = 0
+			} else {
+				arg.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inArg = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(c)
+			inArg = true
+		}
+	}
+	if escaped {
+		return nil, errors.New("unterminated escape character")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote %c", quote)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_splitCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    []string
+		wantErr bool
+	}{
+		{name: "plain", command: "tar -xzf -", want: []string{"tar", "-xzf", "-"}},
+		{name: "extra spaces", command: "  gunzip\t -c  ", want: []string{"gunzip", "-c"}},
+		{name: "single quotes", command: `sh -c 'cat > "out file"'`, want: []string{"sh", "-c", `cat > "out file"`}},
+		{name: "double quotes", command: `tar -C "my dir" -x`, want: []string{"tar", "-C", "my dir", "-x"}},
+		{name: "escaped space", command: `tar -C my\ dir -x`, want: []string{"tar", "-C", "my dir", "-x"}},
+		{name: "empty quotes", command: `echo ""`, want: []string{"echo", ""}},
+		{name: "empty", command: "   ", want: nil},
+		{name: "unterminated quote", command: `sh -c 'cat`, wantErr: true},
+		{name: "unterminated escape", command: `cat \`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitCommand(tt.command)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamTo_Parse(t *testing.T) {
+	opts := StreamTo{StreamCommand: "tar -xf -"}
+	if err := opts.Parse(nil); err != nil {
+		t.Fatalf("StreamTo.Parse() error = %v", err)
+	}
+	if !opts.Streaming() {
+		t.Error("StreamTo.Streaming() = false, want true")
+	}
+	if err := (&StreamTo{StreamCommand: " "}).Parse(nil); err == nil {
+		t.Error("StreamTo.Parse() error = nil, want error for empty command")
+	}
+	if (&StreamTo{}).Streaming() {
+		t.Error("StreamTo.Streaming() = true, want false without command")
+	}
+}
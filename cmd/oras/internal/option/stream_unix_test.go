@@ -0,0 +1,89 @@
+//go:build freebsd || linux || netbsd || openbsd || solaris
+
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestStreamTo_Stream(t *testing.T) {
+	errVerify := errors.New("digest mismatch")
+	content := bytes.Repeat([]byte("hello"), 64*1024)
+	tests := []struct {
+		name         string
+		script       string
+		verifyErr    error
+		wantErr      error
+		wantExitCode int
+		wantOutput   bool
+		wantDone     bool
+	}{
+		{name: "success", script: `cat > "$1" && touch "$2"`, wantOutput: true, wantDone: true},
+		{name: "verification failed", script: `cat > "$1" && touch "$2"`, verifyErr: errVerify, wantErr: errVerify},
+		{name: "command failed", script: `cat > /dev/null; exit 3`, wantExitCode: 3},
+		{name: "command exits early", script: `touch "$2"`, wantDone: true},
+		{name: "command exits early and verification failed", script: `touch "$2"`, verifyErr: errVerify, wantErr: errVerify, wantDone: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			output := filepath.Join(dir, "output")
+			done := filepath.Join(dir, "done")
+			opts := StreamTo{args: []string{"sh", "-c", tt.script, "sh", output, done}}
+			verified := false
+			err := opts.Stream(context.Background(), bytes.NewReader(content), func() error {
+				verified = true
+				return tt.verifyErr
+			})
+			if !verified {
+				t.Error("content is not verified")
+			}
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("StreamTo.Stream() error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantExitCode != 0:
+				var exitErr *exec.ExitError
+				if !errors.As(err, &exitErr) || exitErr.ExitCode() != tt.wantExitCode {
+					t.Errorf("StreamTo.Stream() error = %v, want exit code %d", err, tt.wantExitCode)
+				}
+			case err != nil:
+				t.Errorf("StreamTo.Stream() error = %v", err)
+			}
+			if _, err := os.Stat(done); (err == nil) != tt.wantDone {
+				t.Errorf("command completed = %v, want %v", err == nil, tt.wantDone)
+			}
+			if tt.wantOutput {
+				got, err := os.ReadFile(output)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, content) {
+					t.Errorf("streamed %d bytes, want %d bytes", len(got), len(content))
+				}
+			}
+		})
+	}
+}
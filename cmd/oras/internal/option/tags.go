@@ -0,0 +1,51 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+
+	"github.com/spf13/pflag"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/internal/descriptor"
+)
+
+// ReferrerTags option struct.
+type ReferrerTags struct {
+	IncludeReferrerTags bool
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *ReferrerTags) ApplyFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&opts.IncludeReferrerTags, "include-referrer-tags", false, "include the tags created by the referrers tag schema such as 'sha256-aaaa...', which are excluded by default")
+}
+
+// ListTags lists the tags of lister lexically after last. The tags of the
+// referrers tag schema, i.e. digests with the colon replaced by a hyphen, are
+// excluded unless --include-referrer-tags is specified.
+func (opts *ReferrerTags) ListTags(ctx context.Context, lister registry.TagLister, last string) ([]string, error) {
+	var tags []string
+	err := lister.Tags(ctx, last, func(page []string) error {
+		for _, tag := range page {
+			if !opts.IncludeReferrerTags && descriptor.IsDigestTag(tag) {
+				continue
+			}
+			tags = append(tags, tag)
+		}
+		return nil
+	})
+	return tags, err
+}
@@ -0,0 +1,68 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// tagLister lists tags in pages.
+type tagLister [][]string
+
+func (l tagLister) Tags(_ context.Context, _ string, fn func(tags []string) error) error {
+	for _, page := range l {
+		if err := fn(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestReferrerTags_ListTags(t *testing.T) {
+	lister := tagLister{
+		{"v1", "sha256-2e0e0fe1fb3edbcdddad941c90d2b51e25a6bcd593e82545441a216de7bfa834"},
+		{"sha256-2e0e0fe1fb3e", "sha256-2e0e0fe1fb3edbcdddad941c90d2b51e25a6bcd593e82545441a216de7bfa834.sig", "v1-sha256"},
+	}
+	tests := []struct {
+		name    string
+		include bool
+		want    []string
+	}{
+		{
+			name: "referrer tags excluded",
+			want: []string{"v1", "sha256-2e0e0fe1fb3e", "sha256-2e0e0fe1fb3edbcdddad941c90d2b51e25a6bcd593e82545441a216de7bfa834.sig", "v1-sha256"},
+		},
+		{
+			name:    "referrer tags included",
+			include: true,
+			want:    []string{"v1", "sha256-2e0e0fe1fb3edbcdddad941c90d2b51e25a6bcd593e82545441a216de7bfa834", "sha256-2e0e0fe1fb3e", "sha256-2e0e0fe1fb3edbcdddad941c90d2b51e25a6bcd593e82545441a216de7bfa834.sig", "v1-sha256"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := ReferrerTags{IncludeReferrerTags: tt.include}
+			got, err := opts.ListTags(context.Background(), lister, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReferrerTags.ListTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
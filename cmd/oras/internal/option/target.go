@@ -22,7 +22,9 @@ import (
 	"io"
 	"io/fs"
 	"net/http"
+	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 
@@ -39,11 +41,15 @@ import (
 	"oras.land/oras-go/v2/registry/remote/errcode"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/fileref"
+	"oras.land/oras/internal/docker"
+	"oras.land/oras/internal/registryutil"
+	"oras.land/oras/internal/trace"
 )
 
 const (
-	TargetTypeRemote    = "registry"
-	TargetTypeOCILayout = "oci-layout"
+	TargetTypeRemote        = "registry"
+	TargetTypeOCILayout     = "oci-layout"
+	TargetTypeDockerArchive = "docker-archive"
 )
 
 // Target struct contains flags and arguments specifying one registry or image
@@ -60,6 +66,33 @@ type Target struct {
 	Path string
 
 	IsOCILayout bool
+	// IsDockerArchive is true if the target is a tarball created by
+	// `docker save`.
+	IsDockerArchive bool
+	// DockerArchiveImage is the name of the image selected from the docker
+	// archive.
+	DockerArchiveImage string
+
+	applyDockerArchive bool
+
+	// Tag is the advisory tag of a reference in the form of
+	// <name>:<tag>@<digest>, where the digest is authoritative.
+	Tag            string
+	verifyTag      bool
+	applyVerifyTag bool
+}
+
+// EnableVerifyTagFlag enables the --verify-tag flag, which verifies that the
+// advisory tag of the target reference resolves to its digest.
+func (opts *Target) EnableVerifyTagFlag() {
+	opts.applyVerifyTag = true
+}
+
+// EnableDockerArchiveFlag enables the flag setting the target as a docker
+// archive. Docker archives can be opened as read-only targets, while writing
+// them is handled by the commands.
+func (opts *Target) EnableDockerArchiveFlag() {
+	opts.applyDockerArchive = true
 }
 
 // ApplyFlags applies flags to a command flag set for unary target
@@ -85,6 +118,14 @@ func (opts *Target) AnnotatedReference() string {
 func (opts *Target) applyFlagsWithPrefix(fs *pflag.FlagSet, prefix, description string) {
 	flagPrefix, notePrefix := applyPrefix(prefix, description)
 	fs.BoolVarP(&opts.IsOCILayout, flagPrefix+"oci-layout", "", false, "set "+notePrefix+"target as an OCI image layout")
+	if opts.applyVerifyTag {
+		// only one target of a command can be verified, so the flag is not
+		// prefixed
+		fs.BoolVar(&opts.verifyTag, "verify-tag", false, "for references in the form of <name>:<tag>@<digest>, verify that "+notePrefix+"tag currently resolves to the digest, which is used regardless")
+	}
+	if opts.applyDockerArchive {
+		fs.BoolVar(&opts.IsDockerArchive, flagPrefix+"docker-archive", false, "[Experimental] set "+notePrefix+"target as a docker archive, the tarball format of `docker save` and `docker load`")
+	}
 }
 
 // ApplyFlagsWithPrefix applies flags to a command flag set with a prefix string.
@@ -96,7 +137,30 @@ func (opts *Target) ApplyFlagsWithPrefix(fs *pflag.FlagSet, prefix, description
 
 // Parse gets target options from user input.
 func (opts *Target) Parse(cmd *cobra.Command) error {
+	if err := opts.parse(cmd); err != nil {
+		return err
+	}
+	if opts.verifyTag && opts.Tag == "" {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("--verify-tag requires both a tag and a digest in %q", opts.RawReference),
+			Recommendation: "Please specify the reference in the form of <name>:<tag>@<digest>",
+		}
+	}
+	return nil
+}
+
+func (opts *Target) parse(cmd *cobra.Command) error {
 	switch {
+	case opts.IsDockerArchive:
+		if opts.IsOCILayout {
+			return errors.New("a target cannot be both an OCI image layout and a docker archive")
+		}
+		opts.Type = TargetTypeDockerArchive
+		if len(opts.headerFlags) != 0 {
+			return errors.New("custom header flags cannot be used on a docker archive target")
+		}
+		opts.Path = opts.RawReference
+		return nil
 	case opts.IsOCILayout:
 		opts.Type = TargetTypeOCILayout
 		if len(opts.headerFlags) != 0 {
@@ -105,20 +169,62 @@ func (opts *Target) Parse(cmd *cobra.Command) error {
 		return opts.parseOCILayoutReference()
 	default:
 		opts.Type = TargetTypeRemote
-		if ref, err := registry.ParseReference(opts.RawReference); err != nil {
-			return &oerrors.Error{
-				OperationType:  oerrors.OperationTypeParseArtifactReference,
-				Err:            fmt.Errorf("%q: %w", opts.RawReference, err),
-				Recommendation: "Please make sure the provided reference is in the form of <registry>/<repo>[:tag|@digest]",
-			}
-		} else {
-			opts.Reference = ref.Reference
+		reference := opts.normalizeReference(opts.RawReference)
+		ref, err := parseReference(opts.RawReference, reference)
+		if err != nil {
+			return err
+		}
+		opts.Reference = ref.Reference
+		_, path, _ := strings.Cut(reference, "/")
+		tag, err := parseAdvisoryTag(path)
+		if err != nil {
+			return newErrInvalidReference(opts.RawReference, err)
 		}
+		opts.Tag = tag
+		opts.SetRegistry(ref.Registry)
 		return opts.Remote.Parse(cmd)
 	}
 }
 
-// parseOCILayoutReference parses the raw in format of <path>[:<tag>|@<digest>]
+// registry returns the registry of the remote target, or an empty string if
+// the reference is invalid.
+func (opts *Target) registry() string {
+	ref, err := registry.ParseReference(opts.normalizeReference(opts.RawReference))
+	if err != nil {
+		return ""
+	}
+	return ref.Registry
+}
+
+// parseAdvisoryTag returns the tag of the repository path in the form of
+// <repository>:<tag>@<digest>, which is advisory since the digest is
+// authoritative. An empty tag is returned if there is no digest.
+func parseAdvisoryTag(path string) (string, error) {
+	repository, dgst, found := strings.Cut(path, "@")
+	if !found {
+		return "", nil
+	}
+	_, tag, found := strings.Cut(repository, ":")
+	if !found {
+		return "", nil
+	}
+	if err := validateAdvisoryTag(tag, dgst); err != nil {
+		return "", err
+	}
+	return tag, nil
+}
+
+// validateAdvisoryTag validates the tag and the digest of a reference in the
+// form of <name>:<tag>@<digest>.
+func validateAdvisoryTag(tag, dgst string) error {
+	if dgst == "" {
+		return fmt.Errorf("%w: missing digest after the tag %q", errdef.ErrInvalidReference, tag)
+	}
+	return (registry.Reference{Reference: tag}).ValidateReferenceAsTag()
+}
+
+// parseOCILayoutReference parses the raw in format of
+// <path>[:<tag>|@<digest>|:<tag>@<digest>]
 func (opts *Target) parseOCILayoutReference() error {
 	raw := opts.RawReference
 	var path string
@@ -127,6 +233,19 @@ func (opts *Target) parseOCILayoutReference() error {
 		// `digest` found
 		path = raw[:idx]
 		ref = raw[idx+1:]
+		if _, err := os.Stat(path); err != nil && strings.Contains(path, ":") {
+			// the advisory tag is cut unless it is part of an existing path
+			var tag string
+			if path, tag, err = fileref.Parse(path, ""); err != nil {
+				return errors.Join(err, errdef.ErrInvalidReference)
+			}
+			if tag != "" {
+				if err := validateAdvisoryTag(tag, ref); err != nil {
+					return err
+				}
+			}
+			opts.Tag = tag
+		}
 	} else {
 		// find `tag`
 		var err error
@@ -149,13 +268,22 @@ func (opts *Target) newRepository(common Common, logger logrus.FieldLogger) (*re
 	if err != nil {
 		return nil, err
 	}
-	tmp := repo.Reference
-	tmp.Reference = ""
-	opts.Path = tmp.String()
+	// the repository is shown as given by the user
+	opts.Path = rawRepository(opts.RawReference)
 	opts.Reference = repo.Reference.Reference
 	return repo, nil
 }
 
+// rawRepository returns the repository of the raw reference without the tag
+// or the digest.
+func rawRepository(raw string) string {
+	raw, _, _ = strings.Cut(raw, "@")
+	if i := strings.LastIndex(raw, ":"); i > strings.LastIndex(raw, "/") {
+		raw = raw[:i]
+	}
+	return raw
+}
+
 // NewTarget generates a new target based on opts.
 func (opts *Target) NewTarget(common Common, logger logrus.FieldLogger) (oras.GraphTarget, error) {
 	switch opts.Type {
@@ -221,7 +349,11 @@ func (opts *Target) NewReadonlyTarget(ctx context.Context, common Common, logger
 			return nil, err
 		}
 		if info.IsDir() {
-			return oci.NewFromFS(ctx, os.DirFS(opts.Path))
+			store, err := oci.NewFromFS(ctx, os.DirFS(opts.Path))
+			if err != nil {
+				return nil, err
+			}
+			return &layoutTarget{ReadOnlyGraphTagFinderTarget: store, path: opts.Path}, nil
 		}
 		store, err := oci.NewFromTar(ctx, opts.Path)
 		if err != nil {
@@ -230,6 +362,13 @@ func (opts *Target) NewReadonlyTarget(ctx context.Context, common Common, logger
 			}
 			return nil, err
 		}
+		return &layoutTarget{ReadOnlyGraphTagFinderTarget: store, path: opts.Path}, nil
+	case TargetTypeDockerArchive:
+		store, err := docker.NewArchiveStore(opts.Path, opts.DockerArchiveImage)
+		if err != nil {
+			return nil, opts.decorateDockerArchiveError(err)
+		}
+		opts.Reference = store.Reference()
 		return store, nil
 	case TargetTypeRemote:
 		repo, err := opts.NewRepository(opts.RawReference, common, logger)
@@ -245,6 +384,42 @@ func (opts *Target) NewReadonlyTarget(ctx context.Context, common Common, logger
 	return nil, fmt.Errorf("unknown target type: %q", opts.Type)
 }
 
+// decorateDockerArchiveError decorates the error of opening a docker archive
+// with recommendations.
+func (opts *Target) decorateDockerArchiveError(err error) error {
+	var imageErr *docker.ArchiveImageNotFoundError
+	if !errors.As(err, &imageErr) {
+		return fmt.Errorf("failed to open %q: %w", opts.Path, err)
+	}
+	recommendation := "Please select an image with --name"
+	if len(imageErr.Available) != 0 {
+		recommendation = fmt.Sprintf("Please select an image with --name, one of: %s", strings.Join(imageErr.Available, ", "))
+	}
+	return &oerrors.Error{
+		Err:            fmt.Errorf("failed to open %q: %w", opts.Path, err),
+		Recommendation: recommendation,
+	}
+}
+
+// VerifyTag verifies that the advisory tag of the target reference resolves to
+// its digest if --verify-tag is set.
+func (opts *Target) VerifyTag(ctx context.Context, resolver content.Resolver) error {
+	if !opts.verifyTag {
+		return nil
+	}
+	desc, err := resolver.Resolve(ctx, opts.Tag)
+	if err != nil {
+		return fmt.Errorf("failed to verify tag %q: %w", opts.Tag, err)
+	}
+	if desc.Digest.String() != opts.Reference {
+		return &oerrors.Error{
+			Err:            &oerrors.VerificationError{Err: fmt.Errorf("tag %q resolves to %s instead of %s", opts.Tag, desc.Digest, opts.Reference)},
+			Recommendation: "The tag has been moved since the digest was recorded. Please update the digest, or remove --verify-tag to use the recorded digest regardless",
+		}
+	}
+	return nil
+}
+
 // EnsureReferenceNotEmpty returns formalized error when the reference is empty.
 func (opts *Target) EnsureReferenceNotEmpty(cmd *cobra.Command, allowTag bool) error {
 	if opts.Reference == "" {
@@ -256,59 +431,116 @@ func (opts *Target) EnsureReferenceNotEmpty(cmd *cobra.Command, allowTag bool) e
 // Modify handles error during cmd execution.
 func (opts *Target) Modify(cmd *cobra.Command, err error) (error, bool) {
 	if opts.IsOCILayout {
+		var corruptedErr *LayoutCorruptedError
+		if errors.As(err, &corruptedErr) {
+			return &oerrors.Error{
+				Err:            err,
+				Recommendation: "Please restore the missing blob, or re-create the layout, e.g. via `oras cp --to-oci-layout`",
+			}, true
+		}
+	}
+	if opts.IsOCILayout || opts.IsDockerArchive {
 		return err, false
 	}
 
+	if sizeErr, ok := opts.decorateSizeError(err); ok {
+		return sizeErr, true
+	}
+
 	if errors.Is(err, auth.ErrBasicCredentialNotFound) {
 		return opts.DecorateCredentialError(err), true
 	}
 
 	if errors.Is(err, errdef.ErrNotFound) {
 		cmd.SetErrPrefix(oerrors.RegistryErrorPrefix)
-		return err, true
+		regErr := &oerrors.RegistryError{
+			Kind: oerrors.RegistryErrorNotFound,
+			Err:  err,
+		}
+		return &oerrors.Error{
+			Err:            regErr,
+			Recommendation: regErr.Recommendation(),
+		}, true
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && opts.isTargetURL(urlErr.URL) {
+		if connErr, ok := opts.DecorateConnectionError(err); ok {
+			return connErr, true
+		}
 	}
 
 	var errResp *errcode.ErrorResponse
 	if errors.As(err, &errResp) {
-		ref := registry.Reference{Registry: opts.RawReference}
-		if errResp.URL.Host != ref.Host() {
-			// raw reference is not registry host
-			var parseErr error
-			ref, parseErr = registry.ParseReference(opts.RawReference)
-			if parseErr != nil {
-				// this should not happen
-				return err, false
-			}
-			if errResp.URL.Host != ref.Host() {
-				// not handle if the error is not from the target
-				return err, false
-			}
+		ref, ok := opts.errorReference(err, errResp)
+		if !ok {
+			// not handle if the error is not from the target
+			return err, false
 		}
 
 		cmd.SetErrPrefix(oerrors.RegistryErrorPrefix)
-		ret := &oerrors.Error{
-			Err: oerrors.TrimErrResp(err, errResp),
-		}
+		return opts.decorateErrorResponse(err, errResp, ref.Registry), true
+	}
+	return err, false
+}
 
-		if ref.Registry == "docker.io" && errResp.StatusCode == http.StatusUnauthorized {
-			if ref.Repository != "" && !strings.Contains(ref.Repository, "/") {
-				// docker.io/xxx -> docker.io/library/xxx
-				ref.Repository = "library/" + ref.Repository
-				ret.Recommendation = fmt.Sprintf("Namespace seems missing. Do you mean `%s %s`?", cmd.CommandPath(), ref)
-			}
+// errorReference returns the reference of the target if errResp is returned
+// by the registry of the target, or by its token service when authenticating
+// a request to the registry.
+func (opts *Target) errorReference(err error, errResp *errcode.ErrorResponse) (registry.Reference, bool) {
+	fromTarget := func(ref registry.Reference) bool {
+		host := ref.Host()
+		if errResp.URL.Host == host {
+			return true
+		}
+		switch errResp.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			// the token service wraps its error with the registry request URL
+			return strings.Contains(err.Error(), "://"+host+"/")
 		}
-		return ret, true
+		return false
 	}
-	return err, false
+	ref := registry.Reference{Registry: registryutil.NormalizeDockerHubRegistry(opts.RawReference)}
+	if fromTarget(ref) {
+		return ref, true
+	}
+	// raw reference is not registry host
+	ref, parseErr := registry.ParseReference(opts.normalizeReference(opts.RawReference))
+	if parseErr != nil || !fromTarget(ref) {
+		return registry.Reference{}, false
+	}
+	return ref, true
+}
+
+// isTargetURL returns true if rawURL points to the registry of the target.
+func (opts *Target) isTargetURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	ref := registry.Reference{Registry: registryutil.NormalizeDockerHubRegistry(opts.RawReference)}
+	if u.Host == ref.Host() {
+		return true
+	}
+	ref, err = registry.ParseReference(opts.normalizeReference(opts.RawReference))
+	return err == nil && u.Host == ref.Host()
 }
 
 // BinaryTarget struct contains flags and arguments specifying two registries or
 // image layouts.
 // BinaryTarget implements errors.Handler interface.
 type BinaryTarget struct {
-	From        Target
-	To          Target
-	resolveFlag []string
+	From            Target
+	To              Target
+	resolveFlag     []string
+	userAgentSuffix string
+	limitRate       string
+	noWarnings      bool
+	dockerCompat    bool
+	requestIDHeader string
+	redactHeaders   []string
+	// manifestHeadCheck applies to both the source and the destination.
+	manifestHeadCheck *bool
 }
 
 // EnsureSourceTargetReferenceNotEmpty ensures that the from target reference is not empty.
@@ -330,6 +562,13 @@ func (opts *BinaryTarget) ApplyFlags(fs *pflag.FlagSet) {
 	opts.From.ApplyFlagsWithPrefix(fs, "from", "source")
 	opts.To.ApplyFlagsWithPrefix(fs, "to", "destination")
 	fs.StringArrayVarP(&opts.resolveFlag, "resolve", "", nil, "base DNS rules formatted in `host:port:address[:address_port]` for --from-resolve and --to-resolve")
+	fs.StringVar(&opts.userAgentSuffix, userAgentSuffixFlag, "", "suffix appended to the User-Agent header of requests, e.g. a job identifier")
+	fs.BoolVar(&opts.noWarnings, noWarningsFlag, false, "do not print warnings returned by registries")
+	fs.BoolVar(&opts.dockerCompat, dockerCompatFlag, false, "resolve references without a registry against Docker Hub like docker, e.g. alpine:3.19 as docker.io/library/alpine:3.19")
+	fs.StringVar(&opts.limitRate, limitRateFlag, "", "maximum total transfer `rate` in bytes per second shared by the source and the destination, with an optional K, M or G suffix, e.g. 10M")
+	fs.StringVar(&opts.requestIDHeader, requestIDHeaderFlag, trace.DefaultRequestIDHeader, "`name` of the header carrying the request ID generated for each request in debug logs, empty to not send the header")
+	fs.StringSliceVar(&opts.redactHeaders, redactHeaderFlag, nil, "`names` of the headers, e.g. set via --from-header or --to-header, whose values are redacted in debug logs in addition to the authorization and cookie headers, can be specified multiple times")
+	opts.manifestHeadCheck = fs.Bool(manifestHeadCheckFlag, true, manifestHeadCheckUsage)
 }
 
 // Parse parses user-provided flags and arguments into option struct.
@@ -339,7 +578,107 @@ func (opts *BinaryTarget) Parse(cmd *cobra.Command) error {
 	// resolve are parsed in array order, latter will overwrite former
 	opts.From.resolveFlag = append(opts.resolveFlag, opts.From.resolveFlag...)
 	opts.To.resolveFlag = append(opts.resolveFlag, opts.To.resolveFlag...)
-	return Parse(cmd, opts)
+	opts.From.userAgentSuffix = opts.userAgentSuffix
+	opts.To.userAgentSuffix = opts.userAgentSuffix
+	opts.From.limitRate = opts.limitRate
+	opts.To.limitRate = opts.limitRate
+	opts.From.noWarnings = opts.noWarnings
+	opts.To.noWarnings = opts.noWarnings
+	opts.From.dockerCompat = opts.dockerCompat
+	opts.To.dockerCompat = opts.dockerCompat
+	opts.From.requestIDHeader = opts.requestIDHeader
+	opts.To.requestIDHeader = opts.requestIDHeader
+	opts.From.redactHeaders = opts.redactHeaders
+	opts.To.redactHeaders = opts.redactHeaders
+	opts.From.manifestHeadCheck = opts.manifestHeadCheck
+	opts.To.manifestHeadCheck = opts.manifestHeadCheck
+	if err := Parse(cmd, opts); err != nil {
+		return err
+	}
+	if opts.From.rateLimiter != nil && opts.To.rateLimiter != nil {
+		// the source and the destination share one transfer rate limit
+		opts.To.rateLimiter = opts.From.rateLimiter
+	}
+	return opts.shareClient()
+}
+
+// SameRegistry returns true if both the source and the destination are
+// repositories on the same registry.
+func (opts *BinaryTarget) SameRegistry() bool {
+	if opts.From.Type != TargetTypeRemote || opts.To.Type != TargetTypeRemote {
+		return false
+	}
+	from, to := opts.From.registry(), opts.To.registry()
+	return from != "" && from == to
+}
+
+// SharesClient returns true if the source and the destination share one
+// authenticated client.
+func (opts *BinaryTarget) SharesClient() bool {
+	return opts.From.shared != nil && opts.From.shared == opts.To.shared
+}
+
+// WithSharedScopeHint returns a context hinting the combined scope of pulling
+// from the source and pushing to the destination if they share one client, so
+// that a single token authorizes both.
+func (opts *BinaryTarget) WithSharedScopeHint(ctx context.Context) context.Context {
+	if !opts.SharesClient() {
+		return ctx
+	}
+	if ref, err := registry.ParseReference(opts.From.normalizeReference(opts.From.RawReference)); err == nil {
+		ctx = auth.AppendRepositoryScope(ctx, ref, auth.ActionPull)
+	}
+	if ref, err := registry.ParseReference(opts.To.normalizeReference(opts.To.RawReference)); err == nil {
+		ctx = auth.AppendRepositoryScope(ctx, ref, auth.ActionPull, auth.ActionPush)
+	}
+	return ctx
+}
+
+// shareClient makes the source and the destination on the same registry
+// share one authenticated client, so that tokens and connections are reused.
+// Contradictory connection flags for the same registry are rejected. The
+// client is not shared if the credentials, the headers or the request
+// policies differ.
+func (opts *BinaryTarget) shareClient() error {
+	if !opts.SameRegistry() {
+		return nil
+	}
+	registry := opts.From.registry()
+	from, to := &opts.From.Remote, &opts.To.Remote
+	for _, f := range []struct {
+		name     string
+		from, to any
+	}{
+		{plainHTTPFlag, from.isPlainHttp(registry), to.isPlainHttp(registry)},
+		{insecureFlag, from.isInsecure(registry), to.isInsecure(registry)},
+		{caFileFlag, from.CACertFilePath, to.CACertFilePath},
+		{certFileFlag, from.CertFilePath, to.CertFilePath},
+		{keyFileFlag, from.KeyFilePath, to.KeyFilePath},
+		{unixSocketFlag, from.unixSocket, to.unixSocket},
+		{proxyFlag, from.proxyFlag, to.proxyFlag},
+		{tlsMinVersionFlag, from.tlsMinVersion, to.tlsMinVersion},
+		{disableHTTP2Flag, from.disableHTTP2, to.disableHTTP2},
+	} {
+		if f.from != f.to {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("--%s%s and --%s%s conflict for the same registry %s: %v and %v", from.flagPrefix, f.name, to.flagPrefix, f.name, registry, f.from, f.to),
+				Recommendation: fmt.Sprintf("The source and the destination are on the same registry. Please specify the same value for --%s%s and --%s%s", from.flagPrefix, f.name, to.flagPrefix, f.name),
+			}
+		}
+	}
+	if from.Credential() != to.Credential() ||
+		from.NoDockerConfig != to.NoDockerConfig ||
+		!slices.Equal(from.Configs, to.Configs) ||
+		!slices.Equal(from.headerFlags, to.headerFlags) ||
+		!slices.Equal(from.resolveFlag, to.resolveFlag) ||
+		from.retry != to.retry || from.retryDelay != to.retryDelay || from.retryMaxDelay != to.retryMaxDelay ||
+		from.maxIdleConnsPerHost != to.maxIdleConnsPerHost ||
+		from.MaxMetadataBytes != to.MaxMetadataBytes {
+		return nil
+	}
+	from.shared = &sharedAuthClient{}
+	to.shared = from.shared
+	return nil
 }
 
 // Modify handles error during cmd execution.
@@ -16,16 +16,27 @@ limitations under the License.
 package option
 
 import (
+	"context"
+	"crypto/x509"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/errcode"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/registryutil"
 )
 
 func TestTarget_Parse_oci(t *testing.T) {
@@ -54,6 +65,122 @@ func TestTarget_Parse_remote(t *testing.T) {
 	}
 }
 
+func TestTarget_NewTarget_dockerHub(t *testing.T) {
+	tests := []struct {
+		name          string
+		raw           string
+		dockerCompat  bool
+		wantReference string
+		wantPath      string
+	}{
+		{"docker.io", "docker.io/alpine:3.19", false, "docker.io/library/alpine:3.19", "docker.io/alpine"},
+		{"alias", "index.docker.io/user/app:v1", false, "docker.io/user/app:v1", "index.docker.io/user/app"},
+		{"bare name", "alpine:3.19", true, "docker.io/library/alpine:3.19", "alpine"},
+		{"bare namespace", "user/app:v1", true, "docker.io/user/app:v1", "user/app"},
+		{"other registry", "localhost:5000/app:v1", true, "localhost:5000/app:v1", "localhost:5000/app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Target{RawReference: tt.raw}
+			opts.NoDockerConfig = true
+			cmd := &cobra.Command{}
+			opts.ApplyFlags(cmd.Flags())
+			if tt.dockerCompat {
+				if err := cmd.Flags().Set(dockerCompatFlag, "true"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := opts.Parse(cmd); err != nil {
+				t.Fatalf("Target.Parse() error = %v", err)
+			}
+			target, err := opts.NewTarget(Common{}, logrus.New())
+			if err != nil {
+				t.Fatalf("Target.NewTarget() error = %v", err)
+			}
+			if got := target.(*remote.Repository).Reference.String(); got != tt.wantReference {
+				t.Errorf("Target.NewTarget() reference = %q, want %q", got, tt.wantReference)
+			}
+			if opts.Path != tt.wantPath {
+				t.Errorf("Target.Path = %q, want %q", opts.Path, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestBinaryTarget_Parse_sameRegistry(t *testing.T) {
+	tests := []struct {
+		name        string
+		to          string
+		flags       map[string]string
+		wantShared  bool
+		wantErrFlag string
+	}{
+		{"same registry", "localhost:5000/dst:v1", nil, true, ""},
+		{"different registries", "example.com/dst:v1", nil, false, ""},
+		{"different credentials", "localhost:5000/dst:v1", map[string]string{"to-username": "user", "to-password": "pass"}, false, ""},
+		{"contradictory plain http", "localhost:5000/dst:v1", map[string]string{"from-plain-http": "true", "to-plain-http": "false"}, false, "plain-http"},
+		{"contradictory ca file", "localhost:5000/dst:v1", map[string]string{"from-ca-file": "testdata/localhostServer.crt"}, false, "ca-file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts BinaryTarget
+			opts.From.RawReference = "localhost:5000/src:v1"
+			opts.To.RawReference = tt.to
+			cmd := &cobra.Command{}
+			opts.ApplyFlags(cmd.Flags())
+			for name, value := range tt.flags {
+				if err := cmd.Flags().Set(name, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := opts.Parse(cmd)
+			if tt.wantErrFlag != "" {
+				if err == nil || !strings.Contains(err.Error(), "--from-"+tt.wantErrFlag) || !strings.Contains(err.Error(), "--to-"+tt.wantErrFlag) {
+					t.Fatalf("BinaryTarget.Parse() error = %v, want conflict of %s", err, tt.wantErrFlag)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BinaryTarget.Parse() error = %v", err)
+			}
+			if got := opts.SharesClient(); got != tt.wantShared {
+				t.Fatalf("BinaryTarget.SharesClient() = %v, want %v", got, tt.wantShared)
+			}
+			from, err := opts.From.authClient("localhost:5000", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			to, err := opts.To.authClient("localhost:5000", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := from == to; got != tt.wantShared {
+				t.Errorf("auth client shared = %v, want %v", got, tt.wantShared)
+			}
+		})
+	}
+}
+
+func TestBinaryTarget_Parse_limitRate(t *testing.T) {
+	var opts BinaryTarget
+	opts.From.RawReference = "localhost:5000/src:v1"
+	opts.To.RawReference = "example.com/dst:v1"
+	cmd := &cobra.Command{}
+	opts.ApplyFlags(cmd.Flags())
+	if err := cmd.Flags().Set("limit-rate", "10M"); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.Parse(cmd); err != nil {
+		t.Fatalf("BinaryTarget.Parse() error = %v", err)
+	}
+	if opts.From.rateLimiter == nil {
+		t.Fatal("rate limiter of the source is not set")
+	}
+	if opts.From.rateLimiter != opts.To.rateLimiter {
+		t.Error("the source and the destination do not share the rate limiter")
+	}
+}
+
 func TestTarget_Parse_remote_err(t *testing.T) {
 	opts := Target{
 		RawReference: "/test",
@@ -64,6 +191,105 @@ func TestTarget_Parse_remote_err(t *testing.T) {
 	}
 }
 
+func TestTarget_Parse_tagAndDigest(t *testing.T) {
+	dgst := "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2"
+	tests := []struct {
+		name          string
+		raw           string
+		isOCILayout   bool
+		wantPath      string
+		wantReference string
+		wantTag       string
+		wantErr       bool
+	}{
+		{name: "tag and digest", raw: "localhost:5000/test:v1@" + dgst, wantReference: dgst, wantTag: "v1"},
+		{name: "digest only", raw: "localhost:5000/test@" + dgst, wantReference: dgst},
+		{name: "tag only", raw: "localhost:5000/test:v1", wantReference: "v1"},
+		{name: "tag and empty digest", raw: "localhost:5000/test:v1@", wantErr: true},
+		{name: "empty tag and digest", raw: "localhost:5000/test:@" + dgst, wantErr: true},
+		{name: "invalid tag and digest", raw: "localhost:5000/test:v1:v2@" + dgst, wantErr: true},
+		{name: "tag and invalid digest", raw: "localhost:5000/test:v1@sha256:abc", wantErr: true},
+		{name: "digest and tag", raw: "localhost:5000/test@" + dgst + ":v1", wantErr: true},
+		{name: "layout with tag and digest", raw: "layout:v1@" + dgst, isOCILayout: true, wantPath: "layout", wantReference: dgst, wantTag: "v1"},
+		{name: "layout with tag and empty digest", raw: "layout:v1@", isOCILayout: true, wantErr: true},
+		{name: "layout with invalid tag and digest", raw: "layout:v/1@" + dgst, isOCILayout: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Target{RawReference: tt.raw}
+			cmd := &cobra.Command{}
+			opts.ApplyFlags(cmd.Flags())
+			opts.IsOCILayout = tt.isOCILayout
+			err := opts.Parse(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Target.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.isOCILayout && opts.Path != tt.wantPath {
+				t.Errorf("Target.Parse() path = %q, want %q", opts.Path, tt.wantPath)
+			}
+			if opts.Reference != tt.wantReference {
+				t.Errorf("Target.Parse() reference = %q, want %q", opts.Reference, tt.wantReference)
+			}
+			if opts.Tag != tt.wantTag {
+				t.Errorf("Target.Parse() tag = %q, want %q", opts.Tag, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestTarget_VerifyTag(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	desc, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, []byte(`{"layers":[]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Tag(ctx, desc, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	other := digest.FromString("other")
+
+	for _, tt := range []struct {
+		raw     string
+		wantErr bool
+	}{
+		{raw: "localhost:5000/test:v1@" + desc.Digest.String()},
+		{raw: "localhost:5000/test:v1@" + other.String(), wantErr: true},
+		{raw: "localhost:5000/test:v2@" + desc.Digest.String(), wantErr: true},
+	} {
+		t.Run(tt.raw, func(t *testing.T) {
+			opts := Target{RawReference: tt.raw}
+			opts.EnableVerifyTagFlag()
+			cmd := &cobra.Command{}
+			opts.ApplyFlags(cmd.Flags())
+			if err := cmd.Flags().Set("verify-tag", "true"); err != nil {
+				t.Fatal(err)
+			}
+			if err := opts.Parse(cmd); err != nil {
+				t.Fatalf("Target.Parse() error = %v", err)
+			}
+			if err := opts.VerifyTag(ctx, store); (err != nil) != tt.wantErr {
+				t.Errorf("Target.VerifyTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// --verify-tag requires a tag
+	opts := Target{RawReference: "localhost:5000/test@" + desc.Digest.String()}
+	opts.EnableVerifyTagFlag()
+	cmd := &cobra.Command{}
+	opts.ApplyFlags(cmd.Flags())
+	if err := cmd.Flags().Set("verify-tag", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.Parse(cmd); err == nil {
+		t.Error("Target.Parse() error = nil, want error")
+	}
+}
+
 func Test_parseOCILayoutReference(t *testing.T) {
 	opts := Target{
 		RawReference: "/test",
@@ -162,6 +388,69 @@ func TestTarget_Modify_errHostNotMatching(t *testing.T) {
 	}
 }
 
+func TestTarget_Modify_tokenServiceError(t *testing.T) {
+	errResp := &errcode.ErrorResponse{
+		Method:     http.MethodGet,
+		URL:        &url.URL{Scheme: "https", Host: "auth.example.com", Path: "/token"},
+		StatusCode: http.StatusUnauthorized,
+		Errors: errcode.Errors{
+			errcode.Error{Code: errcode.ErrorCodeUnauthorized, Message: "authentication required"},
+		},
+	}
+	// the auth client wraps the error of the token service with the request
+	err := fmt.Errorf("%s %q: %w", http.MethodGet, "https://registry.example.com/v2/test/manifests/v1", errResp)
+
+	to := &Target{RawReference: "registry.example.com/test:v1"}
+	got, modified := to.Modify(&cobra.Command{}, err)
+	if !modified {
+		t.Fatal("expect error to be modified but received false")
+	}
+	if code := oerrors.ExitCode(got); code != oerrors.ExitCodeUnauthorized {
+		t.Errorf("ExitCode() = %d, want %d", code, oerrors.ExitCodeUnauthorized)
+	}
+	if !strings.Contains(got.Error(), "oras login registry.example.com") {
+		t.Errorf("unexpected error message: %v", got)
+	}
+
+	from := &Target{RawReference: "other.example.com/test:v1"}
+	if _, modified := from.Modify(&cobra.Command{}, err); modified {
+		t.Error("expect error of another registry not to be modified")
+	}
+}
+
+func TestTarget_Modify_notFound(t *testing.T) {
+	opts := &Target{RawReference: "localhost:5000/test:v1"}
+	got, modified := opts.Modify(&cobra.Command{}, fmt.Errorf("v1: %w", errdef.ErrNotFound))
+	if !modified {
+		t.Fatal("expect error to be modified but received false")
+	}
+	if !errors.Is(got, errdef.ErrNotFound) {
+		t.Errorf("expect %v to be errdef.ErrNotFound", got)
+	}
+	if code := oerrors.ExitCode(got); code != oerrors.ExitCodeNotFound {
+		t.Errorf("ExitCode() = %d, want %d", code, oerrors.ExitCodeNotFound)
+	}
+}
+
+func TestTarget_Modify_configSizeError(t *testing.T) {
+	opts := &Target{RawReference: "localhost:5000/test:v1"}
+	sizeErr := &registryutil.ConfigSizeError{
+		Desc:  ocispec.Descriptor{Digest: digest.FromString("config"), Size: 2048},
+		Limit: 1024,
+	}
+	got, modified := opts.Modify(&cobra.Command{}, fmt.Errorf("failed to fetch: %w", sizeErr))
+	if !modified {
+		t.Fatal("expect error to be modified but received false")
+	}
+	var oErr *oerrors.Error
+	if !errors.As(got, &oErr) {
+		t.Fatalf("expect %v to be an oerrors.Error", got)
+	}
+	if !strings.Contains(oErr.Recommendation, "--max-metadata-size") {
+		t.Errorf("recommendation = %q, want --max-metadata-size noted", oErr.Recommendation)
+	}
+}
+
 func TestTarget_Modify_dockerHint(t *testing.T) {
 	type fields struct {
 		Remote       Remote
@@ -178,6 +467,11 @@ func TestTarget_Modify_dockerHint(t *testing.T) {
 			Detail:  map[string]string{"mocked key": "mocked value"},
 		},
 	}
+	unauthorizedErr := &oerrors.RegistryError{
+		Kind: oerrors.RegistryErrorUnauthorized,
+		Host: "docker.io",
+		Err:  errs,
+	}
 	tests := []struct {
 		name        string
 		fields      fields
@@ -192,7 +486,10 @@ func TestTarget_Modify_dockerHint(t *testing.T) {
 				StatusCode: http.StatusUnauthorized,
 				Errors:     errs,
 			},
-			&oerrors.Error{Err: errs},
+			&oerrors.Error{
+				Err:            unauthorizedErr,
+				Recommendation: "Run `oras login docker.io` to log in to the registry, or check whether the provided credential is correct",
+			},
 		},
 		{
 			"no namespace",
@@ -202,7 +499,10 @@ func TestTarget_Modify_dockerHint(t *testing.T) {
 				StatusCode: http.StatusUnauthorized,
 				Errors:     errs,
 			},
-			&oerrors.Error{Err: errs},
+			&oerrors.Error{
+				Err:            unauthorizedErr,
+				Recommendation: "Run `oras login docker.io` to log in to the registry, or check whether the provided credential is correct",
+			},
 		},
 		{
 			"not 401",
@@ -215,7 +515,7 @@ func TestTarget_Modify_dockerHint(t *testing.T) {
 			&oerrors.Error{Err: errs},
 		},
 		{
-			"should hint",
+			"namespace normalized",
 			fields{
 				RawReference: "docker.io/alpine",
 				Path:         "oras test",
@@ -225,9 +525,25 @@ func TestTarget_Modify_dockerHint(t *testing.T) {
 				StatusCode: http.StatusUnauthorized,
 				Errors:     errs,
 			},
+			&oerrors.Error{
+				Err:            unauthorizedErr,
+				Recommendation: "Run `oras login docker.io` to log in to the registry, or check whether the provided credential is correct",
+			},
+		},
+		{
+			"keep recommendation",
+			fields{RawReference: "docker.io/library/alpine:latest"},
+			&oerrors.Error{
+				Err: &errcode.ErrorResponse{
+					URL:        &url.URL{Host: "registry-1.docker.io"},
+					StatusCode: http.StatusMethodNotAllowed,
+					Errors:     errs,
+				},
+				Recommendation: "mocked recommendation",
+			},
 			&oerrors.Error{
 				Err:            errs,
-				Recommendation: "Namespace seems missing. Do you mean ` docker.io/library/alpine`?",
+				Recommendation: "mocked recommendation",
 			},
 		},
 	}
@@ -257,3 +573,27 @@ func TestTarget_Modify_dockerHint(t *testing.T) {
 		})
 	}
 }
+
+func TestTarget_Modify_tlsError(t *testing.T) {
+	tlsErr := &url.Error{
+		Op:  "Get",
+		URL: "https://localhost:5000/v2/",
+		Err: x509.UnknownAuthorityError{},
+	}
+	opts := &Target{
+		RawReference: "localhost:5000/test:v1",
+	}
+	got, modified := opts.Modify(&cobra.Command{}, tlsErr)
+	if !modified {
+		t.Fatal("expect error to be modified")
+	}
+	var oErr *oerrors.Error
+	if !errors.As(got, &oErr) || !strings.Contains(oErr.Recommendation, "--insecure") {
+		t.Fatalf("expect recommendation of --insecure, got %v", got)
+	}
+
+	opts.RawReference = "registry.example.com/test:v1"
+	if _, modified := opts.Modify(&cobra.Command{}, tlsErr); modified {
+		t.Fatal("expect error from other registries not to be modified")
+	}
+}
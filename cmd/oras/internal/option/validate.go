@@ -0,0 +1,89 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"fmt"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/manifest"
+)
+
+// validation modes
+const (
+	ValidateModeWarn  = "warn"
+	ValidateModeError = "error"
+	ValidateModeSkip  = "skip"
+)
+
+// Validate option struct.
+type Validate struct {
+	ValidateMode string
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Validate) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&opts.ValidateMode, "validate", ValidateModeWarn, "`mode` of validating manifests against the OCI specification constraints, one of warn, error and skip")
+}
+
+// Parse parses the validation mode.
+func (opts *Validate) Parse(*cobra.Command) error {
+	switch opts.ValidateMode {
+	case ValidateModeWarn, ValidateModeError, ValidateModeSkip:
+		return nil
+	}
+	return &oerrors.Error{
+		Err:            fmt.Errorf("invalid validation mode %q", opts.ValidateMode),
+		Recommendation: "Please specify one of warn, error and skip for --validate",
+	}
+}
+
+// Enabled returns true if manifests are validated.
+func (opts *Validate) Enabled() bool {
+	return opts.ValidateMode != ValidateModeSkip
+}
+
+// ValidateManifest validates the manifest content described by desc. The
+// violations are logged as warnings, or returned as an error if the
+// validation mode is error.
+func (opts *Validate) ValidateManifest(logger logrus.FieldLogger, desc ocispec.Descriptor, content []byte) error {
+	if !opts.Enabled() {
+		return nil
+	}
+	violations := manifest.Validate(desc, content)
+	if len(violations) == 0 {
+		return nil
+	}
+	if opts.ValidateMode == ValidateModeError {
+		lines := make([]string, len(violations))
+		for i, v := range violations {
+			lines[i] = v.String()
+		}
+		return &oerrors.Error{
+			Err:            fmt.Errorf("manifest %s violates the OCI specification:\n  %s", desc.Digest, strings.Join(lines, "\n  ")),
+			Recommendation: "Use `--validate warn` to proceed with the violations reported as warnings",
+		}
+	}
+	for _, v := range violations {
+		logger.Warnf("manifest %s violates the OCI specification: %s", desc.Digest, v)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestValidate_Parse(t *testing.T) {
+	for _, mode := range []string{ValidateModeWarn, ValidateModeError, ValidateModeSkip} {
+		opts := Validate{ValidateMode: mode}
+		if err := opts.Parse(nil); err != nil {
+			t.Errorf("Validate.Parse() error = %v for mode %q", err, mode)
+		}
+	}
+	opts := Validate{ValidateMode: "strict"}
+	if err := opts.Parse(nil); err == nil {
+		t.Error("Validate.Parse() error = nil for an invalid mode")
+	}
+}
+
+func TestValidate_ValidateManifest(t *testing.T) {
+	content := []byte(`{"schemaVersion":2,"annotations":{"bad key":"value"}}`)
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	tests := []struct {
+		mode         string
+		wantErr      bool
+		wantWarnings int
+	}{
+		{ValidateModeWarn, false, 1},
+		{ValidateModeError, true, 0},
+		{ValidateModeSkip, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			logger, hook := test.NewNullLogger()
+			opts := Validate{ValidateMode: tt.mode}
+			if err := opts.ValidateManifest(logger, desc, content); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate.ValidateManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			warnings := 0
+			for _, entry := range hook.AllEntries() {
+				if entry.Level == logrus.WarnLevel {
+					warnings++
+				}
+			}
+			if warnings != tt.wantWarnings {
+				t.Errorf("Validate.ValidateManifest() logged %d warnings, want %d", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// CommittedSet records the titles of committed content, so that the status
+// of deduplicated content can be reprinted under another title.
+type CommittedSet interface {
+	// Store records the content of desc as committed.
+	Store(desc ocispec.Descriptor) error
+	// Load returns the title the content of dgst is committed with.
+	Load(dgst digest.Digest) (title string, ok bool, err error)
+}
+
+// committedSet is an in-memory CommittedSet storing SHA-256 digests in their
+// binary form and titles only for titled content, which keeps the memory
+// footprint small for graphs with a large number of nodes.
+type committedSet struct {
+	mu     sync.RWMutex
+	sha256 map[[sha256.Size]byte]struct{}
+	others map[digest.Digest]struct{}
+	titles map[digest.Digest]string
+}
+
+// NewCommittedSet returns an in-memory CommittedSet.
+func NewCommittedSet() CommittedSet {
+	return &committedSet{
+		sha256: make(map[[sha256.Size]byte]struct{}),
+		others: make(map[digest.Digest]struct{}),
+		titles: make(map[digest.Digest]string),
+	}
+}
+
+// Store implements CommittedSet.
+func (s *committedSet) Store(desc ocispec.Descriptor) error {
+	title := desc.Annotations[ocispec.AnnotationTitle]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key, ok := sha256Key(desc.Digest); ok {
+		s.sha256[key] = struct{}{}
+	} else {
+		s.others[desc.Digest] = struct{}{}
+	}
+	if title != "" {
+		s.titles[desc.Digest] = title
+	} else {
+		delete(s.titles, desc.Digest)
+	}
+	return nil
+}
+
+// Load implements CommittedSet.
+func (s *committedSet) Load(dgst digest.Digest) (string, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var ok bool
+	if key, isSHA256 := sha256Key(dgst); isSHA256 {
+		_, ok = s.sha256[key]
+	} else {
+		_, ok = s.others[dgst]
+	}
+	return s.titles[dgst], ok, nil
+}
+
+// sha256Key returns the binary form of a SHA-256 digest.
+func sha256Key(dgst digest.Digest) (key [sha256.Size]byte, ok bool) {
+	if dgst.Algorithm() != digest.SHA256 {
+		return key, false
+	}
+	if n, err := hex.Decode(key[:], []byte(dgst.Encoded())); err != nil || n != sha256.Size {
+		return key, false
+	}
+	return key, true
+}
+
+// diskBuckets is the number of bucket files of a DiskCommittedSet.
+const diskBuckets = 4096
+
+// DiskCommittedSet is a CommittedSet spilled to bucket files in a temporary
+// directory, trading lookup speed for a constant memory footprint.
+type DiskCommittedSet struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewDiskCommittedSet returns a CommittedSet stored in a new temporary
+// directory under dir, or under the default directory for temporary files if
+// dir is empty. The directory is removed on Close.
+func NewDiskCommittedSet(dir string) (*DiskCommittedSet, error) {
+	dir, err := os.MkdirTemp(dir, "oras-committed-")
+	if err != nil {
+		return nil, err
+	}
+	return &DiskCommittedSet{dir: dir}, nil
+}
+
+// Store implements CommittedSet.
+func (s *DiskCommittedSet) Store(desc ocispec.Descriptor) error {
+	line := fmt.Sprintf("%s %s\n", desc.Digest, strconv.Quote(desc.Annotations[ocispec.AnnotationTitle]))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.bucket(desc.Digest), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(line); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load implements CommittedSet. The last stored title wins.
+func (s *DiskCommittedSet) Load(dgst digest.Digest) (title string, ok bool, err error) {
+	s.mu.Lock()
+	content, err := os.ReadFile(s.bucket(dgst))
+	s.mu.Unlock()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	prefix := []byte(dgst.String() + " ")
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if quoted, found := bytes.CutPrefix(scanner.Bytes(), prefix); found {
+			if title, err = strconv.Unquote(string(quoted)); err != nil {
+				return "", false, err
+			}
+			ok = true
+		}
+	}
+	return title, ok, scanner.Err()
+}
+
+// Close removes the directory of the set.
+func (s *DiskCommittedSet) Close() error {
+	return os.RemoveAll(s.dir)
+}
+
+// bucket returns the path of the bucket file of dgst.
+func (s *DiskCommittedSet) bucket(dgst digest.Digest) string {
+	sum := sha256.Sum256([]byte(dgst))
+	index := (int(sum[0])<<8 | int(sum[1])) % diskBuckets
+	return filepath.Join(s.dir, strconv.Itoa(index))
+}
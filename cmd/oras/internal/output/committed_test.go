@@ -0,0 +1,163 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// syntheticNodes is the number of nodes of the synthetic graph in benchmarks.
+const syntheticNodes = 200000
+
+// syntheticNode returns the i-th node of a synthetic graph, every tenth of
+// which is titled.
+func syntheticNode(i int) ocispec.Descriptor {
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    digest.FromString(strconv.Itoa(i)),
+		Size:      int64(i),
+	}
+	if i%10 == 0 {
+		desc.Annotations = map[string]string{
+			ocispec.AnnotationTitle: "file-" + strconv.Itoa(i),
+		}
+	}
+	return desc
+}
+
+func testCommittedSet(t *testing.T, s CommittedSet) {
+	titled := syntheticNode(0)
+	untitled := syntheticNode(1)
+	other := ocispec.Descriptor{Digest: digest.NewDigestFromEncoded(digest.SHA512, "abc")}
+	for _, desc := range []ocispec.Descriptor{titled, untitled, other} {
+		if err := s.Store(desc); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+	}
+	tests := []struct {
+		name      string
+		dgst      digest.Digest
+		wantTitle string
+		wantOK    bool
+	}{
+		{"titled", titled.Digest, "file-0", true},
+		{"untitled", untitled.Digest, "", true},
+		{"non-sha256", other.Digest, "", true},
+		{"not committed", syntheticNode(2).Digest, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, ok, err := s.Load(tt.dgst)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if title != tt.wantTitle || ok != tt.wantOK {
+				t.Errorf("Load() = %q, %v, want %q, %v", title, ok, tt.wantTitle, tt.wantOK)
+			}
+		})
+	}
+
+	// the last stored title wins
+	if err := s.Store(ocispec.Descriptor{Digest: titled.Digest}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if title, ok, err := s.Load(titled.Digest); err != nil || !ok || title != "" {
+		t.Errorf("Load() = %q, %v, %v, want the title overwritten", title, ok, err)
+	}
+}
+
+func TestCommittedSet(t *testing.T) {
+	testCommittedSet(t, NewCommittedSet())
+}
+
+func TestDiskCommittedSet(t *testing.T) {
+	s, err := NewDiskCommittedSet(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	testCommittedSet(t, s)
+}
+
+// syncMapCommittedSet is the sync.Map based tracking used before
+// CommittedSet, kept as the baseline of the benchmarks.
+type syncMapCommittedSet struct {
+	m sync.Map
+}
+
+func (s *syncMapCommittedSet) Store(desc ocispec.Descriptor) error {
+	s.m.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
+	return nil
+}
+
+func (s *syncMapCommittedSet) Load(dgst digest.Digest) (string, bool, error) {
+	v, ok := s.m.Load(dgst.String())
+	if !ok {
+		return "", false, nil
+	}
+	return v.(string), true, nil
+}
+
+// BenchmarkCommittedSet reports the heap retained by tracking the nodes of a
+// synthetic 200k-node graph, e.g.
+//
+//	go test -run '^$' -bench CommittedSet -benchtime 1x ./cmd/oras/internal/output
+func BenchmarkCommittedSet(b *testing.B) {
+	sets := []struct {
+		name string
+		new  func(b *testing.B) CommittedSet
+	}{
+		{"sync.Map", func(b *testing.B) CommittedSet { return &syncMapCommittedSet{} }},
+		{"memory", func(b *testing.B) CommittedSet { return NewCommittedSet() }},
+		{"disk", func(b *testing.B) CommittedSet {
+			s, err := NewDiskCommittedSet(b.TempDir())
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.Cleanup(func() { _ = s.Close() })
+			return s
+		}},
+	}
+	digests := make([]ocispec.Descriptor, syntheticNodes)
+	for i := range digests {
+		digests[i] = syntheticNode(i)
+	}
+	for _, set := range sets {
+		b.Run(set.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				var before, after runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+				s := set.new(b)
+				for _, desc := range digests {
+					if err := s.Store(desc); err != nil {
+						b.Fatal(err)
+					}
+				}
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				b.ReportMetric(float64(int64(after.HeapAlloc)-int64(before.HeapAlloc))/syntheticNodes, "heapB/node")
+				runtime.KeepAlive(s)
+			}
+		})
+	}
+}
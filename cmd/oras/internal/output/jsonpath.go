@@ -0,0 +1,140 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSONPath is a parsed JSONPath expression of the subset selecting a single
+// value by object keys and array indexes, e.g. $.config.os or
+// $.manifest.annotations['org.opencontainers.image.version'] or
+// $.files[0].path.
+type JSONPath struct {
+	steps []jsonPathStep
+}
+
+// jsonPathStep selects a field of an object by key, or an element of an array
+// by index.
+type jsonPathStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// ParseJSONPath parses a JSONPath expression. The leading $ and the braces
+// around the expression are optional.
+func ParseJSONPath(expr string) (*JSONPath, error) {
+	s := strings.TrimSpace(expr)
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = s[1 : len(s)-1]
+	}
+	s = strings.TrimPrefix(s, "$")
+	p := &JSONPath{}
+	for s != "" {
+		switch s[0] {
+		case '.':
+			end := strings.IndexAny(s[1:], ".[")
+			if end == -1 {
+				end = len(s) - 1
+			}
+			key := s[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("invalid JSONPath %q: empty key", expr)
+			}
+			p.steps = append(p.steps, jsonPathStep{key: key})
+			s = s[end+1:]
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid JSONPath %q: missing ]", expr)
+			}
+			subscript := s[1:end]
+			if len(subscript) >= 2 && (subscript[0] == '\'' || subscript[0] == '"') && subscript[len(subscript)-1] == subscript[0] {
+				p.steps = append(p.steps, jsonPathStep{key: subscript[1 : len(subscript)-1]})
+			} else if index, err := strconv.Atoi(subscript); err == nil && index >= 0 {
+				p.steps = append(p.steps, jsonPathStep{index: index, isIndex: true})
+			} else {
+				return nil, fmt.Errorf("invalid JSONPath %q: unsupported subscript [%s]", expr, subscript)
+			}
+			s = s[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid JSONPath %q: unexpected %q", expr, s[0])
+		}
+	}
+	return p, nil
+}
+
+// Evaluate returns the value selected from object, which consists of the
+// values decoded from JSON, and whether the value exists.
+func (p *JSONPath) Evaluate(object any) (any, bool) {
+	value := object
+	for _, step := range p.steps {
+		if step.isIndex {
+			array, ok := value.([]any)
+			if !ok || step.index >= len(array) {
+				return nil, false
+			}
+			value = array[step.index]
+			continue
+		}
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[step.key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// ParseAndWriteJSONPath parses the JSONPath expression and writes the value
+// selected from object. Strings are written as is, and other values are
+// written in JSON. An empty line is written if the value does not exist, or an
+// error is returned if strict templates are enabled.
+func ParseAndWriteJSONPath(out io.Writer, object any, expr string) error {
+	p, err := ParseJSONPath(expr)
+	if err != nil {
+		return err
+	}
+	converted, err := ToMap(object)
+	if err != nil {
+		return err
+	}
+	value, ok := p.Evaluate(converted)
+	if !ok {
+		if strictTemplates.Load() {
+			return fmt.Errorf("no value found for JSONPath %q", expr)
+		}
+		_, err = fmt.Fprintln(out)
+		return err
+	}
+	if s, ok := value.(string); ok {
+		_, err = fmt.Fprintln(out, s)
+		return err
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(encoded))
+	return err
+}
@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseAndWriteJSONPath(t *testing.T) {
+	object := map[string]any{
+		"manifest": map[string]any{
+			"annotations": map[string]any{"org.opencontainers.image.version": "1.0"},
+			"layers":      []any{map[string]any{"size": 42}},
+		},
+		"config": map[string]any{"os": "linux"},
+	}
+	tests := []struct {
+		expr    string
+		strict  bool
+		want    string
+		wantErr bool
+	}{
+		{"$.config.os", false, "linux\n", false},
+		{"{.config.os}", false, "linux\n", false},
+		{"$.manifest.annotations['org.opencontainers.image.version']", false, "1.0\n", false},
+		{`$.manifest.annotations["org.opencontainers.image.version"]`, false, "1.0\n", false},
+		{"$.manifest.layers[0].size", false, "42\n", false},
+		{"$.config", false, "{\"os\":\"linux\"}\n", false},
+		{"$.config.version", false, "\n", false},
+		{"$.manifest.layers[1]", false, "\n", false},
+		{"$.config.version", true, "", true},
+		{"$.config.os.name", true, "", true},
+		{"$.config..os", false, "", true},
+		{"$.manifest.layers[-1]", false, "", true},
+		{"$.config[os", false, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			SetStrictTemplates(tt.strict)
+			defer SetStrictTemplates(false)
+			var buf bytes.Buffer
+			err := ParseAndWriteJSONPath(&buf, object, tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAndWriteJSONPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("ParseAndWriteJSONPath() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -19,10 +19,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 
+	"oras.land/oras/cmd/oras/internal/display/humanize"
 	"oras.land/oras/internal/descriptor"
 
+	"github.com/morikuni/aec"
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2/content"
 )
@@ -30,31 +34,84 @@ import (
 // PrintFunc is the function type returned by StatusPrinter.
 type PrintFunc func(ocispec.Descriptor) error
 
-// Printer prints for status handlers.
+// Printer prints for status handlers. Human-readable status, such as transfer
+// status and summaries, is printed to the error output so that the output only
+// contains the results of commands, such as digests and formatted metadata.
 type Printer struct {
-	out     io.Writer
-	err     io.Writer
-	verbose bool
-	lock    sync.Mutex
+	out        io.Writer
+	err        io.Writer
+	verbose    bool
+	quiet      bool
+	color      bool
+	fullDigest bool
+	shortener  *descriptor.Shortener
+	lock       sync.Mutex
+	printed    sync.Map // map[string]struct{}
 }
 
-// NewPrinter creates a new Printer.
+// NewPrinter creates a new Printer printing results to out and status to err.
 func NewPrinter(out io.Writer, err io.Writer, verbose bool) *Printer {
-	return &Printer{out: out, err: err, verbose: verbose}
+	return &Printer{
+		out:       out,
+		err:       err,
+		verbose:   verbose,
+		shortener: descriptor.NewShortener(descriptor.DefaultShortDigestLength),
+	}
+}
+
+// EnableQuiet suppresses status and summary output. Only the results
+// printed via PrintResult and PrintDigest, and the content written via Write,
+// are kept.
+func (p *Printer) EnableQuiet() {
+	p.quiet = true
+}
+
+// Quiet returns true if status and summary output is suppressed.
+func (p *Printer) Quiet() bool {
+	return p.quiet
+}
+
+// EnableColor enables colored transfer status. It should only be called if
+// the output is a terminal.
+func (p *Printer) EnableColor() {
+	p.color = true
+}
+
+// EnableFullDigest makes transfer status include the full digest, the size
+// and the media type of the content instead of the short digest.
+func (p *Printer) EnableFullDigest() {
+	p.fullDigest = true
 }
 
-// Write implements the io.Writer interface.
+// SetShortDigestLength sets the initial length of short digests in status
+// output. Short digests sharing the same prefix are lengthened automatically.
+func (p *Printer) SetShortDigestLength(length int) {
+	p.shortener = descriptor.NewShortener(length)
+}
+
+// Write implements the io.Writer interface. b is written to the output.
 func (p *Printer) Write(b []byte) (int, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	return p.out.Write(b)
 }
 
-// Println prints objects concurrent-safely with newline.
+// Println prints status concurrent-safely with newline.
 func (p *Printer) Println(a ...any) error {
+	if p.quiet {
+		return nil
+	}
+	return p.println(p.err, a...)
+}
+
+// Printf prints status concurrent-safely.
+func (p *Printer) Printf(format string, a ...any) error {
+	if p.quiet {
+		return nil
+	}
 	p.lock.Lock()
 	defer p.lock.Unlock()
-	_, err := fmt.Fprintln(p.out, a...)
+	_, err := fmt.Fprintf(p.err, format, a...)
 	if err != nil {
 		err = fmt.Errorf("display output error: %w", err)
 		_, _ = fmt.Fprint(p.err, err)
@@ -63,11 +120,25 @@ func (p *Printer) Println(a ...any) error {
 	return nil
 }
 
-// Printf prints objects concurrent-safely with newline.
-func (p *Printer) Printf(format string, a ...any) error {
+// PrintResult prints the result of a command to the output concurrent-safely
+// with newline. Unlike Println, the result is printed in quiet mode.
+func (p *Printer) PrintResult(a ...any) error {
+	return p.println(p.out, a...)
+}
+
+// PrintDigest prints the digest of the content produced by a command to the
+// output. Only the digest itself is printed in quiet mode.
+func (p *Printer) PrintDigest(dgst digest.Digest) error {
+	if p.quiet {
+		return p.println(p.out, dgst)
+	}
+	return p.println(p.out, "Digest:", dgst)
+}
+
+func (p *Printer) println(w io.Writer, a ...any) error {
 	p.lock.Lock()
 	defer p.lock.Unlock()
-	_, err := fmt.Fprintf(p.out, format, a...)
+	_, err := fmt.Fprintln(w, a...)
 	if err != nil {
 		err = fmt.Errorf("display output error: %w", err)
 		_, _ = fmt.Fprint(p.err, err)
@@ -86,11 +157,47 @@ func (p *Printer) PrintVerbose(a ...any) error {
 
 // PrintStatus prints transfer status.
 func (p *Printer) PrintStatus(desc ocispec.Descriptor, status string) error {
+	if p.color {
+		status = colorStatus(status)
+	}
 	name, isTitle := descriptor.GetTitleOrMediaType(desc)
+	if p.fullDigest {
+		// columns: digest, size right-aligned in 8 characters, media type,
+		// and title if any
+		fields := []any{status, desc.Digest, fmt.Sprintf("%8s", humanize.Size(desc.Size)), desc.MediaType}
+		if !isTitle {
+			return p.PrintVerbose(fields...)
+		}
+		return p.Println(append(fields, name)...)
+	}
 	if !isTitle {
-		return p.PrintVerbose(status, descriptor.ShortDigest(desc), name)
+		return p.PrintVerbose(status, p.shortener.Shorten(desc.Digest), name)
 	}
-	return p.Println(status, descriptor.ShortDigest(desc), name)
+	return p.Println(status, p.shortener.Shorten(desc.Digest), name)
+}
+
+// PrintStatusOnce prints transfer status like PrintStatus, but only once for
+// the same status of the same content. It is used for statuses which may be
+// reported by concurrent copies of the same content, e.g. "Exists".
+func (p *Printer) PrintStatusOnce(desc ocispec.Descriptor, status string) error {
+	name, _ := descriptor.GetTitleOrMediaType(desc)
+	key := strings.Join([]string{strings.TrimSpace(status), desc.Digest.String(), name}, " ")
+	if _, loaded := p.printed.LoadOrStore(key, struct{}{}); loaded {
+		return nil
+	}
+	return p.PrintStatus(desc, status)
+}
+
+// colorStatus colors the status by its outcome: green for completed transfers
+// and yellow for skipped ones.
+func colorStatus(status string) string {
+	switch strings.TrimSpace(status) {
+	case "Uploaded", "Downloaded", "Copied", "Restored", "Mounted":
+		return aec.GreenF.Apply(status)
+	case "Exists", "Skipped":
+		return aec.YellowF.Apply(status)
+	}
+	return status
 }
 
 // StatusPrinter returns a tracking function for transfer status.
@@ -101,14 +208,17 @@ func (p *Printer) StatusPrinter(status string) PrintFunc {
 }
 
 // PrintSuccessorStatus prints transfer status of successors.
-func PrintSuccessorStatus(ctx context.Context, desc ocispec.Descriptor, fetcher content.Fetcher, committed *sync.Map, print PrintFunc) error {
+func PrintSuccessorStatus(ctx context.Context, desc ocispec.Descriptor, fetcher content.Fetcher, committed CommittedSet, print PrintFunc) error {
 	successors, err := content.Successors(ctx, fetcher, desc)
 	if err != nil {
 		return err
 	}
 	for _, s := range successors {
-		name := s.Annotations[ocispec.AnnotationTitle]
-		if v, ok := committed.Load(s.Digest.String()); ok && v != name {
+		title, ok, err := committed.Load(s.Digest)
+		if err != nil {
+			return err
+		}
+		if ok && title != s.Annotations[ocispec.AnnotationTitle] {
 			// Reprint status for deduplicated content
 			if err := print(s); err != nil {
 				return err
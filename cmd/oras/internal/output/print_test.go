@@ -20,7 +20,11 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/morikuni/aec"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 type mockWriter struct {
@@ -43,7 +47,7 @@ func (mw *mockWriter) String() string {
 
 func TestPrinter_Println(t *testing.T) {
 	mockWriter := &mockWriter{}
-	printer := NewPrinter(mockWriter, os.Stderr, false)
+	printer := NewPrinter(os.Stdout, mockWriter, false)
 	err := printer.Println("boom")
 	if mockWriter.errorCount != 1 {
 		t.Error("Expected one error actual <" + strconv.Itoa(mockWriter.errorCount) + ">")
@@ -62,7 +66,7 @@ func TestPrinter_Println(t *testing.T) {
 
 func TestPrinter_PrintVerbose_noError(t *testing.T) {
 	builder := &strings.Builder{}
-	printer := NewPrinter(builder, os.Stderr, false)
+	printer := NewPrinter(os.Stdout, builder, false)
 
 	expected := "normal\nthing one\n"
 	err := printer.Println("normal")
@@ -85,7 +89,7 @@ func TestPrinter_PrintVerbose_noError(t *testing.T) {
 
 func TestPrinter_PrintVerbose(t *testing.T) {
 	builder := &strings.Builder{}
-	printer := NewPrinter(builder, os.Stderr, true)
+	printer := NewPrinter(os.Stdout, builder, true)
 
 	expected := "normal\nverbose\n"
 	err := printer.Println("normal")
@@ -101,3 +105,115 @@ func TestPrinter_PrintVerbose(t *testing.T) {
 		t.Error("Expected <" + expected + "> not equal to actual <" + actual + ">")
 	}
 }
+
+func TestPrinter_PrintStatusOnce(t *testing.T) {
+	mockWriter := &mockWriter{}
+	printer := NewPrinter(os.Stdout, mockWriter, false)
+	desc := ocispec.Descriptor{
+		MediaType:   "application/vnd.test",
+		Digest:      "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		Size:        3,
+		Annotations: map[string]string{ocispec.AnnotationTitle: "foo.txt"},
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = printer.PrintStatusOnce(desc, "Exists   ")
+		}()
+	}
+	wg.Wait()
+	if got := strings.Count(mockWriter.String(), "Exists"); got != 1 {
+		t.Fatalf("expect status printed once, got %q", mockWriter.String())
+	}
+
+	// the same content with a different name is printed
+	desc.Annotations[ocispec.AnnotationTitle] = "bar.txt"
+	_ = printer.PrintStatusOnce(desc, "Exists   ")
+	if got := strings.Count(mockWriter.String(), "Exists"); got != 2 {
+		t.Fatalf("expect status printed twice, got %q", mockWriter.String())
+	}
+}
+
+func TestPrinter_PrintStatus_color(t *testing.T) {
+	desc := ocispec.Descriptor{
+		MediaType:   "application/vnd.test",
+		Digest:      "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		Size:        3,
+		Annotations: map[string]string{ocispec.AnnotationTitle: "foo.txt"},
+	}
+	tests := []struct {
+		status string
+		color  aec.ANSI
+	}{
+		{"Uploaded ", aec.GreenF},
+		{"Exists   ", aec.YellowF},
+		{"Skipped", aec.YellowF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			mockWriter := &mockWriter{}
+			printer := NewPrinter(os.Stdout, mockWriter, false)
+			printer.EnableColor()
+			_ = printer.PrintStatus(desc, tt.status)
+			if want := tt.color.Apply(tt.status); !strings.HasPrefix(mockWriter.String(), want) {
+				t.Fatalf("PrintStatus() = %q, want prefix %q", mockWriter.String(), want)
+			}
+		})
+	}
+
+	// uncolored status
+	mockWriter := &mockWriter{}
+	printer := NewPrinter(os.Stdout, mockWriter, false)
+	printer.EnableColor()
+	_ = printer.PrintStatus(desc, "Uploading")
+	if strings.Contains(mockWriter.String(), "\x1b[") {
+		t.Fatalf("PrintStatus() = %q, want no color", mockWriter.String())
+	}
+}
+
+func TestPrinter_PrintStatus_fullDigest(t *testing.T) {
+	desc := ocispec.Descriptor{
+		MediaType:   "application/vnd.test",
+		Digest:      "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		Size:        2048,
+		Annotations: map[string]string{ocispec.AnnotationTitle: "foo.txt"},
+	}
+	mockWriter := &mockWriter{}
+	printer := NewPrinter(os.Stdout, mockWriter, false)
+	printer.EnableFullDigest()
+	_ = printer.PrintStatus(desc, "Uploaded ")
+	if want := "Uploaded  sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae  2.0 KiB application/vnd.test foo.txt\n"; mockWriter.String() != want {
+		t.Fatalf("PrintStatus() = %q, want %q", mockWriter.String(), want)
+	}
+
+	// content without title is only printed in verbose mode
+	delete(desc.Annotations, ocispec.AnnotationTitle)
+	_ = printer.PrintStatus(desc, "Uploaded ")
+	if got := strings.Count(mockWriter.String(), "\n"); got != 1 {
+		t.Fatalf("PrintStatus() = %q, want no output for untitled content", mockWriter.String())
+	}
+}
+
+func TestPrinter_quiet(t *testing.T) {
+	builder := &strings.Builder{}
+	printer := NewPrinter(builder, os.Stderr, true)
+	printer.EnableQuiet()
+	desc := ocispec.Descriptor{
+		MediaType:   "application/vnd.oci.image.layer.v1.tar",
+		Digest:      "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		Annotations: map[string]string{ocispec.AnnotationTitle: "foo"},
+	}
+	_ = printer.Println("Pushed", "localhost:5000/test")
+	_ = printer.Printf("%s\n", "summary")
+	_ = printer.PrintVerbose("verbose")
+	_ = printer.PrintStatus(desc, "Uploaded")
+	_ = printer.PrintDigest(desc.Digest)
+	_ = printer.PrintResult("v1")
+
+	want := desc.Digest.String() + "\nv1\n"
+	if got := builder.String(); got != want {
+		t.Errorf("quiet output = %q, want %q", got, want)
+	}
+}
@@ -16,15 +16,49 @@ limitations under the License.
 package output
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"sync/atomic"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/internal/descriptor"
 )
 
+// strictTemplates indicates whether templates and JSONPath expressions fail on
+// nonexistent fields.
+var strictTemplates atomic.Bool
+
+// SetStrictTemplates sets whether templates and JSONPath expressions fail on
+// nonexistent fields instead of writing empty values.
+func SetStrictTemplates(strict bool) {
+	strictTemplates.Store(strict)
+}
+
+// ParseTemplate parses the template string with the sprig functions and the
+// following helper functions:
+//   - shortDigest: shortens a digest to 12 hex characters
+//   - humanSize: formats a size in bytes to a human-readable string
+func ParseTemplate(templateStr string) (*template.Template, error) {
+	funcs := sprig.TxtFuncMap()
+	funcs["shortDigest"] = shortDigest
+	funcs["humanSize"] = humanSize
+	t := template.New("format output").Funcs(funcs)
+	if strictTemplates.Load() {
+		t = t.Option("missingkey=error")
+	}
+	return t.Parse(templateStr)
+}
+
+// ParseAndWrite parses the template string and writes the object with it.
+// Nothing is written if the execution fails.
 func ParseAndWrite(out io.Writer, object any, templateStr string) error {
 	// parse template
-	t, err := template.New("format output").Funcs(sprig.FuncMap()).Parse(templateStr)
+	t, err := ParseTemplate(templateStr)
 	if err != nil {
 		return err
 	}
@@ -33,5 +67,30 @@ func ParseAndWrite(out io.Writer, object any, templateStr string) error {
 	if err != nil {
 		return err
 	}
-	return t.Execute(out, converted)
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, converted); err != nil {
+		return err
+	}
+	_, err = buf.WriteTo(out)
+	return err
+}
+
+// shortDigest returns the short form of the digest string.
+func shortDigest(dgst string) string {
+	return descriptor.ShortDigest(ocispec.Descriptor{Digest: digest.Digest(dgst)})
+}
+
+// humanSize returns the human-readable form of size in bytes.
+func humanSize(size any) (string, error) {
+	switch v := size.(type) {
+	case float64:
+		// numbers in the converted object are float64
+		return humanize.ToBytes(int64(v)).String(), nil
+	case int:
+		return humanize.ToBytes(int64(v)).String(), nil
+	case int64:
+		return humanize.ToBytes(v).String(), nil
+	default:
+		return "", fmt.Errorf("humanSize: unsupported size type %T", size)
+	}
 }
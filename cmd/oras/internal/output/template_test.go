@@ -16,6 +16,7 @@ limitations under the License.
 package output
 
 import (
+	"bytes"
 	"os"
 	"testing"
 )
@@ -25,3 +26,45 @@ func Test_parseAndWrite_err(t *testing.T) {
 		t.Errorf("should return error")
 	}
 }
+
+func Test_parseAndWrite_helpers(t *testing.T) {
+	object := map[string]any{
+		"digest": "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		"size":   2048,
+	}
+	var buf bytes.Buffer
+	if err := ParseAndWrite(&buf, object, `{{shortDigest .digest}} {{humanSize .size}} {{toJson .size}}`); err != nil {
+		t.Fatalf("ParseAndWrite() error = %v", err)
+	}
+	if want := "2c26b46b68ff 2 kB 2048"; buf.String() != want {
+		t.Errorf("ParseAndWrite() = %q, want %q", buf.String(), want)
+	}
+}
+
+func Test_ParseTemplate_err(t *testing.T) {
+	if _, err := ParseTemplate("{{.digest"); err == nil {
+		t.Errorf("should return error")
+	}
+}
+
+func Test_parseAndWrite_strict(t *testing.T) {
+	SetStrictTemplates(true)
+	defer SetStrictTemplates(false)
+	object := map[string]any{
+		"config": map[string]any{"os": "linux"},
+	}
+	var buf bytes.Buffer
+	if err := ParseAndWrite(&buf, object, `{{.config.os}}`); err != nil {
+		t.Fatalf("ParseAndWrite() error = %v", err)
+	}
+	if want := "linux"; buf.String() != want {
+		t.Errorf("ParseAndWrite() = %q, want %q", buf.String(), want)
+	}
+	buf.Reset()
+	if err := ParseAndWrite(&buf, object, `{{.config.os}} {{.config.version}}`); err == nil {
+		t.Error("ParseAndWrite() expects an error for a nonexistent field")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("ParseAndWrite() writes %q on failure", buf.String())
+	}
+}
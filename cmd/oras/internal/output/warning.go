@@ -0,0 +1,45 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import "sync"
+
+// Warning is a warning returned by a registry.
+type Warning struct {
+	Registry string `json:"registry"`
+	Text     string `json:"text"`
+}
+
+// warnings records the registry warnings of the current command run.
+var warnings struct {
+	mu   sync.Mutex
+	list []Warning
+}
+
+// RecordWarning records a registry warning to be included in the JSON output.
+func RecordWarning(w Warning) {
+	warnings.mu.Lock()
+	defer warnings.mu.Unlock()
+	warnings.list = append(warnings.list, w)
+}
+
+// Warnings returns the recorded registry warnings in the order they are
+// received.
+func Warnings() []Warning {
+	warnings.mu.Lock()
+	defer warnings.mu.Unlock()
+	return append([]Warning(nil), warnings.list...)
+}
@@ -19,14 +19,17 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"syscall"
 
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/root"
 )
 
 func main() {
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	// commands are canceled on SIGINT and SIGTERM to clean up temporary files
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 	if err := root.New().ExecuteContext(ctx); err != nil {
-		os.Exit(1)
+		os.Exit(oerrors.ExitCode(err))
 	}
 }
@@ -25,12 +25,14 @@ import (
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
 	"oras.land/oras/internal/graph"
 	"oras.land/oras/internal/registryutil"
 )
@@ -44,6 +46,7 @@ type attachOptions struct {
 
 	artifactType string
 	concurrency  int
+	gcFallback   bool
 }
 
 func attachCmd() *cobra.Command {
@@ -68,6 +71,9 @@ Example - Attach file "hi.txt" using a specific method for the Referrers API:
   oras attach --artifact-type doc/example --distribution-spec v1.1-referrers-api localhost:5000/hello:v1 hi.txt # via API
   oras attach --artifact-type doc/example --distribution-spec v1.1-referrers-tag localhost:5000/hello:v1 hi.txt # via tag scheme
 
+Example - Attach file 'hi.txt' and remove stale entries from the referrers tag schema index of the subject:
+  oras attach --artifact-type doc/example --gc-fallback localhost:5000/hello:v1 hi.txt
+
 Example - Attach file 'hi.txt' and add annotations from file 'annotation.json':
   oras attach --artifact-type doc/example --annotation-file annotation.json localhost:5000/hello:v1 hi.txt
 
@@ -77,6 +83,9 @@ Example - Attach an artifact with manifest annotations:
 Example - Attach file 'hi.txt' and add manifest annotations:
   oras attach --artifact-type doc/example --annotation "key=val" localhost:5000/hello:v1 hi.txt
 
+Example - Attach file 'hi.txt' and add an annotation to its layer:
+  oras attach --artifact-type doc/example --annotation "hi.txt=key=val" localhost:5000/hello:v1 hi.txt
+
 Example - Attach file 'hi.txt' and export the pushed manifest to 'manifest.json':
   oras attach --artifact-type doc/example --export-manifest manifest.json localhost:5000/hello:v1 hi.txt
 
@@ -108,17 +117,21 @@ Example - Attach file to the manifest tagged 'v1' in an OCI image layout folder
 	}
 
 	cmd.Flags().StringVarP(&opts.artifactType, "artifact-type", "", "", "artifact type")
+	_ = cmd.RegisterFlagCompletionFunc("artifact-type", option.CompleteArtifactType)
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	cmd.Flags().BoolVar(&opts.gcFallback, "gc-fallback", false, "[Preview] remove the entries of nonexistent manifests from the referrers tag schema index of the subject")
 	opts.FlagDescription = "[Preview] attach to an arch-specific subject"
 	_ = cmd.MarkFlagRequired("artifact-type")
 	opts.EnableDistributionSpecFlag()
 	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
 func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	opts.Platform.MaxConfigBytes = opts.MaxMetadataBytes
 	displayStatus, displayMetadata, err := display.NewAttachHandler(opts.Printer, opts.Format, opts.TTY)
 	if err != nil {
 		return err
@@ -147,16 +160,15 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	if err != nil {
 		return err
 	}
+	repo, isRemote := dst.(*remote.Repository)
 	// add both pull and push scope hints for dst repository
 	// to save potential push-scope token requests during copy
 	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
-	fetchOpts := oras.DefaultResolveOptions
-	fetchOpts.TargetPlatform = opts.Platform.Platform
-	subject, err := oras.Resolve(ctx, dst, opts.Reference, fetchOpts)
+	subject, err := opts.Platform.Resolve(ctx, dst, opts.Reference)
 	if err != nil {
 		return fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
 	}
-	descs, err := loadFiles(ctx, store, annotations, opts.FileRefs, displayStatus)
+	descs, err := loadFiles(ctx, store, annotations, opts.FileRefs, displayStatus, nil)
 	if err != nil {
 		return err
 	}
@@ -202,6 +214,11 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	if err != nil {
 		return err
 	}
+	if opts.gcFallback && isRemote {
+		if err := pruneReferrersIndex(ctx, opts.Printer, repo, subject); err != nil {
+			return err
+		}
+	}
 	err = displayMetadata.OnCompleted(&opts.Target, root, subject)
 	if err != nil {
 		return err
@@ -210,3 +227,19 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	// Export manifest
 	return opts.ExportManifest(ctx, store, root)
 }
+
+// pruneReferrersIndex removes the entries of nonexistent manifests from the
+// referrers tag schema index of subject in repo. It is a no-op if the index
+// does not exist, e.g. the registry supports the Referrers API.
+func pruneReferrersIndex(ctx context.Context, printer *output.Printer, repo *remote.Repository, subject ocispec.Descriptor) error {
+	removed, err := registryutil.PruneReferrersIndex(ctx, repo, subject)
+	if err != nil {
+		return fmt.Errorf("failed to prune the referrers index of %s: %w", subject.Digest, err)
+	}
+	for _, desc := range removed {
+		if err := printer.Println("Pruned", desc.Digest, "from", registryutil.ReferrersTag(subject)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -16,10 +16,20 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"strings"
 	"testing"
 
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
 	"oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 )
@@ -41,3 +51,90 @@ func Test_runAttach_errType(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+func Test_attachCmd_platform(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifests []ocispec.Descriptor
+	for _, p := range []ocispec.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+	} {
+		configJSON, err := json.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config := content.NewDescriptorFromBytes(ocispec.MediaTypeImageConfig, configJSON)
+		if err := store.Push(ctx, config, bytes.NewReader(configJSON)); err != nil {
+			t.Fatal(err)
+		}
+		manifest, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "", oras.PackManifestOptions{
+			ConfigDescriptor: &config,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifest.Platform = &p
+		manifests = append(manifests, manifest)
+	}
+	indexJSON, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: manifests,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, indexJSON)
+	if err := store.Push(ctx, index, bytes.NewReader(indexJSON)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Tag(ctx, index, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantSubject ocispec.Descriptor
+		wantErr     string
+	}{
+		{"index", nil, index, ""},
+		{"platform", []string{"--platform", "linux/arm64"}, manifests[1], ""},
+		{"no matching platform", []string{"--platform", "linux/s390x"}, ocispec.Descriptor{}, "available platforms: linux/amd64, linux/arm64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := attachCmd()
+			cmd.SetArgs(append([]string{"--oci-layout", "--artifact-type", "doc/example", "--annotation", "test=" + tt.name, dir + ":v1"}, tt.args...))
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SilenceUsage = true
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("attach error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("attach error = %v", err)
+			}
+			store, err := oci.New(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			referrers, err := registry.Referrers(ctx, store, tt.wantSubject, "doc/example")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(referrers) != 1 || referrers[0].Annotations["test"] != tt.name {
+				t.Errorf("referrers of %s = %v, want the attached artifact", tt.wantSubject.Digest, referrers)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/archive"
+	"oras.land/oras/internal/graph"
+	oio "oras.land/oras/internal/io"
+)
+
+type backupOptions struct {
+	option.Common
+	option.ReferrerTags
+	option.Target
+
+	output      string
+	concurrency int
+}
+
+func backupCmd() *cobra.Command {
+	var opts backupOptions
+	cmd := &cobra.Command{
+		Use:   "backup [flags] --output <file> <name>",
+		Short: "[Experimental] Back up all tagged artifacts of a repository to a tar archive",
+		Long: `[Experimental] Back up all tagged artifacts of a repository to a tar archive
+
+All tags of the repository are copied with their referrers into an OCI image
+layout, which is archived to the output file. Content shared by multiple tags is
+stored only once. The output file is created only when the backup completes.
+The tags created by the referrers tag schema are skipped unless
+--include-referrer-tags is specified, since referrers are backed up along with
+their subjects.
+
+Example - Back up the repository 'localhost:5000/hello' to 'hello.tar':
+  oras backup --output hello.tar localhost:5000/hello
+
+Example - Back up the repository with concurrency level tuned:
+  oras backup --concurrency 6 --output hello.tar localhost:5000/hello
+
+Example - Back up the OCI image layout folder 'layout-dir' to 'layout.tar':
+  oras backup --oci-layout --output layout.tar layout-dir
+
+Example - Restore the backup 'hello.tar' to the repository 'localhost:5000/hello-restored':
+  oras restore hello.tar localhost:5000/hello-restored
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the repository to back up"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			return ensureRepositoryOnly(&opts.Target, cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBackup(cmd, &opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "path of the tar archive to be written")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	_ = cmd.MarkFlagRequired("output")
+	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runBackup(cmd *cobra.Command, opts *backupOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	src, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	tags, err := opts.ListTags(ctx, src, "")
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return fmt.Errorf("no tags found in %s", opts.Path)
+	}
+
+	layoutDir, err := os.MkdirTemp("", "oras_backup_*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(layoutDir)
+	dst, err := oci.New(layoutDir)
+	if err != nil {
+		return err
+	}
+	copyOpts := newRepositoryCopyOptions(opts.Printer, opts.concurrency)
+	for _, tag := range tags {
+		desc, err := src.Resolve(ctx, tag)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", tag, err)
+		}
+		if err := recursiveCopy(ctx, src, dst, tag, desc, copyOpts); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", tag, err)
+		}
+		if err := opts.Printer.Println("Backed up", tag); err != nil {
+			return err
+		}
+	}
+
+	if err := writeBackupArchive(layoutDir, opts.output); err != nil {
+		return fmt.Errorf("failed to write %s: %w", opts.output, err)
+	}
+	return opts.Printer.PrintResult(fmt.Sprintf("Backed up %d tag(s) of %s to %s", len(tags), opts.Path, opts.output))
+}
+
+// ensureRepositoryOnly returns an error if a tag or digest is given in the
+// reference of target since all tags are backed up or restored.
+func ensureRepositoryOnly(target *option.Target, cmd *cobra.Command) error {
+	if target.Reference == "" {
+		return nil
+	}
+	return &oerrors.Error{
+		Err:            fmt.Errorf("%q: all tags are processed and a tag or digest cannot be specified", target.RawReference),
+		Usage:          fmt.Sprintf("%s %s", cmd.Parent().CommandPath(), cmd.Use),
+		Recommendation: `To copy a single artifact with its referrers, use "oras cp -r"`,
+	}
+}
+
+// newRepositoryCopyOptions returns the options for copying the tagged
+// artifacts of a repository with their referrers. Content copied for a tag is
+// skipped for the others.
+func newRepositoryCopyOptions(printer *output.Printer, concurrency int) oras.ExtendedCopyOptions {
+	const (
+		promptExists  = "Exists "
+		promptCopying = "Copying"
+		promptCopied  = "Copied "
+	)
+	opts := oras.DefaultExtendedCopyOptions
+	opts.Concurrency = concurrency
+	opts.FindPredecessors = graph.CachePredecessors(func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		return registry.Referrers(ctx, src, desc, "")
+	}, graph.DefaultPredecessorCacheSize)
+	opts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+		return printer.PrintStatusOnce(desc, promptExists)
+	}
+	opts.PreCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		return printer.PrintStatus(desc, promptCopying)
+	}
+	opts.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		return printer.PrintStatus(desc, promptCopied)
+	}
+	return opts
+}
+
+// writeBackupArchive archives the OCI image layout in layoutDir to path. An
+// interrupted backup never leaves a truncated archive at path.
+func writeBackupArchive(layoutDir, path string) error {
+	return oio.WriteFileAtomic(path, func(w io.Writer) error {
+		return archive.TarDirectoryEntries(w, layoutDir, archive.TarOptions{})
+	})
+}
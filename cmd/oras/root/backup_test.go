@@ -0,0 +1,122 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
+)
+
+func Test_backupAndRestore(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	srcDir := filepath.Join(tempDir, "src")
+	src, err := oci.New(srcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range []string{"v1", "v2"} {
+		if err := src.Tag(ctx, subject, tag); err != nil {
+			t.Fatal(err)
+		}
+	}
+	referrer, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.sig", oras.PackManifestOptions{Subject: &subject})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archivePath := filepath.Join(tempDir, "backup.tar")
+	cmd := backupCmd()
+	cmd.SetArgs([]string{"--oci-layout", "--output", archivePath, srcDir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("backup error = %v", err)
+	}
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("expect only the source and the archive in %s, got %v", tempDir, entries)
+	}
+
+	dstDir := filepath.Join(tempDir, "dst")
+	cmd = restoreCmd()
+	cmd.SetArgs([]string{"--oci-layout", archivePath, dstDir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("restore error = %v", err)
+	}
+	dst, err := oci.New(dstDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range []string{"v1", "v2"} {
+		desc, err := dst.Resolve(ctx, tag)
+		if err != nil {
+			t.Fatalf("failed to resolve restored tag %s: %v", tag, err)
+		}
+		if desc.Digest != subject.Digest {
+			t.Errorf("restored tag %s = %s, want %s", tag, desc.Digest, subject.Digest)
+		}
+	}
+	referrers, err := registry.Referrers(ctx, dst, subject, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(referrers) != 1 || referrers[0].Digest != referrer.Digest {
+		t.Errorf("restored referrers = %v, want %v", referrers, []ocispec.Descriptor{referrer})
+	}
+}
+
+func Test_writeBackupArchive_failure(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "backup.tar")
+	if err := writeBackupArchive(filepath.Join(tempDir, "missing"), path); err == nil {
+		t.Fatal("writeBackupArchive() error = nil, want error")
+	}
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expect no file left behind, got %v", entries)
+	}
+}
+
+func Test_runRestore_truncated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.tar")
+	if err := os.WriteFile(path, []byte("index.json"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	cmd := restoreCmd()
+	cmd.SetArgs([]string{"--oci-layout", path, filepath.Join(t.TempDir(), "dst")})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("restore error = nil, want error")
+	}
+}
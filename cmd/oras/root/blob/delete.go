@@ -18,11 +18,13 @@ package blob
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
@@ -36,6 +38,8 @@ type deleteBlobOptions struct {
 	option.Descriptor
 	option.Pretty
 	option.Target
+
+	missingOK bool
 }
 
 func deleteCmd() *cobra.Command {
@@ -54,6 +58,9 @@ Example - Delete a blob without prompting confirmation:
 
 Example - Delete a blob and print its descriptor:
   oras blob delete --descriptor --force localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - Delete a blob and succeed even if it has already been deleted:
+  oras blob delete --force --missing-ok localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
   `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the target blob to delete"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -68,7 +75,9 @@ Example - Delete a blob and print its descriptor:
 		},
 	}
 
+	cmd.Flags().BoolVarP(&opts.missingOK, "missing-ok", "", false, "treat a nonexistent blob as deleted instead of failing")
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
@@ -87,10 +96,9 @@ func deleteBlob(cmd *cobra.Command, opts *deleteBlobOptions) (err error) {
 	desc, err := blobs.Resolve(ctx, opts.Reference)
 	if err != nil {
 		if errors.Is(err, errdef.ErrNotFound) {
-			if opts.Force && !opts.OutputDescriptor {
+			if opts.missingOK || (opts.Force && !opts.OutputDescriptor) {
 				// ignore nonexistent
-				_ = opts.Println("Missing", opts.RawReference)
-				return nil
+				return opts.onMissing()
 			}
 			return fmt.Errorf("%s: the specified blob does not exist", opts.RawReference)
 		}
@@ -107,7 +115,19 @@ func deleteBlob(cmd *cobra.Command, opts *deleteBlobOptions) (err error) {
 	}
 
 	if err = blobs.Delete(ctx, desc); err != nil {
-		return fmt.Errorf("failed to delete %s: %w", opts.RawReference, err)
+		if opts.missingOK && errors.Is(err, errdef.ErrNotFound) {
+			// deleted by others after being resolved
+			return opts.onMissing()
+		}
+		err = fmt.Errorf("failed to delete %s: %w", opts.RawReference, err)
+		var errResp *errcode.ErrorResponse
+		if errors.As(err, &errResp) && errResp.StatusCode == http.StatusMethodNotAllowed {
+			return &oerrors.Error{
+				Err:            err,
+				Recommendation: "Blob deletion is disabled on the registry. Please contact the registry administrator to enable it",
+			}
+		}
+		return err
 	}
 
 	if opts.OutputDescriptor {
@@ -122,3 +142,12 @@ func deleteBlob(cmd *cobra.Command, opts *deleteBlobOptions) (err error) {
 
 	return nil
 }
+
+// onMissing handles a nonexistent blob when it is allowed to be missing.
+func (opts *deleteBlobOptions) onMissing() error {
+	if opts.OutputDescriptor {
+		// keep stdout clean for the descriptor consumers
+		return nil
+	}
+	return opts.Println("Missing", opts.RawReference)
+}
@@ -18,8 +18,11 @@ package blob
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
@@ -31,22 +34,36 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/status/track"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	oio "oras.land/oras/internal/io"
 )
 
+// errRangeNotSupported is returned when the target cannot serve partial
+// content of a blob.
+var errRangeNotSupported = errors.New("range requests are not supported by the target")
+
+// defaultStdoutMaxSize is the default size limit of the blob content printed
+// to stdout.
+const defaultStdoutMaxSize = 64 * 1024 * 1024 // 64 MiB
+
 type fetchBlobOptions struct {
 	option.Cache
 	option.Common
 	option.Descriptor
 	option.Pretty
+	option.StreamTo
 	option.Target
 
 	outputPath string
+	maxSize    int64
+	rangeFlag  string
+	// byteRange is the parsed inclusive byte range, nil if not specified
+	byteRange *[2]int64
 }
 
 func fetchCmd() *cobra.Command {
 	var opts fetchBlobOptions
 	cmd := &cobra.Command{
-		Use:   "fetch [flags] {--output <file> | --descriptor} <name>@<digest>",
+		Use:   "fetch [flags] {--output <file> | --stream-to <command> | --descriptor} <name>@<digest>",
 		Short: "Fetch a blob from a registry or an OCI image layout",
 		Long: `Fetch a blob from a registry or an OCI image layout
 
@@ -56,6 +73,15 @@ Example - Fetch a blob from registry and save it to a local file:
 Example - Fetch a blob from registry and print the raw blob content:
   oras blob fetch --output - localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
 
+Example - Fetch the first 512 bytes of a blob and save them to a local file:
+  oras blob fetch --range 0-511 --output header.bin localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - Fetch a blob and print the raw content if it is no larger than 1 GiB:
+  oras blob fetch --max-size 1073741824 --output - localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - [Experimental] Fetch a blob and extract it with tar without saving it to disk:
+  oras blob fetch --stream-to "tar -xzf -" localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
 Example - Fetch and print the descriptor of a blob:
   oras blob fetch --descriptor localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
 
@@ -70,17 +96,34 @@ Example - Fetch and print a blob from OCI image layout archive file 'layout.tar'
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the target blob to fetch"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if opts.outputPath == "" && !opts.OutputDescriptor {
-				return errors.New("either `--output` or `--descriptor` must be provided")
+			if opts.StreamCommand != "" {
+				if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "stream-to", "output", "descriptor", "range"); err != nil {
+					return err
+				}
+			} else if opts.outputPath == "" && !opts.OutputDescriptor {
+				return errors.New("either `--output`, `--stream-to` or `--descriptor` must be provided")
 			}
 
 			if opts.outputPath == "-" && opts.OutputDescriptor {
 				return errors.New("`--output -` cannot be used with `--descriptor` at the same time")
 			}
+			if opts.rangeFlag != "" {
+				if opts.outputPath == "" {
+					return errors.New("`--range` must be used with `--output`")
+				}
+				byteRange, err := parseRange(opts.rangeFlag)
+				if err != nil {
+					return err
+				}
+				opts.byteRange = &byteRange
+			}
+			if opts.outputPath == "-" && !cmd.Flags().Changed("max-size") {
+				opts.maxSize = defaultStdoutMaxSize
+			}
 			opts.RawReference = args[0]
 			err := option.Parse(cmd, &opts)
 			if err == nil {
-				opts.UpdateTTY(cmd.Flags().Changed(option.NoTTYFlag), opts.outputPath == "-")
+				opts.UpdateTTY(option.IsSet(cmd.Flags(), option.NoTTYFlag), opts.outputPath == "-" || opts.Streaming())
 			}
 			return err
 		},
@@ -91,7 +134,12 @@ Example - Fetch and print a blob from OCI image layout archive file 'layout.tar'
 	}
 
 	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "output file `path`, use - for stdout")
+	cmd.Flags().Int64VarP(&opts.maxSize, "max-size", "", 0, fmt.Sprintf("abort if the blob content is larger than the limit in bytes, defaults to %d when outputting to stdout, use 0 for no limit", defaultStdoutMaxSize))
+	cmd.Flags().StringVarP(&opts.rangeFlag, "range", "", "", "fetch only the bytes within `start-end` (inclusive), skipping digest verification")
+	opts.EnableAnonymousFallback()
+	opts.EnableMirrorFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
@@ -110,9 +158,15 @@ func fetchBlob(cmd *cobra.Command, opts *fetchBlobOptions) (fetchErr error) {
 	if repo, ok := target.(*remote.Repository); ok {
 		target = repo.Blobs()
 	}
-	src, err := opts.CachedTarget(target)
-	if err != nil {
-		return err
+	var src oras.ReadOnlyTarget = target
+	if opts.byteRange == nil {
+		// partial content cannot be cached
+		src, err = opts.CachedTarget(target)
+		if err != nil {
+			return err
+		}
+	} else {
+		cmd.PrintErrln("WARNING! Only partial content is fetched via --range, the content digest will not be verified.")
 	}
 	desc, err := opts.doFetch(ctx, src)
 	if err != nil {
@@ -135,7 +189,7 @@ func fetchBlob(cmd *cobra.Command, opts *fetchBlobOptions) (fetchErr error) {
 
 func (opts *fetchBlobOptions) doFetch(ctx context.Context, src oras.ReadOnlyTarget) (desc ocispec.Descriptor, fetchErr error) {
 	var err error
-	if opts.outputPath == "" {
+	if opts.outputPath == "" && !opts.Streaming() {
 		// fetch blob descriptor only
 		return oras.Resolve(ctx, src, opts.Reference, oras.DefaultResolveOptions)
 	}
@@ -146,11 +200,44 @@ func (opts *fetchBlobOptions) doFetch(ctx context.Context, src oras.ReadOnlyTarg
 		return ocispec.Descriptor{}, err
 	}
 	defer rc.Close()
-	vr := content.NewVerifyReader(rc, desc)
+	var r io.Reader
+	var vr *content.VerifyReader
+	trackDesc := desc
+	if opts.byteRange != nil {
+		// fetch partial content without verification
+		r, trackDesc.Size, err = seekRange(rc, desc, *opts.byteRange)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	} else {
+		vr = content.NewVerifyReader(rc, desc)
+		r = vr
+	}
+	if opts.maxSize > 0 {
+		if trackDesc.Size > opts.maxSize {
+			return ocispec.Descriptor{}, newSizeLimitError(opts.maxSize, fmt.Errorf("blob size %d exceeds the limit of %d bytes: %w", trackDesc.Size, opts.maxSize, oio.ErrSizeLimitExceeded))
+		}
+		r = oio.LimitReader(r, opts.maxSize)
+	}
 
+	// streams blob content into the command if "--stream-to" is used, or
 	// outputs blob content if "--output -" is used
-	writer := os.Stdout
-	if opts.outputPath != "-" {
+	var verify func() error
+	if vr != nil {
+		verify = vr.Verify
+	}
+	var writeTo func(r io.Reader) error
+	switch {
+	case opts.Streaming():
+		writeTo = func(r io.Reader) error {
+			return opts.Stream(ctx, r, verify)
+		}
+	case opts.outputPath == "-":
+		writeTo = func(r io.Reader) error {
+			_, err := io.Copy(os.Stdout, r)
+			return err
+		}
+	default:
 		// save blob content into the local file if the output path is provided
 		file, err := os.Create(opts.outputPath)
 		if err != nil {
@@ -160,30 +247,101 @@ func (opts *fetchBlobOptions) doFetch(ctx context.Context, src oras.ReadOnlyTarg
 			if err := file.Close(); fetchErr == nil {
 				fetchErr = err
 			}
+			if fetchErr != nil {
+				// clean up partial content
+				_ = os.Remove(opts.outputPath)
+			}
 		}()
-		writer = file
+		writeTo = func(r io.Reader) error {
+			_, err := io.Copy(file, r)
+			return err
+		}
 	}
 
 	if opts.TTY == nil {
 		// none TTY output
-		if _, err = io.Copy(writer, vr); err != nil {
-			return ocispec.Descriptor{}, err
+		if err = writeTo(r); err != nil {
+			return ocispec.Descriptor{}, opts.handleCopyError(err)
 		}
 	} else {
 		// TTY output
-		trackedReader, err := track.NewReader(vr, desc, "Downloading", "Downloaded ", opts.TTY)
+		trackedReader, err := track.NewReader(r, trackDesc, "Downloading", "Downloaded ", opts.TTY)
 		if err != nil {
 			return ocispec.Descriptor{}, err
 		}
 		defer trackedReader.StopManager()
 		trackedReader.Start()
-		if _, err = io.Copy(writer, trackedReader); err != nil {
-			return ocispec.Descriptor{}, err
+		if err = writeTo(trackedReader); err != nil {
+			return ocispec.Descriptor{}, opts.handleCopyError(err)
 		}
 		trackedReader.Done()
 	}
-	if err := vr.Verify(); err != nil {
-		return ocispec.Descriptor{}, err
+	if verify != nil {
+		if err := verify(); err != nil {
+			return ocispec.Descriptor{}, err
+		}
 	}
 	return desc, nil
 }
+
+// handleCopyError decorates the size limit error during content copying.
+func (opts *fetchBlobOptions) handleCopyError(err error) error {
+	if errors.Is(err, oio.ErrSizeLimitExceeded) {
+		return newSizeLimitError(opts.maxSize, fmt.Errorf("streamed content exceeds the limit of %d bytes: %w", opts.maxSize, err))
+	}
+	return err
+}
+
+// newSizeLimitError returns an error with recommendation for exceeding the
+// size limit.
+func newSizeLimitError(limit int64, err error) error {
+	return &oerrors.Error{
+		Err:            err,
+		Recommendation: fmt.Sprintf("If the blob is expected to be larger than %d bytes, please increase the limit via `--max-size` or use `--max-size 0` to disable it", limit),
+	}
+}
+
+// parseRange parses the byte range in the form of `start-end`, where both
+// offsets are inclusive.
+func parseRange(value string) ([2]int64, error) {
+	formatError := func(message string) error {
+		return fmt.Errorf("invalid range %q: %s", value, message)
+	}
+	startStr, endStr, found := strings.Cut(value, "-")
+	if !found {
+		return [2]int64{}, formatError("expecting start-end")
+	}
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 {
+		return [2]int64{}, formatError("expecting a non-negative start offset")
+	}
+	end, err := strconv.ParseInt(endStr, 10, 64)
+	if err != nil || end < start {
+		return [2]int64{}, formatError("expecting an end offset no less than the start offset")
+	}
+	return [2]int64{start, end}, nil
+}
+
+// seekRange seeks rc to the start of byteRange and returns a reader limited
+// to the range along with the size of the range.
+func seekRange(rc io.ReadCloser, desc ocispec.Descriptor, byteRange [2]int64) (io.Reader, int64, error) {
+	start, end := byteRange[0], byteRange[1]
+	if start >= desc.Size {
+		return nil, 0, fmt.Errorf("range start %d exceeds the blob size %d", start, desc.Size)
+	}
+	if end >= desc.Size {
+		end = desc.Size - 1
+	}
+	seeker, ok := rc.(io.Seeker)
+	if !ok {
+		return nil, 0, &oerrors.Error{
+			Err:            errRangeNotSupported,
+			Recommendation: "Please fetch the whole blob without `--range`",
+		}
+	}
+	if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+		return nil, 0, fmt.Errorf("failed to seek to offset %d: %w", start, err)
+	}
+	size := end - start + 1
+	return io.LimitReader(rc, size), size, nil
+}
@@ -20,12 +20,17 @@ package blob
 import (
 	"bytes"
 	"context"
+	"errors"
+	"io"
+	"os"
+	"reflect"
 	"testing"
 
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras/cmd/oras/internal/display/status/console/testutils"
+	oio "oras.land/oras/internal/io"
 )
 
 func Test_fetchBlobOptions_doFetch(t *testing.T) {
@@ -65,3 +70,106 @@ func Test_fetchBlobOptions_doFetch(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func Test_parseRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    [2]int64
+		wantErr bool
+	}{
+		{"valid range", "0-511", [2]int64{0, 511}, false},
+		{"single byte", "3-3", [2]int64{3, 3}, false},
+		{"missing separator", "100", [2]int64{}, true},
+		{"missing end", "100-", [2]int64{}, true},
+		{"negative start", "-1-3", [2]int64{}, true},
+		{"end before start", "5-3", [2]int64{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRange(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_seekRange(t *testing.T) {
+	content := []byte("hello world")
+	desc := ocispec.Descriptor{
+		MediaType: "application/octet-stream",
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	path := t.TempDir() + "/blob"
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// test range exceeding the blob size
+	r, size, err := seekRange(f, desc, [2]int64{6, 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 5 {
+		t.Errorf("seekRange() size = %d, want 5", size)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "world" {
+		t.Errorf("seekRange() content = %q, want %q", got, "world")
+	}
+
+	// test range start exceeding the blob size
+	if _, _, err = seekRange(f, desc, [2]int64{11, 12}); err == nil {
+		t.Error("seekRange() expects error but got nil")
+	}
+
+	// test non-seekable content
+	_, _, err = seekRange(io.NopCloser(bytes.NewReader(content)), desc, [2]int64{0, 4})
+	if !errors.Is(err, errRangeNotSupported) {
+		t.Errorf("seekRange() error = %v, want %v", err, errRangeNotSupported)
+	}
+}
+
+func Test_fetchBlobOptions_doFetch_sizeLimitExceeded(t *testing.T) {
+	// prepare
+	src := memory.New()
+	content := []byte("test")
+	desc := ocispec.Descriptor{
+		MediaType: "application/octet-stream",
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	ctx := context.Background()
+	if err := src.Push(ctx, desc, bytes.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+	tag := "blob"
+	if err := src.Tag(ctx, desc, tag); err != nil {
+		t.Fatal(err)
+	}
+	var opts fetchBlobOptions
+	opts.Reference = tag
+	opts.outputPath = t.TempDir() + "/test"
+	opts.maxSize = 3
+	// test
+	_, err := opts.doFetch(ctx, src)
+	if !errors.Is(err, oio.ErrSizeLimitExceeded) {
+		t.Fatalf("doFetch() error = %v, want %v", err, oio.ErrSizeLimitExceeded)
+	}
+	if _, err := os.Stat(opts.outputPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expect output file not to exist but got stat error %v", err)
+	}
+}
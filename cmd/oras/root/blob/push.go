@@ -18,14 +18,18 @@ package blob
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote/errcode"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
 	"oras.land/oras/cmd/oras/internal/display/status/track"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
@@ -36,12 +40,14 @@ import (
 type pushBlobOptions struct {
 	option.Common
 	option.Descriptor
+	option.Format
 	option.Pretty
 	option.Target
 
 	fileRef   string
 	mediaType string
 	size      int64
+	digest    string
 }
 
 func pushCmd() *cobra.Command {
@@ -57,9 +63,15 @@ Example - Push blob 'hi.txt' to a registry:
 Example - Push blob 'hi.txt' with the specific digest:
   oras blob push localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5 hi.txt
 
+Example - Push blob from stdin:
+  oras blob push localhost:5000/hello -
+
 Example - Push blob from stdin with blob size and digest:
   oras blob push --size 12 localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5 -
 
+Example - Push a large blob 'layer.tar' with the known digest and size to skip local digest computation:
+  oras blob push --digest sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5 --size 12 localhost:5000/hello layer.tar
+
 Example - Push blob 'hi.txt' and output the descriptor:
   oras blob push --descriptor localhost:5000/hello hi.txt
 
@@ -69,6 +81,9 @@ Example - Push blob 'hi.txt' with the specific returned media type in the descri
 Example - Push blob 'hi.txt' and output the prettified descriptor:
   oras blob push --descriptor --pretty localhost:5000/hello hi.txt
 
+Example - Push blob 'hi.txt' and output the descriptor in JSON format:
+  oras blob push --format json localhost:5000/hello hi.txt
+
 Example - Push blob without TLS:
   oras blob push --insecure localhost:5000/hello hi.txt
 
@@ -83,12 +98,21 @@ Example - Push blob 'hi.txt' into an OCI image layout folder 'layout-dir':
 				if err := option.CheckStdinConflict(cmd.Flags()); err != nil {
 					return err
 				}
-				if opts.size < 0 {
-					return errors.New("`--size` must be provided if the blob is read from stdin")
+			}
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "format", "descriptor"); err != nil {
+				return err
+			}
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			if opts.digest != "" {
+				if opts.Reference != "" && opts.Reference != opts.digest {
+					return fmt.Errorf("digest %s provided by `--digest` does not match the digest %s in the reference", opts.digest, opts.Reference)
 				}
+				opts.Reference = opts.digest
 			}
-			opts.Verbose = opts.Verbose && !opts.OutputDescriptor
-			return option.Parse(cmd, &opts)
+			opts.Verbose = opts.Verbose && !opts.OutputDescriptor && opts.Format.Type == option.FormatTypeText.Name
+			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return pushBlob(cmd, &opts)
@@ -96,13 +120,20 @@ Example - Push blob 'hi.txt' into an OCI image layout folder 'layout-dir':
 	}
 
 	cmd.Flags().Int64VarP(&opts.size, "size", "", -1, "provide the blob size")
+	cmd.Flags().StringVarP(&opts.digest, "digest", "", "", "provide the blob digest to skip local digest computation")
 	cmd.Flags().StringVarP(&opts.mediaType, "media-type", "", ocispec.MediaTypeImageLayer, "specify the returned media type in the descriptor if --descriptor is used")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
 func pushBlob(cmd *cobra.Command, opts *pushBlobOptions) (err error) {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	metadataHandler, err := display.NewBlobPushHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
 
 	target, err := opts.NewTarget(opts.Common, logger)
 	if err != nil {
@@ -124,6 +155,13 @@ func pushBlob(cmd *cobra.Command, opts *pushBlobOptions) (err error) {
 		err = opts.PrintStatus(desc, "Exists")
 	} else {
 		err = opts.doPush(ctx, opts.Printer, target, desc, rc)
+		if opts.Reference != "" && isContentMismatch(err) {
+			// digest is provided by the user and verified by the target
+			return &oerrors.Error{
+				Err:            fmt.Errorf("provided digest %s does not match content: %w", desc.Digest, err),
+				Recommendation: "Please check the values of the provided digest and size, or omit them to compute locally",
+			}
+		}
 	}
 	if err != nil {
 		return err
@@ -137,11 +175,9 @@ func pushBlob(cmd *cobra.Command, opts *pushBlobOptions) (err error) {
 		return opts.Output(os.Stdout, descJSON)
 	}
 
-	_ = opts.Println("Pushed", opts.AnnotatedReference())
-	_ = opts.Println("Digest:", desc.Digest)
-
-	return nil
+	return metadataHandler.OnBlobPushed(&opts.Target, desc)
 }
+
 func (opts *pushBlobOptions) doPush(ctx context.Context, printer *output.Printer, t oras.Target, desc ocispec.Descriptor, r io.Reader) error {
 	if opts.TTY == nil {
 		// none TTY output
@@ -168,3 +204,22 @@ func (opts *pushBlobOptions) doPush(ctx context.Context, printer *output.Printer
 	trackedReader.Done()
 	return nil
 }
+
+// isContentMismatch returns true if err indicates that the pushed content does
+// not match the provided digest or size.
+func isContentMismatch(err error) bool {
+	if errors.Is(err, content.ErrMismatchedDigest) || errors.Is(err, content.ErrTrailingData) {
+		return true
+	}
+	var errResp *errcode.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+	for _, e := range errResp.Errors {
+		switch e.Code {
+		case errcode.ErrorCodeDigestInvalid, errcode.ErrorCodeSizeInvalid:
+			return true
+		}
+	}
+	return false
+}
@@ -20,14 +20,24 @@ package blob
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"oras.land/oras/cmd/oras/internal/output"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry/remote/errcode"
 	"oras.land/oras/cmd/oras/internal/display/status/console/testutils"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 )
 
 func Test_pushBlobOptions_doPush(t *testing.T) {
@@ -58,3 +68,71 @@ func Test_pushBlobOptions_doPush(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func Test_isContentMismatch(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"other error", errors.New("mocked error"), false},
+		{"mismatched digest", fmt.Errorf("push: %w", content.ErrMismatchedDigest), true},
+		{"digest invalid", &errcode.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Errors:     errcode.Errors{{Code: errcode.ErrorCodeDigestInvalid}},
+		}, true},
+		{"denied", &errcode.ErrorResponse{
+			StatusCode: http.StatusForbidden,
+			Errors:     errcode.Errors{{Code: errcode.ErrorCodeDenied}},
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isContentMismatch(tt.err); got != tt.want {
+				t.Errorf("isContentMismatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pushCmd_digestMismatch(t *testing.T) {
+	dir := t.TempDir()
+	blob := []byte("test")
+	path := filepath.Join(dir, "blob")
+	if err := os.WriteFile(path, blob, 0600); err != nil {
+		t.Fatal(err)
+	}
+	wrong := digest.FromBytes([]byte("tset"))
+	layout := filepath.Join(dir, "layout")
+
+	cmd := pushCmd()
+	cmd.SetArgs([]string{"--oci-layout", "--digest", wrong.String(), "--size", strconv.Itoa(len(blob)), layout, path})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SilenceUsage = true
+	err := cmd.Execute()
+	var oErr *oerrors.Error
+	if !errors.As(err, &oErr) {
+		t.Fatalf("blob push error = %v, want an oerrors.Error", err)
+	}
+	if want := "provided digest " + wrong.String() + " does not match content"; !strings.Contains(oErr.Err.Error(), want) {
+		t.Errorf("blob push error = %v, want %q", oErr.Err, want)
+	}
+	if !errors.Is(err, content.ErrMismatchedDigest) {
+		t.Errorf("blob push error = %v, want %v", err, content.ErrMismatchedDigest)
+	}
+	if want := "Please check the values of the provided digest and size, or omit them to compute locally"; oErr.Recommendation != want {
+		t.Errorf("recommendation = %q, want %q", oErr.Recommendation, want)
+	}
+
+	// --digest conflicting with the digest in the reference
+	cmd = pushCmd()
+	cmd.SetArgs([]string{"--oci-layout", "--digest", wrong.String(), layout + "@" + digest.FromBytes(blob).String(), path})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SilenceUsage = true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "does not match the digest") {
+		t.Errorf("blob push error = %v, want the digest conflict", err)
+	}
+}
@@ -17,7 +17,9 @@ package root
 
 import (
 	"github.com/spf13/cobra"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/root/blob"
+	"oras.land/oras/cmd/oras/root/context"
 	"oras.land/oras/cmd/oras/root/manifest"
 	"oras.land/oras/cmd/oras/root/repo"
 )
@@ -25,6 +27,7 @@ import (
 func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "oras [command]",
+		Long:         "Push, pull and manage OCI artifacts in registries and OCI image layouts.\n\n" + oerrors.ExitCodeHelp,
 		SilenceUsage: true,
 	}
 	cmd.AddCommand(
@@ -36,11 +39,14 @@ func New() *cobra.Command {
 		discoverCmd(),
 		resolveCmd(),
 		copyCmd(),
+		backupCmd(),
+		restoreCmd(),
 		tagCmd(),
 		attachCmd(),
 		blob.Cmd(),
 		manifest.Cmd(),
 		repo.Cmd(),
+		context.Cmd(),
 	)
 	return cmd
 }
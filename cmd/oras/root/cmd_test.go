@@ -0,0 +1,97 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+)
+
+func TestNew_canceled(t *testing.T) {
+	// the registry never responds so that only the cancellation aborts
+	// the commands
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := uri.Host + "/test"
+	tempDir := t.TempDir()
+	file := filepath.Join(tempDir, "hello.txt")
+	if err := os.WriteFile(file, []byte("hello"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	digest := "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"attach", []string{"attach", "--plain-http", "--disable-path-validation", "--artifact-type", "application/vnd.test", repo + ":v1", file}},
+		{"blob fetch", []string{"blob", "fetch", "--plain-http", "--output", filepath.Join(tempDir, "blob"), repo + "@" + digest}},
+		{"cp", []string{"cp", "--from-plain-http", "--to-plain-http", repo + ":v1", repo + "-copy:v1"}},
+		{"cp recursive", []string{"cp", "--from-plain-http", "--to-plain-http", "-r", repo + ":v1", repo + "-copy:v1"}},
+		{"discover", []string{"discover", "--plain-http", repo + ":v1"}},
+		{"manifest diff", []string{"manifest", "diff", "--from-plain-http", "--to-plain-http", repo + ":v1", repo + ":v2"}},
+		{"manifest fetch", []string{"manifest", "fetch", "--plain-http", repo + ":v1"}},
+		{"pull", []string{"pull", "--plain-http", "--output", filepath.Join(tempDir, "pulled"), repo + ":v1"}},
+		{"push", []string{"push", "--plain-http", "--disable-path-validation", repo + ":v1", file}},
+		{"repo du", []string{"repo", "du", "--plain-http", repo + ":v1"}},
+		{"repo ls", []string{"repo", "ls", "--plain-http", uri.Host}},
+		{"repo tags", []string{"repo", "tags", "--plain-http", repo}},
+		{"resolve", []string{"resolve", "--plain-http", repo + ":v1"}},
+		{"tag", []string{"tag", "--plain-http", repo + ":v1", "v2"}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := New()
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- cmd.ExecuteContext(ctx)
+			}()
+			select {
+			case err := <-errCh:
+				var canceledErr *oerrors.CanceledError
+				if !errors.As(err, &canceledErr) || !errors.Is(err, context.Canceled) {
+					t.Fatalf("oras %s error = %v, want %v", tt.name, err, context.Canceled)
+				}
+				if got, want := err.Error(), "operation canceled"; got != want {
+					t.Errorf("oras %s error message = %q, want %q", tt.name, got, want)
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatalf("oras %s is not aborted on cancellation", tt.name)
+			}
+		})
+	}
+}
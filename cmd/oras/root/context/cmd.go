@@ -0,0 +1,54 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context [command]",
+		Short: "Inspect the contexts in the config file",
+		Long: `Inspect the contexts in the config file
+
+Contexts are named sets of registry settings defined in the config file
+~/.oras/config.yaml, or the file specified by $ORAS_CONFIG. A context selected
+via --context or $ORAS_CONTEXT provides the defaults of the registry flags,
+which are always overridden by explicitly specified flags. A context with a
+registry only applies to that registry.
+
+Example config file:
+  contexts:
+    local:
+      registry: localhost:5000
+      plainHTTP: true
+    prod:
+      registry: registry.example.com
+      caFile: /etc/oras/ca.pem
+      certFile: /etc/oras/client.pem
+      keyFile: /etc/oras/client-key.pem
+      registryConfig:
+        - /etc/oras/auth.json
+`,
+	}
+
+	cmd.AddCommand(
+		listCmd(),
+		showCmd(),
+	)
+	return cmd
+}
@@ -0,0 +1,73 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/config"
+)
+
+type listOptions struct {
+	option.Common
+	option.Format
+}
+
+func listCmd() *cobra.Command {
+	var opts listOptions
+	cmd := &cobra.Command{
+		Use:   "ls [flags]",
+		Short: "List the contexts in the config file",
+		Long: `List the contexts in the config file
+
+Example - List the contexts:
+  oras context ls
+
+Example - List the contexts in JSON format:
+  oras context ls --format json
+`,
+		Args:    cobra.NoArgs,
+		Aliases: []string{"list"},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listContexts(&opts)
+		},
+	}
+
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
+
+func listContexts(opts *listOptions) error {
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		return err
+	}
+	handler, err := display.NewContextHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+	contexts := make([]model.Context, 0, len(cfg.Contexts))
+	for _, ctx := range cfg.ContextList() {
+		contexts = append(contexts, model.NewContext(ctx))
+	}
+	return handler.OnContextList(contexts)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/config"
+)
+
+type showOptions struct {
+	option.Common
+	option.Format
+	name string
+}
+
+func showCmd() *cobra.Command {
+	var opts showOptions
+	cmd := &cobra.Command{
+		Use:   "show [flags] [<name>]",
+		Short: "Show the settings of a context",
+		Long: `Show the settings of a context in the config file
+
+Example - Show the settings of a context:
+  oras context show prod
+
+Example - Show the settings of the context selected by $ORAS_CONTEXT:
+  oras context show
+
+Example - Show the settings of a context in JSON format:
+  oras context show --format json prod
+`,
+		Args: oerrors.CheckArgs(argument.AtMost(1), "the name of the context to show"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.name = args[0]
+			} else if opts.name = os.Getenv(config.ContextEnv); opts.name == "" {
+				return &oerrors.Error{
+					Err:            errors.New("no context specified"),
+					Recommendation: "Please specify the name of the context or set $" + config.ContextEnv,
+				}
+			}
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showContext(&opts)
+		},
+	}
+
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
+
+func showContext(opts *showOptions) error {
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		return err
+	}
+	ctx, err := cfg.Context(opts.name)
+	if err != nil {
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: `Run "oras context ls" to list the contexts in the config file`,
+		}
+	}
+	handler, err := display.NewContextHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+	return handler.OnContext(model.NewContext(ctx))
+}
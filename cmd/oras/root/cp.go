@@ -18,7 +18,10 @@ package root
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"slices"
 	"strings"
 	"sync"
@@ -26,6 +29,7 @@ import (
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/registry"
@@ -34,26 +38,54 @@ import (
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/display/status/progress"
 	"oras.land/oras/cmd/oras/internal/display/status/track"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/descriptor"
 	"oras.land/oras/internal/docker"
 	"oras.land/oras/internal/graph"
+	oio "oras.land/oras/internal/io"
 	"oras.land/oras/internal/listener"
 	"oras.land/oras/internal/registryutil"
+	"oras.land/oras/internal/trace"
 )
 
 type copyOptions struct {
+	option.Cache
 	option.Common
+	option.Format
 	option.Platform
 	option.BinaryTarget
-
-	recursive   bool
-	concurrency int
-	extraRefs   []string
+	option.Validate
+
+	recursive         bool
+	concurrency       int
+	lowMemory         bool
+	force             bool
+	gcFallback        bool
+	precheck          bool
+	extraRefs         []string
+	dockerArchiveName string
+	// excludeReferrerTypes are the artifact types of the referrers excluded
+	// from a recursive copy.
+	excludeReferrerTypes []string
+
+	// transferHandler, if set, is notified of the completion state of each
+	// descriptor copied to the destination.
+	transferHandler metadata.TransferHandler
+	// traversalHandler, if set, is notified of the nodes touched by the copy.
+	traversalHandler metadata.TraversalHandler
 }
 
+// lowMemoryPredecessorCacheSize is the maximum number of nodes whose
+// predecessors are cached with --low-memory.
+const lowMemoryPredecessorCacheSize = 256
+
 func copyCmd() *cobra.Command {
 	var opts copyOptions
 	cmd := &cobra.Command{
@@ -65,6 +97,15 @@ func copyCmd() *cobra.Command {
 Example - Copy an artifact between registries:
   oras cp localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
 
+Example - Copy an artifact even if the destination tag already points at it:
+  oras cp --force localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an artifact between registries behind gateways requiring custom headers, redacting the API key in debug logs:
+  oras cp --from-header "X-Api-Key: $API_KEY" --to-header "X-Tenant-Id: team-a" --redact-header X-Api-Key localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an image from Docker Hub referenced by a docker-style short name:
+  oras cp --docker-compat alpine:3.19 localhost:5000/alpine:3.19
+
 Example - Download an artifact into an OCI image layout folder:
   oras cp --to-oci-layout localhost:5000/net-monitor:v1 ./downloaded:v1
 
@@ -74,6 +115,18 @@ Example - Upload an artifact from an OCI image layout folder:
 Example - Upload an artifact from an OCI layout tar archive:
   oras cp --from-oci-layout ./to-upload.tar:v1 localhost:5000/net-monitor:v1
 
+Example - [Experimental] Upload an image from a tarball created by "docker save":
+  oras cp --from-docker-archive ./image.tar localhost:5000/net-monitor:v1
+
+Example - [Experimental] Upload an image selected by name from a tarball containing multiple images:
+  oras cp --from-docker-archive --name net-monitor:v1 ./images.tar localhost:5000/net-monitor:v1
+
+Example - [Experimental] Download an image as a tarball to be loaded by "docker load":
+  oras cp --to-docker-archive localhost:5000/net-monitor:v1 ./image.tar
+
+Example - [Experimental] Download the image of a platform as a tarball with the image name specified:
+  oras cp --to-docker-archive --platform linux/amd64 --name net-monitor:v1 localhost:5000/net-monitor:v1 ./image.tar
+
 Example - Copy an artifact and its referrers:
   oras cp -r localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
 
@@ -89,13 +142,61 @@ Example - Copy an artifact with multiple tags:
 
 Example - Copy an artifact with multiple tags with concurrency tuned:
   oras cp --concurrency 10 localhost:5000/net-monitor:v1 localhost:5000/net-monitor-copy:tag1,tag2,tag3
+
+Example - [Preview] Copy an artifact and its referrers except signatures and their own referrers:
+  oras cp -r --exclude-referrer-type application/vnd.dev.cosign.artifact.sig.v1+json localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an artifact and its referrers, and clean up stale entries in the fallback referrers indexes of the destination:
+  oras cp -r --gc-fallback localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an artifact to a mostly synced mirror, checking the existing content concurrently first:
+  oras cp --precheck --concurrency 10 localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an artifact, failing if any manifest violates the OCI specification constraints:
+  oras cp --validate error localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an artifact and its referrers of a very large graph with bounded memory usage:
+  oras cp -r --low-memory localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an artifact and output the result in JSON format:
+  oras cp --format json localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an artifact and output the digest of the copied artifact using a Go template:
+  oras cp --format go-template='{{.digest}}' localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(2), "the source and destination for copying"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			opts.From.RawReference = args[0]
-			refs := strings.Split(args[1], ",")
-			opts.To.RawReference = refs[0]
-			opts.extraRefs = refs[1:]
+			if opts.To.IsDockerArchive {
+				opts.To.RawReference = args[1]
+			} else {
+				refs := strings.Split(args[1], ",")
+				opts.To.RawReference = refs[0]
+				opts.extraRefs = refs[1:]
+			}
+			switch {
+			case opts.dockerArchiveName == "":
+			case opts.From.IsDockerArchive && opts.To.IsDockerArchive:
+				return errors.New("--from-docker-archive and --to-docker-archive cannot be used together")
+			case opts.From.IsDockerArchive:
+				opts.From.DockerArchiveImage = opts.dockerArchiveName
+			case opts.To.IsDockerArchive:
+				opts.To.DockerArchiveImage = opts.dockerArchiveName
+			default:
+				return &oerrors.Error{
+					Err:            errors.New("--name can only be used with --from-docker-archive or --to-docker-archive"),
+					Recommendation: "Use --from-docker-archive to copy from a tarball created by \"docker save\"",
+				}
+			}
+			if opts.To.IsDockerArchive && opts.recursive {
+				return errors.New("referrers cannot be copied to a docker archive")
+			}
+			if len(opts.excludeReferrerTypes) > 0 && !opts.recursive {
+				return &oerrors.Error{
+					Err:            errors.New("--exclude-referrer-type can only be used with --recursive"),
+					Recommendation: "Use --recursive to copy the artifact with its referrers except the excluded ones",
+				}
+			}
 			return option.Parse(cmd, &opts)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -104,15 +205,37 @@ Example - Copy an artifact with multiple tags with concurrency tuned:
 	}
 	cmd.Flags().BoolVarP(&opts.recursive, "recursive", "r", false, "[Preview] recursively copy the artifact and its referrer artifacts")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "copy even if the destination is already up to date")
+	cmd.Flags().BoolVar(&opts.lowMemory, "low-memory", false, "[Preview] bound the memory usage for very large graphs by tracking copied content in temporary files, at the cost of speed")
+	cmd.Flags().BoolVar(&opts.precheck, "precheck", false, "[Preview] check the existence of all content in the destination concurrently before copying, and only copy the missing content")
+	cmd.Flags().BoolVar(&opts.gcFallback, "gc-fallback", false, "[Preview] remove the entries of nonexistent manifests from the referrers tag schema indexes updated in the destination")
+	cmd.Flags().StringArrayVar(&opts.excludeReferrerTypes, "exclude-referrer-type", nil, "[Preview] artifact `type` of the referrers to be excluded together with their own referrers from a recursive copy, can be specified multiple times")
 	opts.EnableDistributionSpecFlag()
+	opts.From.EnableMirrorFlag()
+	opts.From.EnableDockerArchiveFlag()
+	opts.To.EnableDockerArchiveFlag()
+	opts.From.EnableVerifyTagFlag()
+	cmd.Flags().StringVar(&opts.dockerArchiveName, "name", "", "[Experimental] `name` of the image to copy from a docker archive containing multiple images, or of the image written to a docker archive, e.g. hello:v1")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.BinaryTarget.CompleteReference
 	return oerrors.Command(cmd, &opts.BinaryTarget)
 }
 
 func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	opts.Platform.MaxConfigBytes = opts.From.MaxMetadataBytes
+	handler, err := display.NewCopyHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+	opts.transferHandler = handler
+	if opts.Format.Type != option.FormatTypeText.Name {
+		opts.traversalHandler = handler
+	}
 
 	// Prepare source
+	ctx = opts.WithSharedScopeHint(ctx)
 	src, err := opts.From.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
@@ -120,6 +243,20 @@ func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 	if err := opts.EnsureSourceTargetReferenceNotEmpty(cmd); err != nil {
 		return err
 	}
+	if err := opts.From.VerifyTag(ctx, src); err != nil {
+		return err
+	}
+
+	if opts.To.IsDockerArchive {
+		desc, err := copyToDockerArchive(ctx, opts.Printer, src, opts)
+		if err != nil {
+			return err
+		}
+		if err := handler.OnCopied(&opts.BinaryTarget, desc); err != nil {
+			return err
+		}
+		return handler.OnCompleted(desc)
+	}
 
 	// Prepare destination
 	dst, err := opts.To.NewTarget(opts.Common, logger)
@@ -128,40 +265,69 @@ func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 	}
 	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
 
-	desc, err := doCopy(ctx, opts.Printer, src, dst, opts)
-	if err != nil {
-		return err
+	var desc ocispec.Descriptor
+	upToDate := false
+	if !opts.force {
+		if desc, upToDate, err = checkUpToDate(ctx, src, dst, opts); err != nil {
+			return err
+		}
+	}
+	if !upToDate {
+		if desc, err = doCopy(ctx, opts.Printer, src, dst, opts); err != nil {
+			return err
+		}
 	}
 
 	if from, err := digest.Parse(opts.From.Reference); err == nil && from != desc.Digest {
 		// correct source digest
 		opts.From.RawReference = fmt.Sprintf("%s@%s", opts.From.Path, desc.Digest.String())
 	}
-	_ = opts.Println("Copied", opts.From.AnnotatedReference(), "=>", opts.To.AnnotatedReference())
+	if upToDate {
+		if err := handler.OnUpToDate(&opts.BinaryTarget, desc); err != nil {
+			return err
+		}
+		return handler.OnCompleted(desc)
+	}
+	if err := handler.OnCopied(&opts.BinaryTarget, desc); err != nil {
+		return err
+	}
 
 	if len(opts.extraRefs) != 0 {
 		tagNOpts := oras.DefaultTagNOptions
 		tagNOpts.Concurrency = opts.concurrency
-		handler := display.NewCopyHandler(opts.Printer)
 		tagListener := listener.NewTaggedListener(dst, handler.OnTagged)
 		if _, err = oras.TagN(ctx, tagListener, opts.To.Reference, opts.extraRefs, tagNOpts); err != nil {
 			return err
 		}
 	}
 
-	_ = opts.Println("Digest:", desc.Digest)
-
-	return nil
+	return handler.OnCompleted(desc)
 }
 
 func doCopy(ctx context.Context, printer *output.Printer, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, opts *copyOptions) (ocispec.Descriptor, error) {
 	// Prepare copy options
-	committed := &sync.Map{}
+	committed := output.NewCommittedSet()
+	predecessorCacheSize := graph.DefaultPredecessorCacheSize
+	if opts.lowMemory {
+		diskCommitted, err := output.NewDiskCommittedSet("")
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		defer diskCommitted.Close()
+		committed = diskCommitted
+		predecessorCacheSize = lowMemoryPredecessorCacheSize
+	}
 	extendedCopyOptions := oras.DefaultExtendedCopyOptions
 	extendedCopyOptions.Concurrency = opts.concurrency
-	extendedCopyOptions.FindPredecessors = func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
-		return registry.Referrers(ctx, src, desc, "")
-	}
+	exclusion := newReferrerExclusion(opts.excludeReferrerTypes)
+	// overlapping subgraphs share predecessors, which are fetched only once
+	extendedCopyOptions.FindPredecessors = graph.CachePredecessors(func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		referrers, err := registry.Referrers(ctx, src, desc, "")
+		if err != nil {
+			return nil, err
+		}
+		return exclusion.filter(referrers), nil
+	}, predecessorCacheSize)
 
 	const (
 		promptExists  = "Exists "
@@ -177,24 +343,45 @@ func doCopy(ctx context.Context, printer *output.Printer, src oras.ReadOnlyGraph
 			return []string{srcRepo.Reference.Repository}, nil
 		}
 	}
+	if srcIsRemote {
+		cached, err := opts.CachedGraphTarget(src)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		src = cached
+	}
+	phase := &copyPhase{ctx: ctx, tty: opts.TTY}
+	defer phase.end()
 	if opts.TTY == nil {
-		// none TTY output
+		// none TTY output, status is only printed in text format
+		if opts.Format.Type != option.FormatTypeText.Name {
+			printer = output.NewPrinter(io.Discard, io.Discard, false)
+		}
 		extendedCopyOptions.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
-			committed.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
-			return printer.PrintStatus(desc, promptExists)
+			phase.end()
+			if err := committed.Store(desc); err != nil {
+				return err
+			}
+			return printer.PrintStatusOnce(desc, promptExists)
 		}
 		extendedCopyOptions.PreCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+			phase.end()
 			return printer.PrintStatus(desc, promptCopying)
 		}
 		extendedCopyOptions.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
-			committed.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
+			if err := committed.Store(desc); err != nil {
+				return err
+			}
 			if err := output.PrintSuccessorStatus(ctx, desc, dst, committed, printer.StatusPrinter(promptSkipped)); err != nil {
 				return err
 			}
 			return printer.PrintStatus(desc, promptCopied)
 		}
 		extendedCopyOptions.OnMounted = func(ctx context.Context, desc ocispec.Descriptor) error {
-			committed.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
+			phase.end()
+			if err := committed.Store(desc); err != nil {
+				return err
+			}
 			return printer.PrintStatus(desc, promptMounted)
 		}
 	} else {
@@ -206,91 +393,502 @@ func doCopy(ctx context.Context, printer *output.Printer, src oras.ReadOnlyGraph
 		defer tracked.Close()
 		dst = tracked
 		extendedCopyOptions.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
-			committed.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
+			phase.end()
+			if err := committed.Store(desc); err != nil {
+				return err
+			}
 			return tracked.Prompt(desc, promptExists)
 		}
+		extendedCopyOptions.PreCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+			phase.end()
+			return nil
+		}
 		extendedCopyOptions.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
-			committed.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
+			if err := committed.Store(desc); err != nil {
+				return err
+			}
 			return output.PrintSuccessorStatus(ctx, desc, tracked, committed, func(desc ocispec.Descriptor) error {
 				return tracked.Prompt(desc, promptSkipped)
 			})
 		}
 		extendedCopyOptions.OnMounted = func(ctx context.Context, desc ocispec.Descriptor) error {
-			committed.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
+			phase.end()
+			if err := committed.Store(desc); err != nil {
+				return err
+			}
 			return tracked.Prompt(desc, promptMounted)
 		}
 	}
 
+	if opts.Validate.Enabled() {
+		preCopy := extendedCopyOptions.PreCopy
+		extendedCopyOptions.PreCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+			if descriptor.IsManifest(desc) {
+				if err := validateManifest(ctx, src, desc, &opts.Validate); err != nil {
+					return err
+				}
+			}
+			return preCopy(ctx, desc)
+		}
+	}
+
+	var subjects *subjectRecorder
+	if opts.gcFallback && dstIsRemote {
+		subjects = &subjectRecorder{fetcher: src}
+		postCopy := extendedCopyOptions.PostCopy
+		extendedCopyOptions.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+			if err := postCopy(ctx, desc); err != nil {
+				return err
+			}
+			return subjects.record(ctx, desc)
+		}
+	}
+
+	if opts.transferHandler != nil {
+		reportTransfers(&extendedCopyOptions.CopyGraphOptions, opts.transferHandler, model.TransferStateCopied)
+	}
+	var traversal *traversalRecorder
+	if opts.traversalHandler != nil {
+		traversal = newTraversalRecorder(src)
+		traversal.hook(&extendedCopyOptions.CopyGraphOptions)
+	}
+
 	var desc ocispec.Descriptor
 	var err error
-	rOpts := oras.DefaultResolveOptions
-	rOpts.TargetPlatform = opts.Platform.Platform
+	if opts.precheck {
+		phase.start("Checking destination")
+		root, err := opts.Platform.Resolve(ctx, src, opts.From.Reference)
+		if err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
+		}
+		result, err := precheck(ctx, src, dst, root, opts.concurrency)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		phase.end()
+		if err := printer.Println(result); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		dst = newPrecheckedTarget(dst, result)
+	}
+	phase.start("Resolving source")
 	if opts.recursive {
-		desc, err = oras.Resolve(ctx, src, opts.From.Reference, rOpts)
+		desc, err = opts.Platform.Resolve(ctx, src, opts.From.Reference)
 		if err != nil {
 			return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
 		}
+		phase.start("Enumerating referrers")
 		err = recursiveCopy(ctx, src, dst, opts.To.Reference, desc, extendedCopyOptions)
 	} else {
 		if opts.To.Reference == "" {
-			desc, err = oras.Resolve(ctx, src, opts.From.Reference, rOpts)
+			desc, err = opts.Platform.Resolve(ctx, src, opts.From.Reference)
 			if err != nil {
 				return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
 			}
+			if extendedCopyOptions.CopyGraphOptions, err = copyPlatforms(ctx, src, dst, desc, extendedCopyOptions.CopyGraphOptions); err != nil {
+				return ocispec.Descriptor{}, err
+			}
 			err = oras.CopyGraph(ctx, src, dst, desc, extendedCopyOptions.CopyGraphOptions)
 		} else {
+			if opts.Platform.Platform == nil {
+				// platforms of an index are copied in parallel
+				root, err := opts.Platform.Resolve(ctx, src, opts.From.Reference)
+				if err != nil {
+					return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
+				}
+				if extendedCopyOptions.CopyGraphOptions, err = copyPlatforms(ctx, src, dst, root, extendedCopyOptions.CopyGraphOptions); err != nil {
+					return ocispec.Descriptor{}, err
+				}
+			}
 			copyOptions := oras.CopyOptions{
 				CopyGraphOptions: extendedCopyOptions.CopyGraphOptions,
 			}
-			if opts.Platform.Platform != nil {
-				copyOptions.WithTargetPlatform(opts.Platform.Platform)
-			}
+			opts.Platform.UpdateCopyOptions(&copyOptions)
 			desc, err = oras.Copy(ctx, src, opts.From.Reference, dst, opts.To.Reference, copyOptions)
 		}
 	}
+	phase.end()
+	if err == nil && traversal != nil {
+		err = opts.traversalHandler.OnTraversed(traversal.list())
+	}
+	if err == nil && exclusion.count() > 0 {
+		err = printer.Println(exclusion)
+	}
+	if err == nil && subjects != nil {
+		for _, subject := range subjects.list() {
+			if err = pruneReferrersIndex(ctx, printer, dstRepo, subject); err != nil {
+				break
+			}
+		}
+	}
 	return desc, err
 }
 
+// validateManifest validates the manifest described by desc in src. The
+// content is read as is, so that its inconsistency with desc is reported as a
+// violation instead of a fetch error.
+func validateManifest(ctx context.Context, src content.Fetcher, desc ocispec.Descriptor, opts *option.Validate) error {
+	rc, err := src.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	// read one more byte to detect content larger than desc
+	manifestBytes, err := io.ReadAll(io.LimitReader(rc, desc.Size+1))
+	if err != nil {
+		return err
+	}
+	return opts.ValidateManifest(trace.Logger(ctx), desc, manifestBytes)
+}
+
+// subjectRecorder records the subjects of the copied manifests.
+type subjectRecorder struct {
+	fetcher  content.Fetcher
+	lock     sync.Mutex
+	subjects []ocispec.Descriptor
+	seen     map[digest.Digest]bool
+}
+
+// record records the subject of desc if desc is a manifest with a subject.
+func (r *subjectRecorder) record(ctx context.Context, desc ocispec.Descriptor) error {
+	_, subject, _, err := graph.Successors(ctx, r.fetcher, desc)
+	if err != nil || subject == nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.seen == nil {
+		r.seen = make(map[digest.Digest]bool)
+	}
+	if !r.seen[subject.Digest] {
+		r.seen[subject.Digest] = true
+		r.subjects = append(r.subjects, *subject)
+	}
+	return nil
+}
+
+// list returns the recorded subjects in the order they are recorded.
+func (r *subjectRecorder) list() []ocispec.Descriptor {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]ocispec.Descriptor(nil), r.subjects...)
+}
+
+// checkUpToDate resolves the source and checks whether the destination is
+// already up to date, i.e. all destination tags point at the source digest, or
+// the source digest exists in the destination if no tag is specified. With
+// --recursive, the referrers of the source must also exist in the destination.
+// Failures on resolving the destination are treated as not up to date unless
+// ctx is canceled.
+func checkUpToDate(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.ReadOnlyTarget, opts *copyOptions) (ocispec.Descriptor, bool, error) {
+	logger := trace.Logger(ctx)
+	desc, err := opts.Platform.Resolve(ctx, src, opts.From.Reference)
+	if err != nil {
+		return ocispec.Descriptor{}, false, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
+	}
+
+	if opts.To.Reference == "" {
+		exists, err := dst.Exists(ctx, desc)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ocispec.Descriptor{}, false, ctxErr
+			}
+			logger.Debugf("failed to check the existence of %s in the destination: %v", desc.Digest, err)
+			return desc, false, nil
+		}
+		if !exists {
+			return desc, false, nil
+		}
+	} else {
+		for _, ref := range append([]string{opts.To.Reference}, opts.extraRefs...) {
+			got, err := dst.Resolve(ctx, ref)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return ocispec.Descriptor{}, false, ctxErr
+				}
+				logger.Debugf("failed to resolve %s in the destination: %v", ref, err)
+				return desc, false, nil
+			}
+			if got.Digest != desc.Digest {
+				return desc, false, nil
+			}
+		}
+	}
+	if !opts.recursive {
+		return desc, true, nil
+	}
+	copied, err := referrersExist(ctx, src, dst, desc, newReferrerExclusion(opts.excludeReferrerTypes))
+	return desc, copied, err
+}
+
+// referrersExist checks whether the referrers of root, and of its manifests if
+// root is an index, recursively exist in dst. The referrers excluded by exclusion
+// are not checked.
+func referrersExist(ctx context.Context, src content.ReadOnlyGraphStorage, dst content.ReadOnlyStorage, root ocispec.Descriptor, exclusion *referrerExclusion) (bool, error) {
+	nodes := []ocispec.Descriptor{root}
+	if root.MediaType == ocispec.MediaTypeImageIndex || root.MediaType == docker.MediaTypeManifestList {
+		manifests, err := content.Successors(ctx, src, root)
+		if err != nil {
+			return false, err
+		}
+		nodes = append(nodes, manifests...)
+	}
+	visited := make(map[digest.Digest]bool)
+	for len(nodes) > 0 {
+		node := nodes[len(nodes)-1]
+		nodes = nodes[:len(nodes)-1]
+		if visited[node.Digest] {
+			continue
+		}
+		visited[node.Digest] = true
+		referrers, err := registry.Referrers(ctx, src, node, "")
+		if err != nil {
+			return false, err
+		}
+		referrers = slices.DeleteFunc(referrers, exclusion.excludes)
+		for _, referrer := range referrers {
+			exists, err := dst.Exists(ctx, referrer)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return false, ctxErr
+				}
+				trace.Logger(ctx).Debugf("failed to check the existence of referrer %s in the destination: %v", referrer.Digest, err)
+				return false, nil
+			}
+			if !exists {
+				return false, nil
+			}
+		}
+		nodes = append(nodes, referrers...)
+	}
+	return true, nil
+}
+
+// copyToDockerArchive copies the image of the source reference to a docker
+// archive.
+func copyToDockerArchive(ctx context.Context, printer *output.Printer, src oras.ReadOnlyGraphTarget, opts *copyOptions) (ocispec.Descriptor, error) {
+	desc, err := opts.Platform.Resolve(ctx, src, opts.From.Reference)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
+	}
+
+	writeOpts := docker.ArchiveWriteOptions{
+		PreCopy: func(ctx context.Context, desc ocispec.Descriptor) error {
+			return printer.PrintStatus(desc, "Copying")
+		},
+		PostCopy: func(ctx context.Context, desc ocispec.Descriptor) error {
+			return printer.PrintStatus(desc, "Copied ")
+		},
+	}
+	switch {
+	case opts.To.DockerArchiveImage != "":
+		writeOpts.RepoTags = []string{opts.To.DockerArchiveImage}
+	case opts.From.Type == option.TargetTypeRemote && !contentutil.IsDigest(opts.From.Reference):
+		writeOpts.RepoTags = []string{opts.From.Path + ":" + opts.From.Reference}
+	}
+	err = oio.WriteFileAtomic(opts.To.Path, func(w io.Writer) error {
+		return docker.WriteArchive(ctx, w, src, desc, writeOpts)
+	})
+	switch {
+	case errors.Is(err, docker.ErrIndex):
+		return ocispec.Descriptor{}, &oerrors.Error{
+			Err:            fmt.Errorf("%s is a multi-platform image: %w", opts.From.RawReference, err),
+			Recommendation: "Please select the image of a platform with --platform, e.g. --platform linux/amd64",
+		}
+	case errors.Is(err, docker.ErrNotImage):
+		return ocispec.Descriptor{}, &oerrors.Error{
+			Err:            err,
+			Recommendation: `Docker archives can only hold container images. To download the files of an artifact, use "oras pull"`,
+		}
+	case err != nil:
+		return ocispec.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// copyPhase shows the current phase of a copy until the first status is
+// printed. The phase is rendered with a spinner on TTY, or logged at info level
+// otherwise.
+type copyPhase struct {
+	ctx  context.Context
+	tty  *os.File
+	stop func()
+}
+
+// start ends the current phase and starts a new one.
+func (p *copyPhase) start(label string) {
+	p.end()
+	if p.tty == nil {
+		trace.Logger(p.ctx).Info(label)
+		p.stop = func() {}
+		return
+	}
+	p.stop = progress.StartPhase(p.tty, label+"…").Stop
+}
+
+// end ends the current phase if any. It is safe for concurrent use once the
+// phase is started.
+func (p *copyPhase) end() {
+	if p.stop != nil {
+		p.stop()
+	}
+}
+
 // recursiveCopy copies an artifact and its referrers from one target to another.
 // If the artifact is a manifest list or index, referrers of its manifests are copied as well.
+// The artifact is copied first, and then the subtrees rooted by its direct
+// referrers are copied concurrently as they are independent of each other.
 func recursiveCopy(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.Target, dstRef string, root ocispec.Descriptor, opts oras.ExtendedCopyOptions) error {
+	subjects := []ocispec.Descriptor{root}
 	if root.MediaType == ocispec.MediaTypeImageIndex || root.MediaType == docker.MediaTypeManifestList {
 		fetched, err := content.FetchAll(ctx, src, root)
 		if err != nil {
 			return err
 		}
 		var index ocispec.Index
-		if err = json.Unmarshal(fetched, &index); err != nil {
-			return nil
+		if err = json.Unmarshal(fetched, &index); err == nil {
+			subjects = append(subjects, index.Manifests...)
+		}
+	}
+	if opts.FindPredecessors == nil {
+		opts.FindPredecessors = func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+			return src.Predecessors(ctx, desc)
 		}
+	}
+	referrers, err := graph.FindPredecessors(ctx, src, subjects, opts)
+	if err != nil {
+		return err
+	}
+	referrers = slices.DeleteFunc(referrers, func(desc ocispec.Descriptor) bool {
+		return content.Equal(desc, root)
+	})
 
-		referrers, err := graph.FindPredecessors(ctx, src, index.Manifests, opts)
-		if err != nil {
-			return err
+	// subjects are copied before their referrers
+	copyGraphOptions, err := copyPlatforms(ctx, src, dst, root, opts.CopyGraphOptions)
+	if err != nil {
+		return err
+	}
+	if err := oras.CopyGraph(ctx, src, dst, root, copyGraphOptions); err != nil {
+		return err
+	}
+	if err := copyReferrers(ctx, src, dst, referrers, opts); err != nil {
+		return err
+	}
+	if dstRef == "" || dstRef == root.Digest.String() {
+		return nil
+	}
+	return dst.Tag(ctx, root, dstRef)
+}
+
+// copyReferrers copies the subtrees rooted by referrers concurrently with a
+// bounded number of workers, sharing the concurrency budget of opts. Errors of
+// all subtrees are aggregated.
+func copyReferrers(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.Target, referrers []ocispec.Descriptor, opts oras.ExtendedCopyOptions) error {
+	if len(referrers) == 0 {
+		return nil
+	}
+	workers := min(len(referrers), max(opts.Concurrency, 1))
+	subtreeOpts := opts.ExtendedCopyGraphOptions
+	if opts.Concurrency > 0 {
+		subtreeOpts.Concurrency = max(opts.Concurrency/workers, 1)
+	}
+
+	var g errgroup.Group
+	g.SetLimit(workers)
+	var mu sync.Mutex
+	var errs []error
+	dispatched := make(map[digest.Digest]bool)
+	for _, referrer := range referrers {
+		if dispatched[referrer.Digest] {
+			continue
 		}
-		referrers = slices.DeleteFunc(referrers, func(desc ocispec.Descriptor) bool {
-			return content.Equal(desc, root)
+		dispatched[referrer.Digest] = true
+		g.Go(func() error {
+			if err := oras.ExtendedCopyGraph(ctx, src, dst, referrer, subtreeOpts); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				errs = append(errs, fmt.Errorf("failed to copy referrer %s: %w", referrer.Digest, err))
+			}
+			return nil
 		})
+	}
+	_ = g.Wait()
+	return errors.Join(errs...)
+}
 
-		findPredecessor := opts.FindPredecessors
-		opts.FindPredecessors = func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
-			descs, err := findPredecessor(ctx, src, desc)
-			if err != nil {
-				return nil, err
+// copyPlatforms copies the subtrees rooted by the manifests of the index root
+// concurrently with a bounded number of workers, sharing the concurrency budget
+// of opts, so that the platforms of a multi-platform image are copied in
+// parallel. Errors of all subtrees are aggregated, identifying the platforms.
+// The returned options copy the remaining graph of root, including root
+// itself, which is pushed after all its manifests exist.
+func copyPlatforms(ctx context.Context, src content.ReadOnlyStorage, dst content.Storage, root ocispec.Descriptor, opts oras.CopyGraphOptions) (oras.CopyGraphOptions, error) {
+	if root.MediaType != ocispec.MediaTypeImageIndex && root.MediaType != docker.MediaTypeManifestList {
+		return opts, nil
+	}
+	if exists, err := dst.Exists(ctx, root); err != nil || exists {
+		// the graph of an existing root is not copied
+		return opts, err
+	}
+	fetched, err := content.FetchAll(ctx, src, root)
+	if err != nil {
+		return opts, err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(fetched, &index); err != nil || len(index.Manifests) < 2 {
+		return opts, nil
+	}
+
+	workers := min(len(index.Manifests), max(opts.Concurrency, 1))
+	subtreeOpts := opts
+	if opts.Concurrency > 0 {
+		subtreeOpts.Concurrency = max(opts.Concurrency/workers, 1)
+	}
+	var g errgroup.Group
+	g.SetLimit(workers)
+	var mu sync.Mutex
+	var errs []error
+	dispatched := make(map[digest.Digest]bool)
+	for _, manifest := range index.Manifests {
+		if dispatched[manifest.Digest] {
+			continue
+		}
+		dispatched[manifest.Digest] = true
+		g.Go(func() error {
+			if err := oras.CopyGraph(ctx, src, dst, manifest, subtreeOpts); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				errs = append(errs, fmt.Errorf("failed to copy the manifest %s for platform %s: %w", manifest.Digest, platformName(manifest.Platform), err))
 			}
-			if content.Equal(desc, root) {
-				// make sure referrers of child manifests are copied by pointing them to root
-				descs = append(descs, referrers...)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return opts, err
+	}
+
+	// the copied manifests are reported as skipped when copying root
+	if onCopySkipped := opts.OnCopySkipped; onCopySkipped != nil {
+		opts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+			if dispatched[desc.Digest] {
+				return nil
 			}
-			return descs, nil
+			return onCopySkipped(ctx, desc)
 		}
 	}
+	return opts, nil
+}
 
-	var err error
-	if dstRef == "" || dstRef == root.Digest.String() {
-		err = oras.ExtendedCopyGraph(ctx, src, dst, root, opts.ExtendedCopyGraphOptions)
-	} else {
-		_, err = oras.ExtendedCopy(ctx, src, root.Digest.String(), dst, dstRef, opts)
+// platformName returns the platform in the form of <os>/<arch>[/<variant>],
+// or "unknown" if p is nil.
+func platformName(p *ocispec.Platform) string {
+	if p == nil {
+		return "unknown"
+	}
+	name := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		name += "/" + p.Variant
 	}
-	return err
+	return name
 }
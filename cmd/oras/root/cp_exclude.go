@@ -0,0 +1,102 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// referrerExclusion excludes the referrers of certain artifact types from a
+// recursive copy. As the referrers of an excluded referrer are never
+// enumerated, its whole subtree is excluded.
+type referrerExclusion struct {
+	types    []string
+	lock     sync.Mutex
+	excluded map[digest.Digest]string
+}
+
+// newReferrerExclusion returns a referrerExclusion excluding the referrers of
+// types, or nil if types is empty.
+func newReferrerExclusion(types []string) *referrerExclusion {
+	if len(types) == 0 {
+		return nil
+	}
+	return &referrerExclusion{
+		types:    types,
+		excluded: make(map[digest.Digest]string),
+	}
+}
+
+// excludes returns true if referrer is of an excluded artifact type.
+func (e *referrerExclusion) excludes(referrer ocispec.Descriptor) bool {
+	return e != nil && slices.Contains(e.types, referrer.ArtifactType)
+}
+
+// filter removes the referrers of the excluded artifact types from referrers
+// and records them.
+func (e *referrerExclusion) filter(referrers []ocispec.Descriptor) []ocispec.Descriptor {
+	if e == nil {
+		return referrers
+	}
+	return slices.DeleteFunc(referrers, func(referrer ocispec.Descriptor) bool {
+		if !e.excludes(referrer) {
+			return false
+		}
+		e.lock.Lock()
+		e.excluded[referrer.Digest] = referrer.ArtifactType
+		e.lock.Unlock()
+		return true
+	})
+}
+
+// count returns the number of the excluded referrers.
+func (e *referrerExclusion) count() int {
+	if e == nil {
+		return 0
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return len(e.excluded)
+}
+
+// String returns the summary of the excluded referrers, e.g.
+// "Excluded 3 referrers and their referrers: 2 of type application/vnd.a, 1 of type application/vnd.b".
+func (e *referrerExclusion) String() string {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	counts := make(map[string]int)
+	for _, artifactType := range e.excluded {
+		counts[artifactType]++
+	}
+	var byType []string
+	for _, artifactType := range e.types {
+		if n := counts[artifactType]; n > 0 {
+			byType = append(byType, fmt.Sprintf("%d of type %s", n, artifactType))
+			delete(counts, artifactType)
+		}
+	}
+	noun := "referrers and their"
+	if len(e.excluded) == 1 {
+		noun = "referrer and its"
+	}
+	return fmt.Sprintf("Excluded %d %s referrers: %s", len(e.excluded), noun, strings.Join(byType, ", "))
+}
@@ -0,0 +1,160 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/sync/errgroup"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/cmd/oras/internal/display/humanize"
+)
+
+// precheckResult is the result of checking the existence of the nodes of a
+// graph in the destination before copying.
+type precheckResult struct {
+	exists  map[digest.Digest]bool
+	total   int
+	present int
+	// presentSize and missingSize are the total sizes of the present and the
+	// missing nodes.
+	presentSize int64
+	missingSize int64
+}
+
+// String returns the summary of the result.
+func (r *precheckResult) String() string {
+	return fmt.Sprintf("%d of %d blobs already present (%s skipped), %s to copy", r.present, r.total, humanize.Size(r.presentSize), humanize.Size(r.missingSize))
+}
+
+// precheck walks the graph rooted at root in src level by level, and checks
+// the existence of all its nodes in dst with the given concurrency.
+func precheck(ctx context.Context, src content.ReadOnlyStorage, dst content.ReadOnlyStorage, root ocispec.Descriptor, concurrency int) (*precheckResult, error) {
+	result := &precheckResult{exists: make(map[digest.Digest]bool)}
+	seen := map[digest.Digest]bool{root.Digest: true}
+	var lock sync.Mutex
+	for level := []ocispec.Descriptor{root}; len(level) > 0; {
+		var next []ocispec.Descriptor
+		eg, egCtx := errgroup.WithContext(ctx)
+		eg.SetLimit(concurrency)
+		for _, desc := range level {
+			eg.Go(func() error {
+				exists, err := dst.Exists(egCtx, desc)
+				if err != nil {
+					return err
+				}
+				successors, err := content.Successors(egCtx, src, desc)
+				if err != nil {
+					return err
+				}
+				lock.Lock()
+				defer lock.Unlock()
+				result.exists[desc.Digest] = exists
+				result.total++
+				if exists {
+					result.present++
+					result.presentSize += desc.Size
+				} else {
+					result.missingSize += desc.Size
+				}
+				for _, s := range successors {
+					if !seen[s.Digest] {
+						seen[s.Digest] = true
+						next = append(next, s)
+					}
+				}
+				return nil
+			})
+		}
+		if err := eg.Wait(); err != nil {
+			return nil, err
+		}
+		level = next
+	}
+	return result, nil
+}
+
+// newPrecheckedTarget returns a target answering the existence of the content
+// checked by precheck from result instead of querying dst again.
+func newPrecheckedTarget(dst oras.GraphTarget, result *precheckResult) oras.GraphTarget {
+	t := &precheckedTarget{
+		GraphTarget: dst,
+		result:      result,
+	}
+	if _, ok := dst.(registry.ReferencePusher); ok {
+		return &precheckedReferenceTarget{precheckedTarget: t}
+	}
+	return t
+}
+
+type precheckedTarget struct {
+	oras.GraphTarget
+	result *precheckResult
+	// pushed records the nodes pushed or mounted after the precheck, which
+	// exist regardless of the precheck result.
+	pushed sync.Map // map[digest.Digest]struct{}
+}
+
+type precheckedReferenceTarget struct {
+	*precheckedTarget
+}
+
+// Exists returns the existence checked by precheck if any, or queries the
+// base target otherwise.
+func (t *precheckedTarget) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	if _, ok := t.pushed.Load(target.Digest); ok {
+		return true, nil
+	}
+	if exists, ok := t.result.exists[target.Digest]; ok {
+		return exists, nil
+	}
+	return t.GraphTarget.Exists(ctx, target)
+}
+
+// Mount mounts a blob from a specified repository. This method is invoked only
+// by the `*remote.Repository` target.
+func (t *precheckedTarget) Mount(ctx context.Context, desc ocispec.Descriptor, fromRepo string, getContent func() (io.ReadCloser, error)) error {
+	if err := t.GraphTarget.(registry.Mounter).Mount(ctx, desc, fromRepo, getContent); err != nil {
+		return err
+	}
+	t.pushed.Store(desc.Digest, struct{}{})
+	return nil
+}
+
+// Push pushes the content to the base target.
+func (t *precheckedTarget) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	if err := t.GraphTarget.Push(ctx, expected, content); err != nil {
+		return err
+	}
+	t.pushed.Store(expected.Digest, struct{}{})
+	return nil
+}
+
+// PushReference pushes the content to the base target with a reference.
+func (t *precheckedReferenceTarget) PushReference(ctx context.Context, expected ocispec.Descriptor, content io.Reader, reference string) error {
+	if err := t.GraphTarget.(registry.ReferencePusher).PushReference(ctx, expected, content, reference); err != nil {
+		return err
+	}
+	t.pushed.Store(expected.Digest, struct{}{})
+	return nil
+}
@@ -20,20 +20,33 @@ package root
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/cmd/oras/internal/output"
 	"os"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/cmd/oras/internal/display/humanize"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 	"oras.land/oras/cmd/oras/internal/display/status/console/testutils"
+	"oras.land/oras/internal/docker"
 )
 
 var (
@@ -202,3 +215,856 @@ func Test_doCopy_mounted(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func Test_doCopy_noStatusForFormattedOutput(t *testing.T) {
+	var opts copyOptions
+	opts.Verbose = true
+	opts.Format.Type = option.FormatTypeJSON.Name
+	opts.From.Reference = memDesc.Digest.String()
+	builder := &strings.Builder{}
+	printer := output.NewPrinter(builder, os.Stderr, opts.Verbose)
+	// test
+	if _, err := doCopy(context.Background(), printer, memStore, memory.New(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	// validate
+	if builder.Len() != 0 {
+		t.Fatalf("expect no status output, got %q", builder.String())
+	}
+}
+
+func Test_doCopy_mixedDigestAlgorithms(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	push := func(mediaType string, algorithm digest.Algorithm, blob []byte) ocispec.Descriptor {
+		desc := ocispec.Descriptor{
+			MediaType: mediaType,
+			Digest:    algorithm.FromBytes(blob),
+			Size:      int64(len(blob)),
+		}
+		if err := src.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	pushJSON := func(mediaType string, algorithm digest.Algorithm, v any) ocispec.Descriptor {
+		blob, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return push(mediaType, algorithm, blob)
+	}
+	// a sha256 index of a sha512 manifest with a sha512 layer, and a sha512
+	// referrer of the sha256 index
+	config := push(ocispec.MediaTypeEmptyJSON, digest.SHA256, []byte("{}"))
+	layer := push(ocispec.MediaTypeImageLayer, digest.SHA512, []byte("sha512 layer"))
+	manifest := pushJSON(ocispec.MediaTypeImageManifest, digest.SHA512, ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ocispec.Descriptor{layer},
+	})
+	index := pushJSON(ocispec.MediaTypeImageIndex, digest.SHA256, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{manifest},
+	})
+	referrer := pushJSON(ocispec.MediaTypeImageManifest, digest.SHA512, ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.test",
+		Config:       config,
+		Layers:       []ocispec.Descriptor{config},
+		Subject:      &index,
+	})
+	if err := src.Tag(ctx, index, index.Digest.String()); err != nil {
+		t.Fatal(err)
+	}
+
+	var opts copyOptions
+	opts.recursive = true
+	opts.Format.Type = option.FormatTypeText.Name
+	opts.From.Reference = index.Digest.String()
+	dst := memory.New()
+	builder := &strings.Builder{}
+	printer := output.NewPrinter(builder, builder, true)
+	got, err := doCopy(ctx, printer, src, dst, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Digest != index.Digest {
+		t.Fatalf("doCopy() = %v, want %v", got.Digest, index.Digest)
+	}
+	for _, desc := range []ocispec.Descriptor{config, layer, manifest, index, referrer} {
+		exists, err := dst.Exists(ctx, desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists {
+			t.Errorf("%s is not copied", desc.Digest)
+		}
+	}
+	// sha512 digests are shortened with the algorithm prefix
+	if want := "sha512:" + manifest.Digest.Encoded()[:12]; !strings.Contains(builder.String(), want) {
+		t.Errorf("status output %q does not contain %q", builder.String(), want)
+	}
+}
+
+func Test_checkUpToDate(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	manifest := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.Digest(manifestDigest),
+		Size:      int64(len(manifestContent)),
+	}
+	config := ocispec.Descriptor{
+		MediaType: configMediaType,
+		Digest:    digest.Digest(configDigest),
+		Size:      int64(len(configContent)),
+	}
+	for _, blob := range []struct {
+		desc    ocispec.Descriptor
+		content []byte
+	}{{config, configContent}, {manifest, manifestContent}} {
+		if err := src.Push(ctx, blob.desc, bytes.NewReader(blob.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := src.Tag(ctx, manifest, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	referrerContent, err := json.Marshal(ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.test",
+		Config:       config,
+		Layers:       []ocispec.Descriptor{config},
+		Subject:      &manifest,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	referrer := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(referrerContent),
+		Size:      int64(len(referrerContent)),
+	}
+	if err := src.Push(ctx, referrer, bytes.NewReader(referrerContent)); err != nil {
+		t.Fatal(err)
+	}
+
+	newOpts := func(to string, recursive bool) *copyOptions {
+		var opts copyOptions
+		opts.From.Reference = "v1"
+		opts.To.Reference = to
+		opts.recursive = recursive
+		return &opts
+	}
+	check := func(dst *memory.Store, opts *copyOptions, want bool) {
+		t.Helper()
+		desc, got, err := checkUpToDate(ctx, src, dst, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if desc.Digest != manifest.Digest {
+			t.Errorf("checkUpToDate() desc = %v, want %v", desc.Digest, manifest.Digest)
+		}
+		if got != want {
+			t.Errorf("checkUpToDate() = %v, want %v", got, want)
+		}
+	}
+
+	dst := memory.New()
+	check(dst, newOpts("v1", false), false)
+	check(dst, newOpts("", false), false)
+	if err := dst.Push(ctx, manifest, bytes.NewReader(manifestContent)); err != nil {
+		t.Fatal(err)
+	}
+	check(dst, newOpts("", false), true)
+	check(dst, newOpts("v1", false), false)
+	if err := dst.Tag(ctx, manifest, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	check(dst, newOpts("v1", false), true)
+
+	// all tags must point at the source digest
+	opts := newOpts("v1", false)
+	opts.extraRefs = []string{"v2"}
+	check(dst, opts, false)
+
+	// referrers must be copied with --recursive
+	check(dst, newOpts("v1", true), false)
+	if err := dst.Push(ctx, referrer, bytes.NewReader(referrerContent)); err != nil {
+		t.Fatal(err)
+	}
+	check(dst, newOpts("v1", true), true)
+
+	// other digests are not up to date
+	other := memory.New()
+	if err := other.Push(ctx, memDesc, bytes.NewReader([]byte("test"))); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Tag(ctx, memDesc, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	check(other, newOpts("v1", false), false)
+}
+
+// orderedStore records the order of pushed content.
+type orderedStore struct {
+	*memory.Store
+	mu     sync.Mutex
+	pushed []digest.Digest
+}
+
+func (s *orderedStore) Push(ctx context.Context, expected ocispec.Descriptor, r io.Reader) error {
+	if err := s.Store.Push(ctx, expected, r); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pushed = append(s.pushed, expected.Digest)
+	return nil
+}
+
+func (s *orderedStore) indexOf(dgst digest.Digest) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Index(s.pushed, dgst)
+}
+
+func Test_recursiveCopy_referrers(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	push := func(mediaType string, blob []byte) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(mediaType, blob)
+		if err := src.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	pushManifest := func(artifactType string, subject *ocispec.Descriptor, layers ...ocispec.Descriptor) ocispec.Descriptor {
+		blob, err := json.Marshal(ocispec.Manifest{
+			Versioned:    specs.Versioned{SchemaVersion: 2},
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: artifactType,
+			Config:       ocispec.DescriptorEmptyJSON,
+			Layers:       layers,
+			Subject:      subject,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return push(ocispec.MediaTypeImageManifest, blob)
+	}
+	push(ocispec.MediaTypeEmptyJSON, []byte("{}"))
+	root := pushManifest("application/vnd.test.root", nil, push(ocispec.MediaTypeImageLayer, []byte("root")))
+	signature := pushManifest("application/vnd.test.signature", &root, push(ocispec.MediaTypeImageLayer, []byte("signature")))
+	sbom := pushManifest("application/vnd.test.sbom", &root, push(ocispec.MediaTypeImageLayer, []byte("sbom")))
+	sbomSignature := pushManifest("application/vnd.test.signature", &sbom, push(ocispec.MediaTypeImageLayer, []byte("sbom signature")))
+	// the layer of the attestation is missing in the source
+	missing := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, []byte("missing"))
+	attestation := pushManifest("application/vnd.test.attestation", &root, missing)
+
+	dst := &orderedStore{Store: memory.New()}
+	opts := oras.DefaultExtendedCopyOptions
+	opts.Concurrency = 3
+	err := recursiveCopy(ctx, src, dst, "v1", root, opts)
+	if err == nil || !strings.Contains(err.Error(), attestation.Digest.String()) {
+		t.Fatalf("recursiveCopy() error = %v, want error of %s", err, attestation.Digest)
+	}
+	if _, err := dst.Resolve(ctx, "v1"); err == nil {
+		t.Error("recursiveCopy() tagged the destination on failure")
+	}
+	// other subtrees are copied, with subjects copied before their referrers
+	for _, edge := range [][2]ocispec.Descriptor{
+		{root, signature},
+		{root, sbom},
+		{sbom, sbomSignature},
+	} {
+		subject, referrer := dst.indexOf(edge[0].Digest), dst.indexOf(edge[1].Digest)
+		if subject < 0 || referrer < 0 {
+			t.Fatalf("%s or %s is not copied", edge[0].Digest, edge[1].Digest)
+		}
+		if subject > referrer {
+			t.Errorf("%s is copied before its subject %s", edge[1].Digest, edge[0].Digest)
+		}
+	}
+	if dst.indexOf(attestation.Digest) >= 0 {
+		t.Errorf("%s is copied without its layer", attestation.Digest)
+	}
+}
+
+func Test_doCopy_dockerMediaTypes(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	push := func(mediaType string, blob []byte) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(mediaType, blob)
+		if err := src.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	// manifests are not in canonical JSON so that any re-serialization
+	// changes the digests
+	config := push("application/vnd.docker.container.image.v1+json", []byte(`{"architecture":"amd64","os":"linux"}`))
+	layer := push("application/vnd.docker.image.rootfs.diff.tar.gzip", []byte("docker layer"))
+	manifest := push(docker.MediaTypeManifest, []byte(fmt.Sprintf(`{
+   "schemaVersion": 2,
+   "mediaType": %q,
+   "config": {"mediaType": %q, "size": %d, "digest": %q},
+   "layers": [{"mediaType": %q, "size": %d, "digest": %q}]
+}`, docker.MediaTypeManifest, config.MediaType, config.Size, config.Digest, layer.MediaType, layer.Size, layer.Digest)))
+	list := push(docker.MediaTypeManifestList, []byte(fmt.Sprintf(`{
+   "schemaVersion": 2,
+   "mediaType": %q,
+   "manifests": [{"mediaType": %q, "size": %d, "digest": %q, "platform": {"architecture": "amd64", "os": "linux"}}]
+}`, docker.MediaTypeManifestList, manifest.MediaType, manifest.Size, manifest.Digest)))
+	if err := src.Tag(ctx, list, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	manifestContents := map[digest.Digest][]byte{}
+	for _, desc := range []ocispec.Descriptor{manifest, list} {
+		blob, err := content.FetchAll(ctx, src, desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifestContents[desc.Digest] = blob
+	}
+
+	type pushed struct {
+		contentType string
+		body        []byte
+	}
+	var mu sync.Mutex
+	manifests := make(map[string]pushed)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/blobs/uploads/"):
+			w.Header().Set("Location", r.URL.Path+"upload")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/blobs/uploads/upload"):
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/manifests/"):
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			mu.Lock()
+			manifests[r.URL.Path] = pushed{r.Header.Get("Content-Type"), body}
+			mu.Unlock()
+			w.Header().Set("Docker-Content-Digest", digest.FromBytes(body).String())
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/manifests/"):
+			mu.Lock()
+			m, ok := manifests[r.URL.Path]
+			mu.Unlock()
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", m.contentType)
+			w.Header().Set("Docker-Content-Digest", digest.FromBytes(m.body).String())
+			_, _ = w.Write(m.body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	uri, _ := url.Parse(ts.URL)
+	dst, err := remote.NewRepository(uri.Host + "/" + repoTo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst.PlainHTTP = true
+
+	var opts copyOptions
+	opts.Format.Type = option.FormatTypeText.Name
+	opts.From.Reference = "v1"
+	builder := &strings.Builder{}
+	printer := output.NewPrinter(builder, builder, true)
+	got, err := doCopy(ctx, printer, src, dst, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !content.Equal(got, list) {
+		t.Fatalf("doCopy() = %v, want %v", got, list)
+	}
+	for _, want := range []struct {
+		path string
+		desc ocispec.Descriptor
+	}{
+		{"/v2/" + repoTo + "/manifests/" + manifest.Digest.String(), manifest},
+		{"/v2/" + repoTo + "/manifests/" + list.Digest.String(), list},
+	} {
+		got, ok := manifests[want.path]
+		if !ok {
+			t.Errorf("%s is not pushed", want.path)
+			continue
+		}
+		if got.contentType != want.desc.MediaType {
+			t.Errorf("%s is pushed with Content-Type %q, want %q", want.path, got.contentType, want.desc.MediaType)
+		}
+		if !bytes.Equal(got.body, manifestContents[want.desc.Digest]) {
+			t.Errorf("%s is pushed with %q, want %q", want.path, got.body, manifestContents[want.desc.Digest])
+		}
+	}
+}
+
+// existsCounter is a memory store counting the existence checks.
+type existsCounter struct {
+	*memory.Store
+	count atomic.Int64
+}
+
+func (s *existsCounter) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	s.count.Add(1)
+	return s.Store.Exists(ctx, target)
+}
+
+func Test_doCopy_precheck(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	dst := &existsCounter{Store: memory.New()}
+	push := func(mediaType string, blob []byte, targets ...content.Pusher) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(mediaType, blob)
+		for _, target := range targets {
+			if err := target.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return desc
+	}
+	config := push(ocispec.MediaTypeEmptyJSON, []byte("{}"), src, dst)
+	present := push(ocispec.MediaTypeImageLayer, []byte("present layer"), src, dst)
+	missing := push(ocispec.MediaTypeImageLayer, []byte("missing layer"), src)
+	manifestJSON, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ocispec.Descriptor{present, missing},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := push(ocispec.MediaTypeImageManifest, manifestJSON, src)
+	if err := src.Tag(ctx, manifest, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	var opts copyOptions
+	opts.precheck = true
+	opts.concurrency = 3
+	opts.Format.Type = option.FormatTypeText.Name
+	opts.From.Reference = "v1"
+	builder := &strings.Builder{}
+	printer := output.NewPrinter(builder, builder, false)
+	if _, err := doCopy(ctx, printer, src, dst, &opts); err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf("2 of 4 blobs already present (%s skipped)", humanize.Size(config.Size+present.Size)); !strings.Contains(builder.String(), want) {
+		t.Errorf("output %q does not contain %q", builder.String(), want)
+	}
+	for _, desc := range []ocispec.Descriptor{config, present, missing, manifest} {
+		exists, err := dst.Store.Exists(ctx, desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists {
+			t.Errorf("%s is not copied", desc.Digest)
+		}
+	}
+	// the existence of each node is checked only once
+	if got := dst.count.Load(); got != 4 {
+		t.Errorf("destination existence checked %d times, want 4", got)
+	}
+}
+
+func Test_doCopy_platforms(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	push := func(mediaType string, blob []byte) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(mediaType, blob)
+		if err := src.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	pushManifest := func(layer ocispec.Descriptor) ocispec.Descriptor {
+		blob, err := json.Marshal(ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    ocispec.DescriptorEmptyJSON,
+			Layers:    []ocispec.Descriptor{layer},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return push(ocispec.MediaTypeImageManifest, blob)
+	}
+	pushIndex := func(manifests ...ocispec.Descriptor) ocispec.Descriptor {
+		blob, err := json.Marshal(ocispec.Index{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageIndex,
+			Manifests: manifests,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return push(ocispec.MediaTypeImageIndex, blob)
+	}
+	withPlatform := func(desc ocispec.Descriptor, os, arch string) ocispec.Descriptor {
+		desc.Platform = &ocispec.Platform{OS: os, Architecture: arch}
+		return desc
+	}
+	push(ocispec.MediaTypeEmptyJSON, []byte("{}"))
+	amd64 := withPlatform(pushManifest(push(ocispec.MediaTypeImageLayer, []byte("amd64"))), "linux", "amd64")
+	arm64 := withPlatform(pushManifest(push(ocispec.MediaTypeImageLayer, []byte("arm64"))), "linux", "arm64")
+	root := pushIndex(amd64, arm64)
+	if err := src.Tag(ctx, root, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	// the layer of s390x is missing in the source
+	s390x := withPlatform(pushManifest(content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, []byte("s390x"))), "linux", "s390x")
+	broken := pushIndex(amd64, s390x)
+	if err := src.Tag(ctx, broken, "broken"); err != nil {
+		t.Fatal(err)
+	}
+
+	newOpts := func(reference string) *copyOptions {
+		var opts copyOptions
+		opts.concurrency = 3
+		opts.Format.Type = option.FormatTypeText.Name
+		opts.From.Reference = reference
+		opts.To.Reference = reference
+		return &opts
+	}
+	dst := &orderedStore{Store: memory.New()}
+	builder := &strings.Builder{}
+	printer := output.NewPrinter(builder, builder, false)
+	if _, err := doCopy(ctx, printer, src, dst, newOpts("v1")); err != nil {
+		t.Fatal(err)
+	}
+	rootIndex := dst.indexOf(root.Digest)
+	for _, manifest := range []ocispec.Descriptor{amd64, arm64} {
+		if i := dst.indexOf(manifest.Digest); i < 0 || i > rootIndex {
+			t.Errorf("manifest %s is pushed at %d, want before the index at %d", manifest.Digest, i, rootIndex)
+		}
+	}
+	if strings.Contains(builder.String(), "Exists") {
+		t.Errorf("copied manifests are reported as existing: %q", builder.String())
+	}
+
+	dst = &orderedStore{Store: memory.New()}
+	_, err := doCopy(ctx, printer, src, dst, newOpts("broken"))
+	if err == nil || !strings.Contains(err.Error(), "linux/s390x") {
+		t.Fatalf("doCopy() error = %v, want error of linux/s390x", err)
+	}
+	if dst.indexOf(broken.Digest) >= 0 {
+		t.Error("index is pushed without all its manifests")
+	}
+	if dst.indexOf(amd64.Digest) < 0 {
+		t.Error("manifest of linux/amd64 is not copied")
+	}
+}
+
+func Test_doCopy_validate(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	manifestJSON, err := json.Marshal(ocispec.Manifest{
+		Versioned:   specs.Versioned{SchemaVersion: 2},
+		MediaType:   ocispec.MediaTypeImageManifest,
+		Config:      ocispec.DescriptorEmptyJSON,
+		Layers:      []ocispec.Descriptor{ocispec.DescriptorEmptyJSON},
+		Annotations: map[string]string{"bad key": "value"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Push(ctx, ocispec.DescriptorEmptyJSON, bytes.NewReader(ocispec.DescriptorEmptyJSON.Data)); err != nil {
+		t.Fatal(err)
+	}
+	manifest := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, manifestJSON)
+	if err := src.Push(ctx, manifest, bytes.NewReader(manifestJSON)); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, manifest, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []string{option.ValidateModeWarn, option.ValidateModeError, option.ValidateModeSkip} {
+		t.Run(mode, func(t *testing.T) {
+			var opts copyOptions
+			opts.ValidateMode = mode
+			opts.Format.Type = option.FormatTypeText.Name
+			opts.From.Reference = "v1"
+			dst := memory.New()
+			printer := output.NewPrinter(io.Discard, io.Discard, false)
+			_, err := doCopy(ctx, printer, src, dst, &opts)
+			if mode != option.ValidateModeError {
+				if err != nil {
+					t.Fatalf("doCopy() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), manifest.Digest.String()) || !strings.Contains(err.Error(), "$.annotations['bad key']") {
+				t.Fatalf("doCopy() error = %v, want violation of %s", err, manifest.Digest)
+			}
+			if exists, _ := dst.Exists(ctx, manifest); exists {
+				t.Error("invalid manifest is copied")
+			}
+		})
+	}
+}
+
+type transferRecorder struct {
+	lock   sync.Mutex
+	states map[digest.Digest]string
+}
+
+func (r *transferRecorder) OnTransferred(desc ocispec.Descriptor, state string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.states[desc.Digest] = state
+	return nil
+}
+
+func Test_doCopy_transfers(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	layer := []byte("layer")
+	layerDesc := content.NewDescriptorFromBytes("test/layer", layer)
+	manifestJSON, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    ocispec.DescriptorEmptyJSON,
+		Layers:    []ocispec.Descriptor{layerDesc},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, manifestJSON)
+	for _, blob := range []struct {
+		desc    ocispec.Descriptor
+		content []byte
+	}{
+		{ocispec.DescriptorEmptyJSON, ocispec.DescriptorEmptyJSON.Data},
+		{layerDesc, layer},
+		{manifest, manifestJSON},
+	} {
+		if err := src.Push(ctx, blob.desc, bytes.NewReader(blob.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := src.Tag(ctx, manifest, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	dst := memory.New()
+	if err := dst.Push(ctx, ocispec.DescriptorEmptyJSON, bytes.NewReader(ocispec.DescriptorEmptyJSON.Data)); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := &transferRecorder{states: make(map[digest.Digest]string)}
+	var opts copyOptions
+	opts.Format.Type = option.FormatTypeJSON.Name
+	opts.From.Reference = "v1"
+	opts.transferHandler = recorder
+	printer := output.NewPrinter(io.Discard, io.Discard, false)
+	if _, err := doCopy(ctx, printer, src, dst, &opts); err != nil {
+		t.Fatalf("doCopy() error = %v", err)
+	}
+	want := map[digest.Digest]string{
+		ocispec.DescriptorEmptyJSON.Digest: model.TransferStateExists,
+		layerDesc.Digest:                   model.TransferStateCopied,
+		manifest.Digest:                    model.TransferStateCopied,
+	}
+	if !reflect.DeepEqual(recorder.states, want) {
+		t.Errorf("transfer states = %v, want %v", recorder.states, want)
+	}
+}
+
+func Test_doCopy_excludeReferrerType(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	pushManifest := func(artifactType string, subject *ocispec.Descriptor) ocispec.Descriptor {
+		manifestJSON, err := json.Marshal(ocispec.Manifest{
+			Versioned:    specs.Versioned{SchemaVersion: 2},
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: artifactType,
+			Config:       ocispec.DescriptorEmptyJSON,
+			Layers:       []ocispec.Descriptor{ocispec.DescriptorEmptyJSON},
+			Subject:      subject,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, manifestJSON)
+		if err := src.Push(ctx, desc, bytes.NewReader(manifestJSON)); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	if err := src.Push(ctx, ocispec.DescriptorEmptyJSON, bytes.NewReader(ocispec.DescriptorEmptyJSON.Data)); err != nil {
+		t.Fatal(err)
+	}
+	root := pushManifest("application/vnd.test.image", nil)
+	signature := pushManifest("application/vnd.test.signature", &root)
+	// the referrer of an excluded referrer is excluded as well
+	signatureAttestation := pushManifest("application/vnd.test.attestation", &signature)
+	sbom := pushManifest("application/vnd.test.sbom", &root)
+	if err := src.Tag(ctx, root, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	var opts copyOptions
+	opts.recursive = true
+	opts.excludeReferrerTypes = []string{"application/vnd.test.signature"}
+	opts.Format.Type = option.FormatTypeText.Name
+	opts.From.Reference = "v1"
+	dst := memory.New()
+	builder := &strings.Builder{}
+	printer := output.NewPrinter(builder, builder, false)
+	if _, err := doCopy(ctx, printer, src, dst, &opts); err != nil {
+		t.Fatalf("doCopy() error = %v", err)
+	}
+	for _, tt := range []struct {
+		desc ocispec.Descriptor
+		want bool
+	}{
+		{root, true},
+		{sbom, true},
+		{signature, false},
+		{signatureAttestation, false},
+	} {
+		exists, err := dst.Exists(ctx, tt.desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists != tt.want {
+			t.Errorf("%s exists in the destination = %v, want %v", tt.desc.Digest, exists, tt.want)
+		}
+	}
+	if want := "Excluded 1 referrer and its referrers: 1 of type application/vnd.test.signature"; !strings.Contains(builder.String(), want) {
+		t.Errorf("output %q does not contain %q", builder.String(), want)
+	}
+
+	// the excluded referrers are not required for the destination to be up to
+	// date
+	opts.To.Reference = "v1"
+	if err := dst.Tag(ctx, root, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, upToDate, err := checkUpToDate(ctx, src, dst, &opts); err != nil || !upToDate {
+		t.Errorf("checkUpToDate() = %v, %v, want true, nil", upToDate, err)
+	}
+}
+
+type traversalHandlerFunc func(nodes []model.TraversalNode) error
+
+func (f traversalHandlerFunc) OnTraversed(nodes []model.TraversalNode) error {
+	return f(nodes)
+}
+
+func Test_doCopy_traversal(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	push := func(mediaType string, blob []byte) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(mediaType, blob)
+		if err := src.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	pushManifest := func(artifactType string, layers []ocispec.Descriptor, subject *ocispec.Descriptor) ocispec.Descriptor {
+		manifestJSON, err := json.Marshal(ocispec.Manifest{
+			Versioned:    specs.Versioned{SchemaVersion: 2},
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: artifactType,
+			Config:       ocispec.DescriptorEmptyJSON,
+			Layers:       layers,
+			Subject:      subject,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return push(ocispec.MediaTypeImageManifest, manifestJSON)
+	}
+	push(ocispec.MediaTypeEmptyJSON, ocispec.DescriptorEmptyJSON.Data)
+	layer := push(ocispec.MediaTypeImageLayer, []byte("layer"))
+	root := pushManifest("application/vnd.test.image", []ocispec.Descriptor{layer}, nil)
+	referrer := pushManifest("application/vnd.test.signature", []ocispec.Descriptor{ocispec.DescriptorEmptyJSON}, &root)
+	if err := src.Tag(ctx, root, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	dst := memory.New()
+	if err := dst.Push(ctx, ocispec.DescriptorEmptyJSON, bytes.NewReader(ocispec.DescriptorEmptyJSON.Data)); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []model.TraversalNode
+	var opts copyOptions
+	opts.recursive = true
+	opts.Format.Type = option.FormatTypeJSON.Name
+	opts.From.Reference = "v1"
+	opts.traversalHandler = traversalHandlerFunc(func(nodes []model.TraversalNode) error {
+		got = nodes
+		return nil
+	})
+	printer := output.NewPrinter(io.Discard, io.Discard, false)
+	if _, err := doCopy(ctx, printer, src, dst, &opts); err != nil {
+		t.Fatalf("doCopy() error = %v", err)
+	}
+
+	want := map[string]model.TraversalNode{
+		ocispec.DescriptorEmptyJSON.Digest.String(): {
+			MediaType: ocispec.MediaTypeEmptyJSON,
+			Size:      ocispec.DescriptorEmptyJSON.Size,
+			Action:    model.TraversalActionSkipped,
+			Parents:   []string{root.Digest.String(), referrer.Digest.String()},
+		},
+		layer.Digest.String(): {
+			MediaType: ocispec.MediaTypeImageLayer,
+			Size:      layer.Size,
+			Action:    model.TraversalActionCopied,
+			Parents:   []string{root.Digest.String()},
+		},
+		root.Digest.String(): {
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: "application/vnd.test.image",
+			Size:         root.Size,
+			Action:       model.TraversalActionCopied,
+			Parents:      []string{referrer.Digest.String()},
+		},
+		referrer.Digest.String(): {
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: "application/vnd.test.signature",
+			Size:         referrer.Size,
+			Action:       model.TraversalActionCopied,
+		},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("traversal = %v, want %d nodes", got, len(want))
+	}
+	position := make(map[string]int)
+	for i, node := range got {
+		position[node.Digest] = i
+		wantNode, ok := want[node.Digest]
+		if !ok {
+			t.Fatalf("unexpected node %s in traversal", node.Digest)
+		}
+		wantNode.Digest = node.Digest
+		if !reflect.DeepEqual(node, wantNode) {
+			t.Errorf("traversal node = %+v, want %+v", node, wantNode)
+		}
+	}
+	// children are listed before parents
+	for _, node := range got {
+		for _, parent := range node.Parents {
+			if position[parent] < position[node.Digest] {
+				t.Errorf("parent %s is listed before child %s", parent, node.Digest)
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/internal/descriptor"
+)
+
+// traversalRecorder records the nodes touched by a copy and the edges between
+// them.
+type traversalRecorder struct {
+	fetcher  content.Fetcher
+	lock     sync.Mutex
+	nodes    []model.TraversalNode
+	recorded map[digest.Digest]bool
+	children map[digest.Digest][]digest.Digest
+}
+
+// newTraversalRecorder returns a traversalRecorder reading manifests from
+// fetcher.
+func newTraversalRecorder(fetcher content.Fetcher) *traversalRecorder {
+	return &traversalRecorder{
+		fetcher:  fetcher,
+		recorded: make(map[digest.Digest]bool),
+		children: make(map[digest.Digest][]digest.Digest),
+	}
+}
+
+// hook records the nodes copied, skipped and mounted by a copy with opts.
+func (r *traversalRecorder) hook(opts *oras.CopyGraphOptions) {
+	postCopy := opts.PostCopy
+	opts.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if postCopy != nil {
+			if err := postCopy(ctx, desc); err != nil {
+				return err
+			}
+		}
+		return r.record(ctx, desc, model.TraversalActionCopied)
+	}
+	onCopySkipped := opts.OnCopySkipped
+	opts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if onCopySkipped != nil {
+			if err := onCopySkipped(ctx, desc); err != nil {
+				return err
+			}
+		}
+		return r.record(ctx, desc, model.TraversalActionSkipped)
+	}
+	onMounted := opts.OnMounted
+	opts.OnMounted = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if onMounted != nil {
+			if err := onMounted(ctx, desc); err != nil {
+				return err
+			}
+		}
+		return r.record(ctx, desc, model.TraversalActionMounted)
+	}
+}
+
+// record records desc with action unless desc is recorded already. The
+// artifact type and the successors of manifests are read from the fetcher.
+func (r *traversalRecorder) record(ctx context.Context, desc ocispec.Descriptor, action string) error {
+	r.lock.Lock()
+	recorded := r.recorded[desc.Digest]
+	r.recorded[desc.Digest] = true
+	r.lock.Unlock()
+	if recorded {
+		return nil
+	}
+
+	node := model.TraversalNode{
+		Digest:       desc.Digest.String(),
+		MediaType:    desc.MediaType,
+		ArtifactType: desc.ArtifactType,
+		Size:         desc.Size,
+		Action:       action,
+	}
+	var successors []ocispec.Descriptor
+	if descriptor.IsManifest(desc) {
+		fetched, err := content.FetchAll(ctx, r.fetcher, desc)
+		if err != nil {
+			return err
+		}
+		// the fields of image manifests, indexes and artifact manifests
+		var manifest struct {
+			ArtifactType string               `json:"artifactType"`
+			Config       *ocispec.Descriptor  `json:"config"`
+			Layers       []ocispec.Descriptor `json:"layers"`
+			Blobs        []ocispec.Descriptor `json:"blobs"`
+			Manifests    []ocispec.Descriptor `json:"manifests"`
+			Subject      *ocispec.Descriptor  `json:"subject"`
+		}
+		if err := json.Unmarshal(fetched, &manifest); err != nil {
+			return err
+		}
+		switch {
+		case manifest.ArtifactType != "":
+			node.ArtifactType = manifest.ArtifactType
+		case descriptor.IsImageManifest(desc) && manifest.Config != nil && node.ArtifactType == "":
+			node.ArtifactType = manifest.Config.MediaType
+		}
+		if manifest.Config != nil {
+			successors = append(successors, *manifest.Config)
+		}
+		successors = append(successors, manifest.Layers...)
+		successors = append(successors, manifest.Blobs...)
+		successors = append(successors, manifest.Manifests...)
+		if manifest.Subject != nil {
+			successors = append(successors, *manifest.Subject)
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.nodes = append(r.nodes, node)
+	for _, successor := range successors {
+		r.children[desc.Digest] = append(r.children[desc.Digest], successor.Digest)
+	}
+	return nil
+}
+
+// list returns the recorded nodes ordered child before parent, keeping the
+// recording order otherwise. Edges to the nodes not recorded are omitted.
+func (r *traversalRecorder) list() []model.TraversalNode {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	byDigest := make(map[digest.Digest]*model.TraversalNode, len(r.nodes))
+	for i := range r.nodes {
+		node := r.nodes[i]
+		node.Parents = nil
+		byDigest[digest.Digest(node.Digest)] = &node
+	}
+	for _, node := range r.nodes {
+		parent := digest.Digest(node.Digest)
+		for _, child := range r.children[parent] {
+			if childNode, ok := byDigest[child]; ok && !slices.Contains(childNode.Parents, node.Digest) {
+				childNode.Parents = append(childNode.Parents, node.Digest)
+			}
+		}
+	}
+
+	ordered := make([]model.TraversalNode, 0, len(r.nodes))
+	visited := make(map[digest.Digest]bool, len(r.nodes))
+	var visit func(dgst digest.Digest)
+	visit = func(dgst digest.Digest) {
+		if visited[dgst] {
+			return
+		}
+		visited[dgst] = true
+		for _, child := range r.children[dgst] {
+			if _, ok := byDigest[child]; ok {
+				visit(child)
+			}
+		}
+		ordered = append(ordered, *byDigest[dgst])
+	}
+	for _, node := range r.nodes {
+		visit(digest.Digest(node.Digest))
+	}
+	return ordered
+}
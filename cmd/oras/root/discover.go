@@ -39,6 +39,7 @@ type discoverOptions struct {
 	option.Format
 
 	artifactType string
+	verify       bool
 }
 
 func discoverCmd() *cobra.Command {
@@ -68,6 +69,9 @@ Example - Discover all the referrers of manifest with annotations, displayed in
 Example - Discover referrers with type 'test-artifact' of manifest 'hello:v1' in registry 'localhost:5000':
   oras discover --artifact-type test-artifact localhost:5000/hello:v1
 
+Example - Discover referrers and verify that they link to their subjects consistently:
+  oras discover --verify localhost:5000/hello:v1
+
 Example - Discover referrers of the manifest tagged 'v1' in an OCI image layout folder 'layout-dir':
   oras discover --oci-layout layout-dir:v1
   oras discover --oci-layout -v -o tree layout-dir:v1
@@ -97,6 +101,8 @@ Example - Discover referrers of the manifest tagged 'v1' in an OCI image layout
 	}
 
 	cmd.Flags().StringVarP(&opts.artifactType, "artifact-type", "", "", "artifact type")
+	_ = cmd.RegisterFlagCompletionFunc("artifact-type", option.CompleteArtifactType)
+	cmd.Flags().BoolVar(&opts.verify, "verify", false, "verify that the referrers link to the subject, and that the Referrers API agrees with the referrers tag if both exist, failing on inconsistencies")
 	cmd.Flags().StringVarP(&opts.Format.FormatFlag, "output", "o", "tree", "[Deprecated] format in which to display referrers (table, json, or tree). tree format will also show indirect referrers")
 	opts.SetTypes(
 		option.FormatTypeTree,
@@ -105,12 +111,16 @@ Example - Discover referrers of the manifest tagged 'v1' in an OCI image layout
 		option.FormatTypeGoTemplate.WithUsage("Print direct referrers using the given Go template"),
 	)
 	opts.EnableDistributionSpecFlag()
+	opts.EnableAnonymousFallback()
+	opts.EnableMirrorFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
 func runDiscover(cmd *cobra.Command, opts *discoverOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	opts.Platform.MaxConfigBytes = opts.MaxMetadataBytes
 	repo, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
@@ -120,9 +130,7 @@ func runDiscover(cmd *cobra.Command, opts *discoverOptions) error {
 	}
 
 	// discover artifacts
-	resolveOpts := oras.DefaultResolveOptions
-	resolveOpts.TargetPlatform = opts.Platform.Platform
-	desc, err := oras.Resolve(ctx, repo, opts.Reference, resolveOpts)
+	desc, err := opts.Platform.Resolve(ctx, repo, opts.Reference)
 	if err != nil {
 		return err
 	}
@@ -131,8 +139,12 @@ func runDiscover(cmd *cobra.Command, opts *discoverOptions) error {
 	if err != nil {
 		return err
 	}
+	var verifier *referrersVerifier
+	if opts.verify {
+		verifier = newReferrersVerifier(repo)
+	}
 	if handler.MultiLevelSupported() {
-		if err := fetchAllReferrers(ctx, repo, desc, opts.artifactType, handler); err != nil {
+		if err := fetchAllReferrers(ctx, repo, desc, opts.artifactType, handler, verifier); err != nil {
 			return err
 		}
 	} else {
@@ -140,20 +152,38 @@ func runDiscover(cmd *cobra.Command, opts *discoverOptions) error {
 		if err != nil {
 			return err
 		}
+		if verifier != nil {
+			if err := verifier.verify(ctx, desc, opts.artifactType, refs); err != nil {
+				return err
+			}
+		}
 		for _, ref := range refs {
 			if err := handler.OnDiscovered(ref, desc); err != nil {
 				return err
 			}
 		}
 	}
-	return handler.OnCompleted()
+	if err := handler.OnCompleted(); err != nil {
+		return err
+	}
+	if verifier != nil {
+		return verifier.err()
+	}
+	return nil
 }
 
-func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc ocispec.Descriptor, artifactType string, handler metadata.DiscoverHandler) error {
+// fetchAllReferrers discovers the referrers of desc recursively. The
+// referrers are verified by verifier if it is not nil.
+func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc ocispec.Descriptor, artifactType string, handler metadata.DiscoverHandler, verifier *referrersVerifier) error {
 	results, err := registry.Referrers(ctx, repo, desc, artifactType)
 	if err != nil {
 		return err
 	}
+	if verifier != nil {
+		if err := verifier.verify(ctx, desc, artifactType, results); err != nil {
+			return err
+		}
+	}
 
 	for _, r := range results {
 		if err := handler.OnDiscovered(r, desc); err != nil {
@@ -163,7 +193,7 @@ func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc
 			Digest:    r.Digest,
 			Size:      r.Size,
 			MediaType: r.MediaType,
-		}, artifactType, handler); err != nil {
+		}, artifactType, handler, verifier); err != nil {
 			return err
 		}
 	}
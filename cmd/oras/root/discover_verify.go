@@ -0,0 +1,182 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+)
+
+// referrersVerifier verifies that the listed referrers link to their
+// subjects, and that the Referrers API and the referrers tag schema agree on
+// the referrers when both are available in a remote repository.
+type referrersVerifier struct {
+	fetcher content.Fetcher
+	// apiRepo lists referrers by the Referrers API only, and tagRepo
+	// resolves the referrers tags. They are nil for OCI image layouts.
+	apiRepo *remote.Repository
+	tagRepo *remote.Repository
+
+	issues []error
+}
+
+// newReferrersVerifier returns a referrersVerifier fetching manifests from
+// target.
+func newReferrersVerifier(target content.Fetcher) *referrersVerifier {
+	v := &referrersVerifier{fetcher: target}
+	if repo, ok := target.(*remote.Repository); ok {
+		v.tagRepo = repo
+		v.apiRepo = &remote.Repository{
+			Client:               repo.Client,
+			Reference:            repo.Reference,
+			PlainHTTP:            repo.PlainHTTP,
+			ManifestMediaTypes:   repo.ManifestMediaTypes,
+			TagListPageSize:      repo.TagListPageSize,
+			ReferrerListPageSize: repo.ReferrerListPageSize,
+			MaxMetadataBytes:     repo.MaxMetadataBytes,
+		}
+		_ = v.apiRepo.SetReferrersCapability(true)
+	}
+	return v
+}
+
+// verify verifies the referrers of subject filtered by artifactType.
+func (v *referrersVerifier) verify(ctx context.Context, subject ocispec.Descriptor, artifactType string, referrers []ocispec.Descriptor) error {
+	for _, referrer := range referrers {
+		if err := v.verifySubject(ctx, subject, referrer); err != nil {
+			return err
+		}
+	}
+	if v.apiRepo == nil {
+		return nil
+	}
+	return v.crossCheck(ctx, subject, artifactType)
+}
+
+// verifySubject verifies that the subject of referrer is subject.
+func (v *referrersVerifier) verifySubject(ctx context.Context, subject, referrer ocispec.Descriptor) error {
+	fetched, err := content.FetchAll(ctx, v.fetcher, referrer)
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) || errors.Is(err, content.ErrMismatchedDigest) {
+			v.report("referrer %s of %s cannot be fetched: %v", referrer.Digest, subject.Digest, err)
+			return nil
+		}
+		return err
+	}
+	var manifest struct {
+		Subject *ocispec.Descriptor `json:"subject,omitempty"`
+	}
+	if err := json.Unmarshal(fetched, &manifest); err != nil {
+		v.report("referrer %s of %s is not a valid manifest: %v", referrer.Digest, subject.Digest, err)
+		return nil
+	}
+	switch {
+	case manifest.Subject == nil:
+		v.report("referrer %s of %s has no subject", referrer.Digest, subject.Digest)
+	case manifest.Subject.Digest != subject.Digest:
+		v.report("referrer %s of %s has the subject %s instead", referrer.Digest, subject.Digest, manifest.Subject.Digest)
+	}
+	return nil
+}
+
+// crossCheck compares the referrers of subject listed by the Referrers API
+// and by the referrers tag schema, if both are available.
+func (v *referrersVerifier) crossCheck(ctx context.Context, subject ocispec.Descriptor, artifactType string) error {
+	var byAPI []ocispec.Descriptor
+	err := v.apiRepo.Referrers(ctx, subject, artifactType, func(referrers []ocispec.Descriptor) error {
+		byAPI = append(byAPI, referrers...)
+		return nil
+	})
+	if errors.Is(err, errdef.ErrUnsupported) {
+		// nothing to compare with
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	referrersTag := subject.Digest.Algorithm().String() + "-" + subject.Digest.Encoded()
+	desc, err := v.tagRepo.Resolve(ctx, referrersTag)
+	if errors.Is(err, errdef.ErrNotFound) {
+		// nothing to compare with
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fetched, err := content.FetchAll(ctx, v.tagRepo, desc)
+	if err != nil {
+		return err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(fetched, &index); err != nil {
+		v.report("referrers tag %s of %s is not a valid index: %v", referrersTag, subject.Digest, err)
+		return nil
+	}
+	var byTag []ocispec.Descriptor
+	for _, referrer := range index.Manifests {
+		if artifactType == "" || referrer.ArtifactType == artifactType {
+			byTag = append(byTag, referrer)
+		}
+	}
+
+	for _, dgst := range missingDigests(byAPI, byTag) {
+		v.report("referrer %s of %s is listed by the Referrers API but not by the referrers tag %s", dgst, subject.Digest, referrersTag)
+	}
+	for _, dgst := range missingDigests(byTag, byAPI) {
+		v.report("referrer %s of %s is listed by the referrers tag %s but not by the Referrers API", dgst, subject.Digest, referrersTag)
+	}
+	return nil
+}
+
+// report records an inconsistency.
+func (v *referrersVerifier) report(format string, a ...any) {
+	v.issues = append(v.issues, fmt.Errorf(format, a...))
+}
+
+// err returns the error reporting the inconsistencies found, or nil if there
+// is none.
+func (v *referrersVerifier) err() error {
+	if len(v.issues) == 0 {
+		return nil
+	}
+	return &oerrors.VerificationError{
+		Err: fmt.Errorf("found %d inconsistencies in referrers:\n%w", len(v.issues), errors.Join(v.issues...)),
+	}
+}
+
+// missingDigests returns the digests of descs missing in others.
+func missingDigests(descs, others []ocispec.Descriptor) []digest.Digest {
+	var missing []digest.Digest
+	for _, desc := range descs {
+		if !slices.ContainsFunc(others, func(other ocispec.Descriptor) bool {
+			return other.Digest == desc.Digest
+		}) {
+			missing = append(missing, desc.Digest)
+		}
+	}
+	return missing
+}
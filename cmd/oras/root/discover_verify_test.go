@@ -0,0 +1,119 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+func Test_referrersVerifier_verifySubject(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	pack := func(artifactType string, subject *ocispec.Descriptor) ocispec.Descriptor {
+		desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{Subject: subject})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	subject := pack("application/vnd.test.subject", nil)
+	other := pack("application/vnd.test.other", nil)
+	linked := pack("application/vnd.test", &subject)
+	misLinked := pack("application/vnd.test", &other)
+	missing := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("missing"))
+
+	v := newReferrersVerifier(store)
+	if err := v.verify(ctx, subject, "", []ocispec.Descriptor{linked, misLinked, other, missing}); err != nil {
+		t.Fatalf("verify() error = %v", err)
+	}
+	if len(v.issues) != 3 {
+		t.Fatalf("issues = %v, want 3 issues", v.issues)
+	}
+	for i, want := range []string{"has the subject " + other.Digest.String(), "has no subject", "cannot be fetched"} {
+		if !strings.Contains(v.issues[i].Error(), want) {
+			t.Errorf("issue %d = %v, want containing %q", i, v.issues[i], want)
+		}
+	}
+	if err := v.err(); err == nil {
+		t.Error("err() = nil, want error")
+	}
+}
+
+func Test_referrersVerifier_crossCheck(t *testing.T) {
+	subject := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("subject"), Size: 7}
+	byBoth := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("both"), Size: 4}
+	byTagOnly := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("tag"), Size: 3}
+	indexJSON := func(manifests ...ocispec.Descriptor) []byte {
+		b, err := json.Marshal(ocispec.Index{MediaType: ocispec.MediaTypeImageIndex, Manifests: manifests})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	apiIndex := indexJSON(byBoth)
+	tagIndex := indexJSON(byBoth, byTagOnly)
+	tagIndexDigest := digest.FromBytes(tagIndex)
+	referrersTag := "sha256-" + subject.Digest.Encoded()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/test/referrers/" + subject.Digest.String():
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+			_, _ = w.Write(apiIndex)
+		case "/v2/test/manifests/" + referrersTag, "/v2/test/manifests/" + tagIndexDigest.String():
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+			w.Header().Set("Docker-Content-Digest", tagIndexDigest.String())
+			w.Header().Set("Content-Length", fmt.Sprint(len(tagIndex)))
+			if r.Method == http.MethodGet {
+				_, _ = w.Write(tagIndex)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := remote.NewRepository(uri.Host + "/test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.PlainHTTP = true
+
+	v := newReferrersVerifier(repo)
+	if err := v.crossCheck(context.Background(), subject, ""); err != nil {
+		t.Fatalf("crossCheck() error = %v", err)
+	}
+	if len(v.issues) != 1 || !strings.Contains(v.issues[0].Error(), byTagOnly.Digest.String()+" of "+subject.Digest.String()+" is listed by the referrers tag") {
+		t.Errorf("issues = %v, want %s reported as listed by the referrers tag only", v.issues, byTagOnly.Digest)
+	}
+}
@@ -19,15 +19,20 @@ import (
 	"context"
 	"errors"
 	"io/fs"
+	"os"
 	"path/filepath"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2/content/file"
 	"oras.land/oras/cmd/oras/internal/display/status"
 	"oras.land/oras/cmd/oras/internal/fileref"
+	"oras.land/oras/internal/archive"
+	"oras.land/oras/internal/cleanup"
 )
 
-func loadFiles(ctx context.Context, store *file.Store, annotations map[string]map[string]string, fileRefs []string, displayStatus status.PushHandler) ([]ocispec.Descriptor, error) {
+// loadFiles adds the files referenced by fileRefs to store. Directories are
+// packed by packer if it is not nil, or by store otherwise.
+func loadFiles(ctx context.Context, store *file.Store, annotations map[string]map[string]string, fileRefs []string, displayStatus status.PushHandler, packer *dirPacker) ([]ocispec.Descriptor, error) {
 	var files []ocispec.Descriptor
 	for _, fileRef := range fileRefs {
 		filename, mediaType, err := fileref.Parse(fileRef, "")
@@ -45,7 +50,12 @@ func loadFiles(ctx context.Context, store *file.Store, annotations map[string]ma
 		if err != nil {
 			return nil, err
 		}
-		file, err := addFile(ctx, store, name, mediaType, filename)
+		var file ocispec.Descriptor
+		if packer != nil {
+			file, err = packer.addFile(ctx, store, name, mediaType, filename)
+		} else {
+			file, err = addFile(ctx, store, name, mediaType, filename)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -79,3 +89,58 @@ func addFile(ctx context.Context, store *file.Store, name string, mediaType stri
 	}
 	return file, nil
 }
+
+// dirPacker packs directories into tarballs in a temporary directory, which
+// is removed on Close.
+type dirPacker struct {
+	dir     string
+	opts    archive.TarOptions
+	tracker *cleanup.Tracker
+}
+
+// newDirPacker returns a dirPacker packing directories with opts. The
+// temporary directory is tracked by the cleanup tracker of ctx.
+func newDirPacker(ctx context.Context, opts archive.TarOptions) (*dirPacker, error) {
+	dir, err := os.MkdirTemp("", "oras_push_*")
+	if err != nil {
+		return nil, err
+	}
+	tracker := cleanup.FromContext(ctx)
+	tracker.Track(dir)
+	return &dirPacker{
+		dir:     dir,
+		opts:    opts,
+		tracker: tracker,
+	}, nil
+}
+
+// addFile adds the file to store. If the file is a directory, it is packed by
+// p and added as a tarball to be unpacked on pull.
+func (p *dirPacker) addFile(ctx context.Context, store *file.Store, name string, mediaType string, filename string) (ocispec.Descriptor, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if !info.IsDir() {
+		return addFile(ctx, store, name, mediaType, filename)
+	}
+	gzPath, tarDigest, err := archive.TarGzipDirectory(p.dir, filename, name, p.opts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if mediaType == "" {
+		mediaType = ocispec.MediaTypeImageLayerGzip
+	}
+	desc, err := addFile(ctx, store, name, mediaType, gzPath)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc.Annotations[file.AnnotationDigest] = tarDigest.String()
+	desc.Annotations[file.AnnotationUnpack] = "true"
+	return desc, nil
+}
+
+// Close removes the packed tarballs.
+func (p *dirPacker) Close() error {
+	return p.tracker.Remove(p.dir)
+}
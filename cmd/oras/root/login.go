@@ -36,7 +36,8 @@ import (
 type loginOptions struct {
 	option.Common
 	option.Remote
-	Hostname string
+	Hostname    string
+	useKeychain bool
 }
 
 func loginCmd() *cobra.Command {
@@ -61,18 +62,27 @@ Example - Log in with identity token from stdin:
 Example - Log in with username and password in an interactive terminal:
   oras login localhost:5000
 
+Example - Log in to Docker Hub with the legacy server address:
+  oras login https://index.docker.io/v1/
+
+Example - Log in and store the credential in the native keychain via a credential helper:
+  oras login --use-keychain -u username --password-stdin localhost:5000
+
 Example - Log in with username and password in an interactive terminal and no TLS check:
   oras login --insecure localhost:5000
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the registry to log in to"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.SetRegistry(credential.NormalizeHostname(args[0]))
 			return option.Parse(cmd, &opts)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts.Hostname = args[0]
+			opts.Hostname = credential.NormalizeHostname(args[0])
 			return runLogin(cmd, opts)
 		},
 	}
+	cmd.Flags().BoolVarP(&opts.useKeychain, "use-keychain", "", false, "store the credential via the platform-default native credential helper instead of in plaintext")
+	opts.DisablePasswordPrompt()
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Remote)
 }
@@ -107,7 +117,11 @@ func runLogin(cmd *cobra.Command, opts loginOptions) (err error) {
 		}
 	}
 
-	store, err := credential.NewStore(opts.Configs...)
+	newStore := credential.NewStore
+	if opts.useKeychain {
+		newStore = credential.NewNativeStore
+	}
+	store, err := newStore(opts.Configs...)
 	if err != nil {
 		return err
 	}
@@ -116,12 +130,35 @@ func runLogin(cmd *cobra.Command, opts loginOptions) (err error) {
 		return err
 	}
 	if err = credentials.Login(ctx, store, remote, opts.Credential()); err != nil {
+		if errors.Is(err, credentials.ErrPlaintextPutDisabled) {
+			return &oerrors.Error{
+				Err:            errors.New("no native credential helper is available to store the credential"),
+				Recommendation: `Install a credential helper such as docker-credential-osxkeychain, docker-credential-wincred, docker-credential-secretservice or docker-credential-pass and set it as "credsStore" in the config file, or log in without --use-keychain`,
+			}
+		}
 		return err
 	}
+	if !opts.useKeychain {
+		if configPath := loginConfigPath(store, opts.Configs); configPath != "" && credential.HelperName(configPath, credentials.ServerAddressFromRegistry(opts.Hostname)) == "" {
+			cmd.PrintErrf("WARNING! Your credential is stored unencrypted in %q. Use --use-keychain to store it via a native credential helper.\n", configPath)
+		}
+	}
 	_ = opts.Println("Login Succeeded")
 	return nil
 }
 
+// loginConfigPath returns the path of the config file where the credential is
+// saved to.
+func loginConfigPath(store credentials.Store, configs []string) string {
+	if len(configs) > 0 {
+		return configs[0]
+	}
+	if ds, ok := store.(*credentials.DynamicStore); ok {
+		return ds.ConfigPath()
+	}
+	return ""
+}
+
 func readLine(outWriter io.Writer, prompt string, silent bool) (string, error) {
 	_, _ = fmt.Fprint(outWriter, prompt)
 	fd := int(os.Stdin.Fd())
@@ -16,11 +16,15 @@ limitations under the License.
 package root
 
 import (
+	"fmt"
+
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry/remote/auth"
 	"oras.land/oras-go/v2/registry/remote/credentials"
 	"oras.land/oras/cmd/oras/internal/argument"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/credential"
 )
 
@@ -29,6 +33,7 @@ type logoutOptions struct {
 
 	debug   bool
 	configs []string
+	strict  bool
 }
 
 func logoutCmd() *cobra.Command {
@@ -40,16 +45,23 @@ func logoutCmd() *cobra.Command {
 
 Example - Logout:
   oras logout localhost:5000
+
+Example - Logout from Docker Hub with the legacy server address:
+  oras logout https://index.docker.io/v1/
+
+Example - Logout and fail if not logged in:
+  oras logout --strict localhost:5000
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the registry you want to log out"),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts.hostname = args[0]
+			opts.hostname = credential.NormalizeHostname(args[0])
 			return runLogout(cmd, opts)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&opts.debug, "debug", "d", false, "debug mode")
-	cmd.Flags().StringArrayVarP(&opts.configs, "registry-config", "", nil, "auth config path")
+	cmd.Flags().StringArrayVarP(&opts.configs, "registry-config", "", nil, "auth config path (default $"+option.RegistryConfigEnv+" or the docker config file)")
+	cmd.Flags().BoolVarP(&opts.strict, "strict", "", false, "fail if no credential is stored for the registry")
 	return cmd
 }
 
@@ -59,9 +71,20 @@ func runLogout(cmd *cobra.Command, opts logoutOptions) error {
 		logrus.SetLevel(logrus.DebugLevel)
 	}
 
+	if len(opts.configs) == 0 {
+		opts.configs = option.RegistryConfigsFromEnv()
+	}
 	store, err := credential.NewStore(opts.configs...)
 	if err != nil {
 		return err
 	}
+	cred, err := store.Get(ctx, credentials.ServerAddressFromRegistry(opts.hostname))
+	if err == nil && cred == auth.EmptyCredential {
+		// not logged in
+		if opts.strict {
+			return fmt.Errorf("not logged in to %s", opts.hostname)
+		}
+		return nil
+	}
 	return credentials.Logout(ctx, store, opts.hostname)
 }
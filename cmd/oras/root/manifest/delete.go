@@ -72,7 +72,9 @@ Example - Delete a manifest by digest 'sha256:99e4703fbf30916f549cd6bfa9cdbab614
 	}
 
 	opts.EnableDistributionSpecFlag()
+	opts.EnableVerifyTagFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
@@ -85,6 +87,9 @@ func deleteManifest(cmd *cobra.Command, opts *deleteOptions) error {
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
+	if err := opts.VerifyTag(ctx, manifests); err != nil {
+		return err
+	}
 
 	// add both pull and delete scope hints for dst repository to save potential delete-scope token requests during deleting
 	hints := []string{auth.ActionPull, auth.ActionDelete}
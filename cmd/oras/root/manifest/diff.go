@@ -0,0 +1,508 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/docker"
+	"oras.land/oras/internal/trace"
+)
+
+type diffOptions struct {
+	option.Cache
+	option.Common
+	option.Format
+	option.BinaryTarget
+
+	download bool
+}
+
+func diffCmd() *cobra.Command {
+	var opts diffOptions
+	cmd := &cobra.Command{
+		Use:   "diff [flags] <name>{:<tag>|@<digest>} <name>{:<tag>|@<digest>}",
+		Short: "[Preview] Show the differences between two manifests",
+		Long: `[Preview] Show the differences between two manifests
+
+The config, layers, annotations and subject of the manifests are compared.
+Layers are matched by their titles, or by their digests if untitled. Indexes
+are compared by the manifests of matching platforms.
+
+Example - Show the differences between two versions of an artifact:
+  oras manifest diff localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Show the differences between artifacts in different registries:
+  oras manifest diff localhost:5000/hello:v1 localhost:6000/hello:v1
+
+Example - Show the differences including the files changed in tar layers:
+  oras manifest diff --download localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Show the differences in JSON format:
+  oras manifest diff --format json localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Show the differences between an artifact in an OCI image layout and in a registry:
+  oras manifest diff --from-oci-layout layout-dir:v1 localhost:5000/hello:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(2), "the two manifests to diff"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.From.RawReference = args[0]
+			opts.To.RawReference = args[1]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.download, "download", false, "also compare the files in changed tar layers by their digests, which requires downloading the layers")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.BinaryTarget.CompleteReference
+	return oerrors.Command(cmd, &opts.BinaryTarget)
+}
+
+func runDiff(cmd *cobra.Command, opts *diffOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewManifestDiffHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	fromTarget, err := opts.From.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureSourceTargetReferenceNotEmpty(cmd); err != nil {
+		return err
+	}
+	toTarget, err := opts.To.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if opts.To.Reference == "" {
+		return oerrors.NewErrEmptyTagOrDigest(opts.To.RawReference, cmd, true)
+	}
+	from, err := opts.CachedTarget(fromTarget)
+	if err != nil {
+		return err
+	}
+	to, err := opts.CachedTarget(toTarget)
+	if err != nil {
+		return err
+	}
+
+	fromDesc, err := oras.Resolve(ctx, from, opts.From.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
+	}
+	toDesc, err := oras.Resolve(ctx, to, opts.To.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.To.Reference, err)
+	}
+	d := &manifestDiffer{
+		from:     from,
+		to:       to,
+		fromPath: opts.From.Path,
+		toPath:   opts.To.Path,
+		download: opts.download,
+	}
+	diff, err := d.diff(ctx, fromDesc, toDesc, "")
+	if err != nil {
+		return err
+	}
+	return handler.OnDiffed(diff)
+}
+
+// manifestDiffer diffs manifests fetched from two targets.
+type manifestDiffer struct {
+	from     content.Fetcher
+	to       content.Fetcher
+	fromPath string
+	toPath   string
+	// download indicates whether the files of changed tar layers are compared.
+	download bool
+}
+
+// diff diffs the manifests described by from and to. Manifests of matching
+// platforms are diffed recursively if both are indexes.
+func (d *manifestDiffer) diff(ctx context.Context, from, to ocispec.Descriptor, platform string) (model.ManifestDiff, error) {
+	result := model.ManifestDiff{
+		Platform: platform,
+		From:     model.FromDescriptor(d.fromPath, from),
+		To:       model.FromDescriptor(d.toPath, to),
+		Changes:  []model.Change{},
+	}
+	if from.Digest == to.Digest {
+		return result, nil
+	}
+	fromContent, err := content.FetchAll(ctx, d.from, from)
+	if err != nil {
+		return model.ManifestDiff{}, err
+	}
+	toContent, err := content.FetchAll(ctx, d.to, to)
+	if err != nil {
+		return model.ManifestDiff{}, err
+	}
+
+	switch {
+	case isIndex(from.MediaType) && isIndex(to.MediaType):
+		var fromIndex, toIndex ocispec.Index
+		if err := json.Unmarshal(fromContent, &fromIndex); err != nil {
+			return model.ManifestDiff{}, fmt.Errorf("failed to parse index %s: %w", from.Digest, err)
+		}
+		if err := json.Unmarshal(toContent, &toIndex); err != nil {
+			return model.ManifestDiff{}, fmt.Errorf("failed to parse index %s: %w", to.Digest, err)
+		}
+		result.Changes = diffCommon(from.MediaType, to.MediaType, fromIndex.ArtifactType, toIndex.ArtifactType, fromIndex.Subject, toIndex.Subject, fromIndex.Annotations, toIndex.Annotations)
+		fromManifests, fromKeys := keyByPlatform(fromIndex.Manifests)
+		toManifests, toKeys := keyByPlatform(toIndex.Manifests)
+		for _, key := range fromKeys {
+			toManifest, ok := toManifests[key]
+			if !ok {
+				result.Changes = append(result.Changes, removed("manifests["+key+"]", fromManifests[key].Digest.String()))
+				continue
+			}
+			manifestDiff, err := d.diff(ctx, fromManifests[key], toManifest, key)
+			if err != nil {
+				return model.ManifestDiff{}, err
+			}
+			result.Manifests = append(result.Manifests, manifestDiff)
+		}
+		for _, key := range toKeys {
+			if _, ok := fromManifests[key]; !ok {
+				result.Changes = append(result.Changes, added("manifests["+key+"]", toManifests[key].Digest.String()))
+			}
+		}
+	case isManifest(from.MediaType) && isManifest(to.MediaType):
+		var fromManifest, toManifest ocispec.Manifest
+		if err := json.Unmarshal(fromContent, &fromManifest); err != nil {
+			return model.ManifestDiff{}, fmt.Errorf("failed to parse manifest %s: %w", from.Digest, err)
+		}
+		if err := json.Unmarshal(toContent, &toManifest); err != nil {
+			return model.ManifestDiff{}, fmt.Errorf("failed to parse manifest %s: %w", to.Digest, err)
+		}
+		result.Changes = diffCommon(from.MediaType, to.MediaType, fromManifest.ArtifactType, toManifest.ArtifactType, fromManifest.Subject, toManifest.Subject, fromManifest.Annotations, toManifest.Annotations)
+		result.Changes = append(result.Changes, diffDescriptor("config", fromManifest.Config, toManifest.Config)...)
+		layerChanges, err := d.diffLayers(ctx, fromManifest.Layers, toManifest.Layers)
+		if err != nil {
+			return model.ManifestDiff{}, err
+		}
+		result.Changes = append(result.Changes, layerChanges...)
+	default:
+		result.Changes = append(result.Changes, changed("mediaType", from.MediaType, to.MediaType))
+	}
+	return result, nil
+}
+
+// diffLayers diffs layers matched by their titles, or by their digests if
+// untitled. With download, the files of layers matched by titles, and of the
+// removed and added untitled layers paired in order, are compared as well.
+func (d *manifestDiffer) diffLayers(ctx context.Context, from, to []ocispec.Descriptor) ([]model.Change, error) {
+	fromLayers, fromKeys := keyByTitle(from)
+	toLayers, toKeys := keyByTitle(to)
+	var changes []model.Change
+	var pairs [][2]ocispec.Descriptor
+	var removedLayers, addedLayers []ocispec.Descriptor
+	for _, key := range fromKeys {
+		fromLayer := fromLayers[key]
+		toLayer, ok := toLayers[key]
+		switch {
+		case !ok:
+			changes = append(changes, removed("layers["+key+"]", fromLayer.Digest.String()))
+			if fromLayer.Annotations[ocispec.AnnotationTitle] == "" {
+				removedLayers = append(removedLayers, fromLayer)
+			}
+		case fromLayer.Digest != toLayer.Digest:
+			changes = append(changes, changed("layers["+key+"]", fromLayer.Digest.String(), toLayer.Digest.String()))
+			pairs = append(pairs, [2]ocispec.Descriptor{fromLayer, toLayer})
+		case fromLayer.MediaType != toLayer.MediaType:
+			changes = append(changes, changed("layers["+key+"].mediaType", fromLayer.MediaType, toLayer.MediaType))
+		}
+	}
+	for _, key := range toKeys {
+		if _, ok := fromLayers[key]; !ok {
+			toLayer := toLayers[key]
+			changes = append(changes, added("layers["+key+"]", toLayer.Digest.String()))
+			if toLayer.Annotations[ocispec.AnnotationTitle] == "" {
+				addedLayers = append(addedLayers, toLayer)
+			}
+		}
+	}
+	if !d.download {
+		return changes, nil
+	}
+
+	for i := 0; i < min(len(removedLayers), len(addedLayers)); i++ {
+		pairs = append(pairs, [2]ocispec.Descriptor{removedLayers[i], addedLayers[i]})
+	}
+	for _, pair := range pairs {
+		fileChanges, err := d.diffFiles(ctx, pair[0], pair[1])
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, fileChanges...)
+	}
+	return changes, nil
+}
+
+// diffFiles diffs the files of two tar layers by their digests. Layers which
+// are not tar archives are skipped.
+func (d *manifestDiffer) diffFiles(ctx context.Context, from, to ocispec.Descriptor) ([]model.Change, error) {
+	if !isTarLayer(from.MediaType) || !isTarLayer(to.MediaType) {
+		trace.Logger(ctx).Debugf("skipped comparing files of non-tar layers %s and %s", from.Digest, to.Digest)
+		return nil, nil
+	}
+	fromFiles, err := listFiles(ctx, d.from, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of layer %s: %w", from.Digest, err)
+	}
+	toFiles, err := listFiles(ctx, d.to, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of layer %s: %w", to.Digest, err)
+	}
+
+	key := layerKey(to)
+	if from.Annotations[ocispec.AnnotationTitle] == "" {
+		key = from.Digest.String() + "->" + to.Digest.String()
+	}
+	field := func(name string) string {
+		return "layers[" + key + "]/" + name
+	}
+	var changes []model.Change
+	for _, name := range sortedKeys((fromFiles)) {
+		toFile, ok := toFiles[name]
+		switch {
+		case !ok:
+			changes = append(changes, removed(field(name), fromFiles[name]))
+		case fromFiles[name] != toFile:
+			changes = append(changes, changed(field(name), fromFiles[name], toFile))
+		}
+	}
+	for _, name := range sortedKeys((toFiles)) {
+		if _, ok := fromFiles[name]; !ok {
+			changes = append(changes, added(field(name), toFiles[name]))
+		}
+	}
+	return changes, nil
+}
+
+// listFiles returns the digests of the regular files, and the targets of the
+// links, in a tar layer keyed by their names. Directories are skipped.
+func listFiles(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) (map[string]string, error) {
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	br := bufio.NewReader(content.NewVerifyReader(rc, desc))
+	var r io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	files := make(map[string]string)
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(header.Name, "./"), "/")
+		switch header.Typeflag {
+		case tar.TypeReg:
+			dgst, err := digest.FromReader(tr)
+			if err != nil {
+				return nil, err
+			}
+			files[name] = dgst.String()
+		case tar.TypeSymlink, tar.TypeLink:
+			files[name] = "-> " + header.Linkname
+		}
+	}
+}
+
+// diffCommon diffs the fields shared by manifests and indexes.
+func diffCommon(fromMediaType, toMediaType, fromArtifactType, toArtifactType string, fromSubject, toSubject *ocispec.Descriptor, fromAnnotations, toAnnotations map[string]string) []model.Change {
+	changes := []model.Change{}
+	if fromMediaType != toMediaType {
+		changes = append(changes, changed("mediaType", fromMediaType, toMediaType))
+	}
+	changes = append(changes, diffString("artifactType", fromArtifactType, toArtifactType)...)
+	switch {
+	case fromSubject == nil && toSubject != nil:
+		changes = append(changes, added("subject", toSubject.Digest.String()))
+	case fromSubject != nil && toSubject == nil:
+		changes = append(changes, removed("subject", fromSubject.Digest.String()))
+	case fromSubject != nil && fromSubject.Digest != toSubject.Digest:
+		changes = append(changes, changed("subject", fromSubject.Digest.String(), toSubject.Digest.String()))
+	}
+	for _, key := range sortedKeys((fromAnnotations)) {
+		toValue, ok := toAnnotations[key]
+		if !ok {
+			changes = append(changes, removed("annotations["+key+"]", fromAnnotations[key]))
+			continue
+		}
+		changes = append(changes, diffString("annotations["+key+"]", fromAnnotations[key], toValue)...)
+	}
+	for _, key := range sortedKeys((toAnnotations)) {
+		if _, ok := fromAnnotations[key]; !ok {
+			changes = append(changes, added("annotations["+key+"]", toAnnotations[key]))
+		}
+	}
+	return changes
+}
+
+// diffDescriptor diffs the digests and media types of two descriptors.
+func diffDescriptor(field string, from, to ocispec.Descriptor) []model.Change {
+	if from.Digest != to.Digest {
+		return []model.Change{changed(field, from.Digest.String(), to.Digest.String())}
+	}
+	return diffString(field+".mediaType", from.MediaType, to.MediaType)
+}
+
+// diffString diffs two string values, where an empty value is absent.
+func diffString(field, from, to string) []model.Change {
+	switch {
+	case from == to:
+		return nil
+	case from == "":
+		return []model.Change{added(field, to)}
+	case to == "":
+		return []model.Change{removed(field, from)}
+	default:
+		return []model.Change{changed(field, from, to)}
+	}
+}
+
+func added(field, to string) model.Change {
+	return model.Change{Type: model.ChangeAdded, Field: field, To: to}
+}
+
+func removed(field, from string) model.Change {
+	return model.Change{Type: model.ChangeRemoved, Field: field, From: from}
+}
+
+func changed(field, from, to string) model.Change {
+	return model.Change{Type: model.ChangeChanged, Field: field, From: from, To: to}
+}
+
+// keyByPlatform keys manifests by their platforms, or by their digests if the
+// platforms are not specified. Duplicated platforms are suffixed with their
+// occurrences, e.g. unknown/unknown#2. The keys are returned in order.
+func keyByPlatform(manifests []ocispec.Descriptor) (map[string]ocispec.Descriptor, []string) {
+	return keyBy(manifests, func(desc ocispec.Descriptor) string {
+		if desc.Platform == nil {
+			return desc.Digest.String()
+		}
+		return platformString(desc.Platform)
+	})
+}
+
+// keyByTitle keys layers by their titles, or by their digests if untitled. The
+// keys are returned in order.
+func keyByTitle(layers []ocispec.Descriptor) (map[string]ocispec.Descriptor, []string) {
+	return keyBy(layers, layerKey)
+}
+
+func keyBy(descs []ocispec.Descriptor, keyOf func(ocispec.Descriptor) string) (map[string]ocispec.Descriptor, []string) {
+	keyed := make(map[string]ocispec.Descriptor, len(descs))
+	keys := make([]string, 0, len(descs))
+	occurrences := make(map[string]int)
+	for _, desc := range descs {
+		key := keyOf(desc)
+		occurrences[key]++
+		if n := occurrences[key]; n > 1 {
+			if desc.Digest.String() == key {
+				// duplicated layers are compared once
+				continue
+			}
+			key = fmt.Sprintf("%s#%d", key, n)
+		}
+		keyed[key] = desc
+		keys = append(keys, key)
+	}
+	return keyed, keys
+}
+
+func layerKey(desc ocispec.Descriptor) string {
+	if title := desc.Annotations[ocispec.AnnotationTitle]; title != "" {
+		return title
+	}
+	return desc.Digest.String()
+}
+
+// platformString returns the platform in the form of
+// <os>/<arch>[/<variant>][:<os version>].
+func platformString(p *ocispec.Platform) string {
+	s := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+	if p.OSVersion != "" {
+		s += ":" + p.OSVersion
+	}
+	return s
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func isIndex(mediaType string) bool {
+	return mediaType == ocispec.MediaTypeImageIndex || mediaType == docker.MediaTypeManifestList
+}
+
+func isManifest(mediaType string) bool {
+	return mediaType == ocispec.MediaTypeImageManifest || mediaType == docker.MediaTypeManifest
+}
+
+func isTarLayer(mediaType string) bool {
+	return strings.Contains(mediaType, "tar")
+}
@@ -0,0 +1,217 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+type diffFixture struct {
+	t     *testing.T
+	store *memory.Store
+}
+
+func (f *diffFixture) push(mediaType string, blob []byte) ocispec.Descriptor {
+	desc := content.NewDescriptorFromBytes(mediaType, blob)
+	if err := f.store.Push(context.Background(), desc, bytes.NewReader(blob)); err != nil {
+		f.t.Fatal(err)
+	}
+	return desc
+}
+
+func (f *diffFixture) pushJSON(mediaType string, v any) ocispec.Descriptor {
+	blob, err := json.Marshal(v)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return f.push(mediaType, blob)
+}
+
+func (f *diffFixture) pushTar(files map[string]string) ocispec.Descriptor {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range sortedKeys(files) {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), Typeflag: tar.TypeReg}); err != nil {
+			f.t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(files[name])); err != nil {
+			f.t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		f.t.Fatal(err)
+	}
+	return f.push(ocispec.MediaTypeImageLayer, buf.Bytes())
+}
+
+func (f *diffFixture) pushManifest(layers []ocispec.Descriptor, subject *ocispec.Descriptor, annotations map[string]string) ocispec.Descriptor {
+	return f.pushJSON(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned:   specs.Versioned{SchemaVersion: 2},
+		MediaType:   ocispec.MediaTypeImageManifest,
+		Config:      ocispec.DescriptorEmptyJSON,
+		Layers:      layers,
+		Subject:     subject,
+		Annotations: annotations,
+	})
+}
+
+func titled(desc ocispec.Descriptor, title string) ocispec.Descriptor {
+	desc.Annotations = map[string]string{ocispec.AnnotationTitle: title}
+	return desc
+}
+
+func Test_manifestDiffer_diff(t *testing.T) {
+	ctx := context.Background()
+	f := &diffFixture{t: t, store: memory.New()}
+	a := titled(f.push(ocispec.MediaTypeImageLayer, []byte("a")), "a.txt")
+	b1 := titled(f.push(ocispec.MediaTypeImageLayer, []byte("b1")), "b.txt")
+	b2 := titled(f.push(ocispec.MediaTypeImageLayer, []byte("b2")), "b.txt")
+	c := titled(f.push(ocispec.MediaTypeImageLayer, []byte("c")), "c.txt")
+	subject := f.pushManifest(nil, nil, nil)
+	from := f.pushManifest([]ocispec.Descriptor{a, b1}, nil, map[string]string{"kept": "v", "changed": "v1", "removed": "v"})
+	to := f.pushManifest([]ocispec.Descriptor{b2, c}, &subject, map[string]string{"kept": "v", "changed": "v2", "added": "v"})
+
+	d := &manifestDiffer{from: f.store, to: f.store, fromPath: "localhost:5000/from", toPath: "localhost:5000/to"}
+	got, err := d.diff(ctx, from, to, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []model.Change{
+		{Type: model.ChangeAdded, Field: "subject", To: subject.Digest.String()},
+		{Type: model.ChangeChanged, Field: "annotations[changed]", From: "v1", To: "v2"},
+		{Type: model.ChangeRemoved, Field: "annotations[removed]", From: "v"},
+		{Type: model.ChangeAdded, Field: "annotations[added]", To: "v"},
+		{Type: model.ChangeRemoved, Field: "layers[a.txt]", From: a.Digest.String()},
+		{Type: model.ChangeChanged, Field: "layers[b.txt]", From: b1.Digest.String(), To: b2.Digest.String()},
+		{Type: model.ChangeAdded, Field: "layers[c.txt]", To: c.Digest.String()},
+	}
+	if !reflect.DeepEqual(got.Changes, want) {
+		t.Errorf("diff() changes = %+v, want %+v", got.Changes, want)
+	}
+	if got.From.Reference != "localhost:5000/from@"+from.Digest.String() {
+		t.Errorf("diff() from = %v", got.From.Reference)
+	}
+
+	// identical manifests
+	got, err = d.diff(ctx, from, from, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Identical() {
+		t.Errorf("diff() = %+v, want identical", got)
+	}
+}
+
+func Test_manifestDiffer_diff_index(t *testing.T) {
+	ctx := context.Background()
+	f := &diffFixture{t: t, store: memory.New()}
+	amd64 := f.pushManifest([]ocispec.Descriptor{f.push(ocispec.MediaTypeImageLayer, []byte("amd64"))}, nil, nil)
+	arm64v1 := f.pushManifest([]ocispec.Descriptor{f.push(ocispec.MediaTypeImageLayer, []byte("arm64 v1"))}, nil, nil)
+	arm64v2 := f.pushManifest([]ocispec.Descriptor{f.push(ocispec.MediaTypeImageLayer, []byte("arm64 v2"))}, nil, nil)
+	s390x := f.pushManifest([]ocispec.Descriptor{f.push(ocispec.MediaTypeImageLayer, []byte("s390x"))}, nil, nil)
+	withPlatform := func(desc ocispec.Descriptor, arch string) ocispec.Descriptor {
+		desc.Platform = &ocispec.Platform{OS: "linux", Architecture: arch}
+		return desc
+	}
+	pushIndex := func(manifests ...ocispec.Descriptor) ocispec.Descriptor {
+		return f.pushJSON(ocispec.MediaTypeImageIndex, ocispec.Index{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageIndex,
+			Manifests: manifests,
+		})
+	}
+	from := pushIndex(withPlatform(amd64, "amd64"), withPlatform(arm64v1, "arm64"))
+	to := pushIndex(withPlatform(amd64, "amd64"), withPlatform(arm64v2, "arm64"), withPlatform(s390x, "s390x"))
+
+	d := &manifestDiffer{from: f.store, to: f.store}
+	got, err := d.diff(ctx, from, to, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantChanges := []model.Change{
+		{Type: model.ChangeAdded, Field: "manifests[linux/s390x]", To: s390x.Digest.String()},
+	}
+	if !reflect.DeepEqual(got.Changes, wantChanges) {
+		t.Errorf("diff() changes = %+v, want %+v", got.Changes, wantChanges)
+	}
+	if len(got.Manifests) != 2 {
+		t.Fatalf("diff() manifests = %+v, want 2 manifests", got.Manifests)
+	}
+	if m := got.Manifests[0]; m.Platform != "linux/amd64" || !m.Identical() {
+		t.Errorf("diff() manifests[0] = %+v, want identical linux/amd64", m)
+	}
+	if m := got.Manifests[1]; m.Platform != "linux/arm64" || len(m.Changes) != 2 {
+		t.Errorf("diff() manifests[1] = %+v, want 2 changes of linux/arm64", m)
+	}
+
+	// index and manifest
+	got, err = d.diff(ctx, from, amd64, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantChanges = []model.Change{
+		{Type: model.ChangeChanged, Field: "mediaType", From: ocispec.MediaTypeImageIndex, To: ocispec.MediaTypeImageManifest},
+	}
+	if !reflect.DeepEqual(got.Changes, wantChanges) {
+		t.Errorf("diff() changes = %+v, want %+v", got.Changes, wantChanges)
+	}
+}
+
+func Test_manifestDiffer_diff_download(t *testing.T) {
+	ctx := context.Background()
+	f := &diffFixture{t: t, store: memory.New()}
+	fromLayer := f.pushTar(map[string]string{"kept": "kept", "changed": "v1", "removed": "removed"})
+	toLayer := f.pushTar(map[string]string{"kept": "kept", "changed": "v2", "added": "added"})
+	from := f.pushManifest([]ocispec.Descriptor{fromLayer}, nil, nil)
+	to := f.pushManifest([]ocispec.Descriptor{toLayer}, nil, nil)
+
+	d := &manifestDiffer{from: f.store, to: f.store, download: true}
+	got, err := d.diff(ctx, from, to, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	field := "layers[" + fromLayer.Digest.String() + "->" + toLayer.Digest.String() + "]/"
+	var fileChanges []model.Change
+	for _, change := range got.Changes {
+		if len(change.Field) > len(field) && change.Field[:len(field)] == field {
+			fileChanges = append(fileChanges, change)
+		}
+	}
+	want := []string{
+		model.ChangeChanged + " " + field + "changed",
+		model.ChangeRemoved + " " + field + "removed",
+		model.ChangeAdded + " " + field + "added",
+	}
+	if len(fileChanges) != len(want) {
+		t.Fatalf("diff() file changes = %+v, want %v", fileChanges, want)
+	}
+	for i, change := range fileChanges {
+		if got := change.Type + " " + change.Field; got != want[i] {
+			t.Errorf("diff() file changes[%d] = %v, want %v", i, got, want[i])
+		}
+	}
+}
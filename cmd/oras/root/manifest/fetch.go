@@ -103,12 +103,17 @@ Example - Fetch raw manifest from an OCI layout archive file 'layout.tar':
 		option.FormatTypeJSON.WithUsage("Print in prettified JSON format"),
 		option.FormatTypeGoTemplate.WithUsage("Print using the given Go template"),
 	)
+	opts.EnableAnonymousFallback()
+	opts.EnableMirrorFlag()
+	opts.EnableVerifyTagFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
 func fetchManifest(cmd *cobra.Command, opts *fetchOptions) (fetchErr error) {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	opts.Platform.MaxConfigBytes = opts.MaxMetadataBytes
 	metadataHandler, contentHandler, err := display.NewManifestFetchHandler(opts.Printer, opts.Format, opts.OutputDescriptor, opts.Pretty.Pretty, opts.outputPath)
 	if err != nil {
 		return err
@@ -121,6 +126,9 @@ func fetchManifest(cmd *cobra.Command, opts *fetchOptions) (fetchErr error) {
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
+	if err := opts.VerifyTag(ctx, target); err != nil {
+		return err
+	}
 	if repo, ok := target.(*remote.Repository); ok {
 		repo.ManifestMediaTypes = opts.mediaTypes
 	} else if opts.mediaTypes != nil {
@@ -135,17 +143,20 @@ func fetchManifest(cmd *cobra.Command, opts *fetchOptions) (fetchErr error) {
 	var content []byte
 	if opts.OutputDescriptor && opts.outputPath == "" {
 		// fetch manifest descriptor only
-		fetchOpts := oras.DefaultResolveOptions
-		fetchOpts.TargetPlatform = opts.Platform.Platform
-		desc, err = oras.Resolve(ctx, src, opts.Reference, fetchOpts)
+		desc, err = opts.Platform.Resolve(ctx, src, opts.Reference)
 		if err != nil {
 			return fmt.Errorf("failed to find %q: %w", opts.RawReference, err)
 		}
 	} else {
 		// fetch manifest descriptor and content
-		fetchOpts := oras.DefaultFetchBytesOptions
-		fetchOpts.TargetPlatform = opts.Platform.Platform
-		desc, content, err = oras.FetchBytes(ctx, src, opts.Reference, fetchOpts)
+		reference := opts.Reference
+		if opts.Platform.Platform != nil {
+			if desc, err = opts.Platform.Resolve(ctx, src, opts.Reference); err != nil {
+				return fmt.Errorf("failed to find %q: %w", opts.RawReference, err)
+			}
+			reference = desc.Digest.String()
+		}
+		desc, content, err = oras.FetchBytes(ctx, src, reference, oras.DefaultFetchBytesOptions)
 		if err != nil {
 			return fmt.Errorf("failed to fetch the content of %q: %w", opts.RawReference, err)
 		}
@@ -25,12 +25,12 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
-	"oras.land/oras-go/v2/content"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/registryutil"
 )
 
 type fetchConfigOptions struct {
@@ -69,6 +69,12 @@ Example - Fetch the descriptor of the config:
 
 Example - Fetch and print the prettified descriptor of the config:
   oras manifest fetch-config --descriptor --pretty localhost:5000/hello:v1
+
+Example - Fetch the config from an OCI image layout folder 'layout-dir':
+  oras manifest fetch-config --oci-layout layout-dir:v1
+
+Example - Fetch the descriptor of the config from an OCI image layout archive file 'layout.tar':
+  oras manifest fetch-config --oci-layout --descriptor layout.tar:v1
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the manifest config to fetch"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -84,12 +90,17 @@ Example - Fetch and print the prettified descriptor of the config:
 	}
 
 	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "file `path` to write the fetched config to, use - for stdout")
+	opts.EnableAnonymousFallback()
+	opts.EnableMirrorFlag()
+	opts.EnableVerifyTagFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
 func fetchConfig(cmd *cobra.Command, opts *fetchConfigOptions) (fetchErr error) {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	opts.Platform.MaxConfigBytes = opts.MaxMetadataBytes
 
 	repo, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
@@ -98,20 +109,23 @@ func fetchConfig(cmd *cobra.Command, opts *fetchConfigOptions) (fetchErr error)
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
+	if err := opts.VerifyTag(ctx, repo); err != nil {
+		return err
+	}
 	src, err := opts.CachedTarget(repo)
 	if err != nil {
 		return err
 	}
 
 	// fetch config descriptor
-	configDesc, err := fetchConfigDesc(ctx, src, opts.Reference, opts.Platform.Platform)
+	configDesc, err := fetchConfigDesc(ctx, src, opts.Reference, &opts.Platform)
 	if err != nil {
 		return err
 	}
 
 	if !opts.OutputDescriptor || opts.outputPath != "" {
 		// fetch config content
-		contentBytes, err := content.FetchAll(ctx, src, configDesc)
+		contentBytes, err := registryutil.FetchConfig(ctx, src, configDesc, opts.MaxMetadataBytes)
 		if err != nil {
 			return err
 		}
@@ -139,11 +153,16 @@ func fetchConfig(cmd *cobra.Command, opts *fetchConfigOptions) (fetchErr error)
 	return nil
 }
 
-func fetchConfigDesc(ctx context.Context, src oras.ReadOnlyTarget, reference string, targetPlatform *ocispec.Platform) (ocispec.Descriptor, error) {
+func fetchConfigDesc(ctx context.Context, src oras.ReadOnlyTarget, reference string, platform *option.Platform) (ocispec.Descriptor, error) {
+	if platform.Platform != nil {
+		manifestDesc, err := platform.Resolve(ctx, src, reference)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		reference = manifestDesc.Digest.String()
+	}
 	// fetch manifest descriptor and content
-	fetchOpts := oras.DefaultFetchBytesOptions
-	fetchOpts.TargetPlatform = targetPlatform
-	manifestDesc, manifestContent, err := oras.FetchBytes(ctx, src, reference, fetchOpts)
+	manifestDesc, manifestContent, err := oras.FetchBytes(ctx, src, reference, oras.DefaultFetchBytesOptions)
 	if err != nil {
 		return ocispec.Descriptor{}, err
 	}
@@ -16,6 +16,7 @@ limitations under the License.
 package manifest
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -105,9 +106,10 @@ Example - Push a manifest to an OCI image layout folder 'layout-dir' and tag wit
 	}
 
 	opts.EnableDistributionSpecFlag()
-	option.ApplyFlags(&opts, cmd.Flags())
 	cmd.Flags().StringVarP(&opts.mediaType, "media-type", "", "", "media type of manifest")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
@@ -151,6 +153,12 @@ func pushManifest(cmd *cobra.Command, opts pushOptions) error {
 	ref := opts.Reference
 	if ref == "" {
 		ref = desc.Digest.String()
+	} else if refDigest, err := digest.Parse(ref); err == nil && refDigest.Algorithm() != desc.Digest.Algorithm() {
+		// address the manifest with the digest algorithm of the reference
+		desc.Digest = refDigest.Algorithm().FromBytes(contentBytes)
+		if desc.Digest != refDigest {
+			return fmt.Errorf("manifest digest %s does not match the reference %s", desc.Digest, ref)
+		}
 	}
 	match, err := matchDigest(ctx, target, ref, desc.Digest)
 	if err != nil {
@@ -164,7 +172,12 @@ func pushManifest(cmd *cobra.Command, opts pushOptions) error {
 		if err = opts.PrintStatus(desc, "Uploading"); err != nil {
 			return err
 		}
-		if _, err := oras.TagBytes(ctx, target, mediaType, contentBytes, ref); err != nil {
+		if desc.Digest.Algorithm() == digest.Canonical {
+			_, err = oras.TagBytes(ctx, target, mediaType, contentBytes, ref)
+		} else {
+			err = target.Push(ctx, desc, bytes.NewReader(contentBytes))
+		}
+		if err != nil {
 			return err
 		}
 		if err = opts.PrintStatus(desc, "Uploaded "); err != nil {
@@ -172,13 +185,24 @@ func pushManifest(cmd *cobra.Command, opts pushOptions) error {
 		}
 	}
 
-	tagBytesNOpts := oras.DefaultTagBytesNOptions
-	tagBytesNOpts.Concurrency = opts.concurrency
+	tagN := func(target oras.Target) error {
+		if desc.Digest.Algorithm() == digest.Canonical {
+			tagBytesNOpts := oras.DefaultTagBytesNOptions
+			tagBytesNOpts.Concurrency = opts.concurrency
+			_, err := oras.TagBytesN(ctx, target, mediaType, contentBytes, opts.extraRefs, tagBytesNOpts)
+			return err
+		}
+		// manifests addressed by other digest algorithms are tagged by digest
+		tagNOpts := oras.DefaultTagNOptions
+		tagNOpts.Concurrency = opts.concurrency
+		_, err := oras.TagN(ctx, target, desc.Digest.String(), opts.extraRefs, tagNOpts)
+		return err
+	}
 
 	// outputs manifest's descriptor
 	if opts.OutputDescriptor {
 		if len(opts.extraRefs) != 0 {
-			if _, err = oras.TagBytesN(ctx, target, mediaType, contentBytes, opts.extraRefs, tagBytesNOpts); err != nil {
+			if err := tagN(target); err != nil {
 				return err
 			}
 		}
@@ -192,12 +216,12 @@ func pushManifest(cmd *cobra.Command, opts pushOptions) error {
 	if len(opts.extraRefs) != 0 {
 		handler := display.NewManifestPushHandler(opts.Printer)
 		tagListener := listener.NewTaggedListener(target, handler.OnTagged)
-		if _, err = oras.TagBytesN(ctx, tagListener, mediaType, contentBytes, opts.extraRefs, tagBytesNOpts); err != nil {
+		if err := tagN(tagListener); err != nil {
 			return err
 		}
 	}
 
-	_ = opts.Println("Digest:", desc.Digest)
+	_ = opts.PrintDigest(desc.Digest)
 
 	return nil
 }
@@ -17,9 +17,11 @@ package root
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -35,8 +37,10 @@ import (
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/fileref"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/archive"
 	"oras.land/oras/internal/descriptor"
 	"oras.land/oras/internal/graph"
+	"oras.land/oras/internal/registryutil"
 )
 
 type pullOptions struct {
@@ -45,6 +49,7 @@ type pullOptions struct {
 	option.Platform
 	option.Target
 	option.Format
+	option.StreamTo
 
 	concurrency       int
 	KeepOldFiles      bool
@@ -52,6 +57,8 @@ type pullOptions struct {
 	PathTraversal     bool
 	Output            string
 	ManifestConfigRef string
+	keepTemp          bool
+	noFiles           bool
 }
 
 func pullCmd() *cobra.Command {
@@ -77,12 +84,33 @@ Example - Pull files from a registry with local cache:
   export ORAS_CACHE=~/.oras/cache
   oras pull localhost:5000/hello:v1
 
+Example - Pull files from a registry with local cache in a specific directory:
+  oras pull --cache-dir ~/.oras/cache localhost:5000/hello:v1
+
 Example - Pull files from a registry with certain platform:
   oras pull --platform linux/arm/v5 localhost:5000/hello:v1
 
 Example - Pull all files with concurrency level tuned:
   oras pull --concurrency 6 localhost:5000/hello:v1
 
+Example - Pull files through a registry mirror, falling back to the registry:
+  oras pull --mirror mirror.example.com docker.io/library/hello:v1
+
+Example - Pull files with the download rate limited to 10 MiB per second:
+  oras pull --limit-rate 10M localhost:5000/hello:v1
+
+Example - Pull files by digest, failing if the tag v1 no longer resolves to the digest:
+  oras pull --verify-tag localhost:5000/hello:v1@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - [Experimental] Pull the only file of an artifact and stream it into tar without saving it to disk:
+  oras pull --stream-to "tar -xzf -" localhost:5000/hello:v1
+
+Example - Print a field of the config of an artifact without pulling any file:
+  oras pull --no-files --format go-template='{{.config.metadata.version}}' localhost:5000/hello:v1
+
+Example - Print an annotation of the manifest of the linux/amd64 image, failing if the annotation does not exist:
+  oras pull --no-files --platform linux/amd64 --strict-template --format jsonpath="$.manifest.annotations['org.opencontainers.image.version']" localhost:5000/hello:v1
+
 Example - Pull artifact files from an OCI image layout folder 'layout-dir':
   oras pull --oci-layout layout-dir:v1
 
@@ -92,6 +120,12 @@ Example - Pull artifact files from an OCI layout archive 'layout.tar':
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the artifact reference you want to pull"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			opts.RawReference = args[0]
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "stream-to", "output", "format", "config", "include-subject"); err != nil {
+				return err
+			}
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "no-files", "stream-to", "output", "config", "include-subject"); err != nil {
+				return err
+			}
 			return option.Parse(cmd, &opts)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -100,18 +134,27 @@ Example - Pull artifact files from an OCI layout archive 'layout.tar':
 	}
 
 	cmd.Flags().BoolVarP(&opts.KeepOldFiles, "keep-old-files", "k", false, "do not replace existing files when pulling, treat them as errors")
-	cmd.Flags().BoolVarP(&opts.PathTraversal, "allow-path-traversal", "T", false, "allow storing files out of the output directory")
+	cmd.Flags().BoolVarP(&opts.PathTraversal, "allow-path-traversal", "T", false, "allow storing files and symbolic link targets out of the output directory")
 	cmd.Flags().BoolVarP(&opts.IncludeSubject, "include-subject", "", false, "[Preview] recursively pull the subject of artifacts")
+	cmd.Flags().BoolVar(&opts.keepTemp, "keep-temp", false, "keep temporary and partially pulled files on failure for debugging")
 	cmd.Flags().StringVarP(&opts.Output, "output", "o", ".", "output directory")
 	cmd.Flags().StringVarP(&opts.ManifestConfigRef, "config", "", "", "output manifest config file")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
-	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	cmd.Flags().BoolVar(&opts.noFiles, "no-files", false, "only pull the manifest and the config for formatted output without pulling any file")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate, option.FormatTypeJSONPath)
+	opts.EnableAnonymousFallback()
+	opts.EnableMirrorFlag()
+	opts.EnableVerifyTagFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
 func runPull(cmd *cobra.Command, opts *pullOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	opts.Platform.MaxConfigBytes = opts.MaxMetadataBytes
+	ctx, cleanup := command.WithCleanup(ctx, logger, opts.keepTemp)
+	defer cleanup()
 	statusHandler, metadataHandler, err := display.NewPullHandler(opts.Printer, opts.Format, opts.Path, opts.TTY)
 	if err != nil {
 		return err
@@ -119,9 +162,7 @@ func runPull(cmd *cobra.Command, opts *pullOptions) error {
 	// Copy Options
 	copyOptions := oras.DefaultCopyOptions
 	copyOptions.Concurrency = opts.concurrency
-	if opts.Platform.Platform != nil {
-		copyOptions.WithTargetPlatform(opts.Platform.Platform)
-	}
+	opts.Platform.UpdateCopyOptions(&copyOptions)
 	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
@@ -129,17 +170,37 @@ func runPull(cmd *cobra.Command, opts *pullOptions) error {
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
+	if err := opts.VerifyTag(ctx, target); err != nil {
+		return err
+	}
 	src, err := opts.CachedTarget(target)
 	if err != nil {
 		return err
 	}
-	dst, err := file.New(opts.Output)
+	if opts.Streaming() {
+		return streamFile(ctx, src, opts)
+	}
+	if opts.noFiles {
+		desc, err := pullMetadata(ctx, src, metadataHandler, opts)
+		if err != nil {
+			return err
+		}
+		return metadataHandler.OnCompleted(&opts.Target, desc)
+	}
+	store, err := file.New(opts.Output)
+	if err != nil {
+		return err
+	}
+	store.AllowPathTraversalOnWrite = opts.PathTraversal
+	store.DisableOverwrite = opts.KeepOldFiles
+	dst, err := archive.NewUnpackStore(store, opts.Output, func(message string) {
+		logger.Warn(message)
+	})
 	if err != nil {
+		_ = store.Close()
 		return err
 	}
 	defer dst.Close()
-	dst.AllowPathTraversalOnWrite = opts.PathTraversal
-	dst.DisableOverwrite = opts.KeepOldFiles
 
 	desc, err := doPull(ctx, src, dst, copyOptions, metadataHandler, statusHandler, opts)
 	if err != nil {
@@ -152,6 +213,71 @@ func runPull(cmd *cobra.Command, opts *pullOptions) error {
 	return metadataHandler.OnCompleted(&opts.Target, desc)
 }
 
+// pullMetadata pulls the manifest of the artifact and its config if the config
+// is in JSON, without pulling any file.
+func pullMetadata(ctx context.Context, src oras.ReadOnlyTarget, metadataHandler metadata.PullHandler, opts *pullOptions) (ocispec.Descriptor, error) {
+	root, err := opts.Platform.Resolve(ctx, src, opts.Reference)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
+	}
+	manifestJSON, err := content.FetchAll(ctx, src, root)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	var manifest any
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to parse the manifest %s: %w", root.Digest, err)
+	}
+	var successors struct {
+		Config *ocispec.Descriptor `json:"config"`
+	}
+	if err := json.Unmarshal(manifestJSON, &successors); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to parse the manifest %s: %w", root.Digest, err)
+	}
+	var config any
+	if successors.Config != nil && strings.HasSuffix(successors.Config.MediaType, "json") {
+		configJSON, err := registryutil.FetchConfig(ctx, src, *successors.Config, opts.MaxMetadataBytes)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if err := json.Unmarshal(configJSON, &config); err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to parse the config %s: %w", successors.Config.Digest, err)
+		}
+	}
+	return root, metadataHandler.OnMetadataPulled(manifest, config)
+}
+
+// streamFile streams the only file of the artifact into the stream command.
+func streamFile(ctx context.Context, src oras.ReadOnlyTarget, opts *pullOptions) error {
+	root, err := opts.Platform.Resolve(ctx, src, opts.Reference)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
+	}
+	layers, _, _, err := graph.Successors(ctx, src, root)
+	if err != nil {
+		return err
+	}
+	var files []ocispec.Descriptor
+	for _, layer := range layers {
+		if layer.Annotations[ocispec.AnnotationTitle] != "" {
+			files = append(files, layer)
+		}
+	}
+	if len(files) != 1 {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("%s contains %d files while `--stream-to` expects exactly one", opts.AnnotatedReference(), len(files)),
+			Recommendation: `Use "oras blob fetch --stream-to" to stream a specific blob of the artifact`,
+		}
+	}
+	rc, err := src.Fetch(ctx, files[0])
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	vr := content.NewVerifyReader(rc, files[0])
+	return opts.Stream(ctx, vr, vr.Verify)
+}
+
 func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget, opts oras.CopyOptions, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, po *pullOptions) (ocispec.Descriptor, error) {
 	var configPath, configMediaType string
 	var err error
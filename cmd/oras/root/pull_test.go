@@ -16,12 +16,19 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"testing"
 
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
 	"oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
 )
 
 func Test_runPull_errType(t *testing.T) {
@@ -41,3 +48,65 @@ func Test_runPull_errType(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+func Test_pullCmd_noFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configJSON := []byte(`{"metadata":{"version":"1.2.3"}}`)
+	config := content.NewDescriptorFromBytes("application/vnd.test.config+json", configJSON)
+	if err := store.Push(ctx, config, bytes.NewReader(configJSON)); err != nil {
+		t.Fatal(err)
+	}
+	layer := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, []byte("hello"))
+	layer.Annotations = map[string]string{ocispec.AnnotationTitle: "hello.txt"}
+	if err := store.Push(ctx, layer, bytes.NewReader([]byte("hello"))); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+		Layers:           []ocispec.Descriptor{layer},
+		ConfigDescriptor: &config,
+		ManifestAnnotations: map[string]string{
+			ocispec.AnnotationVersion: "v1",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Tag(ctx, manifest, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{"config field", []string{"--format", "go-template={{.config.metadata.version}}"}, "1.2.3", false},
+		{"annotation", []string{"--format", "jsonpath=$.manifest.annotations['" + ocispec.AnnotationVersion + "']"}, "v1\n", false},
+		{"nonexistent field", []string{"--format", "go-template={{.config.metadata.name}}"}, "<no value>", false},
+		{"nonexistent field in strict mode", []string{"--strict-template", "--format", "go-template={{.config.metadata.name}}"}, "", true},
+		{"nonexistent JSONPath in strict mode", []string{"--strict-template", "--format", "jsonpath=$.config.name"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer output.SetStrictTemplates(false)
+			var out bytes.Buffer
+			cmd := pullCmd()
+			cmd.SetArgs(append([]string{"--oci-layout", "--no-files", dir + ":v1"}, tt.args...))
+			cmd.SetOut(&out)
+			cmd.SetErr(io.Discard)
+			cmd.SilenceUsage = true
+			if err := cmd.Execute(); (err != nil) != tt.wantErr {
+				t.Fatalf("pull error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if out.String() != tt.want {
+				t.Errorf("pull output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ limitations under the License.
 package root
 
 import (
+	"context"
 	"errors"
 	"strings"
 
@@ -29,10 +30,13 @@ import (
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 	"oras.land/oras/cmd/oras/internal/display/status"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/fileref"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/archive"
 	"oras.land/oras/internal/contentutil"
 	"oras.land/oras/internal/listener"
 	"oras.land/oras/internal/registryutil"
@@ -44,11 +48,15 @@ type pushOptions struct {
 	option.ImageSpec
 	option.Target
 	option.Format
+	option.Validate
 
 	extraRefs         []string
 	manifestConfigRef string
 	artifactType      string
 	concurrency       int
+	dereference       bool
+	dedupeIdentical   bool
+	keepTemp          bool
 }
 
 func pushCmd() *cobra.Command {
@@ -79,6 +87,12 @@ Example - Push file "hi.txt" with config type "application/vnd.me.config":
 Example - Push file "hi.txt" with the custom manifest config "config.json" of the custom media type "application/vnd.me.config":
   oras push --config config.json:application/vnd.me.config localhost:5000/hello:v1 hi.txt
 
+Example - Push directory "dir" with the files pointed by its symbolic links instead of the links:
+  oras push --dereference localhost:5000/hello:v1 dir
+
+Example - Push directory "dir" with byte-identical files stored once:
+  oras push --dedupe-identical localhost:5000/hello:v1 dir
+
 Example - Push file to the insecure registry:
   oras push --insecure localhost:5000/hello:v1 hi.txt
 
@@ -88,6 +102,9 @@ Example - Push file to the HTTP registry:
 Example - Push repository with manifest annotations:
   oras push --annotation "key=val" localhost:5000/hello:v1
 
+Example - Push file "hi.txt" with an annotation on its layer:
+  oras push --annotation "hi.txt=key=val" localhost:5000/hello:v1 hi.txt
+
 Example - Push repository with manifest annotation file:
   oras push --annotation-file annotation.json localhost:5000/hello:v1
 
@@ -143,14 +160,21 @@ Example - Push file "hi.txt" into an OCI image layout folder 'layout-dir' with t
 	}
 	cmd.Flags().StringVarP(&opts.manifestConfigRef, "config", "", "", "`path` of image config file")
 	cmd.Flags().StringVarP(&opts.artifactType, "artifact-type", "", "", "artifact type")
+	_ = cmd.RegisterFlagCompletionFunc("artifact-type", option.CompleteArtifactType)
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	cmd.Flags().BoolVarP(&opts.dereference, "dereference", "", false, "follow symbolic links in directories and push the files they point to instead of the links")
+	cmd.Flags().BoolVarP(&opts.dedupeIdentical, "dedupe-identical", "", false, "store byte-identical files in directories once, restored as separate copies on pull")
+	cmd.Flags().BoolVar(&opts.keepTemp, "keep-temp", false, "keep the temporary tarballs of directories for debugging")
 	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
 func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	ctx, cleanup := command.WithCleanup(ctx, logger, opts.keepTemp)
+	defer cleanup()
 	displayStatus, displayMetadata, err := display.NewPushHandler(opts.Printer, opts.Format, opts.TTY)
 	if err != nil {
 		return err
@@ -182,7 +206,15 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 		desc.Annotations = packOpts.ConfigAnnotations
 		packOpts.ConfigDescriptor = &desc
 	}
-	descs, err := loadFiles(ctx, store, annotations, opts.FileRefs, displayStatus)
+	packer, err := newDirPacker(ctx, archive.TarOptions{
+		Dereference:     opts.dereference,
+		DedupeIdentical: opts.dedupeIdentical,
+	})
+	if err != nil {
+		return err
+	}
+	defer packer.Close()
+	descs, err := loadFiles(ctx, store, annotations, opts.FileRefs, displayStatus, packer)
 	if err != nil {
 		return err
 	}
@@ -193,6 +225,15 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 		if err != nil {
 			return ocispec.Descriptor{}, err
 		}
+		if opts.Validate.Enabled() {
+			manifestBytes, err := content.FetchAll(ctx, memoryStore, root)
+			if err != nil {
+				return ocispec.Descriptor{}, err
+			}
+			if err := opts.ValidateManifest(logger, root, manifestBytes); err != nil {
+				return ocispec.Descriptor{}, err
+			}
+		}
 		if err = memoryStore.Tag(ctx, root, root.Digest.String()); err != nil {
 			return ocispec.Descriptor{}, err
 		}
@@ -212,6 +253,7 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	copyOptions.Concurrency = opts.concurrency
 	union := contentutil.MultiReadOnlyTarget(memoryStore, store)
 	displayStatus.UpdateCopyOptions(&copyOptions.CopyGraphOptions, union)
+	reportTransfers(&copyOptions.CopyGraphOptions, displayMetadata, model.TransferStateUploaded)
 	copy := func(root ocispec.Descriptor) error {
 		// add both pull and push scope hints for dst repository
 		// to save potential push-scope token requests during copy
@@ -265,6 +307,25 @@ func doPush(dst oras.Target, stopTrack status.StopTrackTargetFunc, pack packFunc
 	return pushArtifact(dst, pack, copy)
 }
 
+// reportTransfers chains the hooks of opts to report the completion state of
+// each descriptor to handler. copiedState is reported for the descriptors
+// copied to the destination.
+func reportTransfers(opts *oras.CopyGraphOptions, handler metadata.TransferHandler, copiedState string) {
+	report := func(hook func(context.Context, ocispec.Descriptor) error, state string) func(context.Context, ocispec.Descriptor) error {
+		return func(ctx context.Context, desc ocispec.Descriptor) error {
+			if hook != nil {
+				if err := hook(ctx, desc); err != nil {
+					return err
+				}
+			}
+			return handler.OnTransferred(desc, state)
+		}
+	}
+	opts.PostCopy = report(opts.PostCopy, copiedState)
+	opts.OnCopySkipped = report(opts.OnCopySkipped, model.TransferStateExists)
+	opts.OnMounted = report(opts.OnMounted, model.TransferStateMounted)
+}
+
 type packFunc func() (ocispec.Descriptor, error)
 type copyFunc func(desc ocispec.Descriptor) error
 
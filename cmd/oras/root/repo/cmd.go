@@ -25,6 +25,8 @@ func Cmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		deleteCmd(),
+		diskUsageCmd(),
 		listCmd(),
 		showTagsCmd(),
 	)
@@ -0,0 +1,223 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/registryutil"
+)
+
+type deleteOptions struct {
+	option.Common
+	option.Confirmation
+	option.Target
+}
+
+// taggedManifest is a manifest to be deleted along with its tags.
+type taggedManifest struct {
+	desc ocispec.Descriptor
+	tags []string
+}
+
+func deleteCmd() *cobra.Command {
+	var opts deleteOptions
+	cmd := &cobra.Command{
+		Use:     "delete [flags] <name>",
+		Aliases: []string{"remove", "rm"},
+		Short:   "[Preview] Delete all tags and tagged manifests of a repository",
+		Long: `[Preview] Delete all tags and tagged manifests of a repository
+
+** This command is in preview and under development. **
+
+The manifests pointed to by the tags of the repository are deleted after the
+tags are removed. The repository name must be typed to confirm the deletion
+unless --force is specified.
+
+Example - Delete all tags and manifests of the repository 'localhost:5000/hello':
+  oras repo delete localhost:5000/hello
+
+Example - Delete all tags and manifests of the repository 'localhost:5000/hello' without prompting confirmation:
+  oras repo delete --force localhost:5000/hello
+
+Example - Delete all tags and manifests of an OCI image layout folder 'layout-dir':
+  oras repo delete --oci-layout layout-dir
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the repository to delete"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			if opts.Reference != "" {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("%q: unexpected tag or digest", opts.RawReference),
+					Usage:          fmt.Sprintf("%s %s", cmd.Parent().CommandPath(), cmd.Use),
+					Recommendation: fmt.Sprintf(`Please specify a repository without tag or digest. To delete a single manifest, use "oras manifest delete %s"`, opts.RawReference),
+				}
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deleteRepository(cmd, &opts)
+		},
+	}
+
+	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func deleteRepository(cmd *cobra.Command, opts *deleteOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	target, err := opts.NewTarget(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	lister, ok := target.(registry.TagLister)
+	if !ok {
+		return fmt.Errorf("repository deletion is not supported by the target type %q", opts.Type)
+	}
+	deleter, ok := target.(content.Deleter)
+	if !ok {
+		return fmt.Errorf("repository deletion is not supported by the target type %q", opts.Type)
+	}
+	var untag func(ctx context.Context, tag string) error
+	switch t := target.(type) {
+	case *remote.Repository:
+		untag = func(ctx context.Context, tag string) error {
+			return registryutil.DeleteTag(ctx, t, tag)
+		}
+	case *oci.Store:
+		untag = t.Untag
+	}
+
+	manifests, err := listTaggedManifests(ctx, lister, target)
+	if err != nil {
+		return err
+	}
+	if len(manifests) == 0 {
+		return opts.Println("No tags found in", opts.Path)
+	}
+
+	// summarize the deletion
+	tagCount := 0
+	for _, m := range manifests {
+		tagCount += len(m.tags)
+	}
+	_ = opts.Printf("The following %d manifests and %d tags will be deleted from %s:\n", len(manifests), tagCount, opts.Path)
+	for _, m := range manifests {
+		_ = opts.Printf("  %s  %s\n", m.desc.Digest, strings.Join(m.tags, ", "))
+	}
+	confirmed, err := opts.AskForTypedConfirmation(os.Stdin, "This operation cannot be undone.", opts.Path)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+
+	var errs []error
+	var remaining []string
+	for _, m := range manifests {
+		// some registries refuse to delete tagged manifests
+		for _, tag := range m.tags {
+			if untag == nil {
+				break
+			}
+			if err := untag(ctx, tag); err != nil {
+				if errors.Is(err, registryutil.ErrTagDeletionUnsupported) {
+					// the manifests are deleted together with their tags
+					untag = nil
+					break
+				}
+				logger.Debugf("failed to delete tag %s: %v", tag, err)
+			}
+		}
+		if err := deleter.Delete(ctx, m.desc); err != nil {
+			if errors.Is(err, errdef.ErrNotFound) {
+				// already deleted, e.g. concurrently
+				_ = opts.Println("Missing", m.desc.Digest)
+				continue
+			}
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", m.desc.Digest, err))
+			remaining = append(remaining, m.desc.Digest.String())
+			continue
+		}
+		_ = opts.Println("Deleted", m.desc.Digest)
+	}
+	if len(errs) > 0 {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("failed to delete %d of %d manifests from %s: %w", len(errs), len(manifests), opts.Path, errors.Join(errs...)),
+			Recommendation: fmt.Sprintf("The following manifests remain in the repository:\n  %s", strings.Join(remaining, "\n  ")),
+		}
+	}
+	return nil
+}
+
+// listTaggedManifests lists the tags of the repository and groups them by the
+// manifests they point to, sorted by digest.
+func listTaggedManifests(ctx context.Context, lister registry.TagLister, resolver content.Resolver) ([]taggedManifest, error) {
+	byDigest := make(map[digest.Digest]*taggedManifest)
+	err := lister.Tags(ctx, "", func(tags []string) error {
+		for _, tag := range tags {
+			desc, err := resolver.Resolve(ctx, tag)
+			if err != nil {
+				if errors.Is(err, errdef.ErrNotFound) {
+					// deleted after listing
+					continue
+				}
+				return fmt.Errorf("failed to resolve tag %s: %w", tag, err)
+			}
+			m, ok := byDigest[desc.Digest]
+			if !ok {
+				m = &taggedManifest{desc: desc}
+				byDigest[desc.Digest] = m
+			}
+			m.tags = append(m.tags, tag)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	manifests := make([]taggedManifest, 0, len(byDigest))
+	for _, m := range byDigest {
+		sort.Strings(m.tags)
+		manifests = append(manifests, *m)
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].desc.Digest < manifests[j].desc.Digest
+	})
+	return manifests, nil
+}
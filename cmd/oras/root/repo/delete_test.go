@@ -0,0 +1,164 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+)
+
+func Test_deleteRepository_ociLayout(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifests []ocispec.Descriptor
+	for _, artifactType := range []string{"application/vnd.test.a", "application/vnd.test.b"} {
+		desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifests = append(manifests, desc)
+	}
+	for tag, desc := range map[string]ocispec.Descriptor{"v1": manifests[0], "latest": manifests[0], "v2": manifests[1]} {
+		if err := store.Tag(ctx, desc, tag); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := deleteCmd()
+	cmd.SetArgs([]string{"--oci-layout", "--force", dir})
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("repo delete error = %v", err)
+	}
+
+	store, err = oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tags []string
+	if err := store.Tags(ctx, "", func(got []string) error {
+		tags = append(tags, got...)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 0 {
+		t.Errorf("remaining tags = %v, want none", tags)
+	}
+	for _, desc := range manifests {
+		if exists, err := store.Exists(ctx, desc); err != nil || exists {
+			t.Errorf("manifest %s exists = %v, %v, want false", desc.Digest, exists, err)
+		}
+	}
+}
+
+func Test_deleteRepository_partialFailure(t *testing.T) {
+	newManifest := func(name string) []byte {
+		return []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[],"annotations":{"name":"` + name + `"}}`)
+	}
+	manifests := make(map[string][]byte)
+	for _, name := range []string{"deletable", "protected"} {
+		manifest := newManifest(name)
+		manifests[digest.FromBytes(manifest).String()] = manifest
+	}
+	deletable := digest.FromBytes(newManifest("deletable"))
+	protected := digest.FromBytes(newManifest("protected"))
+	tagged := map[string]digest.Digest{"v1": deletable, "v2": protected, "latest": protected}
+	var mu sync.Mutex
+	var deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "/v2/test/manifests/"
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/test/tags/list":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"test","tags":["latest","v1","v2"]}`))
+		case r.Method == http.MethodHead && strings.HasPrefix(r.URL.Path, prefix):
+			dgst, ok := tagged[strings.TrimPrefix(r.URL.Path, prefix)]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+			w.Header().Set("Docker-Content-Digest", dgst.String())
+			w.Header().Set("Content-Length", strconv.Itoa(len(manifests[dgst.String()])))
+		case r.Method == http.MethodGet && manifests[strings.TrimPrefix(r.URL.Path, prefix)] != nil:
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+			_, _ = w.Write(manifests[strings.TrimPrefix(r.URL.Path, prefix)])
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, prefix):
+			ref := strings.TrimPrefix(r.URL.Path, prefix)
+			switch ref {
+			case deletable.String():
+				mu.Lock()
+				deleted = append(deleted, ref)
+				mu.Unlock()
+				w.WriteHeader(http.StatusAccepted)
+			case protected.String():
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":[{"code":"DENIED","message":"immutable"}]}`))
+			default:
+				// tag deletion is not supported
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := deleteCmd()
+	cmd.SetArgs([]string{"--plain-http", "--force", uri.Host + "/test"})
+	cmd.SetErr(io.Discard)
+	err = cmd.Execute()
+	if err == nil {
+		t.Fatal("repo delete error = nil, want error")
+	}
+	var oErr *oerrors.Error
+	if !errors.As(err, &oErr) || !strings.Contains(oErr.Recommendation, protected.String()) {
+		t.Errorf("repo delete error = %v, want the remaining digest %s reported", err, protected)
+	}
+	if strings.Contains(oErr.Recommendation, deletable.String()) {
+		t.Errorf("deleted digest %s is reported as remaining", deletable)
+	}
+	if errors.Is(err, errdef.ErrNotFound) {
+		t.Errorf("repo delete error = %v, want no not found error", err)
+	}
+	if len(deleted) != 1 || deleted[0] != deletable.String() {
+		t.Errorf("deleted = %v, want [%s]", deleted, deletable)
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/docker"
+	"oras.land/oras/internal/graph"
+)
+
+type diskUsageOptions struct {
+	option.Cache
+	option.Common
+	option.Format
+	option.Platform
+	option.Target
+
+	recursive bool
+}
+
+func diskUsageCmd() *cobra.Command {
+	var opts diskUsageOptions
+	cmd := &cobra.Command{
+		Use:   "du [flags] <name>{:<tag>|@<digest>}",
+		Short: "[Preview] Show the size of an artifact graph",
+		Long: `[Preview] Show the size of an artifact graph
+
+The total size counts the contents each time they are referenced, while the
+deduplicated size counts each digest once, which is the storage the artifact
+takes in a registry.
+
+Example - Show the size of an artifact:
+  oras repo du localhost:5000/hello:v1
+
+Example - Show the size of an artifact and its referrers:
+  oras repo du -r localhost:5000/hello:v1
+
+Example - Show the size of the image of a platform in a multi-platform image:
+  oras repo du --platform linux/amd64 localhost:5000/hello:v1
+
+Example - Show the size of an artifact in JSON format:
+  oras repo du --format json localhost:5000/hello:v1
+
+Example - Show the size of an artifact in an OCI image layout folder 'layout-dir':
+  oras repo du --oci-layout layout-dir:v1
+`,
+		Args:    oerrors.CheckArgs(argument.Exactly(1), "the artifact to calculate the size of"),
+		Aliases: []string{"show-size"},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiskUsage(cmd, &opts)
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.recursive, "recursive", "r", false, "include the referrers of the artifact recursively")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	opts.EnableAnonymousFallback()
+	opts.EnableMirrorFlag()
+	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runDiskUsage(cmd *cobra.Command, opts *diskUsageOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	opts.Platform.MaxConfigBytes = opts.MaxMetadataBytes
+	handler, err := display.NewDiskUsageHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	src, err := opts.CachedGraphTarget(target)
+	if err != nil {
+		return err
+	}
+
+	root, err := opts.Platform.Resolve(ctx, src, opts.Reference)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
+	}
+	du, err := diskUsage(ctx, src, root, opts.recursive)
+	if err != nil {
+		return err
+	}
+	du.Root = model.FromDescriptor(opts.Path, root)
+	return handler.OnDiskUsage(du)
+}
+
+// diskUsage walks the graph rooted by root, and the referrers of the manifests
+// in the graph if recursive, and sums the sizes of the contents. Each digest is
+// counted once in the deduplicated size.
+func diskUsage(ctx context.Context, src content.ReadOnlyGraphStorage, root ocispec.Descriptor, recursive bool) (model.DiskUsage, error) {
+	var du model.DiskUsage
+	visited := make(map[digest.Digest]bool)
+	usages := make(map[string]*model.MediaTypeUsage)
+	stack := []ocispec.Descriptor{root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		du.TotalSize += node.Size
+		if visited[node.Digest] {
+			continue
+		}
+		visited[node.Digest] = true
+		du.DeduplicatedSize += node.Size
+		du.Count++
+		usage, ok := usages[node.MediaType]
+		if !ok {
+			usage = &model.MediaTypeUsage{MediaType: node.MediaType}
+			usages[node.MediaType] = usage
+		}
+		usage.Count++
+		usage.Size += node.Size
+
+		if !isManifest(node.MediaType) {
+			continue
+		}
+		successors, _, config, err := graph.Successors(ctx, src, node)
+		if err != nil {
+			return model.DiskUsage{}, err
+		}
+		stack = append(stack, successors...)
+		if config != nil {
+			stack = append(stack, *config)
+		}
+		if recursive {
+			referrers, err := registry.Referrers(ctx, src, node, "")
+			if err != nil {
+				return model.DiskUsage{}, err
+			}
+			stack = append(stack, referrers...)
+		}
+	}
+
+	du.MediaTypes = make([]model.MediaTypeUsage, 0, len(usages))
+	for _, usage := range usages {
+		du.MediaTypes = append(du.MediaTypes, *usage)
+	}
+	sort.Slice(du.MediaTypes, func(i, j int) bool {
+		if du.MediaTypes[i].Size != du.MediaTypes[j].Size {
+			return du.MediaTypes[i].Size > du.MediaTypes[j].Size
+		}
+		return du.MediaTypes[i].MediaType < du.MediaTypes[j].MediaType
+	})
+	return du, nil
+}
+
+func isManifest(mediaType string) bool {
+	switch mediaType {
+	case ocispec.MediaTypeImageManifest, ocispec.MediaTypeImageIndex,
+		docker.MediaTypeManifest, docker.MediaTypeManifestList,
+		graph.MediaTypeArtifactManifest:
+		return true
+	}
+	return false
+}
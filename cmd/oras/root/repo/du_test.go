@@ -0,0 +1,118 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+func Test_diskUsage(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	push := func(mediaType string, blob []byte) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(mediaType, blob)
+		if err := store.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	pushJSON := func(mediaType string, v any) ocispec.Descriptor {
+		blob, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return push(mediaType, blob)
+	}
+	pushManifest := func(subject *ocispec.Descriptor, layers ...ocispec.Descriptor) ocispec.Descriptor {
+		return pushJSON(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    ocispec.DescriptorEmptyJSON,
+			Layers:    layers,
+			Subject:   subject,
+		})
+	}
+	config := push(ocispec.MediaTypeEmptyJSON, []byte("{}"))
+	shared := push(ocispec.MediaTypeImageLayer, []byte("shared layer"))
+	amd64 := pushManifest(nil, shared, push(ocispec.MediaTypeImageLayer, []byte("amd64 layer")))
+	arm64 := pushManifest(nil, shared, push(ocispec.MediaTypeImageLayer, []byte("arm64 layer")))
+	index := pushJSON(ocispec.MediaTypeImageIndex, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{amd64, arm64},
+	})
+	signature := push(ocispec.MediaTypeImageLayer, []byte("signature"))
+	referrer := pushManifest(&index, signature)
+
+	layerSize := shared.Size + int64(len("amd64 layer")) + int64(len("arm64 layer"))
+	manifestSize := index.Size + amd64.Size + arm64.Size
+	tests := []struct {
+		name      string
+		recursive bool
+		want      model.DiskUsage
+	}{
+		{
+			name: "shared contents are deduplicated",
+			want: model.DiskUsage{
+				TotalSize:        manifestSize + layerSize + shared.Size + 2*config.Size,
+				DeduplicatedSize: manifestSize + layerSize + config.Size,
+				Count:            7,
+				MediaTypes: []model.MediaTypeUsage{
+					{MediaType: ocispec.MediaTypeImageManifest, Count: 2, Size: amd64.Size + arm64.Size},
+					{MediaType: ocispec.MediaTypeImageIndex, Count: 1, Size: index.Size},
+					{MediaType: ocispec.MediaTypeImageLayer, Count: 3, Size: layerSize},
+					{MediaType: ocispec.MediaTypeEmptyJSON, Count: 1, Size: config.Size},
+				},
+			},
+		},
+		{
+			name:      "referrers are included recursively",
+			recursive: true,
+			want: model.DiskUsage{
+				TotalSize:        manifestSize + layerSize + shared.Size + 3*config.Size + referrer.Size + signature.Size,
+				DeduplicatedSize: manifestSize + layerSize + config.Size + referrer.Size + signature.Size,
+				Count:            9,
+				MediaTypes: []model.MediaTypeUsage{
+					{MediaType: ocispec.MediaTypeImageManifest, Count: 3, Size: amd64.Size + arm64.Size + referrer.Size},
+					{MediaType: ocispec.MediaTypeImageIndex, Count: 1, Size: index.Size},
+					{MediaType: ocispec.MediaTypeImageLayer, Count: 4, Size: layerSize + signature.Size},
+					{MediaType: ocispec.MediaTypeEmptyJSON, Count: 1, Size: config.Size},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diskUsage(ctx, store, index, tt.recursive)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diskUsage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -18,11 +18,14 @@ package repo
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry/remote/errcode"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/repository"
@@ -31,9 +34,11 @@ import (
 type repositoryOptions struct {
 	option.Remote
 	option.Common
-	hostname  string
-	namespace string
-	last      string
+	option.Format
+	hostname      string
+	namespace     string
+	namespaceFlag string
+	last          string
 }
 
 func listCmd() *cobra.Command {
@@ -49,12 +54,21 @@ Example - List the repositories under the registry:
 Example - List the repositories under a namespace in the registry:
   oras repo ls localhost:5000/example-namespace
 
+Example - List the repositories under a namespace in the registry with the namespace prefix stripped:
+  oras repo ls --namespace example-namespace/ localhost:5000
+
+Example - List the repositories under the registry in JSON format:
+  oras repo ls --format json localhost:5000
+
 Example - List the repositories under the registry that include values lexically after last:
   oras repo ls --last "last_repo" localhost:5000
 `,
 		Args:    oerrors.CheckArgs(argument.Exactly(1), "the target registry to list repositories from"),
 		Aliases: []string{"list"},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// an invalid path is reported when running the command
+			hostname, _, _ := repository.ParseRepoPath(args[0])
+			opts.SetRegistry(hostname)
 			return option.Parse(cmd, &opts)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -62,11 +76,19 @@ Example - List the repositories under the registry that include values lexically
 			if opts.hostname, opts.namespace, err = repository.ParseRepoPath(args[0]); err != nil {
 				return fmt.Errorf("could not parse repository path: %w", err)
 			}
+			if opts.namespaceFlag != "" {
+				if opts.namespace != "" {
+					return fmt.Errorf("namespace %q is provided in the registry %q, `--namespace` cannot be used at the same time", opts.namespace, args[0])
+				}
+				opts.namespace = strings.TrimSuffix(opts.namespaceFlag, "/") + "/"
+			}
 			return listRepository(cmd, &opts)
 		},
 	}
 
 	cmd.Flags().StringVar(&opts.last, "last", "", "start after the repository specified by `last`")
+	cmd.Flags().StringVar(&opts.namespaceFlag, "namespace", "", "only list repositories under the `namespace`, with the namespace prefix stripped")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Remote)
 }
@@ -77,10 +99,16 @@ func listRepository(cmd *cobra.Command, opts *repositoryOptions) error {
 	if err != nil {
 		return err
 	}
+	handler, err := display.NewRepoListHandler(opts.Printer, opts.Format, reg.Reference.Registry, opts.namespace)
+	if err != nil {
+		return err
+	}
 	err = reg.Repositories(ctx, opts.last, func(repos []string) error {
 		for _, repo := range repos {
 			if subRepo, found := strings.CutPrefix(repo, opts.namespace); found {
-				_ = opts.Println(subRepo)
+				if err := handler.OnRepositoryListed(subRepo); err != nil {
+					return err
+				}
 			}
 		}
 		return nil
@@ -93,8 +121,15 @@ func listRepository(cmd *cobra.Command, opts *repositoryOptions) error {
 		} else {
 			repoErr = fmt.Errorf("could not list repositories for %q", reg.Reference.Host())
 		}
-		return errors.Join(repoErr, err)
+		err = errors.Join(repoErr, err)
+		var errResp *errcode.ErrorResponse
+		if errors.As(err, &errResp) && (errResp.StatusCode == http.StatusNotFound || errResp.StatusCode == http.StatusUnauthorized) {
+			return &oerrors.Error{
+				Err:            err,
+				Recommendation: fmt.Sprintf("The registry %q may not support listing repositories via the catalog API `/v2/_catalog`, or the credential is not authorized to access it", reg.Reference.Host()),
+			}
+		}
+		return err
 	}
-
-	return nil
+	return handler.OnCompleted()
 }
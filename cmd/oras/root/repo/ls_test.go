@@ -0,0 +1,111 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+)
+
+// runListCmd runs repo ls against the registry with args, returning the
+// output.
+func runListCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := listCmd()
+	cmd.SetArgs(append([]string{"--plain-http", "--no-docker-config"}, args...))
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	cmd.SilenceUsage = true
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func Test_listCmd_namespace(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/_catalog" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"repositories":["a/b","a/c/d","ab/e","f"]}`)
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no namespace", []string{uri.Host}, "a/b\na/c/d\nab/e\nf\n"},
+		{"namespace flag", []string{"--namespace", "a", uri.Host}, "b\nc/d\n"},
+		{"namespace flag with trailing slash", []string{"--namespace", "a/", uri.Host}, "b\nc/d\n"},
+		{"namespace in the registry", []string{uri.Host + "/a"}, "b\nc/d\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := runListCmd(t, tt.args...)
+			if err != nil {
+				t.Fatalf("repo ls error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("repo ls output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// namespace specified twice
+	if _, err := runListCmd(t, "--namespace", "a", uri.Host+"/a"); err == nil || !strings.Contains(err.Error(), "`--namespace` cannot be used") {
+		t.Errorf("repo ls error = %v, want the namespace conflict", err)
+	}
+}
+
+func Test_listCmd_catalogUnsupported(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"errors":[{"code":"NOT_FOUND","message":"catalog not supported"}]}`)
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = runListCmd(t, uri.Host)
+	var oErr *oerrors.Error
+	if !errors.As(err, &oErr) {
+		t.Fatalf("repo ls error = %v, want an oerrors.Error", err)
+	}
+	if !strings.Contains(oErr.Recommendation, "catalog API `/v2/_catalog`") {
+		t.Errorf("recommendation = %q, want the catalog API noted", oErr.Recommendation)
+	}
+	if want := "could not list repositories for " + `"` + uri.Host + `"`; !strings.Contains(oErr.Err.Error(), want) {
+		t.Errorf("error = %v, want %q", oErr.Err, want)
+	}
+}
@@ -16,12 +16,19 @@ limitations under the License.
 package repo
 
 import (
-	"strings"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/contentutil"
@@ -29,10 +36,15 @@ import (
 
 type showTagsOptions struct {
 	option.Common
+	option.Format
+	option.ReferrerTags
 	option.Target
 
 	last             string
 	excludeDigestTag bool
+	showDigest       bool
+	digest           string
+	concurrency      int
 }
 
 func showTagsCmd() *cobra.Command {
@@ -45,12 +57,21 @@ func showTagsCmd() *cobra.Command {
 Example - Show tags of the target repository:
   oras repo tags localhost:5000/hello
 
-Example - Show tags in the target repository with digest-like tags hidden:
-  oras repo tags --exclude-digest-tag localhost:5000/hello
+Example - Show tags in the target repository including the tags created by the referrers tag schema:
+  oras repo tags --include-referrer-tags localhost:5000/hello
 
 Example - Show tags of the target repository that include values lexically after last:
   oras repo tags --last "last_tag" localhost:5000/hello
 
+Example - Show tags of the target repository along with the digests they point to:
+  oras repo tags --show-digest localhost:5000/hello
+
+Example - Show tags pointing to a particular digest:
+  oras repo tags --digest sha256:c551125a624189cece9135981621f3f3144564ddabe14b523507bf74c2281d9b localhost:5000/hello
+
+Example - Show tags of the target repository in JSON format:
+  oras repo tags --format json localhost:5000/hello
+
 Example - Show tags of the target OCI image layout folder 'layout-dir':
   oras repo tags --oci-layout layout-dir
 
@@ -67,7 +88,18 @@ Example - [Experimental] Show tags associated with a digest:
 		Aliases: []string{"show-tags"},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			opts.RawReference = args[0]
-			return option.Parse(cmd, &opts)
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			if opts.digest != "" {
+				if opts.Reference != "" {
+					return fmt.Errorf("`--digest` cannot be used with the tag or digest in %q", opts.RawReference)
+				}
+				if _, err := digest.Parse(opts.digest); err != nil {
+					return fmt.Errorf("invalid digest %q: %w", opts.digest, err)
+				}
+			}
+			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return showTags(cmd, &opts)
@@ -75,7 +107,15 @@ Example - [Experimental] Show tags associated with a digest:
 	}
 	cmd.Flags().StringVar(&opts.last, "last", "", "start after the tag specified by `last`")
 	cmd.Flags().BoolVar(&opts.excludeDigestTag, "exclude-digest-tags", false, "[Preview] exclude all digest-like tags such as 'sha256-aaaa...'")
+	_ = cmd.Flags().MarkDeprecated("exclude-digest-tags", "digest-like tags are excluded by default, use --include-referrer-tags to include them")
+	cmd.Flags().BoolVar(&opts.showDigest, "show-digest", false, "show the digest each tag points to, separated by a tab")
+	cmd.Flags().StringVar(&opts.digest, "digest", "", "only show tags pointing to the `digest`")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level for resolving tags")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	opts.EnableAnonymousFallback()
+	opts.EnableMirrorFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
@@ -85,7 +125,11 @@ func showTags(cmd *cobra.Command, opts *showTagsOptions) error {
 	if err != nil {
 		return err
 	}
-	filter := ""
+	handler, err := display.NewRepoTagsHandler(opts.Printer, opts.Format, opts.Path)
+	if err != nil {
+		return err
+	}
+	filter := opts.digest
 	if opts.Reference != "" {
 		if contentutil.IsDigest(opts.Reference) {
 			filter = opts.Reference
@@ -98,32 +142,66 @@ func showTags(cmd *cobra.Command, opts *showTagsOptions) error {
 		}
 		logger.Warnf("[Experimental] querying tags associated to %s, it may take a while...\n", filter)
 	}
-	return finder.Tags(ctx, opts.last, func(tags []string) error {
+	if opts.excludeDigestTag {
+		opts.IncludeReferrerTags = false
+	}
+	tags, err := opts.ListTags(ctx, finder, opts.last)
+	if err != nil {
+		return err
+	}
+	// registries are not guaranteed to return tags in lexical order
+	sort.Strings(tags)
+	if filter == "" && !opts.showDigest {
 		for _, tag := range tags {
-			if opts.excludeDigestTag && isDigestTag(tag) {
-				continue
-			}
-			if filter != "" {
-				if tag == opts.Reference {
-					_ = opts.Println(tag)
-					continue
-				}
-				desc, err := finder.Resolve(ctx, tag)
-				if err != nil {
-					return err
-				}
-				if desc.Digest.String() != filter {
-					continue
-				}
+			if err := handler.OnTagListed(tag, nil); err != nil {
+				return err
 			}
-			_ = opts.Println(tag)
 		}
-		return nil
-	})
+		return handler.OnCompleted()
+	}
+
+	descs := resolveTags(ctx, finder, tags, opts.concurrency, logger)
+	for i, tag := range tags {
+		desc := descs[i]
+		if desc == nil {
+			// failed to resolve
+			continue
+		}
+		if filter != "" && desc.Digest.String() != filter {
+			continue
+		}
+		if !opts.showDigest {
+			desc = nil
+		}
+		if err := handler.OnTagListed(tag, desc); err != nil {
+			return err
+		}
+	}
+	return handler.OnCompleted()
 }
 
-func isDigestTag(tag string) bool {
-	dgst := strings.Replace(tag, "-", ":", 1)
-	_, err := digest.Parse(dgst)
-	return err == nil
+// resolveTags resolves tags concurrently. The descriptor of a tag failed to be
+// resolved is set to nil with a warning logged.
+func resolveTags(ctx context.Context, resolver content.Resolver, tags []string, concurrency int, logger logrus.FieldLogger) []*ocispec.Descriptor {
+	descs := make([]*ocispec.Descriptor, len(tags))
+	var wg sync.WaitGroup
+	limiter := make(chan struct{}, max(concurrency, 1))
+	for i, tag := range tags {
+		wg.Add(1)
+		limiter <- struct{}{}
+		go func(i int, tag string) {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			desc, err := resolver.Resolve(ctx, tag)
+			if err != nil {
+				logger.Warnf("failed to resolve tag %q: %v", tag, err)
+				return
+			}
+			descs[i] = &desc
+		}(i, tag)
+	}
+	wg.Wait()
+	return descs
 }
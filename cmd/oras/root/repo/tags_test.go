@@ -0,0 +1,123 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func Test_resolveTags(t *testing.T) {
+	// prepare
+	ctx := context.Background()
+	store := memory.New()
+	content := []byte("test")
+	desc := ocispec.Descriptor{
+		MediaType: "application/octet-stream",
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	if err := store.Push(ctx, desc, bytes.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range []string{"v1", "v2"} {
+		if err := store.Tag(ctx, desc, tag); err != nil {
+			t.Fatal(err)
+		}
+	}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// test
+	got := resolveTags(ctx, store, []string{"v1", "missing", "v2"}, 2, logger)
+
+	// validate
+	if len(got) != 3 {
+		t.Fatalf("resolveTags() returns %d descriptors, want 3", len(got))
+	}
+	if got[0] == nil || got[0].Digest != desc.Digest {
+		t.Errorf("resolveTags()[0] = %v, want %v", got[0], desc)
+	}
+	if got[1] != nil {
+		t.Errorf("resolveTags()[1] = %v, want nil", got[1])
+	}
+	if got[2] == nil || got[2].Digest != desc.Digest {
+		t.Errorf("resolveTags()[2] = %v, want %v", got[2], desc)
+	}
+}
+
+func Test_showTagsCmd_sorted(t *testing.T) {
+	// a registry returning the tags after last in no particular order
+	tags := []string{"v3", "latest", "v1", "v10", "v2"}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/test/tags/list" {
+			http.NotFound(w, r)
+			return
+		}
+		var list []string
+		last := r.URL.Query().Get("last")
+		for _, tag := range tags {
+			if tag > last {
+				list = append(list, tag)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]any{"name": "test", "tags": list}); err != nil {
+			t.Errorf("failed to encode tags: %v", err)
+		}
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"all tags", nil, "latest\nv1\nv10\nv2\nv3\n"},
+		{"tags after last", []string{"--last", "v1"}, "v10\nv2\nv3\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := showTagsCmd()
+			cmd.SetArgs(append([]string{"--plain-http", "--no-docker-config", uri.Host + "/test"}, tt.args...))
+			cmd.SetOut(&out)
+			cmd.SetErr(io.Discard)
+			cmd.SilenceUsage = true
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("repo tags error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("repo tags output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
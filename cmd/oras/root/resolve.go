@@ -19,7 +19,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"oras.land/oras-go/v2"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
@@ -57,12 +56,16 @@ Example - Resolve digest of the target artifact:
 	}
 
 	cmd.Flags().BoolVarP(&opts.fullRef, "full-reference", "l", false, "print the full artifact reference with digest")
+	opts.EnableAnonymousFallback()
+	opts.EnableMirrorFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
 func runResolve(cmd *cobra.Command, opts *resolveOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	opts.Platform.MaxConfigBytes = opts.MaxMetadataBytes
 	repo, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
@@ -70,18 +73,16 @@ func runResolve(cmd *cobra.Command, opts *resolveOptions) error {
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
-	resolveOpts := oras.DefaultResolveOptions
-	resolveOpts.TargetPlatform = opts.Platform.Platform
-	desc, err := oras.Resolve(ctx, repo, opts.Reference, resolveOpts)
+	desc, err := opts.Platform.Resolve(ctx, repo, opts.Reference)
 
 	if err != nil {
 		return fmt.Errorf("failed to resolve digest: %w", err)
 	}
 
 	if opts.fullRef {
-		_ = opts.Printf("%s@%s\n", opts.Path, desc.Digest)
+		_ = opts.PrintResult(opts.Path + "@" + desc.Digest.String())
 	} else {
-		_ = opts.Println(desc.Digest.String())
+		_ = opts.PrintResult(desc.Digest.String())
 	}
 
 	return nil
@@ -0,0 +1,123 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/registryutil"
+)
+
+type restoreOptions struct {
+	option.Common
+	option.ReferrerTags
+	option.Target
+
+	input       string
+	concurrency int
+}
+
+func restoreCmd() *cobra.Command {
+	var opts restoreOptions
+	cmd := &cobra.Command{
+		Use:   "restore [flags] <file> <name>",
+		Short: "[Experimental] Restore all tagged artifacts of a backup to a repository",
+		Long: `[Experimental] Restore all tagged artifacts of a backup to a repository
+
+The tar archive is created by "oras backup". All tags in the archive are copied
+with their referrers to the target repository.
+
+Example - Restore the backup 'hello.tar' to the repository 'localhost:5000/hello':
+  oras restore hello.tar localhost:5000/hello
+
+Example - Restore the backup with concurrency level tuned:
+  oras restore --concurrency 6 hello.tar localhost:5000/hello
+
+Example - Restore the backup to the OCI image layout folder 'layout-dir':
+  oras restore --oci-layout hello.tar layout-dir
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(2), "the backup archive and the repository to restore to"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.input = args[0]
+			opts.RawReference = args[1]
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			return ensureRepositoryOnly(&opts.Target, cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRestore(cmd, &opts)
+		},
+	}
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	if _, err := os.Stat(opts.input); err != nil {
+		return err
+	}
+	src, err := oci.NewFromTar(ctx, opts.input)
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("%q is truncated or not a tar archive: %w", opts.input, err)
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			// no index.json found
+			return fmt.Errorf("%q is not a backup created by oras backup: %w", opts.input, err)
+		}
+		return err
+	}
+	tags, err := opts.ListTags(ctx, src, "")
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return fmt.Errorf("no tags found in %s", opts.input)
+	}
+
+	dst, err := opts.NewTarget(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
+	copyOpts := newRepositoryCopyOptions(opts.Printer, opts.concurrency)
+	for _, tag := range tags {
+		desc, err := src.Resolve(ctx, tag)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", tag, err)
+		}
+		if err := recursiveCopy(ctx, src, dst, tag, desc, copyOpts); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", tag, err)
+		}
+		if err := opts.Printer.Println("Restored", tag); err != nil {
+			return err
+		}
+	}
+	return opts.Printer.PrintResult(fmt.Sprintf("Restored %d tag(s) from %s to %s", len(tags), opts.input, opts.Path))
+}
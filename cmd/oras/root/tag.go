@@ -18,6 +18,7 @@ package root
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"oras.land/oras/cmd/oras/internal/display"
 	"oras.land/oras/internal/listener"
@@ -25,6 +26,7 @@ import (
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
@@ -59,8 +61,14 @@ Example - Tag the manifest 'v1.0.1' in 'localhost:5000/hello' to 'v1.0.2', 'late
 Example - Tag the manifest 'v1.0.1' in 'localhost:5000/hello' to 'v1.0.1', 'v1.0.2', 'latest' with concurrency level tuned:
   oras tag --concurrency 1 localhost:5000/hello:v1.0.1 v1.0.2 latest
 
+Example - Promote the manifest 'staging' in 'localhost:5000/hello' to 'prod' without copying:
+  oras tag localhost:5000/hello:staging localhost:5000/hello:prod
+
 Example - Tag the manifest 'v1.0.1' to 'v1.0.2' in an OCI image layout folder 'layout-dir':
   oras tag --oci-layout layout-dir:v1.0.1 v1.0.2
+
+Example - Delete the tag 'v1.0.1' in 'localhost:5000/hello':
+  oras tag delete localhost:5000/hello:v1.0.1
 `,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 && (args[0] == "list" || args[0] == "ls") {
@@ -87,18 +95,56 @@ Example - Tag the manifest 'v1.0.1' to 'v1.0.2' in an OCI image layout folder 'l
 				}
 				return err
 			}
-			return nil
+			return opts.parseTargetRefs()
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return tagManifest(cmd, &opts)
 		},
 	}
 
-	option.ApplyFlags(&opts, cmd.Flags())
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.AddCommand(deleteTagCmd())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
 	return oerrors.Command(cmd, &opts.Target)
 }
 
+// parseTargetRefs trims the repository out of the target references pointing
+// to the source repository, and rejects the ones pointing to others.
+func (opts *tagOptions) parseTargetRefs() error {
+	if opts.IsOCILayout {
+		return nil
+	}
+	src, err := registry.ParseReference(opts.RawReference)
+	if err != nil {
+		return err
+	}
+	for i, ref := range opts.targetRefs {
+		if !strings.ContainsAny(ref, "/@") {
+			// plain tag
+			continue
+		}
+		if err := option.ValidateReference(ref); err != nil {
+			return err
+		}
+		dst, err := registry.ParseReference(ref)
+		if err != nil {
+			return err
+		}
+		if dst.Registry != src.Registry || dst.Repository != src.Repository {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("unable to tag %q as %q: tagging across repositories is not supported", opts.RawReference, ref),
+				Recommendation: fmt.Sprintf(`To copy the artifact to another repository, use "oras cp %s %s"`, opts.RawReference, ref),
+			}
+		}
+		if err := dst.ValidateReferenceAsTag(); err != nil {
+			return err
+		}
+		opts.targetRefs[i] = dst.Reference
+	}
+	return nil
+}
+
 func tagManifest(cmd *cobra.Command, opts *tagOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
 	target, err := opts.NewTarget(opts.Common, logger)
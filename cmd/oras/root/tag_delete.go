@@ -0,0 +1,144 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/registryutil"
+)
+
+type deleteTagOptions struct {
+	option.Common
+	option.Confirmation
+	option.Target
+
+	extraTags []string
+}
+
+func deleteTagCmd() *cobra.Command {
+	var opts deleteTagOptions
+	cmd := &cobra.Command{
+		Use:     "delete [flags] <name>:<tag> [<tag>...]",
+		Aliases: []string{"remove", "rm"},
+		Short:   "Delete tags from a registry or an OCI image layout without deleting the manifest",
+		Long: `Delete tags from a registry or an OCI image layout without deleting the manifest
+
+Example - Delete the tag 'v1.0.1' in 'localhost:5000/hello':
+  oras tag delete localhost:5000/hello:v1.0.1
+
+Example - Delete the tags 'v1.0.1', 'v1.0.2' and 'latest' in 'localhost:5000/hello' without prompting confirmation:
+  oras tag delete --force localhost:5000/hello:v1.0.1 v1.0.2 latest
+
+Example - Delete the tag 'v1.0.1' in an OCI image layout folder 'layout-dir':
+  oras tag delete --oci-layout layout-dir:v1.0.1
+`,
+		Args: oerrors.CheckArgs(argument.AtLeast(1), "the tag to delete"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			opts.extraTags = args[1:]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deleteTags(cmd, &opts)
+		},
+	}
+
+	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.ValidArgsFunction = opts.Target.CompleteReference
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func deleteTags(cmd *cobra.Command, opts *deleteTagOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	if opts.Reference == "" || contentutil.IsDigest(opts.Reference) {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("%q: no tag specified", opts.RawReference),
+			Usage:          fmt.Sprintf("%s %s", cmd.Parent().CommandPath(), cmd.Use),
+			Recommendation: fmt.Sprintf(`Please specify a reference in the form of "<name>:<tag>". Run "%s -h" for more options and examples`, cmd.CommandPath()),
+		}
+	}
+
+	// validate all tags before deleting any of them
+	tags := append([]string{opts.Reference}, opts.extraTags...)
+	for _, tag := range tags {
+		if err := (registry.Reference{Reference: tag}).ValidateReferenceAsTag(); err != nil {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("invalid tag %q: %w", tag, err),
+				Recommendation: "No tag is deleted. Please specify the tags after the first one without the repository name, e.g. v1.0.1",
+			}
+		}
+	}
+
+	target, err := opts.NewTarget(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	var untag func(ctx context.Context, tag string) error
+	switch t := target.(type) {
+	case *remote.Repository:
+		untag = func(ctx context.Context, tag string) error {
+			return registryutil.DeleteTag(ctx, t, tag)
+		}
+	case *oci.Store:
+		untag = t.Untag
+	default:
+		return fmt.Errorf("tag deletion is not supported by the target type %q", opts.Type)
+	}
+
+	prompt := fmt.Sprintf("Are you sure you want to delete the tags %s from %q?", strings.Join(tags, ", "), opts.Path)
+	confirmed, err := opts.AskForConfirmation(os.Stdin, prompt)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+
+	for _, tag := range tags {
+		ref := fmt.Sprintf("[%s] %s:%s", opts.Type, opts.Path, tag)
+		if err := untag(ctx, tag); err != nil {
+			switch {
+			case errors.Is(err, errdef.ErrNotFound) && opts.Force:
+				// ignore nonexistent
+				_ = opts.Println("Missing", ref)
+				continue
+			case errors.Is(err, registryutil.ErrTagDeletionUnsupported):
+				return &oerrors.Error{
+					Err:            fmt.Errorf("failed to delete %s: %w", ref, err),
+					Recommendation: fmt.Sprintf(`To remove the tag anyway, use "oras manifest delete %s:%s", which deletes the manifest together with ALL tags pointing to it`, opts.Path, tag),
+				}
+			}
+			return fmt.Errorf("failed to delete %s: %w", ref, err)
+		}
+		_ = opts.Println("Deleted", ref)
+	}
+	return nil
+}
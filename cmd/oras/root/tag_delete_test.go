@@ -0,0 +1,150 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+)
+
+// newTaggedLayout returns an OCI image layout with a manifest tagged with
+// tags.
+func newTaggedLayout(t *testing.T, tags ...string) (string, *oci.Store) {
+	t.Helper()
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range tags {
+		if err := store.Tag(ctx, manifest, tag); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir, store
+}
+
+func Test_deleteTagCmd_ociLayout(t *testing.T) {
+	dir, _ := newTaggedLayout(t, "v1", "v2", "v3")
+	var status bytes.Buffer
+	cmd := deleteTagCmd()
+	// nonexistent tags are ignored with --force
+	cmd.SetArgs([]string{"--oci-layout", "--force", dir + ":v1", "missing", "v3"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(&status)
+	cmd.SilenceUsage = true
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("tag delete error = %v", err)
+	}
+	store, err := oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for tag, wantDeleted := range map[string]bool{"v1": true, "v2": false, "v3": true} {
+		_, err := store.Resolve(ctx, tag)
+		if deleted := errors.Is(err, errdef.ErrNotFound); deleted != wantDeleted {
+			t.Errorf("tag %s deleted = %v, want %v: %v", tag, deleted, wantDeleted, err)
+		}
+	}
+	if want := "Missing [oci-layout] " + dir + ":missing"; !strings.Contains(status.String(), want) {
+		t.Errorf("output %q does not contain %q", status.String(), want)
+	}
+}
+
+func Test_deleteTagCmd_invalidTag(t *testing.T) {
+	dir, store := newTaggedLayout(t, "v1", "v2")
+	cmd := deleteTagCmd()
+	// the invalid tag is rejected before deleting v1
+	cmd.SetArgs([]string{"--oci-layout", "--force", dir + ":v1", "v2", dir + ":v3"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SilenceUsage = true
+	err := cmd.Execute()
+	var oErr *oerrors.Error
+	if !errors.As(err, &oErr) || !strings.Contains(err.Error(), "invalid tag") {
+		t.Fatalf("tag delete error = %v, want invalid tag error", err)
+	}
+	ctx := context.Background()
+	for _, tag := range []string{"v1", "v2"} {
+		if _, err := store.Resolve(ctx, tag); err != nil {
+			t.Errorf("tag %s is deleted: %v", tag, err)
+		}
+	}
+}
+
+func Test_deleteTagCmd_partialFailure(t *testing.T) {
+	var lock sync.Mutex
+	var deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tag, ok := strings.CutPrefix(r.URL.Path, "/v2/test/manifests/")
+		if r.Method != http.MethodDelete || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		lock.Lock()
+		deleted = append(deleted, tag)
+		lock.Unlock()
+		if tag == "v2" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var status bytes.Buffer
+	cmd := deleteTagCmd()
+	cmd.SetArgs([]string{"--plain-http", "--force", uri.Host + "/test:v1", "v2", "v3"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(&status)
+	cmd.SilenceUsage = true
+	err = cmd.Execute()
+	var oErr *oerrors.Error
+	if !errors.As(err, &oErr) || !strings.Contains(oErr.Recommendation, "oras manifest delete "+uri.Host+"/test:v2") {
+		t.Fatalf("tag delete error = %v, want tag deletion unsupported error for v2", err)
+	}
+	// v1 is deleted before the failure and v3 is not attempted
+	if want := []string{"v1", "v2"}; !slices.Equal(deleted, want) {
+		t.Errorf("deleted tags = %v, want %v", deleted, want)
+	}
+	if want := "Deleted [registry] " + uri.Host + "/test:v1"; !strings.Contains(status.String(), want) {
+		t.Errorf("output %q does not contain %q", status.String(), want)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_tagCmd_concurrencyEnv(t *testing.T) {
+	cmd := tagCmd()
+	if usage := cmd.Flags().Lookup("concurrency").Usage; !strings.Contains(usage, "$ORAS_CONCURRENCY") {
+		t.Errorf("usage of --concurrency = %q, want $ORAS_CONCURRENCY noted", usage)
+	}
+}
+
+func Test_tagOptions_parseTargetRefs(t *testing.T) {
+	tests := []struct {
+		name       string
+		rawRef     string
+		targetRefs []string
+		want       []string
+		wantErr    bool
+	}{
+		{"plain tags", "localhost:5000/hello:v1", []string{"v2", "latest"}, []string{"v2", "latest"}, false},
+		{"same repository", "localhost:5000/hello:staging", []string{"localhost:5000/hello:prod", "v2"}, []string{"prod", "v2"}, false},
+		{"different repository", "localhost:5000/hello:staging", []string{"localhost:5000/world:prod"}, nil, true},
+		{"different registry", "localhost:5000/hello:staging", []string{"example.com/hello:prod"}, nil, true},
+		{"digest target", "localhost:5000/hello:staging", []string{"localhost:5000/hello@sha256:9463e0d192846bc994279417b50114606712d516aab45f4d8b31cbc6e46aad71"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &tagOptions{targetRefs: tt.targetRefs}
+			opts.RawReference = tt.rawRef
+			err := opts.parseTargetRefs()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTargetRefs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(opts.targetRefs, tt.want) {
+				t.Errorf("parseTargetRefs() = %v, want %v", opts.targetRefs, tt.want)
+			}
+		})
+	}
+}
@@ -18,16 +18,22 @@ package root
 import (
 	"fmt"
 	"os"
-	"runtime"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/cmd/oras/internal/output"
-	"oras.land/oras/internal/version"
 )
 
+type versionOptions struct {
+	option.Format
+}
+
 func versionCmd() *cobra.Command {
+	var opts versionOptions
 	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Show the oras version information",
@@ -35,6 +41,12 @@ func versionCmd() *cobra.Command {
 
 Example - print version:
   oras version
+
+Example - print version in JSON format:
+  oras version --format json
+
+Example - print the version string only:
+  oras version --format go-template='{{.version}}'
 `,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 0 {
@@ -45,36 +57,24 @@ Example - print version:
 			}
 			return nil
 		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return option.Parse(cmd, &opts)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			printer := output.NewPrinter(cmd.OutOrStdout(), cmd.ErrOrStderr(), false)
-			return runVersion(printer)
+			return runVersion(printer, opts.Format)
 		},
 	}
 
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
 	return cmd
 }
 
-func runVersion(printer *output.Printer) error {
-	items := [][]string{
-		{"Version", version.GetVersion()},
-		{"Go version", runtime.Version()},
-	}
-	if version.GitCommit != "" {
-		items = append(items, []string{"Git commit", version.GitCommit})
+func runVersion(printer *output.Printer, format option.Format) error {
+	handler, err := display.NewVersionHandler(printer, format)
+	if err != nil {
+		return err
 	}
-	if version.GitTreeState != "" {
-		items = append(items, []string{"Git tree state", version.GitTreeState})
-	}
-
-	size := 0
-	for _, item := range items {
-		if length := len(item[0]); length > size {
-			size = length
-		}
-	}
-	for _, item := range items {
-		_ = printer.Println(item[0] + ": " + strings.Repeat(" ", size-len(item[0])) + item[1])
-	}
-
-	return nil
+	return handler.OnVersion(model.NewVersion())
 }
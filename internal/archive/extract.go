@@ -0,0 +1,346 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/content/file"
+)
+
+// ExtractOptions contains parameters for ExtractTarGzip.
+type ExtractOptions struct {
+	// Root is the directory that the targets of the extracted symbolic links
+	// must be in, e.g. the output directory.
+	Root string
+	// AllowPathTraversal allows symbolic links pointing out of Root.
+	AllowPathTraversal bool
+	// Warn is called with a warning message when a symbolic link cannot be
+	// created and a file containing the link target is written instead.
+	Warn func(message string)
+}
+
+// ExtractTarGzip extracts the gzip compressed tarball at filename to the
+// directory dir. The entry names are ensured to be under prefix, which is
+// trimmed. The uncompressed tar archive is verified against checksum if
+// checksum is a valid digest.
+func ExtractTarGzip(dir, prefix, filename, checksum string, opts ExtractOptions) (err error) {
+	fp, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+
+	gzr, err := gzip.NewReader(fp)
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+
+	var r io.Reader = gzr
+	var verifier digest.Verifier
+	if dgst, err := digest.Parse(checksum); err == nil {
+		verifier = dgst.Verifier()
+		r = io.TeeReader(r, verifier)
+	}
+	if err := extractTar(dir, prefix, r, opts); err != nil {
+		return err
+	}
+	if verifier != nil {
+		// drain the padding of the archive before verification
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			return err
+		}
+		if !verifier.Verified() {
+			return errors.New("content digest mismatch")
+		}
+	}
+	return nil
+}
+
+// extractTar extracts the tar archive read from r to dir.
+func extractTar(dir, prefix string, r io.Reader, opts ExtractOptions) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		rel, err := ensureBasePath(dir, prefix, header.Name)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, rel)
+
+		switch header.Typeflag {
+		case tar.TypeReg:
+			err = writeFile(path, tr, header.FileInfo().Mode())
+		case tar.TypeDir:
+			err = os.MkdirAll(path, header.FileInfo().Mode())
+		case tar.TypeLink:
+			var target string
+			if target, err = ensureBasePath(dir, prefix, header.Linkname); err == nil {
+				target = filepath.Join(dir, target)
+				if header.PAXRecords[PAXRecordCopy] == "true" {
+					err = copyFile(path, target, header.FileInfo().Mode())
+				} else if err = removeExisting(path); err == nil {
+					err = os.Link(target, path)
+				}
+			}
+		case tar.TypeSymlink:
+			err = createSymlink(path, header.Linkname, opts)
+		default:
+			continue // non-regular files are skipped
+		}
+		if err != nil {
+			return err
+		}
+
+		// change access time and modification time if possible (error ignored)
+		if header.Typeflag != tar.TypeSymlink {
+			_ = os.Chtimes(path, header.AccessTime, header.ModTime)
+		}
+	}
+}
+
+// createSymlink creates the symbolic link at path pointing to target, which
+// must resolve to a path in opts.Root unless path traversal is allowed.
+func createSymlink(path, target string, opts ExtractOptions) error {
+	if !opts.AllowPathTraversal {
+		inRoot, err := linkInRoot(opts.Root, path, target)
+		if err != nil {
+			return err
+		}
+		if !inRoot {
+			return fmt.Errorf("symbolic link %s points to %q outside of %s: %w", path, target, opts.Root, file.ErrPathTraversalDisallowed)
+		}
+	}
+	if err := removeExisting(path); err != nil {
+		return err
+	}
+	err := os.Symlink(target, path)
+	if err == nil || !isSymlinkPrivilegeError(err) {
+		return err
+	}
+	// fall back to a file containing the link target
+	if err := writeFile(path, strings.NewReader(target), 0666); err != nil {
+		return err
+	}
+	if opts.Warn != nil {
+		opts.Warn(fmt.Sprintf("no privilege to create symbolic link %s, a file containing the link target %q is written instead", path, target))
+	}
+	return nil
+}
+
+// linkInRoot returns true if the symbolic link at path pointing to target
+// resolves to a path in root. The symbolic links already extracted are followed
+// so that a chain of links cannot escape root.
+func linkInRoot(root, path, target string) (bool, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return false, err
+	}
+	// root may be under a link as well, e.g. the temporary directory on macOS
+	if resolvedRoot, ok, err := resolveLinks(root); err != nil {
+		return false, err
+	} else if ok {
+		root = resolvedRoot
+	}
+	if !filepath.IsAbs(target) {
+		dir, err := filepath.Abs(filepath.Dir(path))
+		if err != nil {
+			return false, err
+		}
+		target = dir + string(filepath.Separator) + target
+	}
+	resolved, ok, err := resolveLinks(target)
+	if err != nil || !ok {
+		return false, err
+	}
+	return isInRoot(root, resolved), nil
+}
+
+// maxLinks is the maximum number of symbolic links followed on resolving a
+// path.
+const maxLinks = 255
+
+// resolveLinks resolves the absolute path component by component, following
+// the existing symbolic links. The components not existing yet are assumed to
+// be directories. It returns false if the path cannot be resolved reliably,
+// i.e. ".." follows a component not existing yet, which may become a link
+// later, or there are too many links.
+func resolveLinks(path string) (string, bool, error) {
+	volume := filepath.VolumeName(path)
+	resolved := volume + string(filepath.Separator)
+	pending := splitPath(path[len(volume):])
+	links := 0
+	missing := false
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		switch name {
+		case "", ".":
+			continue
+		case "..":
+			if missing {
+				return "", false, nil
+			}
+			resolved = filepath.Dir(resolved)
+			continue
+		}
+		next := filepath.Join(resolved, name)
+		if missing {
+			resolved = next
+			continue
+		}
+		info, err := os.Lstat(next)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return "", false, err
+			}
+			missing = true
+			resolved = next
+			continue
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+		if links++; links > maxLinks {
+			return "", false, nil
+		}
+		linkTarget, err := os.Readlink(next)
+		if err != nil {
+			return "", false, err
+		}
+		if filepath.IsAbs(linkTarget) {
+			volume = filepath.VolumeName(linkTarget)
+			resolved = volume + string(filepath.Separator)
+			linkTarget = linkTarget[len(volume):]
+		}
+		pending = append(splitPath(linkTarget), pending...)
+	}
+	return resolved, true, nil
+}
+
+// splitPath splits path into its components.
+func splitPath(path string) []string {
+	return strings.FieldsFunc(path, func(r rune) bool {
+		return os.IsPathSeparator(uint8(r))
+	})
+}
+
+// isInRoot returns true if path is root or in root.
+func isInRoot(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	return rel != ".." && !strings.HasPrefix(rel, "../")
+}
+
+// removeExisting removes the non-directory file at path if it exists so that
+// files and links can be recreated when pulling to the same directory again.
+func removeExisting(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s: cannot overwrite a directory", path)
+	}
+	return os.Remove(path)
+}
+
+// ensureBasePath ensures the target path is in the base path, returning its
+// relative path to the base path. target can be either an absolute path or a
+// relative path.
+func ensureBasePath(baseAbs, baseRel, target string) (string, error) {
+	base := baseRel
+	if filepath.IsAbs(target) {
+		// ensure base and target are consistent
+		base = baseAbs
+	}
+	path, err := filepath.Rel(base, target)
+	if err != nil {
+		return "", err
+	}
+	cleanPath := filepath.ToSlash(filepath.Clean(path))
+	if cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+		return "", fmt.Errorf("%q is outside of %q", target, baseRel)
+	}
+
+	// no symbolic link allowed in the relative path
+	dir := filepath.Dir(path)
+	for dir != "." {
+		if info, err := os.Lstat(filepath.Join(baseAbs, dir)); err != nil {
+			if !os.IsNotExist(err) {
+				return "", err
+			}
+		} else if info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("no symbolic link allowed between %q and %q", baseRel, target)
+		}
+		dir = filepath.Dir(dir)
+	}
+	return path, nil
+}
+
+// writeFile writes the content read from r to the file at path. The existing
+// file is replaced rather than truncated so that the files linked to it are
+// not changed.
+func writeFile(path string, r io.Reader, perm os.FileMode) (err error) {
+	if err := removeExisting(path); err != nil {
+		return err
+	}
+	fp, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := fp.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+	_, err = io.Copy(fp, r)
+	return err
+}
+
+// copyFile copies the file at src to path.
+func copyFile(path, src string, perm os.FileMode) error {
+	fp, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	return writeFile(path, fp, perm)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/content/file"
+)
+
+func TestExtractTarGzip(t *testing.T) {
+	src := newTestDir(t)
+	gzPath, tarDigest, err := TarGzipDirectory(t.TempDir(), src, "dir", TarOptions{})
+	if err != nil {
+		t.Fatalf("TarGzipDirectory() error = %v", err)
+	}
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "dir")
+	opts := ExtractOptions{Root: root}
+	for i := 0; i < 2; i++ {
+		// extracting again replaces the links
+		if err := ExtractTarGzip(dir, "dir", gzPath, tarDigest.String(), opts); err != nil {
+			t.Fatalf("ExtractTarGzip() error = %v", err)
+		}
+	}
+	if target, err := os.Readlink(filepath.Join(dir, "link")); err != nil || target != filepath.Join("sub", "hello.txt") {
+		t.Errorf("Readlink() = %q, %v, want %q", target, err, filepath.Join("sub", "hello.txt"))
+	}
+	if content, err := os.ReadFile(filepath.Join(dir, "dirlink", "hello.txt")); err != nil || string(content) != "hello" {
+		t.Errorf("ReadFile() = %q, %v, want %q", content, err, "hello")
+	}
+
+	root = t.TempDir()
+	err = ExtractTarGzip(root, "dir", gzPath, digest.FromString("mismatch").String(), ExtractOptions{Root: root})
+	if err == nil || err.Error() != "content digest mismatch" {
+		t.Errorf("ExtractTarGzip() error = %v, want digest mismatch", err)
+	}
+}
+
+func TestExtractTarGzip_pathTraversal(t *testing.T) {
+	src := newTestDir(t)
+	if err := os.Symlink(filepath.Join("..", "..", "outside"), filepath.Join(src, "sub", "escape")); err != nil {
+		t.Fatal(err)
+	}
+	gzPath, _, err := TarGzipDirectory(t.TempDir(), src, "dir", TarOptions{})
+	if err != nil {
+		t.Fatalf("TarGzipDirectory() error = %v", err)
+	}
+
+	// dir/sub/escape points to the root
+	root := t.TempDir()
+	if err := ExtractTarGzip(filepath.Join(root, "dir"), "dir", gzPath, "", ExtractOptions{Root: root}); err != nil {
+		t.Fatalf("ExtractTarGzip() error = %v", err)
+	}
+
+	// dir/sub/escape points out of the root
+	root = t.TempDir()
+	dir := filepath.Join(root, "out", "dir")
+	err = ExtractTarGzip(dir, "dir", gzPath, "", ExtractOptions{Root: dir})
+	if !errors.Is(err, file.ErrPathTraversalDisallowed) {
+		t.Fatalf("ExtractTarGzip() error = %v, want %v", err, file.ErrPathTraversalDisallowed)
+	}
+	if err := ExtractTarGzip(dir, "dir", gzPath, "", ExtractOptions{Root: dir, AllowPathTraversal: true}); err != nil {
+		t.Fatalf("ExtractTarGzip() error = %v", err)
+	}
+	if target, err := os.Readlink(filepath.Join(dir, "sub", "escape")); err != nil || target != filepath.Join("..", "..", "outside") {
+		t.Errorf("Readlink() = %q, %v", target, err)
+	}
+}
+
+// writeTarGzip writes a gzip compressed tarball of the entries in order.
+func writeTarGzip(t *testing.T, headers ...*tar.Header) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.tar.gz")
+	fp, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fp.Close()
+	gw := gzip.NewWriter(fp)
+	tw := tar.NewWriter(gw)
+	for _, header := range headers {
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestExtractTarGzip_chainedLinkTraversal(t *testing.T) {
+	if err := os.Symlink(".", filepath.Join(t.TempDir(), "link")); err != nil {
+		t.Skipf("symbolic links not supported: %v", err)
+	}
+	dirEntry := &tar.Header{Typeflag: tar.TypeDir, Name: "dir/d/", Mode: 0755}
+	link := func(name, target string) *tar.Header {
+		return &tar.Header{Typeflag: tar.TypeSymlink, Name: name, Linkname: target, Mode: 0777}
+	}
+	tests := []struct {
+		name    string
+		entries []*tar.Header
+		wantErr bool
+	}{
+		{
+			name:    "chain staying in root",
+			entries: []*tar.Header{dirEntry, link("dir/d/l1", "."), link("dir/d/l2", "l1/..")},
+		},
+		{
+			// lexically dir/d/l1/../.. is dir, but dir/d/l1 is dir/d, so
+			// dir/d/l2 resolves to the parent of dir
+			name:    "chain escaping root",
+			entries: []*tar.Header{dirEntry, link("dir/d/l1", "."), link("dir/d/l2", "l1/../..")},
+			wantErr: true,
+		},
+		{
+			// dir/d/x may be created as a link later
+			name:    "parent of a nonexistent component",
+			entries: []*tar.Header{dirEntry, link("dir/d/l2", "x/../..")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gzPath := writeTarGzip(t, tt.entries...)
+			dir := filepath.Join(t.TempDir(), "dir")
+			err := ExtractTarGzip(dir, "dir", gzPath, "", ExtractOptions{Root: dir})
+			if tt.wantErr {
+				if !errors.Is(err, file.ErrPathTraversalDisallowed) {
+					t.Fatalf("ExtractTarGzip() error = %v, want %v", err, file.ErrPathTraversalDisallowed)
+				}
+				if _, err := os.Lstat(filepath.Join(dir, "d", "l2")); !os.IsNotExist(err) {
+					t.Errorf("escaping link is created: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExtractTarGzip() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras/internal/cleanup"
+)
+
+// UnpackStore wraps a file store and unpacks the directories pushed to it by
+// itself, so that symbolic links are recreated and their targets validated
+// against the working directory of the store.
+type UnpackStore struct {
+	*file.Store
+	workingDir string
+	warn       func(message string)
+
+	lock     sync.Mutex
+	tempDir  string
+	tracker  *cleanup.Tracker
+	saved    map[digest.Digest]string
+	unpacked map[string]bool
+}
+
+// NewUnpackStore returns an UnpackStore wrapping store with the working
+// directory workingDir. warn is called on warnings during the extraction.
+func NewUnpackStore(store *file.Store, workingDir string, warn func(message string)) (*UnpackStore, error) {
+	workingDirAbs, err := filepath.Abs(workingDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve absolute path for %s: %w", workingDir, err)
+	}
+	return &UnpackStore{
+		Store:      store,
+		workingDir: workingDirAbs,
+		warn:       warn,
+		saved:      make(map[digest.Digest]string),
+		unpacked:   make(map[string]bool),
+	}, nil
+}
+
+// Push pushes the content, matching the expected descriptor. Directories are
+// unpacked to the working directory. The files and directories created are
+// tracked by the cleanup tracker of ctx until they are written completely.
+func (s *UnpackStore) Push(ctx context.Context, expected ocispec.Descriptor, r io.Reader) error {
+	name := expected.Annotations[ocispec.AnnotationTitle]
+	if name == "" {
+		return s.Store.Push(ctx, expected, r)
+	}
+	release := s.trackOutput(ctx, name)
+	if expected.Annotations[file.AnnotationUnpack] != "true" || s.SkipUnpack {
+		if err := s.Store.Push(ctx, expected, r); err != nil {
+			return err
+		}
+		release()
+		return nil
+	}
+
+	gzPath, err := s.save(ctx, expected, r)
+	if err != nil {
+		return err
+	}
+	if err := s.unpack(name, expected, gzPath); err != nil {
+		return err
+	}
+	release()
+	return nil
+}
+
+// trackOutput tracks the output path of name to be removed on cleanup, and
+// returns the function releasing it once written. Existing paths are not
+// tracked so that only the paths created by the store are removed.
+func (s *UnpackStore) trackOutput(ctx context.Context, name string) (release func()) {
+	tracker := cleanup.FromContext(ctx)
+	if tracker == nil {
+		return func() {}
+	}
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.workingDir, path)
+	}
+	if _, err := os.Lstat(path); !os.IsNotExist(err) {
+		return func() {}
+	}
+	tracker.Track(path)
+	return func() {
+		tracker.Release(path)
+	}
+}
+
+// Exists returns true if the described content exists.
+func (s *UnpackStore) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	if _, ok := s.savedPath(target.Digest); ok {
+		return true, nil
+	}
+	return s.Store.Exists(ctx, target)
+}
+
+// Fetch fetches the content identified by the descriptor.
+func (s *UnpackStore) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	if path, ok := s.savedPath(target.Digest); ok {
+		return os.Open(path)
+	}
+	return s.Store.Fetch(ctx, target)
+}
+
+// Close removes the saved tarballs and closes the file store.
+func (s *UnpackStore) Close() error {
+	s.lock.Lock()
+	tempDir := s.tempDir
+	tracker := s.tracker
+	s.lock.Unlock()
+	if tempDir != "" {
+		if err := tracker.Remove(tempDir); err != nil {
+			return err
+		}
+	}
+	return s.Store.Close()
+}
+
+// save saves the tarball matching the expected descriptor and returns its
+// path.
+func (s *UnpackStore) save(ctx context.Context, expected ocispec.Descriptor, r io.Reader) (gzPath string, err error) {
+	s.lock.Lock()
+	if s.tempDir == "" {
+		if s.tempDir, err = os.MkdirTemp("", "oras_unpack_*"); err != nil {
+			s.lock.Unlock()
+			return "", err
+		}
+		s.tracker = cleanup.FromContext(ctx)
+		s.tracker.Track(s.tempDir)
+	}
+	tempDir := s.tempDir
+	s.lock.Unlock()
+
+	fp, err := os.CreateTemp(tempDir, "oras_dir_*.tar.gz")
+	if err != nil {
+		return "", err
+	}
+	gzPath = fp.Name()
+	defer func() {
+		closeErr := fp.Close()
+		if err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(gzPath)
+		}
+	}()
+	vr := content.NewVerifyReader(r, expected)
+	if _, err := io.Copy(fp, vr); err != nil {
+		return "", fmt.Errorf("failed to copy content to %s: %w", gzPath, err)
+	}
+	if err := vr.Verify(); err != nil {
+		return "", err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.saved[expected.Digest] = gzPath
+	return gzPath, nil
+}
+
+// savedPath returns the path of the saved tarball identified by dgst.
+func (s *UnpackStore) savedPath(dgst digest.Digest) (string, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	path, ok := s.saved[dgst]
+	return path, ok
+}
+
+// unpack unpacks the tarball at gzPath described by desc to the directory
+// of name.
+func (s *UnpackStore) unpack(name string, desc ocispec.Descriptor, gzPath string) error {
+	s.lock.Lock()
+	if s.unpacked[name] {
+		s.lock.Unlock()
+		return fmt.Errorf("%s: %w", name, file.ErrDuplicateName)
+	}
+	s.unpacked[name] = true
+	s.lock.Unlock()
+
+	target, err := s.resolveWritePath(name)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path for writing: %w", err)
+	}
+	if err := os.MkdirAll(target, 0777); err != nil {
+		return fmt.Errorf("failed to ensure directories of the target path: %w", err)
+	}
+	opts := ExtractOptions{
+		Root:               s.workingDir,
+		AllowPathTraversal: s.AllowPathTraversalOnWrite,
+		Warn:               s.warn,
+	}
+	if err := ExtractTarGzip(target, name, gzPath, desc.Annotations[file.AnnotationDigest], opts); err != nil {
+		return fmt.Errorf("failed to extract tar to %s: %w", target, err)
+	}
+	return nil
+}
+
+// resolveWritePath resolves the path to write for name, following the path
+// traversal and overwrite settings of the file store.
+func (s *UnpackStore) resolveWritePath(name string) (string, error) {
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.workingDir, path)
+	}
+	if !s.AllowPathTraversalOnWrite && !isInRoot(s.workingDir, path) {
+		return "", file.ErrPathTraversalDisallowed
+	}
+	if s.DisableOverwrite {
+		if _, err := os.Stat(path); err == nil {
+			return "", file.ErrOverwriteDisallowed
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+	return path, nil
+}
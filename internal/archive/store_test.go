@@ -0,0 +1,188 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras/internal/cleanup"
+)
+
+func TestUnpackStore(t *testing.T) {
+	ctx := context.Background()
+	src := newTestDir(t)
+	gzPath, tarDigest, err := TarGzipDirectory(t.TempDir(), src, "dir", TarOptions{})
+	if err != nil {
+		t.Fatalf("TarGzipDirectory() error = %v", err)
+	}
+	gz, err := os.ReadFile(gzPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer := func(name string) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageLayerGzip,
+			Digest:    digest.FromBytes(gz),
+			Size:      int64(len(gz)),
+			Annotations: map[string]string{
+				ocispec.AnnotationTitle: name,
+				file.AnnotationDigest:   tarDigest.String(),
+				file.AnnotationUnpack:   "true",
+			},
+		}
+	}
+	manifest, err := json.Marshal(ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    ocispec.DescriptorEmptyJSON,
+		Layers:    []ocispec.Descriptor{layer("dir")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, manifest)
+
+	root := t.TempDir()
+	store, err := file.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewUnpackStore(store, root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if err := s.Push(ctx, layer("dir"), bytes.NewReader(gz)); err != nil {
+		t.Fatalf("UnpackStore.Push() error = %v", err)
+	}
+	if exists, err := s.Exists(ctx, layer("dir")); err != nil || !exists {
+		t.Errorf("UnpackStore.Exists() = %v, %v, want true", exists, err)
+	}
+	if err := s.Push(ctx, layer("dir"), bytes.NewReader(gz)); !errors.Is(err, file.ErrDuplicateName) {
+		t.Errorf("UnpackStore.Push() error = %v, want %v", err, file.ErrDuplicateName)
+	}
+	if err := s.Push(ctx, manifestDesc, bytes.NewReader(manifest)); err != nil {
+		t.Fatalf("UnpackStore.Push() error = %v", err)
+	}
+	if target, err := os.Readlink(filepath.Join(root, "dir", "link")); err != nil || target != filepath.Join("sub", "hello.txt") {
+		t.Errorf("Readlink() = %q, %v, want %q", target, err, filepath.Join("sub", "hello.txt"))
+	}
+	successors, err := content.Successors(ctx, s, manifestDesc)
+	if err != nil || len(successors) != 2 {
+		t.Errorf("Successors() = %v, %v", successors, err)
+	}
+}
+
+// interruptedReader returns the first n bytes of the content and then fails
+// as the transfer is canceled.
+type interruptedReader struct {
+	r      io.Reader
+	n      int
+	cancel context.CancelFunc
+}
+
+func (r *interruptedReader) Read(p []byte) (int, error) {
+	if r.n <= 0 {
+		r.cancel()
+		return 0, context.Canceled
+	}
+	if len(p) > r.n {
+		p = p[:r.n]
+	}
+	n, err := r.r.Read(p)
+	r.n -= n
+	return n, err
+}
+
+func TestUnpackStore_interrupted(t *testing.T) {
+	src := newTestDir(t)
+	gzPath, tarDigest, err := TarGzipDirectory(t.TempDir(), src, "dir", TarOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz, err := os.ReadFile(gzPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dirDesc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayerGzip,
+		Digest:    digest.FromBytes(gz),
+		Size:      int64(len(gz)),
+		Annotations: map[string]string{
+			ocispec.AnnotationTitle: "dir",
+			file.AnnotationDigest:   tarDigest.String(),
+			file.AnnotationUnpack:   "true",
+		},
+	}
+	fileContent := []byte("hello world")
+	fileDesc := func(name string) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes("test/file", fileContent)
+		desc.Annotations = map[string]string{ocispec.AnnotationTitle: name}
+		return desc
+	}
+
+	root := t.TempDir()
+	store, err := file.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewUnpackStore(store, root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker := cleanup.NewTracker(false)
+	ctx, cancel := context.WithCancel(cleanup.WithTracker(context.Background(), tracker))
+	defer cancel()
+
+	if err := s.Push(ctx, fileDesc("done.txt"), bytes.NewReader(fileContent)); err != nil {
+		t.Fatalf("UnpackStore.Push() error = %v", err)
+	}
+	if err := s.Push(ctx, fileDesc("partial.txt"), &interruptedReader{r: bytes.NewReader(fileContent), n: 5, cancel: cancel}); err == nil {
+		t.Fatal("UnpackStore.Push() error = nil, want error")
+	}
+	if err := s.Push(ctx, dirDesc, &interruptedReader{r: bytes.NewReader(gz), n: len(gz) / 2, cancel: cancel}); err == nil {
+		t.Fatal("UnpackStore.Push() error = nil, want error")
+	}
+	tempDir := s.tempDir
+	if err := tracker.Cleanup(); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "done.txt" {
+		t.Errorf("entries of the working directory = %v, want only done.txt", entries)
+	}
+	if _, err := os.Stat(tempDir); !os.IsNotExist(err) {
+		t.Errorf("temporary directory %s is not removed: %v", tempDir, err)
+	}
+}
@@ -0,0 +1,291 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// TarOptions contains parameters for TarDirectory.
+type TarOptions struct {
+	// Reproducible removes the timestamps of the entries.
+	Reproducible bool
+	// Dereference archives the files and directories pointed by symbolic
+	// links instead of the links.
+	Dereference bool
+	// DedupeIdentical archives the content of byte-identical regular files
+	// once. The duplicates are recorded as hard link entries marked to be
+	// restored as copies.
+	DedupeIdentical bool
+}
+
+// PAXRecordCopy is the PAX record marking a hard link entry to be extracted
+// as a copy of the linked file.
+const PAXRecordCopy = "ORAS.copy"
+
+// TarDirectory writes the directory root to w as a tar archive, with the
+// entries named under prefix. Symbolic links are recorded as symbolic link
+// entries unless opts.Dereference is true, and hard links to the files
+// archived already are recorded as hard link entries.
+func TarDirectory(w io.Writer, root, prefix string, opts TarOptions) (err error) {
+	tw := tar.NewWriter(w)
+	defer func() {
+		closeErr := tw.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+	return newTarWriter(tw, opts).write(root, filepath.ToSlash(prefix), nil)
+}
+
+// TarDirectoryEntries writes the entries in the directory root to w as a tar
+// archive, named relative to root without an entry for root itself.
+func TarDirectoryEntries(w io.Writer, root string, opts TarOptions) (err error) {
+	tw := tar.NewWriter(w)
+	defer func() {
+		closeErr := tw.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+	resolved, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return err
+	}
+	t := newTarWriter(tw, opts)
+	for _, entry := range entries {
+		if err := t.write(filepath.Join(root, entry.Name()), entry.Name(), []string{resolved}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TarGzipDirectory archives the directory root into a gzip compressed tarball
+// created in tempDir. It returns the path of the tarball and the digest of
+// the uncompressed tar archive.
+func TarGzipDirectory(tempDir, root, prefix string, opts TarOptions) (gzPath string, tarDigest digest.Digest, err error) {
+	fp, err := os.CreateTemp(tempDir, "oras_dir_*.tar.gz")
+	if err != nil {
+		return "", "", err
+	}
+	gzPath = fp.Name()
+	defer func() {
+		closeErr := fp.Close()
+		if err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(gzPath)
+		}
+	}()
+
+	gw := gzip.NewWriter(fp)
+	digester := digest.Canonical.Digester()
+	if err := TarDirectory(io.MultiWriter(gw, digester.Hash()), root, prefix, opts); err != nil {
+		return "", "", err
+	}
+	if err := gw.Close(); err != nil {
+		return "", "", err
+	}
+	return gzPath, digester.Digest(), nil
+}
+
+func newTarWriter(tw *tar.Writer, opts TarOptions) *tarWriter {
+	return &tarWriter{
+		Writer: tw,
+		opts:   opts,
+		links:  make(map[fileID]string),
+		sizes:  make(map[int64][]*archivedFile),
+	}
+}
+
+type tarWriter struct {
+	*tar.Writer
+	opts TarOptions
+	// links maps the archived files with multiple hard links to their entry
+	// names.
+	links map[fileID]string
+	// sizes maps the sizes to the archived regular files of the sizes for
+	// detecting identical files.
+	sizes map[int64][]*archivedFile
+}
+
+// archivedFile is an archived regular file with its digest computed on
+// demand.
+type archivedFile struct {
+	path   string
+	name   string
+	digest digest.Digest
+}
+
+// write writes the file at path as the entry name. ancestors are the resolved
+// paths of the directories being archived to detect symbolic link loops.
+func (t *tarWriter) write(filePath, name string, ancestors []string) error {
+	info, err := os.Lstat(filePath)
+	if err != nil {
+		return err
+	}
+	var link string
+	if info.Mode()&os.ModeSymlink != 0 {
+		if t.opts.Dereference {
+			if info, err = os.Stat(filePath); err != nil {
+				return fmt.Errorf("failed to dereference %s: %w", filePath, err)
+			}
+		} else if link, err = os.Readlink(filePath); err != nil {
+			return err
+		}
+	}
+
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return fmt.Errorf("%s: %w", filePath, err)
+	}
+	if info.Mode().IsRegular() {
+		linkname, copied, err := t.findDuplicate(filePath, name, info)
+		if err != nil {
+			return err
+		}
+		if linkname != "" {
+			header.Typeflag = tar.TypeLink
+			header.Linkname = linkname
+			header.Size = 0
+			if copied {
+				header.PAXRecords = map[string]string{PAXRecordCopy: "true"}
+			}
+		}
+	}
+	header.Name = name
+	header.Uid = 0
+	header.Gid = 0
+	header.Uname = ""
+	header.Gname = ""
+	if t.opts.Reproducible {
+		header.ModTime = time.Time{}
+		header.AccessTime = time.Time{}
+		header.ChangeTime = time.Time{}
+	}
+	if err := t.WriteHeader(header); err != nil {
+		return fmt.Errorf("tar: %w", err)
+	}
+
+	switch {
+	case header.Typeflag == tar.TypeLink:
+		return nil
+	case info.Mode().IsRegular():
+		return t.copyFile(filePath)
+	case info.IsDir():
+		resolved, err := filepath.EvalSymlinks(filePath)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(ancestors, resolved) {
+			return fmt.Errorf("%s: symbolic link loop detected", filePath)
+		}
+		entries, err := os.ReadDir(filePath)
+		if err != nil {
+			return err
+		}
+		ancestors = append(ancestors, resolved)
+		for _, entry := range entries {
+			if err := t.write(filepath.Join(filePath, entry.Name()), path.Join(name, entry.Name()), ancestors); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// findDuplicate returns the entry name of the archived file that the regular
+// file at filePath is a hard link to, or is identical to with copied set if
+// opts.DedupeIdentical is true. Otherwise, the file is recorded as archived
+// as name and an empty linkname is returned.
+func (t *tarWriter) findDuplicate(filePath, name string, info os.FileInfo) (linkname string, copied bool, err error) {
+	id, linked := hardLinkID(info)
+	if linked {
+		if linkname, ok := t.links[id]; ok {
+			return linkname, false, nil
+		}
+	}
+	if t.opts.DedupeIdentical && info.Size() > 0 {
+		file := &archivedFile{
+			path: filePath,
+			name: name,
+		}
+		candidates := t.sizes[info.Size()]
+		if len(candidates) > 0 {
+			if file.digest, err = digestFile(filePath); err != nil {
+				return "", false, err
+			}
+		}
+		for _, candidate := range candidates {
+			if candidate.digest == "" {
+				if candidate.digest, err = digestFile(candidate.path); err != nil {
+					return "", false, err
+				}
+			}
+			if candidate.digest == file.digest {
+				if linked {
+					// hard links to this file are linked to the copy
+					t.links[id] = name
+				}
+				return candidate.name, true, nil
+			}
+		}
+		t.sizes[info.Size()] = append(candidates, file)
+	}
+	if linked {
+		t.links[id] = name
+	}
+	return "", false, nil
+}
+
+// digestFile returns the digest of the content of the file at path.
+func digestFile(path string) (digest.Digest, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer fp.Close()
+	return digest.Canonical.FromReader(fp)
+}
+
+// copyFile copies the content of the regular file at path to the archive.
+func (t *tarWriter) copyFile(path string) error {
+	fp, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	if _, err := io.Copy(t, fp); err != nil {
+		return fmt.Errorf("failed to copy to %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestDir creates a directory with a file, a symbolic link to the file and
+// a symbolic link to a sub-directory.
+func newTestDir(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "dir")
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "hello.txt"), []byte("hello"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join("sub", "hello.txt"), filepath.Join(dir, "link")); err != nil {
+		t.Skipf("symbolic links not supported: %v", err)
+	}
+	if err := os.Symlink("sub", filepath.Join(dir, "dirlink")); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// readTar returns the entries of the tar archive in content.
+func readTar(t *testing.T, content []byte) map[string]*tar.Header {
+	t.Helper()
+	headers := make(map[string]*tar.Header)
+	tr := tar.NewReader(bytes.NewReader(content))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return headers
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		headers[header.Name] = header
+	}
+}
+
+func TestTarDirectory(t *testing.T) {
+	dir := newTestDir(t)
+	var buf bytes.Buffer
+	if err := TarDirectory(&buf, dir, "dir", TarOptions{Reproducible: true}); err != nil {
+		t.Fatalf("TarDirectory() error = %v", err)
+	}
+	headers := readTar(t, buf.Bytes())
+	want := map[string]byte{
+		"dir":               tar.TypeDir,
+		"dir/sub":           tar.TypeDir,
+		"dir/sub/hello.txt": tar.TypeReg,
+		"dir/link":          tar.TypeSymlink,
+		"dir/dirlink":       tar.TypeSymlink,
+	}
+	if len(headers) != len(want) {
+		t.Fatalf("TarDirectory() entries = %v, want %v", headers, want)
+	}
+	for name, typeflag := range want {
+		if header := headers[name]; header == nil || header.Typeflag != typeflag {
+			t.Errorf("entry %s = %v, want type %c", name, header, typeflag)
+		}
+	}
+	if got := headers["dir/link"].Linkname; got != filepath.Join("sub", "hello.txt") {
+		t.Errorf("link target = %q, want %q", got, filepath.Join("sub", "hello.txt"))
+	}
+	if headers["dir/sub/hello.txt"].ModTime.Unix() > 0 {
+		t.Error("expect the timestamps to be removed")
+	}
+}
+
+func TestTarDirectoryEntries(t *testing.T) {
+	dir := newTestDir(t)
+	var buf bytes.Buffer
+	if err := TarDirectoryEntries(&buf, dir, TarOptions{}); err != nil {
+		t.Fatalf("TarDirectoryEntries() error = %v", err)
+	}
+	headers := readTar(t, buf.Bytes())
+	for _, name := range []string{"sub", "sub/hello.txt", "link", "dirlink"} {
+		if headers[name] == nil {
+			t.Errorf("entry %s not found in %v", name, headers)
+		}
+	}
+	if len(headers) != 4 {
+		t.Errorf("TarDirectoryEntries() entries = %v, want 4 entries", headers)
+	}
+}
+
+func TestTarDirectory_dereference(t *testing.T) {
+	dir := newTestDir(t)
+	var buf bytes.Buffer
+	if err := TarDirectory(&buf, dir, "dir", TarOptions{Dereference: true}); err != nil {
+		t.Fatalf("TarDirectory() error = %v", err)
+	}
+	headers := readTar(t, buf.Bytes())
+	for name, typeflag := range map[string]byte{
+		"dir/link":              tar.TypeReg,
+		"dir/dirlink":           tar.TypeDir,
+		"dir/dirlink/hello.txt": tar.TypeReg,
+	} {
+		if header := headers[name]; header == nil || header.Typeflag != typeflag {
+			t.Errorf("entry %s = %v, want type %c", name, header, typeflag)
+		}
+	}
+
+	// symbolic link loop
+	if err := os.Symlink("..", filepath.Join(dir, "sub", "loop")); err != nil {
+		t.Fatal(err)
+	}
+	err := TarDirectory(io.Discard, dir, "dir", TarOptions{Dereference: true})
+	if err == nil || !strings.Contains(err.Error(), "loop") {
+		t.Errorf("TarDirectory() error = %v, want loop detected", err)
+	}
+
+	// broken symbolic link
+	if err := os.Remove(filepath.Join(dir, "sub", "loop")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("missing", filepath.Join(dir, "broken")); err != nil {
+		t.Fatal(err)
+	}
+	if err := TarDirectory(io.Discard, dir, "dir", TarOptions{Dereference: true}); err == nil {
+		t.Error("TarDirectory() error = nil, want error on broken link")
+	}
+}
+
+func TestTarDirectory_links(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "dir")
+	if err := os.Mkdir(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"a.bin": "binary",
+		"c.bin": "binary",
+		"d.bin": "others",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Link(filepath.Join(dir, "a.bin"), filepath.Join(dir, "b.bin")); err != nil {
+		t.Skipf("hard links not supported: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		opts   TarOptions
+		copies bool
+	}{
+		{"hard links", TarOptions{}, false},
+		{"identical files", TarOptions{DedupeIdentical: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := TarDirectory(&buf, dir, "dir", tt.opts); err != nil {
+				t.Fatalf("TarDirectory() error = %v", err)
+			}
+			headers := readTar(t, buf.Bytes())
+			if header := headers["dir/b.bin"]; header.Typeflag != tar.TypeLink || header.Linkname != "dir/a.bin" || header.PAXRecords[PAXRecordCopy] != "" {
+				t.Errorf("entry dir/b.bin = %+v, want hard link to dir/a.bin", header)
+			}
+			header := headers["dir/c.bin"]
+			if tt.copies {
+				if header.Typeflag != tar.TypeLink || header.Linkname != "dir/a.bin" || header.PAXRecords[PAXRecordCopy] != "true" {
+					t.Errorf("entry dir/c.bin = %+v, want copy of dir/a.bin", header)
+				}
+			} else if header.Typeflag != tar.TypeReg {
+				t.Errorf("entry dir/c.bin = %+v, want regular file", header)
+			}
+			if header := headers["dir/d.bin"]; header.Typeflag != tar.TypeReg {
+				t.Errorf("entry dir/d.bin = %+v, want regular file", header)
+			}
+
+			// the links and copies are restored
+			gzPath, _, err := TarGzipDirectory(t.TempDir(), dir, "dir", tt.opts)
+			if err != nil {
+				t.Fatalf("TarGzipDirectory() error = %v", err)
+			}
+			root := t.TempDir()
+			out := filepath.Join(root, "dir")
+			if err := ExtractTarGzip(out, "dir", gzPath, "", ExtractOptions{Root: root}); err != nil {
+				t.Fatalf("ExtractTarGzip() error = %v", err)
+			}
+			stat := func(name string) os.FileInfo {
+				info, err := os.Stat(filepath.Join(out, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return info
+			}
+			if !os.SameFile(stat("a.bin"), stat("b.bin")) {
+				t.Error("expect dir/b.bin to be a hard link to dir/a.bin")
+			}
+			if os.SameFile(stat("a.bin"), stat("c.bin")) {
+				t.Error("expect dir/c.bin to be a copy of dir/a.bin")
+			}
+			if content, err := os.ReadFile(filepath.Join(out, "c.bin")); err != nil || string(content) != "binary" {
+				t.Errorf("ReadFile() = %q, %v, want %q", content, err, "binary")
+			}
+		})
+	}
+}
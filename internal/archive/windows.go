@@ -0,0 +1,43 @@
+//go:build windows
+
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// errorPrivilegeNotHeld is the windows error ERROR_PRIVILEGE_NOT_HELD.
+const errorPrivilegeNotHeld syscall.Errno = 1314
+
+// isSymlinkPrivilegeError returns true if err is caused by the lack of the
+// privilege to create symbolic links, e.g. in a non-elevated shell without the
+// developer mode enabled.
+func isSymlinkPrivilegeError(err error) bool {
+	return errors.Is(err, errorPrivilegeNotHeld)
+}
+
+// fileID identifies a file. Hard links are not detected on windows.
+type fileID struct{}
+
+// hardLinkID returns false since the file information on windows does not
+// identify the file.
+func hardLinkID(os.FileInfo) (fileID, bool) {
+	return fileID{}, false
+}
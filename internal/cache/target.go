@@ -17,7 +17,10 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"sync"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -53,9 +56,28 @@ func New(source oras.ReadOnlyTarget, cache content.Storage) oras.ReadOnlyTarget
 	return t
 }
 
+// NewGraph generates a new graph target with caching. Predecessors are always
+// found from the source.
+func NewGraph(source oras.ReadOnlyGraphTarget, cache content.Storage) oras.ReadOnlyGraphTarget {
+	t := &graphTarget{
+		target: &target{
+			ReadOnlyTarget: source,
+			cache:          cache,
+		},
+		PredecessorFinder: source,
+	}
+	if lister, ok := source.(registry.ReferrerLister); ok {
+		return &referrerGraphTarget{
+			graphTarget:    t,
+			ReferrerLister: lister,
+		}
+	}
+	return t
+}
+
 // Fetch fetches the content identified by the descriptor.
 func (t *target) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
-	rc, err := t.cache.Fetch(ctx, target)
+	rc, err := t.fetchCached(ctx, target)
 	if err == nil {
 		// Fetch from cache
 		return rc, nil
@@ -69,17 +91,77 @@ func (t *target) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadC
 	return t.cacheReadCloser(ctx, rc, target), nil
 }
 
+// fetchCached fetches the content from the cache, verifying it while it is
+// read so that the content is read only once. Cached content of a wrong size
+// is removed before it is read so that it is fetched from the origin and
+// cached again. Cached content failing the verification once read is removed
+// and the read fails.
+func (t *target) fetchCached(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	rc, err := t.cache.Fetch(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if f, ok := rc.(interface{ Stat() (fs.FileInfo, error) }); ok {
+		if fi, err := f.Stat(); err == nil && fi.Size() != target.Size {
+			rc.Close()
+			t.deleteCached(ctx, target)
+			return nil, fmt.Errorf("cached content of %s has size %d, want %d", target.Digest, fi.Size(), target.Size)
+		}
+	}
+	return &verifyReadCloser{
+		vr:     content.NewVerifyReader(rc, target),
+		Closer: rc,
+		onInvalid: func() {
+			t.deleteCached(ctx, target)
+		},
+	}, nil
+}
+
+// deleteCached removes the content from the cache if the cache supports
+// deletion.
+func (t *target) deleteCached(ctx context.Context, target ocispec.Descriptor) {
+	if deleter, ok := t.cache.(content.Deleter); ok {
+		_ = deleter.Delete(ctx, target)
+	}
+}
+
+// verifyReadCloser verifies the content against its descriptor while it is
+// read, calling onInvalid once if the verification fails.
+type verifyReadCloser struct {
+	vr *content.VerifyReader
+	io.Closer
+	onInvalid func()
+	once      sync.Once
+}
+
+// Read implements io.Reader.
+func (r *verifyReadCloser) Read(p []byte) (int, error) {
+	n, err := r.vr.Read(p)
+	if err == io.EOF {
+		err = r.vr.Verify()
+		if err == nil {
+			return n, io.EOF
+		}
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, content.ErrMismatchedDigest) || errors.Is(err, content.ErrTrailingData) {
+		r.once.Do(r.onInvalid)
+	}
+	return n, err
+}
+
+// cacheReadCloser returns a ReadCloser reading from rc and caching the read
+// content. Failing to cache the content, e.g. the content is being cached by
+// another process, does not fail the read.
 func (t *target) cacheReadCloser(ctx context.Context, rc io.ReadCloser, target ocispec.Descriptor) io.ReadCloser {
 	pr, pw := io.Pipe()
 	var wg sync.WaitGroup
 
 	wg.Add(1)
-	var pushErr error
 	go func() {
 		defer wg.Done()
-		pushErr = t.cache.Push(ctx, target, pr)
-		if pushErr != nil {
-			pr.CloseWithError(pushErr)
+		if err := t.cache.Push(ctx, target, pr); err != nil {
+			// drain the content so that the read is not blocked
+			_, _ = io.Copy(io.Discard, pr)
 		}
 	}()
 
@@ -94,9 +176,6 @@ func (t *target) cacheReadCloser(ctx context.Context, rc io.ReadCloser, target o
 				return err
 			}
 			wg.Wait()
-			if pushErr != nil {
-				return pushErr
-			}
 			return rcErr
 		}),
 	}
@@ -133,21 +212,29 @@ func (t *referenceTarget) FetchReference(ctx context.Context, reference string)
 		return ocispec.Descriptor{}, nil, err
 	}
 	if exists {
-		err = rc.Close()
-		if err != nil {
-			return ocispec.Descriptor{}, nil, err
-		}
-
 		// get rc from the cache
-		rc, err = t.cache.Fetch(ctx, target)
-		if err != nil {
-			return ocispec.Descriptor{}, nil, err
+		if cached, err := t.fetchCached(ctx, target); err == nil {
+			if err := rc.Close(); err != nil {
+				cached.Close()
+				return ocispec.Descriptor{}, nil, err
+			}
+			// no need to do tee'd push
+			return target, cached, nil
 		}
-
-		// no need to do tee'd push
-		return target, rc, nil
 	}
 
 	// Fetch from origin with caching
 	return target, t.cacheReadCloser(ctx, rc, target), nil
 }
+
+// Cache graphTarget struct.
+type graphTarget struct {
+	*target
+	content.PredecessorFinder
+}
+
+// Cache referrerGraphTarget struct.
+type referrerGraphTarget struct {
+	*graphTarget
+	registry.ReferrerLister
+}
@@ -19,11 +19,14 @@ import (
 	"bytes"
 	"context"
 	_ "crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"sync/atomic"
@@ -34,6 +37,7 @@ import (
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
 	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
 )
@@ -270,3 +274,169 @@ func TestProxy_fetchReference(t *testing.T) {
 		t.Errorf("unexpected number of successful requests: %d, want %d", successCount, wantSuccessCount)
 	}
 }
+
+// failingStorage is a storage failing to push any content.
+type failingStorage struct {
+	content.Storage
+}
+
+func (failingStorage) Push(_ context.Context, _ ocispec.Descriptor, _ io.Reader) error {
+	return errors.New("push failed")
+}
+
+func TestTarget_Fetch_corruptedCache(t *testing.T) {
+	blob := []byte("hello world")
+	desc := ocispec.Descriptor{
+		MediaType: "test",
+		Digest:    digest.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+	ctx := context.Background()
+	source := memory.New()
+	if err := source.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+	root := t.TempDir()
+	blobPath := filepath.Join(root, "blobs", desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+	if err := os.MkdirAll(filepath.Dir(blobPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(blobPath, []byte("hello wörld"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	storage, err := oci.NewStorage(root)
+	if err != nil {
+		t.Fatal("oci.NewStorage() error =", err)
+	}
+
+	target := New(source, storage)
+	got, err := content.FetchAll(ctx, target, desc)
+	if err != nil {
+		t.Fatal("Fetch() error =", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Errorf("Fetch() = %q, want %q", got, blob)
+	}
+	// the corrupted content is replaced
+	got, err = content.FetchAll(ctx, storage, desc)
+	if err != nil {
+		t.Fatal("cache Fetch() error =", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Errorf("cached content = %q, want %q", got, blob)
+	}
+}
+
+func TestTarget_Fetch_corruptedCacheSameSize(t *testing.T) {
+	blob := []byte("hello world")
+	desc := ocispec.Descriptor{
+		MediaType: "test",
+		Digest:    digest.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+	ctx := context.Background()
+	source := memory.New()
+	if err := source.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+	root := t.TempDir()
+	blobPath := filepath.Join(root, "blobs", desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+	if err := os.MkdirAll(filepath.Dir(blobPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(blobPath, []byte("hello WORLD"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	storage, err := oci.NewStorage(root)
+	if err != nil {
+		t.Fatal("oci.NewStorage() error =", err)
+	}
+
+	target := New(source, storage)
+	if _, err := content.FetchAll(ctx, target, desc); err == nil {
+		t.Fatal("Fetch() error = nil, want error")
+	}
+	// the corrupted content is removed
+	if exists, err := storage.Exists(ctx, desc); err != nil || exists {
+		t.Fatalf("cache Exists() = %v, %v, want false, nil", exists, err)
+	}
+	got, err := content.FetchAll(ctx, target, desc)
+	if err != nil {
+		t.Fatal("Fetch() error =", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Errorf("Fetch() = %q, want %q", got, blob)
+	}
+}
+
+func TestTarget_Fetch_cacheHitReadOnce(t *testing.T) {
+	blob := []byte("hello world")
+	desc := ocispec.Descriptor{
+		MediaType: "test",
+		Digest:    digest.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+	ctx := context.Background()
+	storage := &countingStorage{Storage: memory.New()}
+	if err := storage.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+
+	target := New(memory.New(), storage)
+	got, err := content.FetchAll(ctx, target, desc)
+	if err != nil {
+		t.Fatal("Fetch() error =", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Errorf("Fetch() = %q, want %q", got, blob)
+	}
+	if n := storage.fetches.Load(); n != 1 {
+		t.Errorf("cache fetched %d times, want 1", n)
+	}
+}
+
+func TestTarget_Fetch_cachePushFailure(t *testing.T) {
+	blob := []byte("hello world")
+	desc := ocispec.Descriptor{
+		MediaType: "test",
+		Digest:    digest.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+	ctx := context.Background()
+	source := memory.New()
+	if err := source.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+
+	target := New(source, failingStorage{memory.New()})
+	got, err := content.FetchAll(ctx, target, desc)
+	if err != nil {
+		t.Fatal("Fetch() error =", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Errorf("Fetch() = %q, want %q", got, blob)
+	}
+}
+
+func TestNewGraph(t *testing.T) {
+	repo, err := remote.NewRepository("localhost:5000/test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := NewGraph(repo, memory.New()).(registry.ReferrerLister); !ok {
+		t.Error("NewGraph() should keep the referrers API of the source")
+	}
+	if _, ok := NewGraph(memory.New(), memory.New()).(registry.ReferrerLister); ok {
+		t.Error("NewGraph() should not list referrers if the source does not")
+	}
+}
+
+type countingStorage struct {
+	content.Storage
+	fetches atomic.Int64
+}
+
+func (s *countingStorage) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	s.fetches.Add(1)
+	return s.Storage.Fetch(ctx, target)
+}
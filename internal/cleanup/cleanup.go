@@ -0,0 +1,118 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cleanup tracks the temporary and partially written paths created
+// during a command, so that they are removed when the command fails or is
+// interrupted.
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"sync"
+)
+
+// contextKey is the context key of the tracker.
+type contextKey struct{}
+
+// Tracker tracks the paths to be removed on cleanup. A nil Tracker tracks
+// nothing and removes paths immediately. A Tracker is safe for concurrent use.
+type Tracker struct {
+	keep  bool
+	lock  sync.Mutex
+	paths []string
+}
+
+// NewTracker returns a Tracker. The tracked paths are kept on cleanup if keep
+// is true, e.g. for debugging.
+func NewTracker(keep bool) *Tracker {
+	return &Tracker{keep: keep}
+}
+
+// WithTracker returns a context carrying t.
+func WithTracker(ctx context.Context, t *Tracker) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the Tracker carried by ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Tracker {
+	t, _ := ctx.Value(contextKey{}).(*Tracker)
+	return t
+}
+
+// Track tracks path to be removed on cleanup.
+func (t *Tracker) Track(path string) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.paths = append(t.paths, path)
+}
+
+// Release stops tracking path, e.g. once it is written completely.
+func (t *Tracker) Release(path string) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.paths = slices.DeleteFunc(t.paths, func(p string) bool {
+		return p == path
+	})
+}
+
+// Remove removes the temporary path which is no longer needed, unless the
+// paths are to be kept.
+func (t *Tracker) Remove(path string) error {
+	if t != nil {
+		if t.keep {
+			return nil
+		}
+		t.Release(path)
+	}
+	return os.RemoveAll(path)
+}
+
+// Paths returns the tracked paths in the order they are tracked.
+func (t *Tracker) Paths() []string {
+	if t == nil {
+		return nil
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return slices.Clone(t.paths)
+}
+
+// Cleanup removes the tracked paths in the reverse order they are tracked,
+// unless the paths are to be kept.
+func (t *Tracker) Cleanup() error {
+	if t == nil || t.keep {
+		return nil
+	}
+	t.lock.Lock()
+	paths := t.paths
+	t.paths = nil
+	t.lock.Unlock()
+	var errs []error
+	for i := len(paths) - 1; i >= 0; i-- {
+		if err := os.RemoveAll(paths[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTracker_Cleanup(t *testing.T) {
+	dir := t.TempDir()
+	partial := filepath.Join(dir, "partial")
+	completed := filepath.Join(dir, "completed")
+	tempDir := filepath.Join(dir, "temp")
+	for _, path := range []string{partial, completed} {
+		if err := os.WriteFile(path, []byte("hello"), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(tempDir, "sub"), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	tracker := NewTracker(false)
+	ctx := WithTracker(context.Background(), tracker)
+	FromContext(ctx).Track(tempDir)
+	FromContext(ctx).Track(partial)
+	FromContext(ctx).Track(completed)
+	FromContext(ctx).Release(completed)
+	if err := tracker.Cleanup(); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	for _, path := range []string{partial, tempDir} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s is not removed: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(completed); err != nil {
+		t.Errorf("released path %s is removed: %v", completed, err)
+	}
+}
+
+func TestTracker_keep(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "temp")
+	if err := os.WriteFile(path, []byte("hello"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	tracker := NewTracker(true)
+	tracker.Track(path)
+	if err := tracker.Remove(path); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := tracker.Cleanup(); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("kept path %s is removed: %v", path, err)
+	}
+	if got := tracker.Paths(); len(got) != 1 || got[0] != path {
+		t.Errorf("Paths() = %v, want [%s]", got, path)
+	}
+}
+
+func TestTracker_nil(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "temp")
+	if err := os.WriteFile(path, []byte("hello"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	tracker := FromContext(context.Background())
+	tracker.Track(path)
+	if err := tracker.Remove(path); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("%s is not removed: %v", path, err)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigEnv is the environment variable overriding the path of the config
+// file.
+const ConfigEnv = "ORAS_CONFIG"
+
+// ContextEnv is the environment variable selecting the context to use when
+// no context is specified via flags.
+const ContextEnv = "ORAS_CONTEXT"
+
+// ErrContextNotFound is returned when a context is not defined in the config
+// file.
+var ErrContextNotFound = errors.New("context not found")
+
+// Config is the ORAS config file. Both YAML and JSON are accepted.
+type Config struct {
+	Contexts map[string]*Context `yaml:"contexts" json:"contexts"`
+}
+
+// Context is a named set of registry settings used as defaults of the remote
+// flags.
+type Context struct {
+	// Name is the name of the context, filled in from the key in the config
+	// file.
+	Name string `yaml:"-" json:"name"`
+	// Registry is the registry host the context is meant for.
+	Registry string `yaml:"registry,omitempty" json:"registry,omitempty"`
+	// PlainHTTP, if set, enforces or disables plain HTTP.
+	PlainHTTP *bool `yaml:"plainHTTP,omitempty" json:"plainHTTP,omitempty"`
+	// Insecure skips TLS certificate verification.
+	Insecure bool `yaml:"insecure,omitempty" json:"insecure,omitempty"`
+	// CAFile is the path of the server certificate authority file.
+	CAFile string `yaml:"caFile,omitempty" json:"caFile,omitempty"`
+	// CertFile is the path of the client certificate file.
+	CertFile string `yaml:"certFile,omitempty" json:"certFile,omitempty"`
+	// KeyFile is the path of the client private key file.
+	KeyFile string `yaml:"keyFile,omitempty" json:"keyFile,omitempty"`
+	// RegistryConfig are the paths of the authentication files, the first
+	// match wins.
+	RegistryConfig []string `yaml:"registryConfig,omitempty" json:"registryConfig,omitempty"`
+	// NoDockerConfig disables reading credentials from the docker config
+	// file and credential helpers.
+	NoDockerConfig bool `yaml:"noDockerConfig,omitempty" json:"noDockerConfig,omitempty"`
+}
+
+// Path returns the path of the config file, which is $ORAS_CONFIG if set or
+// ~/.oras/config.yaml otherwise.
+func Path() (string, error) {
+	if path := os.Getenv(ConfigEnv); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".oras", "config.yaml"), nil
+}
+
+// Load loads the config file at path. A missing file results in an empty
+// config.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+	// JSON is a subset of YAML so that both are parsed by the YAML decoder
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	for name, ctx := range cfg.Contexts {
+		if ctx == nil {
+			ctx = &Context{}
+			cfg.Contexts[name] = ctx
+		}
+		ctx.Name = name
+		if (ctx.CertFile == "") != (ctx.KeyFile == "") {
+			return nil, fmt.Errorf("invalid context %q in config file %s: certFile and keyFile must be specified together", name, path)
+		}
+		if ctx.PlainHTTP != nil && *ctx.PlainHTTP && ctx.Insecure {
+			return nil, fmt.Errorf("invalid context %q in config file %s: plainHTTP and insecure cannot be specified together", name, path)
+		}
+		if ctx.NoDockerConfig && len(ctx.RegistryConfig) > 0 {
+			return nil, fmt.Errorf("invalid context %q in config file %s: noDockerConfig and registryConfig cannot be specified together", name, path)
+		}
+	}
+	return cfg, nil
+}
+
+// LoadDefault loads the config file at the path returned by Path.
+func LoadDefault() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// Context returns the context of the given name.
+func (c *Config) Context(name string) (*Context, error) {
+	ctx, ok := c.Contexts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrContextNotFound, name)
+	}
+	return ctx, nil
+}
+
+// ContextList returns all contexts sorted by name.
+func (c *Config) ContextList() []*Context {
+	list := make([]*Context, 0, len(c.Contexts))
+	for _, ctx := range c.Contexts {
+		list = append(list, ctx)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
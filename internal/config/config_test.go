@@ -0,0 +1,131 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	plainHTTP := true
+	want := map[string]*Context{
+		"local": {
+			Name:      "local",
+			Registry:  "localhost:5000",
+			PlainHTTP: &plainHTTP,
+		},
+		"prod": {
+			Name:           "prod",
+			Registry:       "registry.example.com",
+			CAFile:         "ca.pem",
+			CertFile:       "cert.pem",
+			KeyFile:        "key.pem",
+			RegistryConfig: []string{"auth.json"},
+		},
+	}
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "yaml",
+			content: `contexts:
+  local:
+    registry: localhost:5000
+    plainHTTP: true
+  prod:
+    registry: registry.example.com
+    caFile: ca.pem
+    certFile: cert.pem
+    keyFile: key.pem
+    registryConfig: [auth.json]
+`,
+		},
+		{
+			name: "json",
+			content: `{"contexts": {
+  "local": {"registry": "localhost:5000", "plainHTTP": true},
+  "prod": {"registry": "registry.example.com", "caFile": "ca.pem", "certFile": "cert.pem", "keyFile": "key.pem", "registryConfig": ["auth.json"]}
+}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(writeConfig(t, tt.content))
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(cfg.Contexts, want) {
+				t.Errorf("Load() = %v, want %v", cfg.Contexts, want)
+			}
+			list := cfg.ContextList()
+			if len(list) != 2 || list[0].Name != "local" || list[1].Name != "prod" {
+				t.Errorf("ContextList() = %v, want contexts sorted by name", list)
+			}
+		})
+	}
+}
+
+func TestLoad_notExist(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Contexts) != 0 {
+		t.Errorf("Load() = %v, want no context", cfg.Contexts)
+	}
+	if _, err := cfg.Context("local"); err == nil {
+		t.Error("Context() error = nil, want ErrContextNotFound")
+	}
+}
+
+func TestLoad_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"malformed", "contexts: ["},
+		{"cert file only", "contexts:\n  c:\n    certFile: cert.pem\n"},
+		{"plain HTTP and insecure", "contexts:\n  c:\n    plainHTTP: true\n    insecure: true\n"},
+		{"registry config and no docker config", "contexts:\n  c:\n    registryConfig: [auth.json]\n    noDockerConfig: true\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(writeConfig(t, tt.content)); err == nil {
+				t.Error("Load() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	t.Setenv(ConfigEnv, "/path/to/config.json")
+	if got, err := Path(); err != nil || got != "/path/to/config.json" {
+		t.Errorf("Path() = %q, %v, want $%s", got, err, ConfigEnv)
+	}
+}
@@ -15,7 +15,12 @@ limitations under the License.
 
 package credential
 
-import "oras.land/oras-go/v2/registry/remote/auth"
+import (
+	"strings"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/internal/registryutil"
+)
 
 // Credential converts user input username and password to a credential.
 func Credential(username, password string) auth.Credential {
@@ -29,3 +34,13 @@ func Credential(username, password string) auth.Credential {
 		Password: password,
 	}
 }
+
+// NormalizeHostname trims the URL scheme and path from the registry hostname
+// provided by the user, and maps the Docker Hub aliases to "docker.io".
+// For example, "https://index.docker.io/v1/" is normalized to "docker.io".
+func NormalizeHostname(hostname string) string {
+	hostname = strings.TrimPrefix(hostname, "https://")
+	hostname = strings.TrimPrefix(hostname, "http://")
+	hostname, _, _ = strings.Cut(hostname, "/")
+	return registryutil.NormalizeDockerHubRegistry(hostname)
+}
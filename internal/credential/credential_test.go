@@ -49,3 +49,25 @@ func Test_Credential_refreshToken(t *testing.T) {
 		t.Fatalf("Expected credential to be '%v' but got '%v'", expected, cred)
 	}
 }
+
+func Test_NormalizeHostname(t *testing.T) {
+	tests := []struct {
+		hostname string
+		want     string
+	}{
+		{"localhost:5000", "localhost:5000"},
+		{"registry.example.com/", "registry.example.com"},
+		{"https://registry.example.com/v2/", "registry.example.com"},
+		{"http://localhost:5000", "localhost:5000"},
+		{"docker.io", "docker.io"},
+		{"https://index.docker.io/v1/", "docker.io"},
+		{"registry-1.docker.io", "docker.io"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			if got := credential.NormalizeHostname(tt.hostname); got != tt.want {
+				t.Errorf("NormalizeHostname() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,12 +16,26 @@ limitations under the License.
 package credential
 
 import (
+	"encoding/json"
+	"os"
+
 	"oras.land/oras-go/v2/registry/remote/credentials"
 )
 
 // NewStore generates a store based on the passed-in config file paths.
 func NewStore(configPaths ...string) (credentials.Store, error) {
-	opts := credentials.StoreOptions{AllowPlaintextPut: true}
+	return newStore(credentials.StoreOptions{AllowPlaintextPut: true}, configPaths...)
+}
+
+// NewNativeStore generates a store based on the passed-in config file paths,
+// which saves credentials only via native credential helpers. If the config
+// file has no authentication configured, the platform-default native
+// credential helper is detected and saved into the config file on first put.
+func NewNativeStore(configPaths ...string) (credentials.Store, error) {
+	return newStore(credentials.StoreOptions{DetectDefaultNativeStore: true}, configPaths...)
+}
+
+func newStore(opts credentials.StoreOptions, configPaths ...string) (credentials.Store, error) {
 	if len(configPaths) == 0 {
 		// use default docker config file path
 		return credentials.NewStoreFromDocker(opts)
@@ -37,3 +51,25 @@ func NewStore(configPaths ...string) (credentials.Store, error) {
 	}
 	return credentials.NewStoreWithFallbacks(stores[0], stores[1:]...), nil
 }
+
+// HelperName returns the name of the credential helper configured in the
+// config file for the given server address, e.g. "desktop" for
+// docker-credential-desktop. An empty string is returned if no helper is
+// configured or the config file cannot be read.
+func HelperName(configPath, serverAddress string) string {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return ""
+	}
+	var config struct {
+		CredentialsStore  string            `json:"credsStore"`
+		CredentialHelpers map[string]string `json:"credHelpers"`
+	}
+	if err := json.Unmarshal(content, &config); err != nil {
+		return ""
+	}
+	if helper, ok := config.CredentialHelpers[serverAddress]; ok {
+		return helper
+	}
+	return config.CredentialsStore
+}
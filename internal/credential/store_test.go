@@ -16,11 +16,16 @@ limitations under the License.
 package credential
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path"
 	"reflect"
 	"strings"
 	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
 )
 
 func TestNewStoreError(t *testing.T) {
@@ -48,3 +53,44 @@ func TestNewStoreError(t *testing.T) {
 		t.Errorf("Expected err to be not nil")
 	}
 }
+
+func TestHelperName(t *testing.T) {
+	configPath := path.Join(t.TempDir(), "config.json")
+	config := `{"credsStore":"desktop","credHelpers":{"registry.example.com":"ecr-login"}}`
+	if err := os.WriteFile(configPath, []byte(config), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	tests := []struct {
+		name          string
+		configPath    string
+		serverAddress string
+		want          string
+	}{
+		{"credential helper", configPath, "registry.example.com", "ecr-login"},
+		{"credentials store", configPath, "localhost:5000", "desktop"},
+		{"missing config", path.Join(t.TempDir(), "missing.json"), "localhost:5000", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HelperName(tt.configPath, tt.serverAddress); got != tt.want {
+				t.Errorf("HelperName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewNativeStore_plaintextDisabled(t *testing.T) {
+	configPath := path.Join(t.TempDir(), "config.json")
+	config := `{"auths":{"localhost:5000":{"auth":"dXNlcm5hbWU6cGFzc3dvcmQ="}}}`
+	if err := os.WriteFile(configPath, []byte(config), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	store, err := NewNativeStore(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = store.Put(context.Background(), "localhost:5000", auth.Credential{Username: "username", Password: "password"})
+	if !errors.Is(err, credentials.ErrPlaintextPutDisabled) {
+		t.Fatalf("expect error %v, got %v", credentials.ErrPlaintextPutDisabled, err)
+	}
+}
@@ -16,11 +16,19 @@ limitations under the License.
 package crypto
 
 import (
+	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"errors"
+	"fmt"
 	"os"
+	"strings"
 )
 
+// ErrEncryptedPrivateKey is returned by LoadX509KeyPair when the private key is
+// encrypted.
+var ErrEncryptedPrivateKey = errors.New("encrypted private key is not supported")
+
 // LoadCertPool returns a new cert pool loaded from the cert file.
 func LoadCertPool(path string) (*x509.CertPool, error) {
 	pool := x509.NewCertPool()
@@ -33,3 +41,43 @@ func LoadCertPool(path string) (*x509.CertPool, error) {
 	}
 	return pool, nil
 }
+
+// LoadX509KeyPair loads a client key pair from the certificate file and the
+// private key file, distinguishing unreadable files, encrypted private keys
+// and mismatched key pairs in the returned error.
+func LoadX509KeyPair(certPath, keyPath string) (tls.Certificate, error) {
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to read client certificate file: %w", err)
+	}
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to read client private key file: %w", err)
+	}
+	if isEncryptedPEM(keyPEM) {
+		return tls.Certificate{}, fmt.Errorf("%s: %w", keyPath, ErrEncryptedPrivateKey)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		if strings.Contains(err.Error(), "does not match") {
+			return tls.Certificate{}, fmt.Errorf("client certificate %s does not match private key %s", certPath, keyPath)
+		}
+		return tls.Certificate{}, fmt.Errorf("failed to load client key pair from %s and %s: %w", certPath, keyPath, err)
+	}
+	return cert, nil
+}
+
+// isEncryptedPEM returns true if any PEM block in data is an encrypted
+// private key, either in PKCS #8 or legacy RFC 1423 format.
+func isEncryptedPEM(data []byte) bool {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return false
+		}
+		if block.Type == "ENCRYPTED PRIVATE KEY" || strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+			return true
+		}
+	}
+}
@@ -17,12 +17,20 @@ package crypto
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 var ts *httptest.Server
@@ -77,3 +85,69 @@ func TestLoadCertPool_pemNotExist(t *testing.T) {
 		return
 	}
 }
+
+func TestLoadX509KeyPair(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return path
+	}
+	newKey := func() (*ecdsa.PrivateKey, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return key, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	}
+	key, keyPEM := newKey()
+	_, otherKeyPEM := newKey()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "oras-test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	certPath := writeFile("cert.pem", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPath := writeFile("key.pem", keyPEM)
+	otherKeyPath := writeFile("other-key.pem", otherKeyPEM)
+	encryptedKeyPath := writeFile("encrypted-key.pem", pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("encrypted")}))
+	missingPath := filepath.Join(tempDir, "missing.pem")
+
+	tests := []struct {
+		name     string
+		certPath string
+		keyPath  string
+		wantErr  string
+	}{
+		{"valid", certPath, keyPath, ""},
+		{"missing certificate", missingPath, keyPath, "failed to read client certificate file"},
+		{"missing key", certPath, missingPath, "failed to read client private key file"},
+		{"encrypted key", certPath, encryptedKeyPath, ErrEncryptedPrivateKey.Error()},
+		{"mismatched key", certPath, otherKeyPath, "does not match private key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadX509KeyPair(tt.certPath, tt.keyPath)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expect error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
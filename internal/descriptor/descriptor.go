@@ -16,10 +16,15 @@ limitations under the License.
 package descriptor
 
 import (
+	// register sha384 and sha512 so that digests of those algorithms are valid
+	_ "crypto/sha512"
+	"strings"
+
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 
 	"oras.land/oras/internal/docker"
+	"oras.land/oras/internal/graph"
 )
 
 // IsImageManifest checks whether a manifest is an image manifest.
@@ -27,17 +32,57 @@ func IsImageManifest(desc ocispec.Descriptor) bool {
 	return desc.MediaType == docker.MediaTypeManifest || desc.MediaType == ocispec.MediaTypeImageManifest
 }
 
-// ShortDigest converts the digest of the descriptor to a short form for displaying.
+// IsManifest checks whether a descriptor describes a manifest or an index.
+func IsManifest(desc ocispec.Descriptor) bool {
+	switch desc.MediaType {
+	case ocispec.MediaTypeImageManifest, ocispec.MediaTypeImageIndex,
+		docker.MediaTypeManifest, docker.MediaTypeManifestList,
+		graph.MediaTypeArtifactManifest:
+		return true
+	}
+	return false
+}
+
+// ShortDigest converts the digest of the descriptor to a short form for
+// displaying. Digests of algorithms other than sha256 keep the algorithm
+// prefix in the short form.
 func ShortDigest(desc ocispec.Descriptor) (digestString string) {
 	digestString = desc.Digest.String()
 	if err := desc.Digest.Validate(); err == nil {
-		if algo := desc.Digest.Algorithm(); algo == digest.SHA256 {
-			digestString = desc.Digest.Encoded()[:12]
-		}
+		digestString = algorithmPrefix(desc.Digest) + desc.Digest.Encoded()[:DefaultShortDigestLength]
 	}
 	return digestString
 }
 
+// maxReferrersTagEncodedLength is the maximum length of the encoded part of
+// a referrers tag, beyond which the encoded digest is truncated.
+const maxReferrersTagEncodedLength = 64
+
+// IsDigestTag returns true if tag is formatted as a digest with the colon
+// replaced by a hyphen, e.g. the tags of the referrers tag schema. The encoded
+// digests longer than 64 characters may be truncated to 64 characters as
+// specified by the referrers tag schema, e.g. for sha512 digests.
+func IsDigestTag(tag string) bool {
+	alg, encoded, found := strings.Cut(tag, "-")
+	if !found {
+		return false
+	}
+	algorithm := digest.Algorithm(alg)
+	if !algorithm.Available() {
+		return false
+	}
+	size := algorithm.Size() * 2
+	if len(encoded) != size && (size <= maxReferrersTagEncodedLength || len(encoded) != maxReferrersTagEncodedLength) {
+		return false
+	}
+	for _, c := range encoded {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
 // GetTitleOrMediaType gets a descriptor name using either title or media type.
 func GetTitleOrMediaType(desc ocispec.Descriptor) (name string, isTitle bool) {
 	name, ok := desc.Annotations[ocispec.AnnotationTitle]
@@ -64,6 +64,36 @@ func TestDescriptor_ShortDigest(t *testing.T) {
 	}
 }
 
+func TestDescriptor_ShortDigest_sha512(t *testing.T) {
+	desc := ocispec.Descriptor{
+		Digest: "sha512:9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043",
+	}
+	if got, want := descriptor.ShortDigest(desc), "sha512:9b71d224bd62"; got != want {
+		t.Fatalf("ShortDigest() got %v, want %v", got, want)
+	}
+}
+
+func TestDescriptor_IsDigestTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{"sha256-2e0e0fe1fb3edbcdddad941c90d2b51e25a6bcd593e82545441a216de7bfa834", true},
+		{"sha512-9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043", true},
+		// truncated by the referrers tag schema
+		{"sha512-9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca7", true},
+		{"sha256-2e0e0fe1fb3e", false},
+		{"sha256-2E0E0FE1FB3EDBCDDDAD941C90D2B51E25A6BCD593E82545441A216DE7BFA834", false},
+		{"md5-2e0e0fe1fb3edbcdddad941c90d2b51e", false},
+		{"v1.0.0", false},
+	}
+	for _, tt := range tests {
+		if got := descriptor.IsDigestTag(tt.tag); got != tt.want {
+			t.Errorf("IsDigestTag(%q) = %v, want %v", tt.tag, got, tt.want)
+		}
+	}
+}
+
 func TestDescriptor_GetTitleOrMediaType(t *testing.T) {
 	expected := "application/vnd.oci.image.manifest.v1+json"
 	name, isTitle := descriptor.GetTitleOrMediaType(imageDesc)
@@ -0,0 +1,95 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descriptor
+
+import (
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// DefaultShortDigestLength is the default length of short digests.
+const DefaultShortDigestLength = 12
+
+// Shortener converts digests to short forms for displaying. Short forms of
+// digests sharing the same prefix are lengthened, so that digests displayed
+// by the same Shortener are distinguishable.
+type Shortener struct {
+	length  int
+	lengths map[digest.Digest]int
+	lock    sync.Mutex
+}
+
+// NewShortener creates a Shortener with the initial short digest length.
+func NewShortener(length int) *Shortener {
+	return &Shortener{
+		length:  length,
+		lengths: make(map[digest.Digest]int),
+	}
+}
+
+// Shorten returns the short form of dgst. Digests of algorithms other than
+// sha256 keep the algorithm prefix in the short form, e.g. "sha512:f1d2d2f924e9".
+// Invalid digests are not shortened.
+func (s *Shortener) Shorten(dgst digest.Digest) string {
+	if dgst.Validate() != nil {
+		return dgst.String()
+	}
+	encoded := dgst.Encoded()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	n, ok := s.lengths[dgst]
+	if !ok {
+		n = s.length
+	}
+	for other, m := range s.lengths {
+		if other == dgst || other.Algorithm() != dgst.Algorithm() {
+			continue
+		}
+		common := commonPrefixLength(encoded, other.Encoded())
+		if common >= n {
+			n = common + 1
+		}
+		if common >= m {
+			// lengthen the colliding digest for later displays
+			s.lengths[other] = common + 1
+		}
+	}
+	n = min(n, len(encoded))
+	s.lengths[dgst] = n
+	return algorithmPrefix(dgst) + encoded[:n]
+}
+
+// algorithmPrefix returns the algorithm prefix of the short form of dgst,
+// which is empty for sha256 digests.
+func algorithmPrefix(dgst digest.Digest) string {
+	if dgst.Algorithm() == digest.SHA256 {
+		return ""
+	}
+	return dgst.Algorithm().String() + ":"
+}
+
+// commonPrefixLength returns the length of the common prefix of a and b.
+func commonPrefixLength(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
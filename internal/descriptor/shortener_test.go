@@ -0,0 +1,76 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descriptor_test
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras/internal/descriptor"
+)
+
+func TestShortener_Shorten(t *testing.T) {
+	s := descriptor.NewShortener(8)
+	first := digest.Digest("sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae")
+	if got, want := s.Shorten(first), "2c26b46b"; got != want {
+		t.Fatalf("Shorten() = %q, want %q", got, want)
+	}
+	unrelated := digest.Digest("sha256:fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9")
+	if got, want := s.Shorten(unrelated), "fcde2b2e"; got != want {
+		t.Fatalf("Shorten() = %q, want %q", got, want)
+	}
+
+	// a digest sharing the first 10 characters is lengthened to 11
+	colliding := digest.Digest("sha256:2c26b46b68aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	if got, want := s.Shorten(colliding), "2c26b46b68a"; got != want {
+		t.Fatalf("Shorten() = %q, want %q", got, want)
+	}
+	// the previously displayed digest is lengthened as well
+	if got, want := s.Shorten(first), "2c26b46b68f"; got != want {
+		t.Fatalf("Shorten() = %q, want %q", got, want)
+	}
+	if got, want := s.Shorten(unrelated), "fcde2b2e"; got != want {
+		t.Fatalf("Shorten() = %q, want %q", got, want)
+	}
+}
+
+func TestShortener_Shorten_sha512(t *testing.T) {
+	s := descriptor.NewShortener(8)
+	sha512 := digest.Digest("sha512:9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043")
+	if got, want := s.Shorten(sha512), "sha512:9b71d224"; got != want {
+		t.Fatalf("Shorten() = %q, want %q", got, want)
+	}
+	// digests of different algorithms do not collide
+	sha256 := digest.Digest("sha256:9b71d224aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	if got, want := s.Shorten(sha256), "9b71d224"; got != want {
+		t.Fatalf("Shorten() = %q, want %q", got, want)
+	}
+	if got, want := s.Shorten(sha512), "sha512:9b71d224"; got != want {
+		t.Fatalf("Shorten() = %q, want %q", got, want)
+	}
+}
+
+func TestShortener_Shorten_notShortened(t *testing.T) {
+	s := descriptor.NewShortener(8)
+	for _, dgst := range []digest.Digest{
+		"sha512:9b71d224",
+		"invalid",
+	} {
+		if got := s.Shorten(dgst); got != dgst.String() {
+			t.Errorf("Shorten() = %q, want %q", got, dgst)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+)
+
+const (
+	// archiveManifestFile is the file listing the images in a docker archive.
+	archiveManifestFile = "manifest.json"
+	// archiveRepositoriesFile is the file listing the tags in a legacy docker
+	// archive.
+	archiveRepositoriesFile = "repositories"
+	// maxArchiveSymlinks is the maximum number of symbolic links followed to
+	// resolve an entry.
+	maxArchiveSymlinks = 16
+)
+
+var (
+	// ErrLegacyArchive is returned when the docker archive is in the legacy
+	// format without manifest.json.
+	ErrLegacyArchive = errors.New("legacy docker archive without manifest.json is not supported, please save it again with Docker 1.10 or later")
+	// ErrNotArchive is returned when the tarball is not a docker archive.
+	ErrNotArchive = errors.New("not a docker archive: manifest.json not found")
+)
+
+// ArchiveImageNotFoundError is returned when the image to be copied is not
+// selected from a docker archive containing multiple images, or the selected
+// image is not found.
+type ArchiveImageNotFoundError struct {
+	// Name is the name used to select the image, or empty if no image is
+	// selected.
+	Name string
+	// Available is the names of the images in the archive.
+	Available []string
+}
+
+// Error returns the error message.
+func (e *ArchiveImageNotFoundError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("the docker archive contains multiple images: %s", strings.Join(e.Available, ", "))
+	}
+	return fmt.Sprintf("image %q not found in the docker archive", e.Name)
+}
+
+// archiveImage is an image entry in the manifest.json of a docker archive.
+type archiveImage struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+// archiveEntry is a regular file in a tarball.
+type archiveEntry struct {
+	offset int64
+	size   int64
+}
+
+// archiveBlob is the content of a blob, either in memory or in the tarball.
+type archiveBlob struct {
+	desc  ocispec.Descriptor
+	data  []byte
+	entry archiveEntry
+}
+
+// ArchiveStore is a read-only store of an image in a docker archive created by
+// `docker save`, which is served as an OCI image with OCI media types.
+type ArchiveStore struct {
+	path     string
+	tags     []string
+	manifest ocispec.Descriptor
+	blobs    map[digest.Digest]archiveBlob
+}
+
+// NewArchiveStore opens the docker archive at path, and selects the image
+// with the tag name. name can be omitted if the archive contains only one
+// image.
+func NewArchiveStore(path, name string) (*ArchiveStore, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+	entries, err := indexArchive(fp)
+	if err != nil {
+		return nil, err
+	}
+	manifestEntry, ok := entries[archiveManifestFile]
+	if !ok {
+		if _, ok := entries[archiveRepositoriesFile]; ok {
+			return nil, ErrLegacyArchive
+		}
+		return nil, ErrNotArchive
+	}
+	manifestJSON, err := readEntry(fp, manifestEntry)
+	if err != nil {
+		return nil, err
+	}
+	var images []archiveImage
+	if err := json.Unmarshal(manifestJSON, &images); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", archiveManifestFile, err)
+	}
+	image, err := selectArchiveImage(images, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &ArchiveStore{
+		path:  path,
+		blobs: make(map[digest.Digest]archiveBlob),
+	}
+	for _, tag := range image.RepoTags {
+		if _, t, ok := cutTag(tag); ok {
+			s.tags = append(s.tags, t)
+		}
+	}
+	slices.Sort(s.tags)
+
+	configEntry, err := resolveArchiveEntry(entries, image.Config)
+	if err != nil {
+		return nil, err
+	}
+	configJSON, err := readEntry(fp, configEntry)
+	if err != nil {
+		return nil, err
+	}
+	manifest := ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    s.addData(ocispec.MediaTypeImageConfig, configJSON),
+		Layers:    make([]ocispec.Descriptor, 0, len(image.Layers)),
+	}
+	for _, layer := range image.Layers {
+		entry, err := resolveArchiveEntry(entries, layer)
+		if err != nil {
+			return nil, err
+		}
+		desc, err := describeLayer(fp, entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read layer %s: %w", layer, err)
+		}
+		s.blobs[desc.Digest] = archiveBlob{desc: desc, entry: entry}
+		manifest.Layers = append(manifest.Layers, desc)
+	}
+	manifestContent, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	s.manifest = s.addData(ocispec.MediaTypeImageManifest, manifestContent)
+	return s, nil
+}
+
+// Reference returns the reference resolving to the selected image.
+func (s *ArchiveStore) Reference() string {
+	return s.manifest.Digest.String()
+}
+
+// Resolve resolves the digest of the selected image, or one of its tags, to
+// the manifest descriptor.
+func (s *ArchiveStore) Resolve(_ context.Context, reference string) (ocispec.Descriptor, error) {
+	if reference == s.manifest.Digest.String() || slices.Contains(s.tags, reference) {
+		return s.manifest, nil
+	}
+	return ocispec.Descriptor{}, fmt.Errorf("%s: %w", reference, errdef.ErrNotFound)
+}
+
+// Fetch fetches the content identified by the descriptor.
+func (s *ArchiveStore) Fetch(_ context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	blob, ok := s.blobs[target.Digest]
+	if !ok {
+		return nil, fmt.Errorf("%s: %s: %w", target.Digest, target.MediaType, errdef.ErrNotFound)
+	}
+	if blob.data != nil {
+		return io.NopCloser(bytes.NewReader(blob.data)), nil
+	}
+	fp, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.NewSectionReader(fp, blob.entry.offset, blob.entry.size),
+		Closer: fp,
+	}, nil
+}
+
+// Exists returns true if the described content exists.
+func (s *ArchiveStore) Exists(_ context.Context, target ocispec.Descriptor) (bool, error) {
+	_, ok := s.blobs[target.Digest]
+	return ok, nil
+}
+
+// Predecessors returns nil since there is no referrer in a docker archive.
+func (s *ArchiveStore) Predecessors(_ context.Context, _ ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	return nil, nil
+}
+
+// Tags lists the tags of the selected image after last in lexical order.
+func (s *ArchiveStore) Tags(_ context.Context, last string, fn func(tags []string) error) error {
+	i, _ := slices.BinarySearch(s.tags, last)
+	if i < len(s.tags) && s.tags[i] == last {
+		i++
+	}
+	return fn(s.tags[i:])
+}
+
+// addData adds the content in memory and returns its descriptor.
+func (s *ArchiveStore) addData(mediaType string, data []byte) ocispec.Descriptor {
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+	s.blobs[desc.Digest] = archiveBlob{desc: desc, data: data}
+	return desc
+}
+
+// selectArchiveImage selects the image by the tag name.
+func selectArchiveImage(images []archiveImage, name string) (archiveImage, error) {
+	if len(images) == 0 {
+		return archiveImage{}, fmt.Errorf("no image found in %s", archiveManifestFile)
+	}
+	if name == "" && len(images) == 1 {
+		return images[0], nil
+	}
+	var available []string
+	for _, image := range images {
+		for _, tag := range image.RepoTags {
+			if tag == name || tag == name+":latest" {
+				return image, nil
+			}
+			available = append(available, tag)
+		}
+	}
+	return archiveImage{}, &ArchiveImageNotFoundError{
+		Name:      name,
+		Available: available,
+	}
+}
+
+// cutTag cuts the tag from a docker image name.
+func cutTag(name string) (repository, tag string, ok bool) {
+	i := strings.LastIndex(name, ":")
+	if i < 0 || strings.Contains(name[i+1:], "/") {
+		return name, "", false
+	}
+	return name[:i], name[i+1:], true
+}
+
+// indexArchive indexes the regular files in the tarball by their names, with
+// the symbolic links resolved.
+func indexArchive(fp *os.File) (map[string]archiveEntry, error) {
+	entries := make(map[string]archiveEntry)
+	links := make(map[string]string)
+	tr := tar.NewReader(fp)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the docker archive: %w", err)
+		}
+		name := path.Clean(header.Name)
+		switch header.Typeflag {
+		case tar.TypeReg:
+			offset, err := fp.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return nil, err
+			}
+			entries[name] = archiveEntry{offset: offset, size: header.Size}
+		case tar.TypeSymlink:
+			links[name] = path.Join(path.Dir(name), header.Linkname)
+		case tar.TypeLink:
+			links[name] = path.Clean(header.Linkname)
+		}
+	}
+	for name, target := range links {
+		for i := 0; i < maxArchiveSymlinks; i++ {
+			next, ok := links[target]
+			if !ok {
+				break
+			}
+			target = next
+		}
+		if entry, ok := entries[target]; ok {
+			entries[name] = entry
+		}
+	}
+	return entries, nil
+}
+
+// resolveArchiveEntry returns the entry of the file referenced by
+// manifest.json.
+func resolveArchiveEntry(entries map[string]archiveEntry, name string) (archiveEntry, error) {
+	entry, ok := entries[path.Clean(name)]
+	if !ok {
+		return archiveEntry{}, fmt.Errorf("%s referenced by %s not found in the docker archive", name, archiveManifestFile)
+	}
+	return entry, nil
+}
+
+// readEntry reads the content of the entry.
+func readEntry(fp *os.File, entry archiveEntry) ([]byte, error) {
+	content := make([]byte, entry.size)
+	if _, err := fp.ReadAt(content, entry.offset); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// describeLayer digests the layer and detects its media type by the magic
+// number of the compression.
+func describeLayer(fp *os.File, entry archiveEntry) (ocispec.Descriptor, error) {
+	r := io.NewSectionReader(fp, entry.offset, entry.size)
+	magic := make([]byte, 4)
+	n, err := io.ReadFull(r, magic)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return ocispec.Descriptor{}, err
+	}
+	magic = magic[:n]
+	mediaType := ocispec.MediaTypeImageLayer
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		mediaType = ocispec.MediaTypeImageLayerGzip
+	case bytes.HasPrefix(magic, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		mediaType = ocispec.MediaTypeImageLayerZstd
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	dgst, err := digest.Canonical.FromReader(r)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    dgst,
+		Size:      entry.size,
+	}, nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+)
+
+// archiveFile is a file or a symbolic link in a test tarball.
+type archiveFile struct {
+	name     string
+	content  string
+	linkname string
+}
+
+func writeArchive(t *testing.T, files []archiveFile) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "image.tar")
+	fp, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fp.Close()
+	tw := tar.NewWriter(fp)
+	for _, f := range files {
+		header := &tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.content)), Typeflag: tar.TypeReg}
+		if f.linkname != "" {
+			header.Typeflag = tar.TypeSymlink
+			header.Linkname = f.linkname
+			header.Size = 0
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(tw, f.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func manifestJSON(t *testing.T, images ...archiveImage) string {
+	t.Helper()
+	b, err := json.Marshal(images)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestNewArchiveStore(t *testing.T) {
+	config := `{"architecture":"amd64","os":"linux"}`
+	layer := "layer content"
+	gzipLayer := "\x1f\x8b gzip layer"
+	path := writeArchive(t, []archiveFile{
+		{name: "config.json", content: config},
+		{name: "l1/layer.tar", content: layer},
+		{name: "l2/layer.tar", content: gzipLayer},
+		{name: "l3/layer.tar", linkname: "../l1/layer.tar"},
+		{name: "manifest.json", content: manifestJSON(t, archiveImage{
+			Config:   "config.json",
+			RepoTags: []string{"localhost:5000/hello:v1"},
+			Layers:   []string{"l1/layer.tar", "l2/layer.tar", "l3/layer.tar"},
+		})},
+	})
+	s, err := NewArchiveStore(path, "")
+	if err != nil {
+		t.Fatalf("NewArchiveStore() error = %v", err)
+	}
+	ctx := context.Background()
+	desc, err := s.Resolve(ctx, "v1")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if desc.Digest.String() != s.Reference() {
+		t.Errorf("Resolve() = %v, want %s", desc, s.Reference())
+	}
+	manifestContent, err := content.FetchAll(ctx, s, desc)
+	if err != nil {
+		t.Fatalf("failed to fetch the manifest: %v", err)
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestContent, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Config.MediaType != ocispec.MediaTypeImageConfig || manifest.Config.Digest != digest.FromString(config) {
+		t.Errorf("config = %v", manifest.Config)
+	}
+	wantLayers := []ocispec.Descriptor{
+		{MediaType: ocispec.MediaTypeImageLayer, Digest: digest.FromString(layer), Size: int64(len(layer))},
+		{MediaType: ocispec.MediaTypeImageLayerGzip, Digest: digest.FromString(gzipLayer), Size: int64(len(gzipLayer))},
+		{MediaType: ocispec.MediaTypeImageLayer, Digest: digest.FromString(layer), Size: int64(len(layer))},
+	}
+	if len(manifest.Layers) != len(wantLayers) {
+		t.Fatalf("layers = %v, want %v", manifest.Layers, wantLayers)
+	}
+	for i, want := range wantLayers {
+		got := manifest.Layers[i]
+		if !content.Equal(got, want) {
+			t.Errorf("layer %d = %v, want %v", i, got, want)
+		}
+		fetched, err := content.FetchAll(ctx, s, got)
+		if err != nil {
+			t.Fatalf("failed to fetch layer %d: %v", i, err)
+		}
+		if digest.FromBytes(fetched) != want.Digest {
+			t.Errorf("fetched layer %d mismatches", i)
+		}
+	}
+}
+
+func TestNewArchiveStore_selectImage(t *testing.T) {
+	path := writeArchive(t, []archiveFile{
+		{name: "a.json", content: `{"os":"linux"}`},
+		{name: "b.json", content: `{"os":"windows"}`},
+		{name: "manifest.json", content: manifestJSON(t,
+			archiveImage{Config: "a.json", RepoTags: []string{"a:v1"}},
+			archiveImage{Config: "b.json", RepoTags: []string{"b:latest"}},
+		)},
+	})
+	var imageErr *ArchiveImageNotFoundError
+	if _, err := NewArchiveStore(path, ""); !errors.As(err, &imageErr) || len(imageErr.Available) != 2 {
+		t.Fatalf("NewArchiveStore() error = %v, want ArchiveImageNotFoundError listing 2 images", err)
+	}
+	if _, err := NewArchiveStore(path, "c:v1"); !errors.As(err, &imageErr) {
+		t.Fatalf("NewArchiveStore() error = %v, want ArchiveImageNotFoundError", err)
+	}
+	s, err := NewArchiveStore(path, "b")
+	if err != nil {
+		t.Fatalf("NewArchiveStore() error = %v", err)
+	}
+	if _, err := s.Resolve(context.Background(), "latest"); err != nil {
+		t.Errorf("Resolve() error = %v", err)
+	}
+}
+
+func TestNewArchiveStore_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []archiveFile
+		wantErr error
+	}{
+		{
+			name:    "legacy archive",
+			files:   []archiveFile{{name: "repositories", content: "{}"}, {name: "l1/json", content: "{}"}},
+			wantErr: ErrLegacyArchive,
+		},
+		{
+			name:    "not an archive",
+			files:   []archiveFile{{name: "hello.txt", content: "hello"}},
+			wantErr: ErrNotArchive,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewArchiveStore(writeArchive(t, tt.files), ""); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewArchiveStore() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+)
+
+var (
+	// ErrIndex is returned when an index is to be written to a docker
+	// archive, which holds images of a single platform.
+	ErrIndex = errors.New("an index or a manifest list cannot be written to a docker archive")
+	// ErrNotImage is returned when a manifest to be written to a docker
+	// archive is not an image manifest.
+	ErrNotImage = errors.New("only container images can be written to a docker archive")
+)
+
+// ArchiveWriteOptions contains parameters for WriteArchive.
+type ArchiveWriteOptions struct {
+	// RepoTags are the names of the image in the archive, e.g. hello:v1.
+	RepoTags []string
+	// PreCopy is called before a blob is fetched.
+	PreCopy func(ctx context.Context, desc ocispec.Descriptor) error
+	// PostCopy is called after a blob is written to the archive.
+	PostCopy func(ctx context.Context, desc ocispec.Descriptor) error
+}
+
+// WriteArchive writes the image described by desc as a docker archive, which
+// can be loaded by `docker load`.
+func WriteArchive(ctx context.Context, w io.Writer, fetcher content.Fetcher, desc ocispec.Descriptor, opts ArchiveWriteOptions) error {
+	manifest, err := fetchImageManifest(ctx, fetcher, desc)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	image := archiveImage{
+		Config:   blobPath(manifest.Config.Digest),
+		RepoTags: opts.RepoTags,
+	}
+	written := map[digest.Digest]bool{manifest.Config.Digest: true}
+	if err := writeBlob(ctx, tw, fetcher, manifest.Config, opts); err != nil {
+		return err
+	}
+	for _, layer := range manifest.Layers {
+		image.Layers = append(image.Layers, blobPath(layer.Digest))
+		if written[layer.Digest] {
+			continue
+		}
+		written[layer.Digest] = true
+		if err := writeBlob(ctx, tw, fetcher, layer, opts); err != nil {
+			return err
+		}
+	}
+
+	manifestJSON, err := json.Marshal([]archiveImage{image})
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     archiveManifestFile,
+		Mode:     0644,
+		Size:     int64(len(manifestJSON)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(manifestJSON); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// fetchImageManifest fetches the manifest described by desc, and ensures that
+// it is an image manifest with the media types supported by docker.
+func fetchImageManifest(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) (ocispec.Manifest, error) {
+	switch desc.MediaType {
+	case ocispec.MediaTypeImageManifest, MediaTypeManifest:
+	case ocispec.MediaTypeImageIndex, MediaTypeManifestList:
+		return ocispec.Manifest{}, ErrIndex
+	default:
+		return ocispec.Manifest{}, fmt.Errorf("%s is of media type %s: %w", desc.Digest, desc.MediaType, ErrNotImage)
+	}
+	content, err := content.FetchAll(ctx, fetcher, desc)
+	if err != nil {
+		return ocispec.Manifest{}, err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return ocispec.Manifest{}, fmt.Errorf("failed to parse manifest %s: %w", desc.Digest, err)
+	}
+	switch manifest.Config.MediaType {
+	case ocispec.MediaTypeImageConfig, MediaTypeConfig:
+	default:
+		if manifest.ArtifactType != "" {
+			return ocispec.Manifest{}, fmt.Errorf("%s is an artifact of type %s: %w", desc.Digest, manifest.ArtifactType, ErrNotImage)
+		}
+		return ocispec.Manifest{}, fmt.Errorf("%s has a config of media type %s: %w", desc.Digest, manifest.Config.MediaType, ErrNotImage)
+	}
+	for _, layer := range manifest.Layers {
+		switch layer.MediaType {
+		case ocispec.MediaTypeImageLayer, ocispec.MediaTypeImageLayerGzip, ocispec.MediaTypeImageLayerZstd, MediaTypeLayerGzip:
+		default:
+			return ocispec.Manifest{}, fmt.Errorf("%s has a layer %s of media type %s: %w", desc.Digest, layer.Digest, layer.MediaType, ErrNotImage)
+		}
+	}
+	return manifest, nil
+}
+
+// writeBlob fetches the blob and writes it to the archive.
+func writeBlob(ctx context.Context, tw *tar.Writer, fetcher content.Fetcher, desc ocispec.Descriptor, opts ArchiveWriteOptions) error {
+	if opts.PreCopy != nil {
+		if err := opts.PreCopy(ctx, desc); err != nil {
+			return err
+		}
+	}
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     blobPath(desc.Digest),
+		Mode:     0644,
+		Size:     desc.Size,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	vr := content.NewVerifyReader(rc, desc)
+	if _, err := io.Copy(tw, vr); err != nil {
+		return err
+	}
+	if err := vr.Verify(); err != nil {
+		return err
+	}
+	if opts.PostCopy != nil {
+		return opts.PostCopy(ctx, desc)
+	}
+	return nil
+}
+
+// blobPath returns the path of the blob in the archive, which is the same as
+// in an OCI image layout.
+func blobPath(dgst digest.Digest) string {
+	return "blobs/" + dgst.Algorithm().String() + "/" + dgst.Encoded()
+}
@@ -0,0 +1,125 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestWriteArchive(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	config, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageConfig, []byte(`{"os":"linux"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageLayer, []byte("layer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	image, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "", oras.PackManifestOptions{
+		ConfigDescriptor: &config,
+		Layers:           []ocispec.Descriptor{layer, layer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "image.tar")
+	fp, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var copied []ocispec.Descriptor
+	err = WriteArchive(ctx, fp, store, image, ArchiveWriteOptions{
+		RepoTags: []string{"hello:v1"},
+		PostCopy: func(_ context.Context, desc ocispec.Descriptor) error {
+			copied = append(copied, desc)
+			return nil
+		},
+	})
+	if closeErr := fp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		t.Fatalf("WriteArchive() error = %v", err)
+	}
+	if len(copied) != 2 {
+		t.Errorf("copied blobs = %v, want the config and the deduplicated layer", copied)
+	}
+
+	// the archive is read back as the same image
+	s, err := NewArchiveStore(path, "hello:v1")
+	if err != nil {
+		t.Fatalf("NewArchiveStore() error = %v", err)
+	}
+	desc, err := s.Resolve(ctx, "v1")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	manifestJSON, err := content.FetchAll(ctx, s, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if !content.Equal(manifest.Config, config) || len(manifest.Layers) != 2 || !content.Equal(manifest.Layers[1], layer) {
+		t.Errorf("read back manifest = %s, want config %v and layers %v", manifestJSON, config, []ocispec.Descriptor{layer, layer})
+	}
+}
+
+func TestWriteArchive_notImage(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	artifact, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	index, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	index.MediaType = ocispec.MediaTypeImageIndex
+
+	tests := []struct {
+		name    string
+		desc    ocispec.Descriptor
+		wantErr error
+	}{
+		{name: "artifact", desc: artifact, wantErr: ErrNotImage},
+		{name: "index", desc: index, wantErr: ErrIndex},
+		{name: "blob", desc: ocispec.Descriptor{MediaType: "application/octet-stream"}, wantErr: ErrNotImage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := WriteArchive(ctx, nil, store, tt.desc, ArchiveWriteOptions{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("WriteArchive() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
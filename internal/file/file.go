@@ -47,9 +47,10 @@ func PrepareManifestContent(path string) ([]byte, error) {
 }
 
 // PrepareBlobContent prepares the content descriptor for blob from the file
-// path or stdin. Use the input digest and size if they are provided. Will
-// return error if the content is from stdin but the content digest and size
-// are missing.
+// path or stdin. Use the input digest and size if they are provided. If the
+// content is from stdin and the digest or the size is missing, the content is
+// spooled into a temporary file via spoolBlobContent to compute them, and the
+// temporary file is removed when the returned ReadCloser is closed.
 func PrepareBlobContent(path string, mediaType string, dgstStr string, size int64) (desc ocispec.Descriptor, rc io.ReadCloser, prepareErr error) {
 	if path == "" {
 		return ocispec.Descriptor{}, nil, errors.New("missing file name")
@@ -67,18 +68,15 @@ func PrepareBlobContent(path string, mediaType string, dgstStr string, size int6
 
 	// prepares the content descriptor from stdin
 	if path == "-" {
-		// throw err if size or digest is not provided.
-		if size < 0 {
-			return ocispec.Descriptor{}, nil, errors.New("content size must be provided if it is read from stdin")
+		if size >= 0 && dgst != "" {
+			return ocispec.Descriptor{
+				MediaType: mediaType,
+				Digest:    dgst,
+				Size:      size,
+			}, os.Stdin, nil
 		}
-		if dgst == "" {
-			return ocispec.Descriptor{}, nil, errors.New("content digest must be provided if it is read from stdin")
-		}
-		return ocispec.Descriptor{
-			MediaType: mediaType,
-			Digest:    dgst,
-			Size:      size,
-		}, os.Stdin, nil
+		// spool stdin into a temporary file for digest and size computation
+		return spoolBlobContent(os.Stdin, mediaType, dgst, size)
 	}
 
 	file, err := os.Open(path)
@@ -116,3 +114,58 @@ func PrepareBlobContent(path string, mediaType string, dgstStr string, size int6
 		Size:      actualSize,
 	}, file, nil
 }
+
+// spoolBlobContent copies the content from r into a temporary file and
+// computes its digest and size. The temporary file is removed when the
+// returned ReadCloser is closed.
+func spoolBlobContent(r io.Reader, mediaType string, dgst digest.Digest, size int64) (desc ocispec.Descriptor, rc io.ReadCloser, spoolErr error) {
+	tmp, err := os.CreateTemp("", "oras_blob_*")
+	if err != nil {
+		return ocispec.Descriptor{}, nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	spooled := &tempFile{File: tmp}
+	defer func() {
+		if spoolErr != nil {
+			spooled.Close()
+		}
+	}()
+
+	digester := digest.Canonical.Digester()
+	if dgst != "" {
+		digester = dgst.Algorithm().Digester()
+	}
+	actualSize, err := io.Copy(tmp, io.TeeReader(r, digester.Hash()))
+	if err != nil {
+		return ocispec.Descriptor{}, nil, fmt.Errorf("failed to read content from stdin: %w", err)
+	}
+	if size >= 0 && size != actualSize {
+		return ocispec.Descriptor{}, nil, fmt.Errorf("input size %d does not match the actual content size %d", size, actualSize)
+	}
+	actualDigest := digester.Digest()
+	if dgst != "" && dgst != actualDigest {
+		return ocispec.Descriptor{}, nil, fmt.Errorf("input digest %s does not match the actual content digest %s", dgst, actualDigest)
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+
+	return ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    actualDigest,
+		Size:      actualSize,
+	}, spooled, nil
+}
+
+// tempFile is a file which is removed on close.
+type tempFile struct {
+	*os.File
+}
+
+// Close closes and removes the file.
+func (f *tempFile) Close() error {
+	closeErr := f.File.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		return err
+	}
+	return closeErr
+}
@@ -16,6 +16,7 @@ limitations under the License.
 package file_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -226,16 +227,47 @@ func TestFile_PrepareBlobContent_fromStdin(t *testing.T) {
 	}
 
 	// test PrepareBlobContent from stdin with missing size
-	_, _, err = file.PrepareBlobContent("-", blobMediaType, "", -1)
-	expected := "content size must be provided if it is read from stdin"
-	if err.Error() != expected {
-		t.Fatalf("PrepareBlobContent() error = %v, wantErr %v", err, expected)
+	if _, err = tmpfile.Seek(0, io.SeekStart); err != nil {
+		t.Fatal("error calling Seek(), error =", err)
+	}
+	gotDesc, gotRc, err = file.PrepareBlobContent("-", blobMediaType, string(dgst), -1)
+	if err != nil {
+		t.Fatal("PrepareBlobContent() error=", err)
+	}
+	if !reflect.DeepEqual(gotDesc, wantDesc) {
+		t.Errorf("PrepareBlobContent() = %v, want %v", gotDesc, wantDesc)
+	}
+	gotContent, err := io.ReadAll(gotRc)
+	if err != nil {
+		t.Fatal("error calling ReadAll(), error =", err)
+	}
+	if !bytes.Equal(gotContent, content) {
+		t.Errorf("PrepareBlobContent() content = %v, want %v", gotContent, content)
+	}
+	if err := gotRc.Close(); err != nil {
+		t.Fatal("error calling Close(), error =", err)
 	}
 
-	// test PrepareBlobContent from stdin with missing digest
+	// test PrepareBlobContent from stdin with missing digest and size
+	if _, err = tmpfile.Seek(0, io.SeekStart); err != nil {
+		t.Fatal("error calling Seek(), error =", err)
+	}
+	gotDesc, gotRc, err = file.PrepareBlobContent("-", blobMediaType, "", -1)
+	if err != nil {
+		t.Fatal("PrepareBlobContent() error=", err)
+	}
+	defer gotRc.Close()
+	if !reflect.DeepEqual(gotDesc, wantDesc) {
+		t.Errorf("PrepareBlobContent() = %v, want %v", gotDesc, wantDesc)
+	}
+
+	// test PrepareBlobContent from stdin with mismatched size
+	if _, err = tmpfile.Seek(0, io.SeekStart); err != nil {
+		t.Fatal("error calling Seek(), error =", err)
+	}
 	_, _, err = file.PrepareBlobContent("-", blobMediaType, "", 5)
-	expected = "content digest must be provided if it is read from stdin"
-	if err.Error() != expected {
+	expected := "input size 5 does not match the actual content size 12"
+	if err == nil || err.Error() != expected {
 		t.Fatalf("PrepareBlobContent() error = %v, wantErr %v", err, expected)
 	}
 }
@@ -0,0 +1,112 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"container/list"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+)
+
+// DefaultPredecessorCacheSize is the default maximum number of nodes whose
+// predecessors are cached.
+const DefaultPredecessorCacheSize = 4096
+
+// PredecessorFinder finds the predecessors of a node, e.g. the referrers of a
+// manifest.
+type PredecessorFinder func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error)
+
+// CachePredecessors returns a PredecessorFinder memoizing the predecessors
+// found by find, keyed by the digest of the node, so that the predecessors of
+// each node are found at most once. Concurrent lookups of the same node share
+// a single call to find, and failed lookups are not cached. At most size nodes
+// are cached with the least recently used ones evicted.
+// The returned finder is meant to be used with a single source.
+func CachePredecessors(find PredecessorFinder, size int) PredecessorFinder {
+	c := &predecessorCache{
+		find:    find,
+		size:    size,
+		entries: make(map[digest.Digest]*list.Element),
+		lru:     list.New(),
+	}
+	return c.findPredecessors
+}
+
+// predecessorEntry is a cached or in-flight lookup of predecessors.
+type predecessorEntry struct {
+	digest digest.Digest
+	done   chan struct{}
+	descs  []ocispec.Descriptor
+	err    error
+}
+
+type predecessorCache struct {
+	find    PredecessorFinder
+	size    int
+	mu      sync.Mutex
+	entries map[digest.Digest]*list.Element
+	lru     *list.List
+}
+
+func (c *predecessorCache) findPredecessors(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	c.mu.Lock()
+	if elem, ok := c.entries[desc.Digest]; ok {
+		c.lru.MoveToFront(elem)
+		c.mu.Unlock()
+		e := elem.Value.(*predecessorEntry)
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if e.err != nil {
+			return nil, e.err
+		}
+		// callers may append to the returned slice
+		return slices.Clone(e.descs), nil
+	}
+	e := &predecessorEntry{
+		digest: desc.Digest,
+		done:   make(chan struct{}),
+	}
+	c.entries[desc.Digest] = c.lru.PushFront(e)
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*predecessorEntry).digest)
+	}
+	c.mu.Unlock()
+
+	e.descs, e.err = c.find(ctx, src, desc)
+	if e.err != nil {
+		c.mu.Lock()
+		if elem, ok := c.entries[desc.Digest]; ok && elem.Value == e {
+			c.lru.Remove(elem)
+			delete(c.entries, desc.Digest)
+		}
+		c.mu.Unlock()
+	}
+	close(e.done)
+	if e.err != nil {
+		return nil, e.err
+	}
+	return slices.Clone(e.descs), nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+func TestCachePredecessors_referrers(t *testing.T) {
+	subjects := []ocispec.Descriptor{
+		{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("a"), Size: 1},
+		{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("b"), Size: 1},
+	}
+	var hits sync.Map
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ref, found := strings.Cut(r.URL.Path, "/v2/test/referrers/")
+		if !found || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		count, _ := hits.LoadOrStore(ref, &atomic.Int32{})
+		count.(*atomic.Int32).Add(1)
+		index := ocispec.Index{
+			MediaType: ocispec.MediaTypeImageIndex,
+			Manifests: []ocispec.Descriptor{{
+				MediaType:    ocispec.MediaTypeImageManifest,
+				ArtifactType: "application/vnd.test",
+				Digest:       digest.FromString("referrer of " + ref),
+				Size:         1,
+			}},
+		}
+		w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+		_ = json.NewEncoder(w).Encode(index)
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := remote.NewRepository(uri.Host + "/test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.PlainHTTP = true
+
+	find := CachePredecessors(func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		return registry.Referrers(ctx, src, desc, "")
+	}, DefaultPredecessorCacheSize)
+	opts := oras.DefaultExtendedCopyOptions
+	opts.FindPredecessors = find
+	opts.Concurrency = 4
+	// duplicated and repeated lookups
+	descs := append(append([]ocispec.Descriptor{}, subjects...), subjects...)
+	for i := 0; i < 3; i++ {
+		got, err := FindPredecessors(context.Background(), repo, descs, opts)
+		if err != nil {
+			t.Fatalf("FindPredecessors() error = %v", err)
+		}
+		if len(got) != len(descs) {
+			t.Fatalf("FindPredecessors() got %d referrers, want %d", len(got), len(descs))
+		}
+	}
+	for _, subject := range subjects {
+		count, ok := hits.Load(subject.Digest.String())
+		if !ok {
+			t.Errorf("referrers of %s are not fetched", subject.Digest)
+			continue
+		}
+		if got := count.(*atomic.Int32).Load(); got != 1 {
+			t.Errorf("referrers of %s are fetched %d times, want 1", subject.Digest, got)
+		}
+	}
+}
+
+func TestCachePredecessors_eviction(t *testing.T) {
+	var calls atomic.Int32
+	find := CachePredecessors(func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		calls.Add(1)
+		return []ocispec.Descriptor{desc}, nil
+	}, 1)
+	a := ocispec.Descriptor{Digest: digest.FromString("a")}
+	b := ocispec.Descriptor{Digest: digest.FromString("b")}
+	for _, desc := range []ocispec.Descriptor{a, a, b, a} {
+		if _, err := find(context.Background(), nil, desc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("find called %d times, want 3", got)
+	}
+}
+
+func TestCachePredecessors_error(t *testing.T) {
+	var calls atomic.Int32
+	errFind := errors.New("find failed")
+	find := CachePredecessors(func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		if calls.Add(1) == 1 {
+			return nil, errFind
+		}
+		return nil, nil
+	}, DefaultPredecessorCacheSize)
+	desc := ocispec.Descriptor{Digest: digest.FromString("a")}
+	if _, err := find(context.Background(), nil, desc); !errors.Is(err, errFind) {
+		t.Fatalf("find() error = %v, want %v", err, errFind)
+	}
+	if _, err := find(context.Background(), nil, desc); err != nil {
+		t.Fatalf("find() error = %v, want nil", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("find called %d times, want 2", got)
+	}
+}
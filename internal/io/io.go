@@ -17,9 +17,13 @@ package io
 
 import (
 	"bytes"
+	"errors"
 	"io"
 )
 
+// ErrSizeLimitExceeded is returned when the content exceeds the size limit.
+var ErrSizeLimitExceeded = errors.New("size limit exceeded")
+
 // ReadLine reads a line from the reader with trailing \r dropped.
 func ReadLine(reader io.Reader) ([]byte, error) {
 	var line []byte
@@ -43,3 +47,31 @@ func ReadLine(reader io.Reader) ([]byte, error) {
 	}
 	return bytes.TrimSuffix(line, []byte{'\r'}), nil
 }
+
+// LimitReader returns a reader that reads from r but fails with
+// ErrSizeLimitExceeded once more than n bytes are read.
+func LimitReader(r io.Reader, n int64) io.Reader {
+	return &limitedReader{r: r, n: n}
+}
+
+type limitedReader struct {
+	r io.Reader
+	n int64 // remaining bytes allowed
+}
+
+// Read implements io.Reader.
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, ErrSizeLimitExceeded
+	}
+	if int64(len(p)) > l.n+1 {
+		// read one more byte to detect exceeding content
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n + int(l.n), ErrSizeLimitExceeded
+	}
+	return n, err
+}
@@ -18,6 +18,8 @@ package io_test
 import (
 	"errors"
 	"io"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -80,3 +82,57 @@ func TestReadLine_err(t *testing.T) {
 		t.Errorf("ReadLine() = %v, want error", got)
 	}
 }
+
+func TestLimitReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		n       int64
+		wantErr error
+	}{
+		{"within limit", "foo", 4, nil},
+		{"equal to limit", "foo", 3, nil},
+		{"exceeding limit", "foobar", 3, iotest.ErrSizeLimitExceeded},
+		{"zero limit", "foo", 0, iotest.ErrSizeLimitExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(iotest.LimitReader(strings.NewReader(tt.content), tt.n))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LimitReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if int64(len(got)) > tt.n {
+				t.Errorf("LimitReader() read %d bytes, want no more than %d", len(got), tt.n)
+			}
+		})
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.tar")
+	if err := iotest.WriteFileAtomic(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "hello")
+		return err
+	}); err != nil {
+		t.Fatalf("WriteFileAtomic() error = %v", err)
+	}
+	if got, err := os.ReadFile(path); err != nil || string(got) != "hello" {
+		t.Fatalf("file content = %q, %v, want %q", got, err, "hello")
+	}
+
+	// failed writes leave the existing file untouched
+	writeErr := errors.New("interrupted")
+	if err := iotest.WriteFileAtomic(path, func(w io.Writer) error {
+		_, _ = io.WriteString(w, "partial")
+		return writeErr
+	}); !errors.Is(err, writeErr) {
+		t.Fatalf("WriteFileAtomic() error = %v, want %v", err, writeErr)
+	}
+	if got, err := os.ReadFile(path); err != nil || string(got) != "hello" {
+		t.Errorf("file content = %q, %v, want %q", got, err, "hello")
+	}
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 1 {
+		t.Errorf("expect no temporary file left behind, got %v, %v", entries, err)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// minBurst is the minimum burst size of a RateLimiter so that small limits do
+// not degrade into byte-sized reads.
+const minBurst = 4 * 1024
+
+// RateLimiter is a token bucket limiting the number of bytes transferred per
+// second. A RateLimiter is safe for concurrent use and is meant to be shared
+// by all transfers to be limited as a whole.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // bytes per second
+	burst  int
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing bytesPerSecond bytes per
+// second.
+func NewRateLimiter(bytesPerSecond int64) *RateLimiter {
+	burst := int(bytesPerSecond / 10)
+	if burst < minBurst {
+		burst = minBurst
+	}
+	return &RateLimiter{
+		rate:   float64(bytesPerSecond),
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// WaitN blocks until n bytes are allowed to be transferred or ctx is done.
+func (l *RateLimiter) WaitN(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+	l.last = now
+	// reserve the tokens so that concurrent waiters queue up behind
+	l.tokens -= float64(n)
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// NewRateLimitedReader returns a reader that reads from r no faster than
+// allowed by limiter.
+func NewRateLimitedReader(ctx context.Context, r io.Reader, limiter *RateLimiter) io.Reader {
+	return &rateLimitedReader{ctx: ctx, r: r, limiter: limiter}
+}
+
+type rateLimitedReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *RateLimiter
+}
+
+// Read implements io.Reader.
+func (rr *rateLimitedReader) Read(p []byte) (int, error) {
+	if len(p) > rr.limiter.burst {
+		p = p[:rr.limiter.burst]
+	}
+	n, err := rr.r.Read(p)
+	if n > 0 {
+		if waitErr := rr.limiter.WaitN(rr.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+// NewRateLimitedReadCloser returns a ReadCloser that reads from rc no faster
+// than allowed by limiter and closes rc when closed.
+func NewRateLimitedReadCloser(ctx context.Context, rc io.ReadCloser, limiter *RateLimiter) io.ReadCloser {
+	return struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: NewRateLimitedReader(ctx, rc, limiter),
+		Closer: rc,
+	}
+}
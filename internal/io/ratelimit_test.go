@@ -0,0 +1,76 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	iotest "oras.land/oras/internal/io"
+)
+
+func TestRateLimitedReader(t *testing.T) {
+	// 40 KiB at 80 KiB/s takes 0.5s minus the initial burst of 8 KiB
+	limiter := iotest.NewRateLimiter(80 * 1024)
+	content := bytes.Repeat([]byte("a"), 40*1024)
+	start := time.Now()
+	got, err := io.ReadAll(iotest.NewRateLimitedReader(context.Background(), bytes.NewReader(content), limiter))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatal("ReadAll() got unexpected content")
+	}
+	if elapsed := time.Since(start); elapsed < 350*time.Millisecond {
+		t.Errorf("ReadAll() took %v, expected to be throttled", elapsed)
+	}
+}
+
+func TestRateLimiter_shared(t *testing.T) {
+	// two readers of 20 KiB sharing 80 KiB/s take as long as one of 40 KiB
+	limiter := iotest.NewRateLimiter(80 * 1024)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := iotest.NewRateLimitedReader(context.Background(), bytes.NewReader(make([]byte, 20*1024)), limiter)
+			if _, err := io.Copy(io.Discard, r); err != nil {
+				t.Errorf("Copy() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed < 350*time.Millisecond {
+		t.Errorf("concurrent reads took %v, expected to be throttled as a whole", elapsed)
+	}
+}
+
+func TestRateLimitedReader_contextCanceled(t *testing.T) {
+	limiter := iotest.NewRateLimiter(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := iotest.NewRateLimitedReader(ctx, bytes.NewReader(make([]byte, 8*1024)), limiter)
+	if _, err := io.ReadAll(r); !errors.Is(err, context.Canceled) {
+		t.Errorf("ReadAll() error = %v, want %v", err, context.Canceled)
+	}
+}
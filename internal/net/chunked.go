@@ -0,0 +1,255 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// headers negotiating the chunk size of blob uploads
+const (
+	HeaderChunkMinLength = "OCI-Chunk-Min-Length"
+	HeaderChunkMaxLength = "OCI-Chunk-Max-Length"
+)
+
+// DefaultUploadChunkSize is the preferred size of the chunks uploaded to
+// registries limiting the size of upload requests.
+const DefaultUploadChunkSize int64 = 64 * 1024 * 1024
+
+// chunkLimits is the chunk length range advertised by a registry for an
+// upload session. Zero means not limited.
+type chunkLimits struct {
+	min int64
+	max int64
+}
+
+// ChunkedUploadTransport is an http.RoundTripper splitting monolithic blob
+// uploads into chunks if the registry advertises a maximum chunk length
+// smaller than the blob when the upload session is started.
+//
+// Each chunk is buffered in memory so that it can be retried by the Base
+// transport without restarting the blob upload.
+type ChunkedUploadTransport struct {
+	Base http.RoundTripper
+	// ChunkSize is the preferred chunk size, bounded by the limits advertised
+	// by the registry. DefaultUploadChunkSize is used if it is not positive.
+	ChunkSize int64
+
+	sessions sync.Map // map[string]chunkLimits, keyed by the upload path
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ChunkedUploadTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodPost:
+		resp, err := t.Base.RoundTrip(req)
+		if err == nil && resp.StatusCode == http.StatusAccepted && strings.HasSuffix(req.URL.Path, "/blobs/uploads/") {
+			t.recordSession(resp)
+		}
+		return resp, err
+	case http.MethodPut:
+		if !req.URL.Query().Has("digest") {
+			break
+		}
+		value, ok := t.sessions.LoadAndDelete(req.URL.Path)
+		if !ok {
+			break
+		}
+		limits := value.(chunkLimits)
+		if limits.max <= 0 || req.ContentLength <= limits.max || req.Body == nil {
+			break
+		}
+		return t.uploadChunks(req, limits)
+	}
+	return t.Base.RoundTrip(req)
+}
+
+// recordSession records the chunk limits of the upload session started by
+// resp.
+func (t *ChunkedUploadTransport) recordSession(resp *http.Response) {
+	location, err := resp.Location()
+	if err != nil {
+		return
+	}
+	limits := chunkLimits{
+		min: parseLength(resp.Header.Get(HeaderChunkMinLength)),
+		max: parseLength(resp.Header.Get(HeaderChunkMaxLength)),
+	}
+	if limits.max > 0 {
+		t.sessions.Store(location.Path, limits)
+	}
+}
+
+// chunkSize returns the size of the chunks to be uploaded within limits.
+func (t *ChunkedUploadTransport) chunkSize(limits chunkLimits) int64 {
+	size := t.ChunkSize
+	if size <= 0 {
+		size = DefaultUploadChunkSize
+	}
+	return max(min(size, limits.max), limits.min)
+}
+
+// uploadChunks uploads the body of the monolithic upload request req in
+// chunks and completes the upload.
+func (t *ChunkedUploadTransport) uploadChunks(req *http.Request, limits chunkLimits) (*http.Response, error) {
+	defer req.Body.Close()
+	size := req.ContentLength
+	query := req.URL.Query()
+	dgst := query.Get("digest")
+	query.Del("digest")
+	location := *req.URL
+	location.RawQuery = query.Encode()
+
+	chunkSize := t.chunkSize(limits)
+	buf := make([]byte, chunkSize)
+	for offset := int64(0); offset < size; offset += chunkSize {
+		chunk := buf[:min(chunkSize, size-offset)]
+		if _, err := io.ReadFull(req.Body, chunk); err != nil {
+			return nil, err
+		}
+		resp, err := t.uploadChunk(req, &location, offset, chunk)
+		if err != nil || resp != nil {
+			return resp, err
+		}
+	}
+
+	// complete the upload
+	query = location.Query()
+	query.Set("digest", dgst)
+	location.RawQuery = query.Encode()
+	putReq, err := newUploadRequest(req, http.MethodPut, &location, nil)
+	if err != nil {
+		return nil, err
+	}
+	return t.Base.RoundTrip(putReq)
+}
+
+// uploadChunk uploads chunk starting at offset to location, which is updated
+// to the location of the next request. If a chunk is rejected as out of
+// range, the upload is resumed from the offset received by the registry.
+// A non-nil response is returned if the registry rejects the chunk.
+func (t *ChunkedUploadTransport) uploadChunk(req *http.Request, location *url.URL, offset int64, chunk []byte) (*http.Response, error) {
+	for len(chunk) > 0 {
+		patchReq, err := newUploadRequest(req, http.MethodPatch, location, chunk)
+		if err != nil {
+			return nil, err
+		}
+		patchReq.Header.Set("Content-Range", fmt.Sprintf("%d-%d", offset, offset+int64(len(chunk))-1))
+		resp, err := t.Base.RoundTrip(patchReq)
+		if err != nil {
+			return nil, err
+		}
+		switch resp.StatusCode {
+		case http.StatusAccepted:
+			resp.Body.Close()
+			if err := updateLocation(location, resp); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		case http.StatusRequestedRangeNotSatisfiable:
+			// a previous attempt of the chunk is partially received
+			resp.Body.Close()
+			received, err := t.uploadStatus(req, location)
+			if err != nil {
+				return nil, err
+			}
+			if received <= offset || received > offset+int64(len(chunk)) {
+				return nil, fmt.Errorf("%s %q: cannot resume upload at offset %d: %d bytes received by the registry", http.MethodPatch, location.Redacted(), offset, received)
+			}
+			chunk = chunk[received-offset:]
+			offset = received
+		default:
+			return resp, nil
+		}
+	}
+	return nil, nil
+}
+
+// uploadStatus returns the number of bytes received by the registry for the
+// upload session at location, which is updated to the location of the next
+// request.
+func (t *ChunkedUploadTransport) uploadStatus(req *http.Request, location *url.URL) (int64, error) {
+	getReq, err := newUploadRequest(req, http.MethodGet, location, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := t.Base.RoundTrip(getReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		return 0, fmt.Errorf("%s %q: unexpected status code %d when checking upload status", http.MethodGet, location.Redacted(), resp.StatusCode)
+	}
+	if err := updateLocation(location, resp); err != nil {
+		return 0, err
+	}
+	// the Range header is inclusive, e.g. "0-1023" for 1024 bytes
+	_, end, ok := strings.Cut(resp.Header.Get("Range"), "-")
+	if !ok {
+		return 0, nil
+	}
+	received, err := strconv.ParseInt(end, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s %q: invalid Range header %q", http.MethodGet, location.Redacted(), resp.Header.Get("Range"))
+	}
+	return received + 1, nil
+}
+
+// newUploadRequest returns a request of the upload session at location with
+// the headers of req.
+func newUploadRequest(req *http.Request, method string, location *url.URL, body []byte) (*http.Request, error) {
+	newReq, err := http.NewRequestWithContext(req.Context(), method, location.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	newReq.Header = req.Header.Clone()
+	newReq.Header.Del("Content-Length")
+	if body == nil {
+		newReq.Header.Del("Content-Type")
+	}
+	return newReq, nil
+}
+
+// updateLocation updates location to the Location header of resp, if any.
+func updateLocation(location *url.URL, resp *http.Response) error {
+	if resp.Header.Get("Location") == "" {
+		return nil
+	}
+	next, err := resp.Location()
+	if err != nil {
+		return err
+	}
+	*location = *next
+	return nil
+}
+
+// parseLength parses a non-negative length header value. Invalid values are
+// treated as absent.
+func parseLength(value string) int64 {
+	length, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || length < 0 {
+		return 0
+	}
+	return length
+}
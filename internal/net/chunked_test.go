@@ -0,0 +1,206 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+// chunkLimitedRegistry is a registry rejecting upload requests larger than
+// maxChunk. The PATCH requests listed in fail fail once, after receiving
+// the number of bytes of the value.
+type chunkLimitedRegistry struct {
+	maxChunk int64
+	fail     map[int]int
+
+	lock     sync.Mutex
+	received []byte
+	patches  int
+	puts     int
+	blob     []byte
+}
+
+func (r *chunkLimitedRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	const location = "/v2/test/blobs/uploads/session"
+	setStatus := func() {
+		w.Header().Set("Location", location)
+		if len(r.received) > 0 {
+			w.Header().Set("Range", fmt.Sprintf("0-%d", len(r.received)-1))
+		}
+	}
+	switch {
+	case req.Method == http.MethodPost && req.URL.Path == "/v2/test/blobs/uploads/":
+		w.Header().Set("Location", location)
+		w.Header().Set(HeaderChunkMaxLength, fmt.Sprint(r.maxChunk))
+		w.WriteHeader(http.StatusAccepted)
+	case req.Method == http.MethodGet && req.URL.Path == location:
+		setStatus()
+		w.WriteHeader(http.StatusNoContent)
+	case req.Method == http.MethodPatch && req.URL.Path == location:
+		r.patches++
+		var start, end int
+		if _, err := fmt.Sscanf(req.Header.Get("Content-Range"), "%d-%d", &start, &end); err != nil || start != len(r.received) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		body, _ := io.ReadAll(req.Body)
+		if int64(len(body)) > r.maxChunk || len(body) != end-start+1 {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		if n, ok := r.fail[r.patches]; ok {
+			r.received = append(r.received, body[:n]...)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		r.received = append(r.received, body...)
+		setStatus()
+		w.WriteHeader(http.StatusAccepted)
+	case req.Method == http.MethodPut && req.URL.Path == location:
+		r.puts++
+		body, _ := io.ReadAll(req.Body)
+		if int64(len(body)) > r.maxChunk {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		blob := append(r.received, body...)
+		if digest.FromBytes(blob).String() != req.URL.Query().Get("digest") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blob = blob
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestChunkedUploadTransport(t *testing.T) {
+	blob := []byte(strings.Repeat("0123456789", 5))
+	desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, blob)
+	policy := &retry.GenericPolicy{
+		Retryable: retry.DefaultPredicate,
+		Backoff:   retry.ExponentialBackoff(time.Millisecond, 1, 0.1),
+		MinWait:   time.Millisecond,
+		MaxWait:   time.Millisecond,
+		MaxRetry:  3,
+	}
+	tests := []struct {
+		name        string
+		maxChunk    int64
+		fail        map[int]int
+		wantPatches int
+		wantPuts    int
+	}{
+		{
+			name:        "monolithic upload within limit",
+			maxChunk:    100,
+			wantPatches: 0,
+			wantPuts:    1,
+		},
+		{
+			name:        "chunked upload",
+			maxChunk:    16,
+			wantPatches: 4,
+			wantPuts:    1,
+		},
+		{
+			name:     "chunks retried and resumed",
+			maxChunk: 16,
+			// the 2nd PATCH fails before receiving anything and the 4th one
+			// fails after receiving 5 bytes
+			fail: map[int]int{2: 0, 4: 5},
+			// 4 chunks, a retried chunk, a retried chunk rejected for its
+			// range and a resumed chunk
+			wantPatches: 7,
+			wantPuts:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := &chunkLimitedRegistry{maxChunk: tt.maxChunk, fail: tt.fail}
+			ts := httptest.NewServer(registry)
+			defer ts.Close()
+			repo, err := remote.NewRepository(strings.TrimPrefix(ts.URL, "http://") + "/test")
+			if err != nil {
+				t.Fatal(err)
+			}
+			repo.PlainHTTP = true
+			repo.Client = &auth.Client{
+				Client: &http.Client{
+					Transport: &ChunkedUploadTransport{
+						Base: &retry.Transport{
+							Base:   http.DefaultTransport,
+							Policy: func() retry.Policy { return policy },
+						},
+					},
+				},
+			}
+			if err := repo.Blobs().Push(context.Background(), desc, bytes.NewReader(blob)); err != nil {
+				t.Fatalf("Push() error = %v", err)
+			}
+			if !bytes.Equal(registry.blob, blob) {
+				t.Errorf("uploaded blob = %q, want %q", registry.blob, blob)
+			}
+			if registry.patches != tt.wantPatches {
+				t.Errorf("PATCH requests = %d, want %d", registry.patches, tt.wantPatches)
+			}
+			if registry.puts != tt.wantPuts {
+				t.Errorf("PUT requests = %d, want %d", registry.puts, tt.wantPuts)
+			}
+		})
+	}
+}
+
+func TestChunkedUploadTransport_chunkSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		chunkSize int64
+		limits    chunkLimits
+		want      int64
+	}{
+		{"default bounded by max", 0, chunkLimits{max: 1024}, 1024},
+		{"default within max", 0, chunkLimits{max: 1 << 40}, DefaultUploadChunkSize},
+		{"preferred bounded by min", 10, chunkLimits{min: 100, max: 1000}, 100},
+		{"preferred within limits", 500, chunkLimits{min: 100, max: 1000}, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &ChunkedUploadTransport{ChunkSize: tt.chunkSize}
+			if got := transport.chunkSize(tt.limits); got != tt.want {
+				t.Errorf("chunkSize() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// headerDockerContentDigest is the header carrying the digest of a manifest.
+const headerDockerContentDigest = "Docker-Content-Digest"
+
+// ManifestHeadTransport is an http.RoundTripper making the HEAD requests of
+// manifests, which are used to resolve references and to check existence,
+// reliable on registries with broken HEAD support. A HEAD request falls back
+// to a GET request with the same headers if the HEAD response is unreliable,
+// or always if GetOnly is true. The response of the GET request is converted
+// to the one of the HEAD request.
+type ManifestHeadTransport struct {
+	Base    http.RoundTripper
+	GetOnly bool
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ManifestHeadTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	_, reference, ok := strings.Cut(req.URL.Path, "/manifests/")
+	if req.Method != http.MethodHead || !ok {
+		return t.Base.RoundTrip(req)
+	}
+	refDigest, err := digest.Parse(reference)
+	if err != nil {
+		// the reference is a tag
+		refDigest = ""
+	}
+	if !t.GetOnly {
+		resp, err := t.Base.RoundTrip(req)
+		if err != nil || !headUnreliable(resp, refDigest) {
+			return resp, err
+		}
+		resp.Body.Close()
+	}
+	return t.get(req, refDigest)
+}
+
+// headUnreliable returns true if resp of a manifest HEAD request should not
+// be trusted, i.e. the registry does not support HEAD or responds without the
+// headers required to describe the manifest.
+func headUnreliable(resp *http.Response, refDigest digest.Digest) bool {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if resp.Header.Get("Content-Type") == "" || resp.ContentLength < 0 {
+			return true
+		}
+		serverDigest, err := digest.Parse(resp.Header.Get(headerDockerContentDigest))
+		if err != nil {
+			return true
+		}
+		return refDigest != "" && serverDigest != refDigest
+	case http.StatusBadRequest, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	default:
+		return false
+	}
+}
+
+// get sends the manifest HEAD request req as a GET request and converts the
+// response to the one of req. A manifest whose content does not match
+// refDigest is reported as not found.
+func (t *ManifestHeadTransport) get(req *http.Request, refDigest digest.Digest) (*http.Response, error) {
+	getReq := req.Clone(req.Context())
+	getReq.Method = http.MethodGet
+	resp, err := t.Base.RoundTrip(getReq)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	defer resp.Body.Close()
+	algorithm := digest.Canonical
+	if refDigest != "" {
+		algorithm = refDigest.Algorithm()
+	}
+	digester := algorithm.Digester()
+	size, err := io.Copy(digester.Hash(), resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	contentDigest := digester.Digest()
+	headResp := &http.Response{
+		Status:        resp.Status,
+		StatusCode:    resp.StatusCode,
+		Proto:         resp.Proto,
+		ProtoMajor:    resp.ProtoMajor,
+		ProtoMinor:    resp.ProtoMinor,
+		Header:        resp.Header.Clone(),
+		Body:          http.NoBody,
+		ContentLength: size,
+		Request:       req,
+	}
+	if refDigest != "" && contentDigest != refDigest {
+		headResp.Status = fmt.Sprintf("%d %s", http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		headResp.StatusCode = http.StatusNotFound
+		headResp.ContentLength = 0
+		return headResp, nil
+	}
+	headResp.Header.Set(headerDockerContentDigest, contentDigest.String())
+	return headResp, nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestManifestHeadTransport(t *testing.T) {
+	manifestJSON := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json"}`)
+	manifest := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, manifestJSON)
+	missing := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("missing"))
+
+	tests := []struct {
+		name    string
+		getOnly bool
+		// head handles the HEAD requests of the manifest
+		head      func(w http.ResponseWriter)
+		wantHeads int
+		wantGets  int
+		wantFound bool
+	}{
+		{
+			name: "reliable HEAD",
+			head: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", manifest.MediaType)
+				w.Header().Set("Docker-Content-Digest", manifest.Digest.String())
+				w.Header().Set("Content-Length", strconv.Itoa(len(manifestJSON)))
+			},
+			wantHeads: 1,
+			wantFound: true,
+		},
+		{
+			name: "HEAD not found is trusted",
+			head: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantHeads: 1,
+		},
+		{
+			name:    "HEAD not found skipped",
+			getOnly: true,
+			head: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantGets:  1,
+			wantFound: true,
+		},
+		{
+			name: "HEAD without digest",
+			head: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", manifest.MediaType)
+			},
+			wantHeads: 1,
+			wantGets:  1,
+			wantFound: true,
+		},
+		{
+			name: "HEAD with mismatched digest",
+			head: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Type", manifest.MediaType)
+				w.Header().Set("Docker-Content-Digest", digest.FromString("other").String())
+			},
+			wantHeads: 1,
+			wantGets:  1,
+			wantFound: true,
+		},
+		{
+			name: "HEAD not allowed",
+			head: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			},
+			wantHeads: 1,
+			wantGets:  1,
+			wantFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var heads, gets int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/manifests/"+manifest.Digest.String()) {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if accept := r.Header.Get("Accept"); !strings.Contains(accept, ocispec.MediaTypeImageManifest) || !strings.Contains(accept, ocispec.MediaTypeImageIndex) {
+					t.Errorf("Accept header = %q, want all manifest media types", accept)
+				}
+				switch r.Method {
+				case http.MethodHead:
+					heads++
+					tt.head(w)
+				case http.MethodGet:
+					gets++
+					w.Header().Set("Content-Type", manifest.MediaType)
+					_, _ = w.Write(manifestJSON)
+				}
+			}))
+			defer ts.Close()
+			repo, err := remote.NewRepository(strings.TrimPrefix(ts.URL, "http://") + "/test")
+			if err != nil {
+				t.Fatal(err)
+			}
+			repo.PlainHTTP = true
+			repo.Client = &auth.Client{
+				Client: &http.Client{
+					Transport: &ManifestHeadTransport{
+						Base:    http.DefaultTransport,
+						GetOnly: tt.getOnly,
+					},
+				},
+			}
+			found, err := repo.Exists(context.Background(), manifest)
+			if err != nil {
+				t.Fatalf("Exists() error = %v", err)
+			}
+			if found != tt.wantFound {
+				t.Errorf("Exists() = %v, want %v", found, tt.wantFound)
+			}
+			if heads != tt.wantHeads || gets != tt.wantGets {
+				t.Errorf("HEAD requests = %d, GET requests = %d, want %d and %d", heads, gets, tt.wantHeads, tt.wantGets)
+			}
+			if found {
+				desc, err := repo.Resolve(context.Background(), manifest.Digest.String())
+				if err != nil {
+					t.Fatalf("Resolve() error = %v", err)
+				}
+				if desc.Digest != manifest.Digest || desc.Size != manifest.Size || desc.MediaType != manifest.MediaType {
+					t.Errorf("Resolve() = %v, want %v", desc, manifest)
+				}
+			}
+			if found, err := repo.Exists(context.Background(), missing); err != nil || found {
+				t.Errorf("Exists() of missing manifest = %v, %v, want false", found, err)
+			}
+		})
+	}
+}
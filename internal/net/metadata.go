@@ -0,0 +1,109 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	oio "oras.land/oras/internal/io"
+)
+
+// MetadataSizeError is returned when the response of a metadata API exceeds
+// the size limit.
+type MetadataSizeError struct {
+	Method string
+	URL    string
+	// Size is the size of the response, or -1 if the response is read
+	// partially and only known to be larger than the limit.
+	Size  int64
+	Limit int64
+}
+
+// Error returns the error message.
+func (e *MetadataSizeError) Error() string {
+	if e.Size < 0 {
+		return fmt.Sprintf("%s %q: response is larger than the metadata size limit of %d bytes", e.Method, e.URL, e.Limit)
+	}
+	return fmt.Sprintf("%s %q: response size %d exceeds the metadata size limit of %d bytes", e.Method, e.URL, e.Size, e.Limit)
+}
+
+// Unwrap returns oio.ErrSizeLimitExceeded.
+func (e *MetadataSizeError) Unwrap() error {
+	return oio.ErrSizeLimitExceeded
+}
+
+// MetadataSizeLimitTransport is an http.RoundTripper limiting the size of the
+// responses of the metadata APIs, i.e. manifests, referrers, tag list and
+// catalog. Blob responses are not limited.
+type MetadataSizeLimitTransport struct {
+	Base  http.RoundTripper
+	Limit int64
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *MetadataSizeLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || req.Method != http.MethodGet || !isMetadataPath(req.URL.Path) {
+		return resp, err
+	}
+	sizeErr := &MetadataSizeError{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Size:   resp.ContentLength,
+		Limit:  t.Limit,
+	}
+	if resp.ContentLength > t.Limit {
+		resp.Body.Close()
+		return nil, sizeErr
+	}
+	sizeErr.Size = -1
+	resp.Body = &metadataBody{
+		Reader: oio.LimitReader(resp.Body, t.Limit),
+		Closer: resp.Body,
+		err:    sizeErr,
+	}
+	return resp, nil
+}
+
+// metadataBody converts oio.ErrSizeLimitExceeded to a MetadataSizeError
+// naming the endpoint.
+type metadataBody struct {
+	io.Reader
+	io.Closer
+	err *MetadataSizeError
+}
+
+// Read implements io.Reader.
+func (b *metadataBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if errors.Is(err, oio.ErrSizeLimitExceeded) {
+		err = b.err
+	}
+	return n, err
+}
+
+// isMetadataPath returns true if path is a distribution API endpoint
+// returning metadata.
+func isMetadataPath(path string) bool {
+	return strings.Contains(path, "/manifests/") ||
+		strings.Contains(path, "/referrers/") ||
+		strings.HasSuffix(path, "/tags/list") ||
+		strings.HasSuffix(path, "/_catalog")
+}
@@ -0,0 +1,81 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	oio "oras.land/oras/internal/io"
+)
+
+func TestMetadataSizeLimitTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content := strings.Repeat("a", 100)
+		if strings.HasSuffix(r.URL.Path, "/chunked") {
+			// no Content-Length is sent for flushed responses
+			w.(http.Flusher).Flush()
+		}
+		_, _ = io.WriteString(w, content)
+	}))
+	defer ts.Close()
+	client := &http.Client{
+		Transport: &MetadataSizeLimitTransport{
+			Base:  http.DefaultTransport,
+			Limit: 10,
+		},
+	}
+
+	t.Run("manifest exceeding limit", func(t *testing.T) {
+		_, err := client.Get(ts.URL + "/v2/test/manifests/latest")
+		var sizeErr *MetadataSizeError
+		if !errors.As(err, &sizeErr) {
+			t.Fatalf("Get() error = %v, want %T", err, sizeErr)
+		}
+		if sizeErr.Size != 100 || sizeErr.Limit != 10 {
+			t.Errorf("Get() error = %v, want size 100 and limit 10", sizeErr)
+		}
+	})
+
+	t.Run("chunked referrers exceeding limit", func(t *testing.T) {
+		resp, err := client.Get(ts.URL + "/v2/test/referrers/chunked")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		defer resp.Body.Close()
+		_, err = io.ReadAll(resp.Body)
+		var sizeErr *MetadataSizeError
+		if !errors.As(err, &sizeErr) || !errors.Is(err, oio.ErrSizeLimitExceeded) {
+			t.Fatalf("ReadAll() error = %v, want %T", err, sizeErr)
+		}
+	})
+
+	t.Run("blob not limited", func(t *testing.T) {
+		resp, err := client.Get(ts.URL + "/v2/test/blobs/sha256:abc")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		defer resp.Body.Close()
+		got, err := io.ReadAll(resp.Body)
+		if err != nil || len(got) != 100 {
+			t.Fatalf("ReadAll() = %d bytes, %v, want 100 bytes", len(got), err)
+		}
+	})
+}
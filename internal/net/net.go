@@ -17,8 +17,13 @@ package net
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 // DialFunc is the function type for http.DialContext.
@@ -28,6 +33,13 @@ type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
 type Dialer struct {
 	BaseDialContext DialFunc
 	resolve         map[string]string
+	wildcards       map[string]wildcardResolve
+}
+
+// wildcardResolve is a DNS resolve entry matching any port of a host.
+type wildcardResolve struct {
+	to     net.IP
+	toPort int
 }
 
 // Add adds an entry for DNS resolve.
@@ -38,11 +50,103 @@ func (d *Dialer) Add(from string, fromPort int, to net.IP, toPort int) {
 	d.resolve[fmt.Sprintf("%s:%d", from, fromPort)] = fmt.Sprintf("%s:%d", to, toPort)
 }
 
+// AddWildcard adds an entry for DNS resolve matching any port of the host.
+// If toPort is 0, the port being dialed is kept.
+// Entries added by Add take precedence.
+func (d *Dialer) AddWildcard(from string, to net.IP, toPort int) {
+	if d.wildcards == nil {
+		d.wildcards = make(map[string]wildcardResolve)
+	}
+	d.wildcards[from] = wildcardResolve{to: to, toPort: toPort}
+}
+
 // DialContext connects to the addr on the named network using the provided
 // context.
 func (d *Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
 	if resolved, ok := d.resolve[addr]; ok {
 		addr = resolved
+	} else if host, port, err := net.SplitHostPort(addr); err == nil {
+		if wildcard, ok := d.wildcards[host]; ok {
+			if wildcard.toPort != 0 {
+				port = strconv.Itoa(wildcard.toPort)
+			}
+			addr = net.JoinHostPort(wildcard.to.String(), port)
+		}
 	}
 	return d.BaseDialContext(ctx, network, addr)
 }
+
+// MatchHost reports whether the registry, in the form of host[:port], matches
+// any of the patterns. A pattern is a host, a host:port pair, or a CIDR
+// notation matching registries addressed by IP.
+func MatchHost(registry string, patterns []string) bool {
+	host, _, err := net.SplitHostPort(registry)
+	if err != nil {
+		host = registry
+	}
+	ip := net.ParseIP(host)
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, ipNet, err := net.ParseCIDR(pattern); err == nil {
+			if ip != nil && ipNet.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if pattern == registry || pattern == host {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLoopback reports whether the host, in the form of host[:port], is
+// "localhost" or a loopback IP address.
+func IsLoopback(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ProxyConnectError is returned when the proxy rejects a CONNECT request.
+type ProxyConnectError struct {
+	ProxyURL *url.URL
+	Status   string
+}
+
+// Error returns the error message with the proxy credential redacted.
+func (e *ProxyConnectError) Error() string {
+	return fmt.Sprintf("proxy %s rejected the connection: %s", e.ProxyURL.Redacted(), e.Status)
+}
+
+// CheckProxyConnectResponse returns a *ProxyConnectError if the proxy does not
+// accept the CONNECT request. It is used as OnProxyConnectResponse of
+// http.Transport.
+func CheckProxyConnectResponse(_ context.Context, proxyURL *url.URL, _ *http.Request, resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return &ProxyConnectError{
+			ProxyURL: proxyURL,
+			Status:   resp.Status,
+		}
+	}
+	return nil
+}
+
+// IsProxyError reports whether err is caused by failing to connect to the
+// registry via the proxy.
+func IsProxyError(err error) bool {
+	var connectErr *ProxyConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "proxyconnect"
+}
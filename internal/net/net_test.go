@@ -16,6 +16,7 @@ limitations under the License.
 package net
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"reflect"
@@ -39,3 +40,83 @@ func TestRemote_parseResolve_ipv4(t *testing.T) {
 		t.Fatalf("expecting %v  but got %v", want, d.resolve)
 	}
 }
+
+func TestMatchHost(t *testing.T) {
+	patterns := []string{"lab.example.com", "localhost:5000", "10.0.0.0/8", " 192.168.1.1 "}
+	tests := []struct {
+		registry string
+		want     bool
+	}{
+		{"lab.example.com", true},
+		{"lab.example.com:8443", true},
+		{"localhost:5000", true},
+		{"localhost:6000", false},
+		{"10.1.2.3:5000", true},
+		{"11.1.2.3:5000", false},
+		{"192.168.1.1", true},
+		{"registry.example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.registry, func(t *testing.T) {
+			if got := MatchHost(tt.registry, patterns); got != tt.want {
+				t.Errorf("MatchHost(%q) = %v, want %v", tt.registry, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialer_DialContext_wildcard(t *testing.T) {
+	var dialed string
+	d := Dialer{
+		BaseDialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = addr
+			return nil, nil
+		},
+	}
+	d.Add("registry.example.com", 5000, net.ParseIP("10.0.0.1"), 5001)
+	d.AddWildcard("registry.example.com", net.ParseIP("10.0.0.2"), 0)
+	d.AddWildcard("mirror.example.com", net.ParseIP("10.0.0.3"), 8443)
+
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{"registry.example.com:5000", "10.0.0.1:5001"},
+		{"registry.example.com:443", "10.0.0.2:443"},
+		{"mirror.example.com:443", "10.0.0.3:8443"},
+		{"other.example.com:443", "other.example.com:443"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if _, err := d.DialContext(context.Background(), "tcp", tt.addr); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dialed != tt.want {
+				t.Fatalf("expect dialing %s, got %s", tt.want, dialed)
+			}
+		})
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"localhost", true},
+		{"localhost:5000", true},
+		{"127.0.0.1:5000", true},
+		{"127.1.2.3", true},
+		{"[::1]:5000", true},
+		{"::1", true},
+		{"registry.example.com:5000", false},
+		{"10.0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := IsLoopback(tt.host); got != tt.want {
+				t.Errorf("IsLoopback(%q) = %v, want %v", tt.host, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"net/http"
+
+	"oras.land/oras/internal/io"
+)
+
+// RateLimitTransport is an http.RoundTripper limiting the transfer rate of
+// request and response bodies with a shared rate limiter.
+type RateLimitTransport struct {
+	Base    http.RoundTripper
+	Limiter *io.RateLimiter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(ctx)
+		req.Body = io.NewRateLimitedReadCloser(ctx, req.Body, t.Limiter)
+	}
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Body != nil && resp.Body != http.NoBody {
+		resp.Body = io.NewRateLimitedReadCloser(ctx, resp.Body, t.Limiter)
+	}
+	return resp, nil
+}
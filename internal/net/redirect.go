@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// maxRedirects is the maximum number of redirects followed for a request,
+// which is the same as the default policy of http.Client.
+const maxRedirects = 10
+
+// RedirectDowngradeError is returned when an HTTPS request is redirected to
+// a plain HTTP URL.
+type RedirectDowngradeError struct {
+	From string
+	To   string
+}
+
+// Error returns the error message.
+func (e *RedirectDowngradeError) Error() string {
+	return fmt.Sprintf("refusing to follow the redirect from %q to %q: the connection would be downgraded from HTTPS to plain HTTP", e.From, e.To)
+}
+
+// CheckRedirect returns a redirect policy for http.Client. Redirects from
+// HTTPS to plain HTTP are refused unless allowPlainHTTP is true, and the
+// Authorization header is stripped once a request is redirected to a host
+// other than the one of the original request.
+func CheckRedirect(allowPlainHTTP bool) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return errors.New("stopped after 10 redirects")
+		}
+		prev := via[len(via)-1]
+		if !allowPlainHTTP && prev.URL.Scheme == "https" && req.URL.Scheme == "http" {
+			return &RedirectDowngradeError{
+				From: prev.URL.Redacted(),
+				To:   req.URL.Redacted(),
+			}
+		}
+		origin := via[0].URL.Host
+		if req.URL.Host != origin || slices.ContainsFunc(via, func(r *http.Request) bool {
+			return r.URL.Host != origin
+		}) {
+			req.Header.Del("Authorization")
+		}
+		return nil
+	}
+}
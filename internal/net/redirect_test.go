@@ -0,0 +1,123 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCheckRedirect_downgrade(t *testing.T) {
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer plain.Close()
+	secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, plain.URL+"/blob", http.StatusTemporaryRedirect)
+	}))
+	defer secure.Close()
+
+	t.Run("refused by default", func(t *testing.T) {
+		client := secure.Client()
+		client.CheckRedirect = CheckRedirect(false)
+		resp, err := client.Get(secure.URL + "/v2/test/blobs/sha256:abc")
+		if err == nil {
+			resp.Body.Close()
+			t.Fatal("Get() error = nil, want error")
+		}
+		var downgradeErr *RedirectDowngradeError
+		if !errors.As(err, &downgradeErr) {
+			t.Fatalf("Get() error = %v, want %T", err, downgradeErr)
+		}
+		if !strings.HasPrefix(downgradeErr.From, secure.URL) || downgradeErr.To != plain.URL+"/blob" {
+			t.Errorf("Get() error = %v, want redirect from %s to %s", err, secure.URL, plain.URL)
+		}
+	})
+
+	t.Run("allowed with plain HTTP", func(t *testing.T) {
+		client := secure.Client()
+		client.CheckRedirect = CheckRedirect(true)
+		resp, err := client.Get(secure.URL + "/v2/test/blobs/sha256:abc")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Get() status = %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+	})
+}
+
+func TestCheckRedirect_authorization(t *testing.T) {
+	var storageAuth, registryAuth []string
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		storageAuth = append(storageAuth, r.Header.Get("Authorization"))
+		if r.URL.Path == "/back" {
+			// redirect back to the registry
+			http.Redirect(w, r, r.URL.Query().Get("to"), http.StatusTemporaryRedirect)
+		}
+	}))
+	defer storage.Close()
+	var registry *httptest.Server
+	registry = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		registryAuth = append(registryAuth, r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/same-host":
+			http.Redirect(w, r, "/blob", http.StatusTemporaryRedirect)
+		case "/other-host":
+			http.Redirect(w, r, storage.URL+"/blob", http.StatusTemporaryRedirect)
+		case "/round-trip":
+			http.Redirect(w, r, storage.URL+"/back?to="+registry.URL+"/blob", http.StatusTemporaryRedirect)
+		}
+	}))
+	defer registry.Close()
+
+	tests := []struct {
+		path             string
+		wantRegistryAuth []string
+		wantStorageAuth  []string
+	}{
+		{"/same-host", []string{"Bearer token", "Bearer token"}, nil},
+		{"/other-host", []string{"Bearer token"}, []string{""}},
+		{"/round-trip", []string{"Bearer token", ""}, []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			registryAuth, storageAuth = nil, nil
+			client := &http.Client{CheckRedirect: CheckRedirect(false)}
+			req, err := http.NewRequest(http.MethodGet, registry.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Authorization", "Bearer token")
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			resp.Body.Close()
+			if !slices.Equal(registryAuth, tt.wantRegistryAuth) {
+				t.Errorf("Authorization received by registry = %q, want %q", registryAuth, tt.wantRegistryAuth)
+			}
+			if !slices.Equal(storageAuth, tt.wantStorageAuth) {
+				t.Errorf("Authorization received by storage = %q, want %q", storageAuth, tt.wantStorageAuth)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryAfterRecorder records the delays requested by the Retry-After headers
+// of rate-limited responses per host. It is safe for concurrent use.
+type RetryAfterRecorder struct {
+	delays sync.Map // map[string]time.Duration
+}
+
+// Get returns the last delay requested by host.
+func (r *RetryAfterRecorder) Get(host string) (time.Duration, bool) {
+	delay, ok := r.delays.Load(host)
+	if !ok {
+		return 0, false
+	}
+	return delay.(time.Duration), true
+}
+
+// RetryAfterTransport is an http.RoundTripper recording the Retry-After
+// headers of the responses with status 429 Too Many Requests.
+type RetryAfterTransport struct {
+	Base     http.RoundTripper
+	Recorder *RetryAfterRecorder
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+	if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		t.Recorder.delays.Store(req.URL.Host, delay)
+	}
+	return resp, nil
+}
+
+// parseRetryAfter parses the Retry-After header value, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(date.Sub(now), 0), true
+}
@@ -0,0 +1,171 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenTimeoutError is returned when a token endpoint does not respond within
+// the timeout.
+type TokenTimeoutError struct {
+	URL     string
+	Timeout time.Duration
+}
+
+// Error returns the error message.
+func (e *TokenTimeoutError) Error() string {
+	return fmt.Sprintf("token endpoint %q did not respond within %v", e.URL, e.Timeout)
+}
+
+// Unwrap returns context.DeadlineExceeded.
+func (e *TokenTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// TokenTimeoutTransport is an http.RoundTripper limiting the duration of the
+// requests to token endpoints, i.e. the realms of the Bearer challenges
+// returned by the registry, so that a hung token server fails fast while the
+// requests for content may stream for long. The timeout covers reading the
+// response body.
+type TokenTimeoutTransport struct {
+	Base    http.RoundTripper
+	Timeout time.Duration
+
+	realms sync.Map // map[string]struct{}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *TokenTimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := tokenEndpoint(req.URL)
+	if _, ok := t.realms.Load(endpoint); ok {
+		return t.roundTripToken(req, endpoint)
+	}
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	for _, challenge := range resp.Header.Values("WWW-Authenticate") {
+		if realm, ok := parseBearerRealm(challenge); ok {
+			if realmURL, err := url.Parse(realm); err == nil {
+				t.realms.Store(tokenEndpoint(realmURL), struct{}{})
+			}
+		}
+	}
+	return resp, nil
+}
+
+// roundTripToken sends the request to the token endpoint with the timeout.
+func (t *TokenTimeoutTransport) roundTripToken(req *http.Request, endpoint string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.Timeout)
+	timeoutErr := &TokenTimeoutError{
+		URL:     endpoint,
+		Timeout: t.Timeout,
+	}
+	resp, err := t.Base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded) && req.Context().Err() == nil
+		cancel()
+		if timedOut {
+			return nil, timeoutErr
+		}
+		return nil, err
+	}
+	resp.Body = &tokenBody{
+		ReadCloser: resp.Body,
+		ctx:        ctx,
+		parent:     req.Context(),
+		cancel:     cancel,
+		err:        timeoutErr,
+	}
+	return resp, nil
+}
+
+// tokenBody is the response body of a token request, converting the errors
+// caused by the timeout to a TokenTimeoutError and releasing the timer on
+// close.
+type tokenBody struct {
+	io.ReadCloser
+	ctx    context.Context
+	parent context.Context
+	cancel context.CancelFunc
+	err    *TokenTimeoutError
+}
+
+// Read implements io.Reader.
+func (b *tokenBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF && errors.Is(b.ctx.Err(), context.DeadlineExceeded) && b.parent.Err() == nil {
+		err = b.err
+	}
+	return n, err
+}
+
+// Close implements io.Closer.
+func (b *tokenBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// tokenEndpoint returns u without the query and the fragment, which
+// identifies the token endpoint.
+func tokenEndpoint(u *url.URL) string {
+	endpoint := *u
+	endpoint.RawQuery = ""
+	endpoint.ForceQuery = false
+	endpoint.Fragment = ""
+	endpoint.RawFragment = ""
+	endpoint.User = nil
+	return endpoint.String()
+}
+
+// parseBearerRealm returns the realm of a Bearer challenge of the
+// WWW-Authenticate header, e.g. `Bearer realm="https://auth.example/token",service="registry"`.
+func parseBearerRealm(challenge string) (string, bool) {
+	scheme, params, ok := strings.Cut(strings.TrimSpace(challenge), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return "", false
+	}
+	for params != "" {
+		var key string
+		key, params, ok = strings.Cut(strings.TrimLeft(params, " ,"), "=")
+		if !ok {
+			return "", false
+		}
+		var value string
+		if strings.HasPrefix(params, `"`) {
+			end := strings.Index(params[1:], `"`)
+			if end < 0 {
+				return "", false
+			}
+			value, params = params[1:end+1], params[end+2:]
+		} else {
+			value, params, _ = strings.Cut(params, ",")
+		}
+		if strings.EqualFold(strings.TrimSpace(key), "realm") {
+			return value, value != ""
+		}
+	}
+	return "", false
+}
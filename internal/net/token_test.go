@@ -0,0 +1,125 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestTokenTimeoutTransport(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// hang until the client gives up
+		<-r.Context().Done()
+	}))
+	defer tokenServer.Close()
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/test/blobs/slow":
+			// content transfers are not limited by the timeout
+			time.Sleep(100 * time.Millisecond)
+			_, _ = io.WriteString(w, "blob")
+		default:
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+tokenServer.URL+`/token",service="registry",scope="repository:test:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer registry.Close()
+
+	client := &auth.Client{
+		Client: &http.Client{
+			Transport: &TokenTimeoutTransport{
+				Base:    http.DefaultTransport,
+				Timeout: 20 * time.Millisecond,
+			},
+		},
+		Cache: auth.NewCache(),
+	}
+
+	t.Run("slow content", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, registry.URL+"/v2/test/blobs/slow", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		defer resp.Body.Close()
+		if body, err := io.ReadAll(resp.Body); err != nil || string(body) != "blob" {
+			t.Errorf("response body = %q, %v, want %q", body, err, "blob")
+		}
+	})
+
+	t.Run("hung token server", func(t *testing.T) {
+		ctx := auth.AppendScopes(context.Background(), "repository:test:pull")
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, registry.URL+"/v2/test/manifests/latest", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			t.Fatal("Do() error = nil, want error")
+		}
+		var timeoutErr *TokenTimeoutError
+		if !errors.As(err, &timeoutErr) {
+			t.Fatalf("Do() error = %v, want %T", err, timeoutErr)
+		}
+		if want := tokenServer.URL + "/token"; timeoutErr.URL != want {
+			t.Errorf("TokenTimeoutError.URL = %s, want %s", timeoutErr.URL, want)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Do() error = %v, want context.DeadlineExceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Do() took %v, want it to time out quickly", elapsed)
+		}
+	})
+}
+
+func Test_parseBearerRealm(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge string
+		want      string
+		wantOK    bool
+	}{
+		{"realm first", `Bearer realm="https://auth.example/token",service="registry"`, "https://auth.example/token", true},
+		{"realm last", `Bearer service="registry", realm="https://auth.example/token"`, "https://auth.example/token", true},
+		{"case insensitive", `bearer Realm="https://auth.example/token"`, "https://auth.example/token", true},
+		{"unquoted", `Bearer realm=https://auth.example/token,service=registry`, "https://auth.example/token", true},
+		{"basic", `Basic realm="registry"`, "", false},
+		{"no realm", `Bearer service="registry"`, "", false},
+		{"unterminated", `Bearer realm="https://auth.example/token`, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseBearerRealm(tt.challenge)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseBearerRealm() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
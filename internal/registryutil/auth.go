@@ -17,9 +17,13 @@ package registryutil
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
 
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
 )
 
 // WithScopeHint adds a hinted scope to the context.
@@ -29,3 +33,65 @@ func WithScopeHint(ctx context.Context, target any, actions ...string) context.C
 	}
 	return ctx
 }
+
+// AnonymousFallbackClient is a remote client that retries read-only requests
+// anonymously if the credentials are rejected by the registry.
+// Requests other than GET and HEAD never fall back.
+type AnonymousFallbackClient struct {
+	client     *auth.Client
+	anonymous  *auth.Client
+	onFallback func()
+	fellBack   atomic.Bool
+}
+
+// NewAnonymousFallbackClient wraps client with anonymous fallback for
+// read-only requests. onFallback, if not nil, is called once when the client
+// falls back to anonymous access for the first time.
+func NewAnonymousFallbackClient(client *auth.Client, onFallback func()) *AnonymousFallbackClient {
+	anonymous := *client
+	anonymous.Credential = nil
+	anonymous.Cache = auth.NewCache()
+	return &AnonymousFallbackClient{
+		client:     client,
+		anonymous:  &anonymous,
+		onFallback: onFallback,
+	}
+}
+
+// Do sends the request with credentials, and retries it anonymously if the
+// request is read-only and the credentials are rejected.
+func (c *AnonymousFallbackClient) Do(req *http.Request) (*http.Response, error) {
+	if !isReadOnly(req) {
+		return c.client.Do(req)
+	}
+	if c.fellBack.Load() {
+		return c.anonymous.Do(req)
+	}
+	resp, err := c.client.Do(req)
+	if !isUnauthorized(resp, err) {
+		return resp, err
+	}
+	if resp != nil {
+		resp.Body.Close()
+	}
+	if !c.fellBack.Swap(true) && c.onFallback != nil {
+		c.onFallback()
+	}
+	return c.anonymous.Do(req)
+}
+
+func isReadOnly(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return req.Body == nil || req.Body == http.NoBody
+	}
+	return false
+}
+
+func isUnauthorized(resp *http.Response, err error) bool {
+	if err != nil {
+		var errResp *errcode.ErrorResponse
+		return errors.As(err, &errResp) && errResp.StatusCode == http.StatusUnauthorized
+	}
+	return resp.StatusCode == http.StatusUnauthorized
+}
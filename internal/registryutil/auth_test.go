@@ -0,0 +1,98 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryutil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestAnonymousFallbackClient(t *testing.T) {
+	const anonymousToken = "anonymous-token"
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if _, _, ok := r.BasicAuth(); ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprintf(w, `{"access_token":%q}`, anonymousToken)
+		case "/v2/test/manifests/latest":
+			if r.Header.Get("Authorization") != "Bearer "+anonymousToken {
+				w.Header().Set("Www-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:test:pull"`, ts.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	newClient := func(onFallback func()) *AnonymousFallbackClient {
+		return NewAnonymousFallbackClient(&auth.Client{
+			Credential: auth.StaticCredential(ts.Listener.Addr().String(), auth.Credential{
+				Username: "stale",
+				Password: "stale",
+			}),
+		}, onFallback)
+	}
+	url := ts.URL + "/v2/test/manifests/latest"
+
+	t.Run("read-only request falls back", func(t *testing.T) {
+		fallbacks := 0
+		client := newClient(func() { fallbacks++ })
+		for i := 0; i < 2; i++ {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("expect status %d, got %d", http.StatusOK, resp.StatusCode)
+			}
+		}
+		if fallbacks != 1 {
+			t.Fatalf("expect fallback callback to be called once, got %d", fallbacks)
+		}
+	})
+
+	t.Run("write request does not fall back", func(t *testing.T) {
+		client := newClient(func() {
+			t.Fatal("unexpected fallback")
+		})
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, url, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			t.Fatal("expect error for rejected credentials")
+		}
+	})
+}
@@ -0,0 +1,54 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryutil
+
+import (
+	"context"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	oio "oras.land/oras/internal/io"
+)
+
+// ConfigSizeError is returned when the size of a config exceeds the metadata
+// size limit.
+type ConfigSizeError struct {
+	Desc  ocispec.Descriptor
+	Limit int64
+}
+
+// Error returns the error message.
+func (e *ConfigSizeError) Error() string {
+	return fmt.Sprintf("config %s of size %d exceeds the metadata size limit of %d bytes", e.Desc.Digest, e.Desc.Size, e.Limit)
+}
+
+// Unwrap returns oio.ErrSizeLimitExceeded.
+func (e *ConfigSizeError) Unwrap() error {
+	return oio.ErrSizeLimitExceeded
+}
+
+// FetchConfig fetches the content of the config desc into memory. Configs
+// larger than limit are rejected before fetching if limit is positive.
+func FetchConfig(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor, limit int64) ([]byte, error) {
+	if limit > 0 && desc.Size > limit {
+		return nil, &ConfigSizeError{
+			Desc:  desc,
+			Limit: limit,
+		}
+	}
+	return content.FetchAll(ctx, fetcher, desc)
+}
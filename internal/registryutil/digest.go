@@ -0,0 +1,79 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryutil
+
+import (
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// headerDockerContentDigest is the response header carrying the digest of the
+// content stored by a registry.
+const headerDockerContentDigest = "Docker-Content-Digest"
+
+// ManifestDigestClient is a remote client detecting registries rewriting the
+// pushed manifests, i.e. responding to a manifest push with a digest different
+// from the digest of the pushed content.
+type ManifestDigestClient struct {
+	// Client is the underlying client sending the requests.
+	Client remote.Client
+	// OnRewritten is called with the digest of the pushed manifest and the
+	// digest returned by the registry if they differ.
+	OnRewritten func(pushed, stored digest.Digest)
+}
+
+// Do sends the request, and compares the pushed and the returned digests if
+// the request pushes a manifest.
+func (c *ManifestDigestClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.Client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated || !isManifestPush(req) {
+		return resp, err
+	}
+	stored, err := digest.Parse(resp.Header.Get(headerDockerContentDigest))
+	if err != nil {
+		return resp, nil
+	}
+	if pushed, ok := pushedDigest(req, stored.Algorithm()); ok && pushed != stored {
+		c.OnRewritten(pushed, stored)
+	}
+	return resp, nil
+}
+
+// pushedDigest returns the digest of the manifest pushed by req, which is the
+// reference of the request if pushed by digest, or computed with algorithm
+// from a copy of the request body otherwise.
+func pushedDigest(req *http.Request, algorithm digest.Algorithm) (digest.Digest, bool) {
+	if dgst, err := digest.Parse(path.Base(req.URL.Path)); err == nil {
+		return dgst, true
+	}
+	if !algorithm.Available() || req.GetBody == nil || req.ContentLength < 0 || req.ContentLength > maxManifestProbeBytes {
+		return "", false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "", false
+	}
+	defer body.Close()
+	dgst, err := algorithm.FromReader(io.LimitReader(body, maxManifestProbeBytes))
+	if err != nil {
+		return "", false
+	}
+	return dgst, true
+}
@@ -0,0 +1,76 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryutil
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestManifestDigestClient(t *testing.T) {
+	body := `{"mediaType":"application/vnd.oci.image.manifest.v1+json"}`
+	pushed := digest.FromString(body)
+	rewritten := digest.FromString("rewritten")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if dgst := r.URL.Query().Get("digest"); dgst != "" {
+			w.Header().Set(headerDockerContentDigest, dgst)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantPushed digest.Digest
+		wantStored digest.Digest
+		wantCalled bool
+	}{
+		{"tag kept", "/v2/test/manifests/v1?digest=" + pushed.String(), "", "", false},
+		{"tag rewritten", "/v2/test/manifests/v1?digest=" + rewritten.String(), pushed, rewritten, true},
+		{"digest rewritten", "/v2/test/manifests/" + pushed.String() + "?digest=" + rewritten.String(), pushed, rewritten, true},
+		{"no digest returned", "/v2/test/manifests/v1", "", "", false},
+		{"blob upload", "/v2/test/blobs/uploads/1?digest=" + rewritten.String(), "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			var gotPushed, gotStored digest.Digest
+			client := &ManifestDigestClient{
+				Client: http.DefaultClient,
+				OnRewritten: func(pushed, stored digest.Digest) {
+					called, gotPushed, gotStored = true, pushed, stored
+				},
+			}
+			req, err := http.NewRequest(http.MethodPut, ts.URL+tt.path, bytes.NewReader([]byte(body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if called != tt.wantCalled || gotPushed != tt.wantPushed || gotStored != tt.wantStored {
+				t.Errorf("OnRewritten() called = %v with (%v, %v), want called = %v with (%v, %v)", called, gotPushed, gotStored, tt.wantCalled, tt.wantPushed, tt.wantStored)
+			}
+		})
+	}
+}
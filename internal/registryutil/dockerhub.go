@@ -0,0 +1,78 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryutil
+
+import "strings"
+
+// DockerHubRegistry is the registry name of Docker Hub, which is served at
+// registry-1.docker.io.
+const DockerHubRegistry = "docker.io"
+
+// dockerHubLibrary is the namespace of the official images on Docker Hub.
+const dockerHubLibrary = "library/"
+
+// DockerHubAliases are the host names referring to Docker Hub, including the
+// keys its credentials may be stored under in the docker config file.
+var DockerHubAliases = []string{DockerHubRegistry, "index.docker.io", "registry-1.docker.io"}
+
+// IsDockerHub returns true if registry is Docker Hub or one of its aliases.
+func IsDockerHub(registry string) bool {
+	for _, alias := range DockerHubAliases {
+		if registry == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeDockerHubRegistry maps the Docker Hub aliases to "docker.io".
+func NormalizeDockerHubRegistry(registry string) string {
+	if IsDockerHub(registry) {
+		return DockerHubRegistry
+	}
+	return registry
+}
+
+// NormalizeDockerHubReference normalizes a Docker Hub reference in the way of
+// docker: the Docker Hub aliases are mapped to "docker.io", and the "library/"
+// namespace is inserted for single-segment repositories. For example,
+// "index.docker.io/alpine:3.19" is normalized to
+// "docker.io/library/alpine:3.19".
+//
+// If bareNames is true, references without a registry are resolved against
+// Docker Hub, where the first segment of a reference is a registry only if it
+// contains a "." or a ":", or is "localhost". For example, "alpine:3.19" is
+// normalized to "docker.io/library/alpine:3.19" and "user/app" to
+// "docker.io/user/app".
+//
+// Other references are returned as is.
+func NormalizeDockerHubReference(reference string, bareNames bool) string {
+	registry, path, found := strings.Cut(reference, "/")
+	switch {
+	case found && IsDockerHub(registry):
+	case bareNames && (!found || !strings.ContainsAny(registry, ".:") && registry != "localhost"):
+		path = reference
+	default:
+		return reference
+	}
+	if path == "" {
+		return reference
+	}
+	if !strings.Contains(path, "/") {
+		path = dockerHubLibrary + path
+	}
+	return DockerHubRegistry + "/" + path
+}
@@ -0,0 +1,46 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryutil
+
+import "testing"
+
+func TestNormalizeDockerHubReference(t *testing.T) {
+	tests := []struct {
+		reference string
+		bareNames bool
+		want      string
+	}{
+		{"docker.io/library/alpine:3.19", false, "docker.io/library/alpine:3.19"},
+		{"docker.io/alpine:3.19", false, "docker.io/library/alpine:3.19"},
+		{"index.docker.io/alpine@sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2", false, "docker.io/library/alpine@sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2"},
+		{"registry-1.docker.io/user/app", false, "docker.io/user/app"},
+		{"docker.io", false, "docker.io"},
+		{"alpine:3.19", false, "alpine:3.19"},
+		{"user/app:v1", false, "user/app:v1"},
+		{"alpine:3.19", true, "docker.io/library/alpine:3.19"},
+		{"user/app:v1", true, "docker.io/user/app:v1"},
+		{"docker.io/alpine", true, "docker.io/library/alpine"},
+		{"localhost/app:v1", true, "localhost/app:v1"},
+		{"localhost:5000/app:v1", true, "localhost:5000/app:v1"},
+		{"example.com/app", true, "example.com/app"},
+		{"example.com/alpine", false, "example.com/alpine"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeDockerHubReference(tt.reference, tt.bareNames); got != tt.want {
+			t.Errorf("NormalizeDockerHubReference(%q, %v) = %q, want %q", tt.reference, tt.bareNames, got, tt.want)
+		}
+	}
+}
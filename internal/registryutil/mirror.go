@@ -0,0 +1,77 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryutil
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// MirrorClient is a remote client that sends read-only requests to the
+// mirrors in order before the origin registry. A mirror is skipped if the
+// request fails or the mirror responds with 404. Other requests are always
+// sent to the origin registry.
+type MirrorClient struct {
+	// Client is the underlying client sending the requests to the origin
+	// registry.
+	Client remote.Client
+	// Mirrors are the mirrors, in the order of preference.
+	Mirrors []Mirror
+	// Logger logs the host serving each request.
+	Logger logrus.FieldLogger
+}
+
+// Mirror is a mirror of the origin registry.
+type Mirror struct {
+	// Host is the host of the mirror.
+	Host string
+	// Client is the client sending the requests to the mirror. It must not
+	// carry the credentials or the TLS settings of the origin registry.
+	Client remote.Client
+}
+
+// Do sends the request to the mirrors if it is read-only, and falls back to
+// the origin registry.
+func (c *MirrorClient) Do(req *http.Request) (*http.Response, error) {
+	if isReadOnly(req) {
+		for _, mirror := range c.Mirrors {
+			mirrorReq := req.Clone(req.Context())
+			mirrorReq.URL.Host = mirror.Host
+			mirrorReq.Host = ""
+			// an Authorization header meant for the origin registry is not forwarded
+			mirrorReq.Header.Del("Authorization")
+			resp, err := mirror.Client.Do(mirrorReq)
+			if err != nil {
+				c.Logger.Debugf("Mirror %s failed for %s %s, trying next: %v", mirror.Host, req.Method, req.URL.Path, err)
+				continue
+			}
+			if resp.StatusCode == http.StatusNotFound {
+				resp.Body.Close()
+				c.Logger.Debugf("Mirror %s responded 404 for %s %s, trying next", mirror.Host, req.Method, req.URL.Path)
+				continue
+			}
+			c.Logger.Debugf("%s %s served by mirror %s", req.Method, req.URL.Path, mirror.Host)
+			return resp, nil
+		}
+	}
+	resp, err := c.Client.Do(req)
+	if err == nil {
+		c.Logger.Debugf("%s %s served by %s", req.Method, req.URL.Path, req.URL.Host)
+	}
+	return resp, err
+}
@@ -0,0 +1,89 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryutil
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestMirrorClient(t *testing.T) {
+	newServer := func(name string, status int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, name)
+		}))
+	}
+	origin := newServer("origin", http.StatusOK)
+	defer origin.Close()
+	missing := newServer("missing", http.StatusNotFound)
+	defer missing.Close()
+	mirror := newServer("mirror", http.StatusOK)
+	defer mirror.Close()
+	host := func(ts *httptest.Server) string {
+		u, _ := url.Parse(ts.URL)
+		return u.Host
+	}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	tests := []struct {
+		name    string
+		method  string
+		mirrors []string
+		want    string
+	}{
+		{"no mirror", http.MethodGet, nil, "origin"},
+		{"served by mirror", http.MethodGet, []string{host(mirror)}, "mirror"},
+		{"skip mirror responding 404", http.MethodGet, []string{host(missing), host(mirror)}, "mirror"},
+		{"fall back to origin", http.MethodGet, []string{host(missing)}, "origin"},
+		{"skip unreachable mirror", http.MethodGet, []string{"127.0.0.1:0", host(mirror)}, "mirror"},
+		{"write goes to origin", http.MethodDelete, []string{host(mirror)}, "origin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MirrorClient{
+				Client: http.DefaultClient,
+				Logger: logger,
+			}
+			for _, mirror := range tt.mirrors {
+				client.Mirrors = append(client.Mirrors, Mirror{Host: mirror, Client: http.DefaultClient})
+			}
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, origin.URL+"/v2/test/manifests/latest", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+			got, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("expect response from %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryutil
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"oras.land/oras-go/v2/registry/remote"
+	onet "oras.land/oras/internal/net"
+)
+
+// PlainHTTPFallbackClient is a remote client that retries requests to loopback
+// hosts via plain HTTP if the server does not speak TLS. Once fallen back, all
+// subsequent requests to loopback hosts are sent via plain HTTP.
+type PlainHTTPFallbackClient struct {
+	// Client is the underlying client sending the requests.
+	Client remote.Client
+	// OnFallback, if not nil, is called once when the client falls back to
+	// plain HTTP for the first time.
+	OnFallback func()
+
+	fellBack atomic.Bool
+}
+
+// Do sends the request, and retries it via plain HTTP if the request is sent
+// to a loopback host which does not speak TLS.
+func (c *PlainHTTPFallbackClient) Do(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != "https" || !onet.IsLoopback(req.URL.Host) {
+		return c.Client.Do(req)
+	}
+	if c.fellBack.Load() {
+		return c.Client.Do(toPlainHTTP(req))
+	}
+	resp, err := c.Client.Do(req)
+	if err == nil || !isNotTLSError(err) {
+		return resp, err
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, err
+		}
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+	if !c.fellBack.Swap(true) && c.OnFallback != nil {
+		c.OnFallback()
+	}
+	return c.Client.Do(toPlainHTTP(req))
+}
+
+// toPlainHTTP returns a copy of req sent via plain HTTP.
+func toPlainHTTP(req *http.Request) *http.Request {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = "http"
+	return req
+}
+
+// isNotTLSError returns true if err indicates that the server does not speak
+// TLS.
+func isNotTLSError(err error) bool {
+	var recordErr tls.RecordHeaderError
+	return errors.As(err, &recordErr) || strings.Contains(err.Error(), "server gave HTTP response to HTTPS client")
+}
@@ -0,0 +1,164 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/internal/trace"
+)
+
+// headerOCISubject is the response header returned by registries supporting
+// the referrers API on pushing a manifest with a subject.
+const headerOCISubject = "OCI-Subject"
+
+// maxManifestProbeBytes is the maximum size of a pushed manifest inspected
+// from the request body.
+const maxManifestProbeBytes = 4 * 1024 * 1024
+
+// ReferrersCapabilityClient is a remote client detecting the referrers API
+// capability of a registry from the responses of pushing manifests with a
+// subject: the OCI-Subject header is returned if and only if the registry
+// supports the referrers API.
+type ReferrersCapabilityClient struct {
+	// Client is the underlying client sending the requests.
+	Client remote.Client
+	// OnDetected is called with the capability detected from each successful
+	// push of a manifest with a subject.
+	OnDetected func(supported bool)
+}
+
+// Do sends the request, and detects the referrers API capability if the
+// request pushes a manifest with a subject.
+func (c *ReferrersCapabilityClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.Client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated || !isManifestPush(req) || !hasSubject(req) {
+		return resp, err
+	}
+	c.OnDetected(resp.Header.Get(headerOCISubject) != "")
+	return resp, nil
+}
+
+// isManifestPush returns true if req pushes a manifest.
+func isManifestPush(req *http.Request) bool {
+	return req.Method == http.MethodPut && strings.Contains(req.URL.Path, "/manifests/")
+}
+
+// hasSubject returns true if the manifest pushed by req has a subject. The
+// manifest is read from a copy of the request body.
+func hasSubject(req *http.Request) bool {
+	if req.GetBody == nil || req.ContentLength > maxManifestProbeBytes {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	defer body.Close()
+	var manifest struct {
+		Subject *ocispec.Descriptor `json:"subject"`
+	}
+	if err := json.NewDecoder(io.LimitReader(body, maxManifestProbeBytes)).Decode(&manifest); err != nil {
+		return false
+	}
+	return manifest.Subject != nil
+}
+
+// ReferrersIndexTarget is a target holding referrers tag schema indexes.
+type ReferrersIndexTarget interface {
+	oras.Target
+	content.Deleter
+}
+
+// ReferrersTag returns the tag of the referrers tag schema index of subject.
+func ReferrersTag(subject ocispec.Descriptor) string {
+	return subject.Digest.Algorithm().String() + "-" + subject.Digest.Encoded()
+}
+
+// PruneReferrersIndex removes the entries of the manifests which no longer
+// exist from the referrers tag schema index of subject, keeping the order and
+// the fields of the remaining entries, and returns the removed entries. The
+// superseded index is deleted on a best-effort basis.
+func PruneReferrersIndex(ctx context.Context, target ReferrersIndexTarget, subject ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	tag := ReferrersTag(subject)
+	indexDesc, err := target.Resolve(ctx, tag)
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	indexJSON, err := content.FetchAll(ctx, target, indexDesc)
+	if err != nil {
+		return nil, err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(indexJSON, &index); err != nil {
+		return nil, fmt.Errorf("failed to decode referrers index %s: %w", tag, err)
+	}
+
+	kept := make([]ocispec.Descriptor, 0, len(index.Manifests))
+	var removed []ocispec.Descriptor
+	for _, referrer := range index.Manifests {
+		exists, err := target.Exists(ctx, referrer)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			kept = append(kept, referrer)
+		} else {
+			removed = append(removed, referrer)
+		}
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	if len(kept) > 0 {
+		index.Manifests = kept
+		prunedJSON, err := json.Marshal(index)
+		if err != nil {
+			return nil, err
+		}
+		prunedDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, prunedJSON)
+		if err := target.Push(ctx, prunedDesc, bytes.NewReader(prunedJSON)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+			return nil, err
+		}
+		if err := target.Tag(ctx, prunedDesc, tag); err != nil {
+			return nil, err
+		}
+	}
+	// an index without entries is removed together with its tag
+	if err := target.Delete(ctx, indexDesc); err != nil {
+		if len(kept) == 0 {
+			return nil, err
+		}
+		trace.Logger(ctx).Debugf("failed to delete the superseded referrers index %s: %v", indexDesc.Digest, err)
+	}
+	return removed, nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestReferrersCapabilityClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("header") {
+			w.Header().Set(headerOCISubject, "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2")
+		}
+		if r.URL.Query().Has("error") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	withSubject := `{"mediaType":"application/vnd.oci.image.manifest.v1+json","subject":{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2","size":2}}`
+	withoutSubject := `{"mediaType":"application/vnd.oci.image.manifest.v1+json"}`
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		wantDetected bool
+		wantSupport  bool
+	}{
+		{"header returned", http.MethodPut, "/v2/test/manifests/v1?header", withSubject, true, true},
+		{"header omitted", http.MethodPut, "/v2/test/manifests/v1", withSubject, true, false},
+		{"push rejected", http.MethodPut, "/v2/test/manifests/v1?error", withSubject, false, false},
+		{"no subject", http.MethodPut, "/v2/test/manifests/v1", withoutSubject, false, false},
+		{"blob upload", http.MethodPut, "/v2/test/blobs/uploads/1", withSubject, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var detected, supported bool
+			client := &ReferrersCapabilityClient{
+				Client: http.DefaultClient,
+				OnDetected: func(s bool) {
+					detected, supported = true, s
+				},
+			}
+			req, err := http.NewRequest(tt.method, ts.URL+tt.path, bytes.NewReader([]byte(tt.body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if detected != tt.wantDetected || supported != tt.wantSupport {
+				t.Errorf("OnDetected() called = %v with %v, want called = %v with %v", detected, supported, tt.wantDetected, tt.wantSupport)
+			}
+		})
+	}
+}
+
+// deletableStore is a memory store recording the deleted content.
+type deletableStore struct {
+	*memory.Store
+	deleted []digest.Digest
+}
+
+func (s *deletableStore) Delete(_ context.Context, target ocispec.Descriptor) error {
+	s.deleted = append(s.deleted, target.Digest)
+	return nil
+}
+
+func pushJSON(t *testing.T, store *deletableStore, mediaType string, v any) ocispec.Descriptor {
+	t.Helper()
+	blob, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc := content.NewDescriptorFromBytes(mediaType, blob)
+	if err := store.Push(context.Background(), desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal(err)
+	}
+	return desc
+}
+
+func TestPruneReferrersIndex(t *testing.T) {
+	ctx := context.Background()
+	subject := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte(`{"subject":true}`))
+	tag := ReferrersTag(subject)
+	tagIndex := func(t *testing.T, store *deletableStore, referrers ...ocispec.Descriptor) ocispec.Descriptor {
+		t.Helper()
+		index := ocispec.Index{
+			MediaType: ocispec.MediaTypeImageIndex,
+			Manifests: referrers,
+		}
+		index.SchemaVersion = 2
+		desc := pushJSON(t, store, ocispec.MediaTypeImageIndex, index)
+		if err := store.Tag(ctx, desc, tag); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	referrer := func(t *testing.T, store *deletableStore, name string) ocispec.Descriptor {
+		t.Helper()
+		desc := pushJSON(t, store, ocispec.MediaTypeImageManifest, map[string]string{"name": name})
+		desc.ArtifactType = "application/vnd.test." + name
+		desc.Annotations = map[string]string{"name": name}
+		return desc
+	}
+	stale := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("stale"))
+
+	t.Run("index not found", func(t *testing.T) {
+		store := &deletableStore{Store: memory.New()}
+		removed, err := PruneReferrersIndex(ctx, store, subject)
+		if err != nil || removed != nil {
+			t.Fatalf("PruneReferrersIndex() = %v, %v, want nil, nil", removed, err)
+		}
+	})
+
+	t.Run("nothing to prune", func(t *testing.T) {
+		store := &deletableStore{Store: memory.New()}
+		indexDesc := tagIndex(t, store, referrer(t, store, "sbom"))
+		removed, err := PruneReferrersIndex(ctx, store, subject)
+		if err != nil || removed != nil {
+			t.Fatalf("PruneReferrersIndex() = %v, %v, want nil, nil", removed, err)
+		}
+		if got, _ := store.Resolve(ctx, tag); got.Digest != indexDesc.Digest {
+			t.Errorf("index is updated to %s, want %s", got.Digest, indexDesc.Digest)
+		}
+		if len(store.deleted) != 0 {
+			t.Errorf("deleted = %v, want none", store.deleted)
+		}
+	})
+
+	t.Run("stale entries removed", func(t *testing.T) {
+		store := &deletableStore{Store: memory.New()}
+		first := referrer(t, store, "signature")
+		last := referrer(t, store, "sbom")
+		indexDesc := tagIndex(t, store, first, stale, last)
+		removed, err := PruneReferrersIndex(ctx, store, subject)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []ocispec.Descriptor{stale}; !reflect.DeepEqual(removed, want) {
+			t.Errorf("removed = %v, want %v", removed, want)
+		}
+		desc, err := store.Resolve(ctx, tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blob, err := content.FetchAll(ctx, store, desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var index ocispec.Index
+		if err := json.Unmarshal(blob, &index); err != nil {
+			t.Fatal(err)
+		}
+		if want := []ocispec.Descriptor{first, last}; !reflect.DeepEqual(index.Manifests, want) {
+			t.Errorf("index entries = %v, want %v", index.Manifests, want)
+		}
+		if want := []digest.Digest{indexDesc.Digest}; !reflect.DeepEqual(store.deleted, want) {
+			t.Errorf("deleted = %v, want %v", store.deleted, want)
+		}
+	})
+
+	t.Run("all entries removed", func(t *testing.T) {
+		store := &deletableStore{Store: memory.New()}
+		indexDesc := tagIndex(t, store, stale)
+		removed, err := PruneReferrersIndex(ctx, store, subject)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []ocispec.Descriptor{stale}; !reflect.DeepEqual(removed, want) {
+			t.Errorf("removed = %v, want %v", removed, want)
+		}
+		if want := []digest.Digest{indexDesc.Digest}; !reflect.DeepEqual(store.deleted, want) {
+			t.Errorf("deleted = %v, want %v", store.deleted, want)
+		}
+	})
+}
@@ -17,6 +17,7 @@ package trace
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -26,20 +27,17 @@ type contextKey int
 // loggerKey is the associated key type for logger entry in context.
 const loggerKey contextKey = iota
 
-// NewLogger returns a logger.
-func NewLogger(ctx context.Context, debug bool, verbose bool) (context.Context, logrus.FieldLogger) {
-	var logLevel logrus.Level
-	if debug {
-		logLevel = logrus.DebugLevel
-	} else if verbose {
-		logLevel = logrus.InfoLevel
-	} else {
-		logLevel = logrus.WarnLevel
-	}
-
+// NewLogger returns a logger logging at the given level. Log lines are
+// prefixed with RFC 3339 timestamps at the debug and trace levels.
+func NewLogger(ctx context.Context, level logrus.Level) (context.Context, logrus.FieldLogger) {
 	logger := logrus.New()
-	logger.SetFormatter(&logrus.TextFormatter{DisableQuote: true})
-	logger.SetLevel(logLevel)
+	formatter := &logrus.TextFormatter{DisableQuote: true}
+	if level >= logrus.DebugLevel {
+		formatter.FullTimestamp = true
+		formatter.TimestampFormat = time.RFC3339
+	}
+	logger.SetFormatter(formatter)
+	logger.SetLevel(level)
 	entry := logger.WithContext(ctx)
 	return context.WithValue(ctx, loggerKey, entry), entry
 }
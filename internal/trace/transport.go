@@ -16,12 +16,27 @@ limitations under the License.
 package trace
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"strings"
 	"sync/atomic"
+	"time"
 )
 
+// DefaultRequestIDHeader is the default name of the header carrying the
+// request ID.
+const DefaultRequestIDHeader = "X-Request-Id"
+
+// payloadSizeLimit is the maximum number of bytes of a request or response
+// body to be logged.
+const payloadSizeLimit = 16 * 1024
+
 var (
 	// requestCount records the number of logged request-response pairs and will
 	// be used as the unique id for the next pair.
@@ -30,20 +45,38 @@ var (
 	// toScrub is a set of headers that should be scrubbed from the log.
 	toScrub = []string{
 		"Authorization",
+		"Proxy-Authorization",
+		"Cookie",
 		"Set-Cookie",
 	}
+
+	// toScrubInBody is a set of JSON fields that should be scrubbed from the
+	// logged bodies.
+	toScrubInBody = []string{
+		"token",
+		"access_token",
+		"refresh_token",
+	}
 )
 
 // Transport is an http.RoundTripper that keeps track of the in-flight
 // request and add hooks to report HTTP tracing events.
 type Transport struct {
 	http.RoundTripper
+	// RequestIDHeader is the name of the header carrying the request ID
+	// generated for each request so that the logs can be correlated with the
+	// logs of the registry. The header is not sent if it is empty.
+	RequestIDHeader string
+	// SensitiveHeaders are the names of the headers scrubbed from the log in
+	// addition to the authorization and cookie headers.
+	SensitiveHeaders []string
 }
 
 // NewTransport creates and returns a new instance of Transport
 func NewTransport(base http.RoundTripper) *Transport {
 	return &Transport{
-		RoundTripper: base,
+		RoundTripper:    base,
+		RequestIDHeader: DefaultRequestIDHeader,
 	}
 }
 
@@ -53,31 +86,53 @@ func (t *Transport) RoundTrip(req *http.Request) (resp *http.Response, err error
 	ctx := req.Context()
 	e := Logger(ctx)
 
+	var requestID string
+	if t.RequestIDHeader != "" {
+		// keep the request ID specified by the user, e.g. via --header
+		if requestID = req.Header.Get(t.RequestIDHeader); requestID == "" {
+			requestID = newRequestID()
+			req = req.Clone(ctx)
+			req.Header.Set(t.RequestIDHeader, requestID)
+		}
+	}
+
 	// log the request
-	e.Debugf("Request #%d\n> Request URL: %q\n> Request method: %q\n> Request headers:\n%s",
-		id, req.URL, req.Method, logHeader(req.Header))
+	e.Debugf("Request #%d\n> Request ID: %q\n> Request URL: %q\n> Request method: %q\n> Request headers:\n%s%s",
+		id, requestID, req.URL, req.Method, logHeader(req.Header, t.SensitiveHeaders), logRequestBody(req))
 
 	// log the response
+	start := time.Now()
 	resp, err = t.RoundTripper.RoundTrip(req)
+	elapsed := time.Since(start)
 	if err != nil {
-		e.Errorf("Error in getting response: %w", err)
+		e.Errorf("Error in getting response after %v: %v", elapsed, err)
 	} else if resp == nil {
 		e.Errorf("No response obtained for request %s %q", req.Method, req.URL)
 	} else {
-		e.Debugf("Response #%d\n< Response Status: %q\n< Response headers:\n%s",
-			id, resp.Status, logHeader(resp.Header))
+		e.Debugf("Response #%d\n< Response Status: %q\n< Response protocol: %q\n< Response time: %v\n< Response headers:\n%s%s",
+			id, resp.Status, resp.Proto, elapsed, logHeader(resp.Header, t.SensitiveHeaders), logResponseBody(resp))
 	}
 	return resp, err
 }
 
+// newRequestID returns a random request ID.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
+
 // logHeader prints out the provided header keys and values, with auth header
-// scrubbed.
-func logHeader(header http.Header) string {
+// and the sensitive headers scrubbed.
+func logHeader(header http.Header, sensitive []string) string {
 	if len(header) > 0 {
 		headers := []string{}
+		scrubbed := append(append([]string(nil), toScrub...), sensitive...)
 		for k, v := range header {
-			for _, h := range toScrub {
-				if strings.EqualFold(k, h) {
+			for _, h := range scrubbed {
+				if strings.EqualFold(strings.TrimSpace(k), h) {
 					v = []string{"*****"}
 				}
 			}
@@ -87,3 +142,93 @@ func logHeader(header http.Header) string {
 	}
 	return "   Empty header"
 }
+
+// logRequestBody returns the JSON body of the request to be logged, with
+// secrets scrubbed and size capped. The request body is kept intact.
+func logRequestBody(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil || !isJSON(req.Header.Get("Content-Type")) {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	payload, err := io.ReadAll(io.LimitReader(body, payloadSizeLimit+1))
+	if err != nil {
+		return ""
+	}
+	return "\n> Request body:\n" + formatBody(payload)
+}
+
+// logResponseBody returns the JSON body of the response to be logged, with
+// secrets scrubbed and size capped. The logged bytes are put back so that the
+// response body can still be fully read by the caller.
+func logResponseBody(resp *http.Response) string {
+	if resp.Body == nil || resp.Body == http.NoBody || !isJSON(resp.Header.Get("Content-Type")) {
+		return ""
+	}
+	payload, err := io.ReadAll(io.LimitReader(resp.Body, payloadSizeLimit+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(bytes.NewReader(payload), resp.Body),
+		Closer: resp.Body,
+	}
+	if err != nil {
+		return ""
+	}
+	return "\n< Response body:\n" + formatBody(payload)
+}
+
+// formatBody formats the JSON payload for logging.
+func formatBody(payload []byte) string {
+	if len(payload) > payloadSizeLimit {
+		if containsSecret(payload) {
+			return "   Body omitted: truncated body containing secrets"
+		}
+		return fmt.Sprintf("   %s\n   ...(truncated to %d bytes)", payload[:payloadSizeLimit], payloadSizeLimit)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		if containsSecret(payload) {
+			return "   Body omitted: unparsable body containing secrets"
+		}
+		return "   " + string(payload)
+	}
+	scrubbed := false
+	for _, key := range toScrubInBody {
+		if _, ok := fields[key]; ok {
+			fields[key] = "*****"
+			scrubbed = true
+		}
+	}
+	if !scrubbed {
+		return "   " + string(payload)
+	}
+	redacted, err := json.Marshal(fields)
+	if err != nil {
+		return "   Body omitted: failed to scrub secrets"
+	}
+	return "   " + string(redacted)
+}
+
+// containsSecret returns true if the payload possibly contains a secret field.
+func containsSecret(payload []byte) bool {
+	for _, key := range toScrubInBody {
+		if bytes.Contains(payload, []byte(`"`+key+`"`)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isJSON returns true if the media type is JSON.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,160 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestTransport_RoundTrip(t *testing.T) {
+	const tokenBody = `{"token":"secret-token","expires_in":300}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-cookie")
+		_, _ = io.WriteString(w, tokenBody)
+	}))
+	defer ts.Close()
+
+	var logs bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&logs)
+	logger.SetLevel(logrus.DebugLevel)
+	ctx := context.WithValue(context.Background(), loggerKey, logrus.FieldLogger(logger))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer secret-auth")
+	req.Header.Set("X-Api-Key", "secret-api-key")
+	req.Header.Set("X-Tenant-Id", "tenant-id")
+	transport := NewTransport(http.DefaultTransport)
+	transport.SensitiveHeaders = []string{"x-api-key"}
+	client := &http.Client{Transport: transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != tokenBody {
+		t.Fatalf("expect response body %q to be intact, got %q", tokenBody, got)
+	}
+
+	output := logs.String()
+	for _, secret := range []string{"secret-token", "secret-cookie", "secret-auth", "secret-api-key"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("expect %q to be scrubbed from logs: %s", secret, output)
+		}
+	}
+	for _, want := range []string{"Response time", "expires_in", "tenant-id"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expect logs to contain %q: %s", want, output)
+		}
+	}
+}
+
+func Test_formatBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload []byte
+		want    string
+	}{
+		{"plain JSON", []byte(`{"name":"value"}`), `{"name":"value"}`},
+		{"scrubbed token", []byte(`{"access_token":"secret"}`), `{"access_token":"*****"}`},
+		{"truncated secret", []byte(`{"token":"` + strings.Repeat("a", payloadSizeLimit) + `"}`), "Body omitted"},
+		{"truncated", []byte(`[` + strings.Repeat(" ", payloadSizeLimit) + `]`), "truncated to"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatBody(tt.payload); !strings.Contains(got, tt.want) {
+				t.Errorf("formatBody() = %q, want containing %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransport_RoundTrip_requestID(t *testing.T) {
+	var received []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("X-Correlation-Id"))
+	}))
+	defer ts.Close()
+
+	var logs bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&logs)
+	logger.SetLevel(logrus.DebugLevel)
+	ctx := context.WithValue(context.Background(), loggerKey, logrus.FieldLogger(logger))
+	transport := NewTransport(http.DefaultTransport)
+	transport.RequestIDHeader = "X-Correlation-Id"
+	client := &http.Client{Transport: transport}
+
+	// generated request ID
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if req.Header.Get("X-Correlation-Id") != "" {
+		t.Error("expect the original request not to be modified")
+	}
+
+	// request ID specified by the user
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req.Header.Set("X-Correlation-Id", "user-id")
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(received) != 2 || len(received[0]) != 32 || received[1] != "user-id" {
+		t.Fatalf("unexpected request IDs received: %q", received)
+	}
+	for _, id := range received {
+		if !strings.Contains(logs.String(), id) {
+			t.Errorf("expect request ID %q to be logged: %s", id, logs.String())
+		}
+	}
+}
+
+func TestNewLogger_timestamp(t *testing.T) {
+	_, logger := NewLogger(context.Background(), logrus.DebugLevel)
+	formatter, ok := logger.(*logrus.Entry).Logger.Formatter.(*logrus.TextFormatter)
+	if !ok || !formatter.FullTimestamp || formatter.TimestampFormat != time.RFC3339 {
+		t.Errorf("expect RFC 3339 timestamps in debug logs, got %+v", formatter)
+	}
+}
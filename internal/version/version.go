@@ -15,6 +15,11 @@ limitations under the License.
 
 package version
 
+import (
+	"fmt"
+	"runtime"
+)
+
 var (
 	// Version is the current version of the oras.
 	Version = "1.2.0"
@@ -24,6 +29,8 @@ var (
 	GitCommit = ""
 	// GitTreeState is the state of the git tree
 	GitTreeState = ""
+	// BuildDate is the build time in RFC 3339 format
+	BuildDate = ""
 )
 
 // GetVersion returns the semver string of the version
@@ -33,3 +40,9 @@ func GetVersion() string {
 	}
 	return Version + "+" + BuildMetadata
 }
+
+// GetUserAgent returns the user agent string identifying oras, in the form of
+// oras/<version> (<os>/<arch>).
+func GetUserAgent() string {
+	return fmt.Sprintf("oras/%s (%s/%s)", GetVersion(), runtime.GOOS, runtime.GOARCH)
+}
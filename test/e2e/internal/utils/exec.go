@@ -156,9 +156,9 @@ func (opts *ExecOption) MatchTrimmedContent(content string) *ExecOption {
 	return opts
 }
 
-// MatchStatus adds full content matching to the execution option.
+// MatchStatus adds full status matching to stderr.
 func (opts *ExecOption) MatchStatus(keys []match.StateKey, verbose bool, successCount int) *ExecOption {
-	opts.stdout = append(opts.stdout, match.NewStatusMatcher(keys, opts.args[0], verbose, successCount))
+	opts.stderr = append(opts.stderr, match.NewStatusMatcher(keys, opts.args[0], verbose, successCount))
 	return opts
 }
 
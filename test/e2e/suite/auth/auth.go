@@ -88,7 +88,7 @@ var _ = Describe("Common registry user", func() {
 		It("should succeed to use basic auth", func() {
 			ORAS("login", ZOTHost, "-u", Username, "-p", Password, "--registry-config", filepath.Join(GinkgoT().TempDir(), tmpConfigName)).
 				WithTimeOut(20*time.Second).
-				MatchContent("Login Succeeded\n").
+				MatchErrKeyWords("Login Succeeded\n").
 				MatchErrKeyWords("WARNING", "Using --password via the CLI is insecure", "Use --password-stdin").Exec()
 		})
 
@@ -159,7 +159,7 @@ var _ = Describe("Common registry user", func() {
 			ORAS("login", ZOTHost, "--registry-config", filepath.Join(GinkgoT().TempDir(), tmpConfigName)).
 				WithTimeOut(20*time.Second).
 				WithInput(strings.NewReader(fmt.Sprintf("%s\n%s\n", Username, Password))).
-				MatchKeyWords("Username: ", "Password: ", "Login Succeeded\n").Exec()
+				MatchKeyWords("Username: ", "Password: ").MatchErrKeyWords("Login Succeeded\n").Exec()
 		})
 
 		It("should fail as the test server doesn't support token service", func() {
@@ -53,14 +53,6 @@ var _ = Describe("ORAS beginners:", func() {
 					MatchErrKeyWords("Error: `-` read file from input and `--identity-token-stdin` read identity token from input cannot be both used").Exec()
 			})
 
-			It("should fail to push a blob from stdin but no blob size provided", func() {
-				repo := fmt.Sprintf(repoFmt, "push", "no-size")
-				ORAS("blob", "push", RegistryRef(ZOTHost, repo, pushDigest), "-").
-					WithInput(strings.NewReader(pushContent)).
-					ExpectFailure().
-					MatchErrKeyWords("Error: `--size` must be provided if the blob is read from stdin").Exec()
-			})
-
 			It("should fail to push a blob from stdin if invalid blob size provided", func() {
 				content := "another-test"
 				digest := "sha256:c897eff15c4586525388034f8246346681cb48d75a619039c566c4939a18102e"
@@ -208,7 +200,7 @@ var _ = Describe("1.1 registry users:", func() {
 			toDeleteRef := RegistryRef(ZOTHost, dstRepo, foobar.FooBlobDigest)
 			ORAS("blob", "delete", toDeleteRef).
 				WithInput(strings.NewReader("y")).
-				MatchKeyWords("Deleted", toDeleteRef).Exec()
+				MatchErrKeyWords("Deleted", toDeleteRef).Exec()
 			// cannot verify the whether blob is deleted since zot will cache the blob
 			// https://github.com/project-zot/zot/issues/1733
 		})
@@ -223,7 +215,7 @@ var _ = Describe("1.1 registry users:", func() {
 		It("should return success when deleting a non-existent blob with force flag set", func() {
 			toDeleteRef := RegistryRef(ZOTHost, ImageRepo, invalidDigest)
 			ORAS("blob", "delete", toDeleteRef, "--force").
-				MatchKeyWords("Missing", toDeleteRef).
+				MatchErrKeyWords("Missing", toDeleteRef).
 				Exec()
 		})
 	})
@@ -238,7 +230,7 @@ var _ = Describe("1.1 registry users:", func() {
 
 			ORAS("blob", "push", RegistryRef(ZOTHost, repo, ""), blobPath, "-v").
 				WithDescription("skip the pushing if the blob already exists in the target repo").
-				MatchKeyWords("Exists").Exec()
+				MatchErrKeyWords("Exists").Exec()
 		})
 
 		It("should push a blob from a stdin and output the descriptor with specific media-type", func() {
@@ -249,6 +241,14 @@ var _ = Describe("1.1 registry users:", func() {
 				MatchContent(fmt.Sprintf(pushDescFmt, mediaType)).Exec()
 			ORAS("blob", "fetch", RegistryRef(ZOTHost, repo, pushDigest), "--output", "-").MatchContent(pushContent).Exec()
 		})
+
+		It("should push a blob from a stdin without size and digest and output the descriptor in JSON", func() {
+			repo := fmt.Sprintf(repoFmt, "push", "blob-stdin-json")
+			ORAS("blob", "push", RegistryRef(ZOTHost, repo, ""), "-", "--format", "json").
+				WithInput(strings.NewReader(pushContent)).
+				MatchKeyWords(pushDigest, `"size": 9`).Exec()
+			ORAS("blob", "fetch", RegistryRef(ZOTHost, repo, pushDigest), "--output", "-").MatchContent(pushContent).Exec()
+		})
 	})
 
 	When("running `blob fetch`", func() {
@@ -284,7 +284,7 @@ var _ = Describe("OCI image layout users:", func() {
 			// test
 			ORAS("blob", "delete", Flags.Layout, toDeleteRef).
 				WithInput(strings.NewReader("y")).
-				MatchKeyWords("Deleted", toDeleteRef).Exec()
+				MatchErrKeyWords("Deleted", toDeleteRef).Exec()
 			// validate
 			ORAS("blob", "fetch", toDeleteRef, Flags.Layout, "--output", "-").ExpectFailure().Exec()
 		})
@@ -303,7 +303,7 @@ var _ = Describe("OCI image layout users:", func() {
 			toDeleteRef := RegistryRef(ZOTHost, ImageRepo, invalidDigest)
 			// test
 			ORAS("blob", "delete", Flags.Layout, toDeleteRef, "--force").
-				MatchKeyWords("Missing", toDeleteRef).
+				MatchErrKeyWords("Missing", toDeleteRef).
 				Exec()
 		})
 	})
@@ -349,7 +349,7 @@ var _ = Describe("OCI image layout users:", func() {
 				MatchContent(fmt.Sprintf(pushDescFmt, mediaType)).Exec()
 			ORAS("blob", "push", Flags.Layout, LayoutRef(tmpRoot, pushDigest), blobPath, "-v").
 				WithDescription("skip pushing if the blob already exists in the target repo").
-				MatchKeyWords("Exists").Exec()
+				MatchErrKeyWords("Exists").Exec()
 			// validate
 			ORAS("blob", "fetch", LayoutRef(tmpRoot, pushDigest), Flags.Layout, "--output", "-").MatchContent(pushContent).Exec()
 		})
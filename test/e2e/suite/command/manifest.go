@@ -364,7 +364,7 @@ var _ = Describe("1.1 registry users:", func() {
 		It("should push a manifest from stdin without media type flag", func() {
 			tag := "from-stdin"
 			ORAS("manifest", "push", RegistryRef(ZOTHost, ImageRepo, tag), "-").
-				MatchKeyWords("Pushed", RegistryRef(ZOTHost, ImageRepo, tag), "Digest:", digest).
+				MatchErrKeyWords("Pushed", RegistryRef(ZOTHost, ImageRepo, tag)).MatchKeyWords("Digest:", digest).
 				WithInput(strings.NewReader(manifest)).Exec()
 		})
 
@@ -392,7 +392,7 @@ var _ = Describe("1.1 registry users:", func() {
 			manifestPath := WriteTempFile("manifest.json", manifest)
 			tag := "from-file"
 			ORAS("manifest", "push", RegistryRef(ZOTHost, ImageRepo, tag), manifestPath, "--media-type", "application/vnd.oci.image.manifest.v1+json").
-				MatchKeyWords("Pushed", RegistryRef(ZOTHost, ImageRepo, tag), "Digest:", digest).
+				MatchErrKeyWords("Pushed", RegistryRef(ZOTHost, ImageRepo, tag)).MatchKeyWords("Digest:", digest).
 				WithInput(strings.NewReader(manifest)).Exec()
 		})
 
@@ -468,7 +468,7 @@ var _ = Describe("1.1 registry users:", func() {
 		It("should succeed when deleting a non-existent manifest with force flag set", func() {
 			toDeleteRef := RegistryRef(ZOTHost, ImageRepo, invalidDigest)
 			ORAS("manifest", "delete", toDeleteRef, "--force").
-				MatchKeyWords("Missing", toDeleteRef).
+				MatchErrKeyWords("Missing", toDeleteRef).
 				Exec()
 		})
 	})
@@ -634,7 +634,7 @@ var _ = Describe("OCI image layout users:", func() {
 			// prepare
 			toDeleteRef := LayoutRef(PrepareTempOCI(ImageRepo), invalidDigest)
 			ORAS("manifest", "delete", Flags.Layout, toDeleteRef, "--force").
-				MatchKeyWords("Missing", toDeleteRef).
+				MatchErrKeyWords("Missing", toDeleteRef).
 				Exec()
 		})
 	})
@@ -659,7 +659,7 @@ var _ = Describe("1.0 registry users:", func() {
 			// prepare
 			ORAS("cp", RegistryRef(FallbackHost, ArtifactRepo, foobar.Tag), RegistryRef(FallbackHost, dstRepo, foobar.Tag)).Exec()
 			ORAS("manifest", "push", RegistryRef(FallbackHost, dstRepo, tag), "-", "--media-type", "application/vnd.oci.image.manifest.v1+json").
-				MatchKeyWords("Pushed", RegistryRef(FallbackHost, dstRepo, tag), "Digest:", digest).
+				MatchErrKeyWords("Pushed", RegistryRef(FallbackHost, dstRepo, tag)).MatchKeyWords("Digest:", digest).
 				WithInput(strings.NewReader(manifest)).Exec()
 
 			ORAS("manifest", "push", RegistryRef(FallbackHost, dstRepo, ""), "-").
@@ -700,7 +700,7 @@ var _ = Describe("OCI image layout users:", func() {
 			root := GinkgoT().TempDir()
 			prepare(root)
 			ORAS("manifest", "push", Flags.Layout, root, "-").
-				MatchKeyWords("Pushed", root, "Digest:", manifestDigest).
+				MatchErrKeyWords("Pushed", root).MatchKeyWords("Digest:", manifestDigest).
 				WithInput(strings.NewReader(manifest)).Exec()
 			validate(root, manifestDigest, "")
 		})
@@ -709,7 +709,7 @@ var _ = Describe("OCI image layout users:", func() {
 			root := GinkgoT().TempDir()
 			ref := LayoutRef(root, tag)
 			ORAS("manifest", "push", Flags.Layout, ref, "-").
-				MatchKeyWords("Pushed", ref, "Digest:", manifestDigest).
+				MatchErrKeyWords("Pushed", ref).MatchKeyWords("Digest:", manifestDigest).
 				WithInput(strings.NewReader(manifest)).Exec()
 			validate(root, manifestDigest, tag)
 		})
@@ -730,7 +730,7 @@ var _ = Describe("OCI image layout users:", func() {
 			tag := "from-file"
 			ref := LayoutRef(root, tag)
 			ORAS("manifest", "push", Flags.Layout, ref, manifestPath).
-				MatchKeyWords("Pushed", ref, "Digest:", manifestDigest).
+				MatchErrKeyWords("Pushed", ref).MatchKeyWords("Digest:", manifestDigest).
 				WithInput(strings.NewReader(manifest)).Exec()
 			validate(root, manifestDigest, tag)
 		})
@@ -744,7 +744,7 @@ var _ = Describe("OCI image layout users:", func() {
 			tag := "mediatype-flag"
 			ref := LayoutRef(root, tag)
 			ORAS("manifest", "push", Flags.Layout, ref, "-", "--media-type", "application/vnd.oci.image.manifest.v1+json").
-				MatchKeyWords("Pushed", ref, "Digest:", manifestDigest).
+				MatchErrKeyWords("Pushed", ref).MatchKeyWords("Digest:", manifestDigest).
 				WithInput(strings.NewReader(manifest)).Exec()
 			validate(root, manifestDigest, tag)
 
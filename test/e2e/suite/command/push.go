@@ -424,7 +424,7 @@ var _ = Describe("Remote registry users:", func() {
 			tempDir := PrepareTempFiles()
 
 			ORAS("push", RegistryRef(ZOTHost, repo, tag), foobar.FileBarName, "-v", "--image-spec", "v1.1").
-				MatchKeyWords("ArtifactType: ", "application/vnd.unknown.artifact.v1").
+				MatchErrKeyWords("ArtifactType: ", "application/vnd.unknown.artifact.v1").
 				WithWorkDir(tempDir).Exec()
 		})
 
@@ -434,7 +434,7 @@ var _ = Describe("Remote registry users:", func() {
 			tempDir := PrepareTempFiles()
 
 			ORAS("push", RegistryRef(ZOTHost, repo, tag), "--config", fmt.Sprintf("%s:%s", foobar.FileConfigName, configType), foobar.FileBarName, "-v", "--image-spec", "v1.0").
-				MatchKeyWords("ArtifactType: ", configType).
+				MatchErrKeyWords("ArtifactType: ", configType).
 				WithWorkDir(tempDir).Exec()
 		})
 
@@ -204,7 +204,7 @@ var _ = Describe("1.1 registry users:", func() {
 				prepare(RegistryRef(Host, ImageRepo, foobar.Digest), RegistryRef(Host, repo, foobar.Digest))
 				//test
 				ORAS(append([]string{"manifest", "push", RegistryRef(Host, repo, tag), "-"}, unary...)...).
-					MatchKeyWords("Pushed", RegistryRef(Host, repo, tag), "Digest:", digest).
+					MatchErrKeyWords("Pushed", RegistryRef(Host, repo, tag)).MatchKeyWords("Digest:", digest).
 					WithInput(strings.NewReader(manifest)).Exec()
 				validateTag(RegistryRef(Host, repo, ""), tag, false)
 			})
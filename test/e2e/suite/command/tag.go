@@ -59,7 +59,7 @@ var _ = Describe("ORAS beginners:", func() {
 })
 
 func tagAndValidate(reg string, repo string, tagOrDigest string, digestText string, tags ...string) {
-	out := ORAS(append([]string{"tag", RegistryRef(reg, repo, tagOrDigest)}, tags...)...).MatchKeyWords(tags...).Exec().Out
+	out := ORAS(append([]string{"tag", RegistryRef(reg, repo, tagOrDigest)}, tags...)...).MatchErrKeyWords(tags...).Exec().Err
 	hint := regexp.QuoteMeta(fmt.Sprintf("Tagging [registry] %s", RegistryRef(reg, repo, digestText)))
 	gomega.Expect(out).To(gbytes.Say(hint))
 	gomega.Expect(out).NotTo(gbytes.Say(hint)) // should only say hint once
@@ -118,7 +118,7 @@ var _ = Describe("1.0 registry users:", func() {
 
 var _ = Describe("OCI image layout users:", func() {
 	var tagAndValidate = func(root string, tagOrDigest string, digest string, tags ...string) {
-		out := ORAS(append([]string{"tag", LayoutRef(root, tagOrDigest), Flags.Layout}, tags...)...).MatchKeyWords(tags...).Exec().Out
+		out := ORAS(append([]string{"tag", LayoutRef(root, tagOrDigest), Flags.Layout}, tags...)...).MatchErrKeyWords(tags...).Exec().Err
 		hint := regexp.QuoteMeta(fmt.Sprintf("Tagging [oci-layout] %s", LayoutRef(root, digest)))
 		gomega.Expect(out).To(gbytes.Say(hint))
 		gomega.Expect(out).NotTo(gbytes.Say(hint)) // should only say hint once
@@ -153,8 +153,8 @@ var _ = Describe("OCI image layout users:", func() {
 			root := PrepareTempOCI(ImageRepo)
 			dir := filepath.Dir(root)
 			ref := filepath.Base(root)
-			ORAS("tag", LayoutRef(ref, multi_arch.Tag), Flags.Layout, "latest").WithWorkDir(dir).MatchKeyWords("Tagging [oci-layout]", "Tagged latest").Exec()
-			ORAS("tag", LayoutRef(ref, multi_arch.Tag), Flags.Layout, "tag2").WithWorkDir(dir).MatchKeyWords("Tagging [oci-layout]", "Tagged tag2").Exec()
+			ORAS("tag", LayoutRef(ref, multi_arch.Tag), Flags.Layout, "latest").WithWorkDir(dir).MatchErrKeyWords("Tagging [oci-layout]", "Tagged latest").Exec()
+			ORAS("tag", LayoutRef(ref, multi_arch.Tag), Flags.Layout, "tag2").WithWorkDir(dir).MatchErrKeyWords("Tagging [oci-layout]", "Tagged tag2").Exec()
 			ORAS("repo", "tags", Flags.Layout, LayoutRef(ref, multi_arch.Tag)).WithWorkDir(dir).MatchKeyWords(multi_arch.Tag, "latest", "tag2").Exec()
 		})
 	})